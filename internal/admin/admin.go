@@ -0,0 +1,16 @@
+package admin
+
+import "go.uber.org/fx"
+
+// ======== EXPORTS ========
+
+// Module exports services present
+var Context = fx.Options(
+	fx.Provide(GetAdminController),
+	fx.Provide(fx.Annotate(
+		GetAdminService,
+		fx.As(new(Service)),
+	)),
+	fx.Provide(GetAdminRepository),
+	fx.Provide(SetAdminRoutes),
+)
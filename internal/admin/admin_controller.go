@@ -0,0 +1,241 @@
+package admin
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"hostflow/profile-service/internal/middlewares"
+	"hostflow/profile-service/pkg/common"
+
+	"github.com/gin-gonic/gin"
+)
+
+type AdminController struct {
+	service Service
+}
+
+func GetAdminController(service Service) *AdminController {
+	return &AdminController{
+		service: service,
+	}
+}
+
+// ListOrganizationsHandler godoc
+// @Summary List organizations
+// @Description Returns all organizations by ID with their member counts, optionally searched by ID, name or slug and filtered by closure status or suspension. Requires platform staff signed in with a second factor.
+// @Tags admin
+// @Produce json
+// @Security ApiKeyAuth
+// @Param q query string false "ID, or part of the name or slug"
+// @Param status query string false "ACTIVE, CLOSING or CLOSED"
+// @Param suspended query bool false "Only suspended (true) or not suspended (false) organizations"
+// @Param limit query int false "Page size (default 50, max 200)"
+// @Param after query int false "Return organizations with an ID above this cursor"
+// @Success 200 {object} Page
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Router /admin/organizations [get]
+func (c *AdminController) ListOrganizationsHandler(ctx *gin.Context) {
+	limit, errLimit := strconv.Atoi(ctx.DefaultQuery("limit", "0"))
+	after, errAfter := strconv.ParseInt(ctx.DefaultQuery("after", "0"), 10, 64)
+	if errLimit != nil || errAfter != nil {
+		ctx.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "Invalid query",
+			Message: "'limit' and 'after' must be numeric",
+		})
+		return
+	}
+
+	filter := OrganizationFilter{Query: ctx.Query("q"), Status: ctx.Query("status")}
+	if value := ctx.Query("suspended"); value != "" {
+		suspended, err := strconv.ParseBool(value)
+		if err != nil {
+			ctx.JSON(http.StatusBadRequest, ErrorResponse{
+				Error:   "Invalid query",
+				Message: "'suspended' must be true or false",
+			})
+			return
+		}
+		filter.Suspended = &suspended
+	}
+
+	page, err := c.service.ListOrganizations(ctx.Request.Context(), filter, after, limit)
+	if err != nil {
+		respondError(ctx, "Failed to fetch organizations", err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, page)
+}
+
+// GetOrganizationHandler godoc
+// @Summary Get an organization
+// @Description Returns an organization with its member counts and suspension. Requires platform staff signed in with a second factor.
+// @Tags admin
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path int true "Organization ID"
+// @Success 200 {object} Organization
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /admin/organizations/{id} [get]
+func (c *AdminController) GetOrganizationHandler(ctx *gin.Context) {
+	id, ok := organizationID(ctx)
+	if !ok {
+		return
+	}
+
+	organization, err := c.service.GetOrganization(ctx.Request.Context(), id)
+	if err != nil {
+		respondError(ctx, "Failed to fetch organization", err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, organization)
+}
+
+// SuspendOrganizationHandler godoc
+// @Summary Suspend an organization
+// @Description Suspends an organization: requests of its members and API keys are refused with 403 and the code organization_suspended until it is unsuspended. Closed organizations cannot be suspended. Recorded in the organization's audit log. Requires platform staff signed in with a second factor.
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path int true "Organization ID"
+// @Param body body SuspendRequest true "Suspension"
+// @Success 200 {object} Organization
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Router /admin/organizations/{id}/suspension [post]
+func (c *AdminController) SuspendOrganizationHandler(ctx *gin.Context) {
+	principal := middlewares.GetPrincipal(ctx)
+	id, ok := organizationID(ctx)
+	if !ok {
+		return
+	}
+
+	var body SuspendRequest
+	if errors := common.Validation.ValidateBody(ctx, &body); errors != nil {
+		ctx.JSON(http.StatusBadRequest, errors)
+		return
+	}
+
+	organization, err := c.service.Suspend(ctx.Request.Context(), principal.UserID, id, body)
+	if err != nil {
+		respondError(ctx, "Failed to suspend organization", err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, organization)
+}
+
+// UnsuspendOrganizationHandler godoc
+// @Summary Unsuspend an organization
+// @Description Lifts the suspension of an organization. Recorded in the organization's audit log. Requires platform staff signed in with a second factor.
+// @Tags admin
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path int true "Organization ID"
+// @Success 200 {object} Organization
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Router /admin/organizations/{id}/suspension [delete]
+func (c *AdminController) UnsuspendOrganizationHandler(ctx *gin.Context) {
+	principal := middlewares.GetPrincipal(ctx)
+	id, ok := organizationID(ctx)
+	if !ok {
+		return
+	}
+
+	organization, err := c.service.Unsuspend(ctx.Request.Context(), principal.UserID, id)
+	if err != nil {
+		respondError(ctx, "Failed to unsuspend organization", err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, organization)
+}
+
+// LookupUsersHandler godoc
+// @Summary Look up users by e-mail
+// @Description Returns the profiles with the e-mail address and the organizations they belong to. Each lookup is recorded in the organization's audit log. Requires platform staff signed in with a second factor.
+// @Tags admin
+// @Produce json
+// @Security ApiKeyAuth
+// @Param email query string true "E-mail address"
+// @Success 200 {array} Member
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Router /admin/users [get]
+func (c *AdminController) LookupUsersHandler(ctx *gin.Context) {
+	principal := middlewares.GetPrincipal(ctx)
+
+	members, err := c.service.LookupEmail(ctx.Request.Context(), principal.UserID, ctx.Query("email"))
+	if err != nil {
+		respondError(ctx, "Failed to look up users", err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, members)
+}
+
+// LookupUserHandler godoc
+// @Summary Look up a user
+// @Description Returns the profile of a user and the organization they belong to. The lookup is recorded in the organization's audit log. Requires platform staff signed in with a second factor.
+// @Tags admin
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "User ID"
+// @Success 200 {object} Member
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /admin/users/{id} [get]
+func (c *AdminController) LookupUserHandler(ctx *gin.Context) {
+	principal := middlewares.GetPrincipal(ctx)
+
+	member, err := c.service.LookupUser(ctx.Request.Context(), principal.UserID, ctx.Param("id"))
+	if err != nil {
+		respondError(ctx, "Failed to look up user", err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, member)
+}
+
+// organizationID reads the organization ID from the path. It responds with
+// 400 and returns false when it is not numeric.
+func organizationID(ctx *gin.Context) (int64, bool) {
+	id, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "Invalid organization ID",
+			Message: "'id' must be numeric",
+		})
+		return 0, false
+	}
+	return id, true
+}
+
+// respondError maps service errors to HTTP status codes.
+func respondError(ctx *gin.Context, title string, err error) {
+	status := http.StatusInternalServerError
+	switch {
+	case errors.Is(err, ErrInvalidInput):
+		status = http.StatusBadRequest
+	case errors.Is(err, ErrForbidden):
+		status = http.StatusForbidden
+	case errors.Is(err, ErrNotFound):
+		status = http.StatusNotFound
+	case errors.Is(err, ErrConflict):
+		status = http.StatusConflict
+	}
+
+	ctx.JSON(status, ErrorResponse{
+		Error:   title,
+		Message: err.Error(),
+	})
+}
@@ -0,0 +1,133 @@
+package admin
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"hostflow/profile-service/internal/middlewares"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockAdminService struct {
+	mock.Mock
+}
+
+func (m *MockAdminService) ListOrganizations(ctx context.Context, filter OrganizationFilter, after int64, limit int) (*Page, error) {
+	args := m.Called(ctx, filter, after, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*Page), args.Error(1)
+}
+
+func (m *MockAdminService) GetOrganization(ctx context.Context, id int64) (*Organization, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*Organization), args.Error(1)
+}
+
+func (m *MockAdminService) Suspend(ctx context.Context, staffID string, id int64, req SuspendRequest) (*Organization, error) {
+	args := m.Called(ctx, staffID, id, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*Organization), args.Error(1)
+}
+
+func (m *MockAdminService) Unsuspend(ctx context.Context, staffID string, id int64) (*Organization, error) {
+	args := m.Called(ctx, staffID, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*Organization), args.Error(1)
+}
+
+func (m *MockAdminService) LookupUser(ctx context.Context, staffID string, userID string) (*Member, error) {
+	args := m.Called(ctx, staffID, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*Member), args.Error(1)
+}
+
+func (m *MockAdminService) LookupEmail(ctx context.Context, staffID string, email string) ([]Member, error) {
+	args := m.Called(ctx, staffID, email)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]Member), args.Error(1)
+}
+
+func staffRouter(controller *AdminController) *gin.Engine {
+	r := gin.New()
+	r.Use(func(c *gin.Context) {
+		middlewares.SetPrincipal(c, middlewares.Principal{UserID: "staff-1", AAL: "aal2", PlatformStaff: true})
+	})
+	r.GET("/admin/organizations", controller.ListOrganizationsHandler)
+	r.GET("/admin/organizations/:id", controller.GetOrganizationHandler)
+	r.POST("/admin/organizations/:id/suspension", controller.SuspendOrganizationHandler)
+	return r
+}
+
+func TestListOrganizationsHandler_PassesFilter(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	mockSvc := new(MockAdminService)
+	r := staffRouter(GetAdminController(mockSvc))
+
+	suspended := true
+	filter := OrganizationFilter{Query: "bled", Status: "ACTIVE", Suspended: &suspended}
+	mockSvc.On("ListOrganizations", mock.Anything, filter, int64(40), 10).
+		Return(&Page{Organizations: []Organization{{ID: 41, Name: "Vila Bled", MemberCount: 12}}}, nil)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/admin/organizations?q=bled&status=ACTIVE&suspended=true&after=40&limit=10", nil)
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"member_count":12`)
+}
+
+func TestListOrganizationsHandler_InvalidQuery(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	mockSvc := new(MockAdminService)
+	r := staffRouter(GetAdminController(mockSvc))
+
+	for _, query := range []string{"limit=ten", "suspended=maybe"} {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/admin/organizations?"+query, nil)
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code, query)
+	}
+	mockSvc.AssertNotCalled(t, "ListOrganizations")
+}
+
+func TestSuspendOrganizationHandler(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	mockSvc := new(MockAdminService)
+	r := staffRouter(GetAdminController(mockSvc))
+
+	body := SuspendRequest{Reason: "Chargeback on invoice 2026-0312"}
+	mockSvc.On("Suspend", mock.Anything, "staff-1", int64(7), body).Return(nil, ErrConflict)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("POST", "/admin/organizations/7/suspension", strings.NewReader(`{"reason":"Chargeback on invoice 2026-0312"}`))
+	req.Header.Set("Content-Type", "application/json")
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusConflict, w.Code)
+
+	w = httptest.NewRecorder()
+	req, _ = http.NewRequest("GET", "/admin/organizations/seven", nil)
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
@@ -0,0 +1,68 @@
+package admin
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// DefaultPageSize and MaxPageSize bound organization pages.
+	DefaultPageSize = 50
+	MaxPageSize     = 200
+)
+
+// Organization is a tenant as platform staff see it.
+type Organization struct {
+	ID           int64   `json:"id" db:"id"`
+	Name         string  `json:"name" db:"name" example:"Vila Bled"`
+	Slug         *string `json:"slug" db:"slug" example:"vila-bled"`
+	ContactEmail *string `json:"contact_email" db:"contact_email" example:"info@vilabled.si"`
+	// Status is the closure status: ACTIVE, CLOSING or CLOSED. Suspension
+	// is reported separately.
+	Status           string     `json:"status" db:"status" example:"ACTIVE"`
+	SuspendedAt      *time.Time `json:"suspended_at" db:"suspended_at"`
+	SuspendedBy      *uuid.UUID `json:"suspended_by" db:"suspended_by"`
+	SuspensionReason *string    `json:"suspension_reason" db:"suspension_reason"`
+	// MemberCount counts the profiles of the organization, ActiveMemberCount
+	// only those not deactivated.
+	MemberCount       int `json:"member_count" db:"member_count"`
+	ActiveMemberCount int `json:"active_member_count" db:"active_member_count"`
+}
+
+// OrganizationFilter narrows the organizations listed. Query matches the ID,
+// or part of the name or slug.
+type OrganizationFilter struct {
+	Query     string
+	Status    string
+	Suspended *bool
+}
+
+// Page is a page of organizations by ID. NextAfter is the cursor for the
+// next page, nil on the last one.
+type Page struct {
+	Organizations []Organization `json:"organizations"`
+	NextAfter     *int64         `json:"next_after"`
+}
+
+// Member is a user's profile with the organization it belongs to.
+type Member struct {
+	UserID           uuid.UUID `json:"user_id" db:"id"`
+	Email            string    `json:"email" db:"email" example:"ana@villa-bled.si"`
+	Name             string    `json:"name" db:"full_name" example:"Ana Novak"`
+	Role             string    `json:"role" db:"role" example:"MEMBER"`
+	Status           string    `json:"status" db:"status" example:"ACTIVE"`
+	OrganizationID   int64     `json:"organization_id" db:"organization_id"`
+	OrganizationName string    `json:"organization_name" db:"organization_name" example:"Vila Bled"`
+}
+
+// SuspendRequest suspends an organization.
+type SuspendRequest struct {
+	Reason string `json:"reason" binding:"required,min=10,max=500" example:"Chargeback on invoice 2026-0312"`
+}
+
+// ErrorResponse represents an error response
+type ErrorResponse struct {
+	Error   string `json:"error" example:"Invalid request"`
+	Message string `json:"message,omitempty" example:"The provided data is invalid"`
+}
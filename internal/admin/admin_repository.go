@@ -0,0 +1,159 @@
+package admin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// organizationQuery selects organizations with the counts of their
+// members; soft-deleted profiles are not counted.
+const organizationQuery = `
+    SELECT o.id, o.name, o.slug, o.contact_email, o.status,
+           o.suspended_at, o.suspended_by, o.suspension_reason,
+           m.member_count, m.active_member_count
+    FROM organization o
+    CROSS JOIN LATERAL (
+        SELECT count(*) AS member_count,
+               count(*) FILTER (WHERE p.status <> 'INACTIVE') AS active_member_count
+        FROM "profiles" p
+        WHERE p.organization_id = o.id AND p.deleted_at IS NULL
+    ) m`
+
+const memberQuery = `
+    SELECT p.id, p.email, p.full_name, p.role, p.status, p.organization_id, o.name AS organization_name
+    FROM "profiles" p
+    JOIN organization o ON o.id = p.organization_id`
+
+type AdminRepository struct {
+	db *pgxpool.Pool
+}
+
+func GetAdminRepository(db *pgxpool.Pool) *AdminRepository {
+	return &AdminRepository{
+		db: db,
+	}
+}
+
+// ListOrganizations returns up to limit organizations matching the filter
+// with an ID above after, by ID.
+func (r *AdminRepository) ListOrganizations(ctx context.Context, filter OrganizationFilter, after int64, limit int) ([]Organization, error) {
+	conditions := []string{"o.id > $1"}
+	args := []any{after}
+	if filter.Query != "" {
+		args = append(args, "%"+escapeLike(filter.Query)+"%")
+		condition := fmt.Sprintf("o.name ILIKE $%[1]d OR o.slug ILIKE $%[1]d", len(args))
+		if id, err := strconv.ParseInt(filter.Query, 10, 64); err == nil {
+			args = append(args, id)
+			condition += fmt.Sprintf(" OR o.id = $%d", len(args))
+		}
+		conditions = append(conditions, "("+condition+")")
+	}
+	if filter.Status != "" {
+		args = append(args, filter.Status)
+		conditions = append(conditions, fmt.Sprintf("o.status = $%d", len(args)))
+	}
+	if filter.Suspended != nil {
+		if *filter.Suspended {
+			conditions = append(conditions, "o.suspended_at IS NOT NULL")
+		} else {
+			conditions = append(conditions, "o.suspended_at IS NULL")
+		}
+	}
+	args = append(args, limit)
+
+	rows, err := r.db.Query(ctx,
+		organizationQuery+` WHERE `+strings.Join(conditions, " AND ")+fmt.Sprintf(` ORDER BY o.id LIMIT $%d`, len(args)),
+		args...,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return pgx.CollectRows(rows, pgx.RowToStructByName[Organization])
+}
+
+// FindOrganization returns the organization, or ErrNotFound.
+func (r *AdminRepository) FindOrganization(ctx context.Context, id int64) (*Organization, error) {
+	rows, err := r.db.Query(ctx, organizationQuery+` WHERE o.id = $1`, id)
+	if err != nil {
+		return nil, err
+	}
+	organization, err := pgx.CollectOneRow(rows, pgx.RowToStructByName[Organization])
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+	return &organization, nil
+}
+
+// Suspend marks the organization as suspended unless it already is or is
+// closed. It reports whether it did.
+func (r *AdminRepository) Suspend(ctx context.Context, id int64, staffID uuid.UUID, reason string) (bool, error) {
+	result, err := r.db.Exec(ctx, `
+        UPDATE organization
+        SET suspended_at = now(), suspended_by = $2, suspension_reason = $3
+        WHERE id = $1 AND suspended_at IS NULL AND status <> 'CLOSED'`,
+		id, staffID, reason,
+	)
+	if err != nil {
+		return false, err
+	}
+	return result.RowsAffected() == 1, nil
+}
+
+// Unsuspend lifts the suspension of the organization. It reports whether it
+// was suspended.
+func (r *AdminRepository) Unsuspend(ctx context.Context, id int64) (bool, error) {
+	result, err := r.db.Exec(ctx, `
+        UPDATE organization
+        SET suspended_at = NULL, suspended_by = NULL, suspension_reason = NULL
+        WHERE id = $1 AND suspended_at IS NOT NULL`,
+		id,
+	)
+	if err != nil {
+		return false, err
+	}
+	return result.RowsAffected() == 1, nil
+}
+
+// FindMember returns the user's profile, or ErrNotFound.
+func (r *AdminRepository) FindMember(ctx context.Context, userID uuid.UUID) (*Member, error) {
+	rows, err := r.db.Query(ctx, memberQuery+` WHERE p.id = $1 AND p.deleted_at IS NULL`, userID)
+	if err != nil {
+		return nil, err
+	}
+	member, err := pgx.CollectOneRow(rows, pgx.RowToStructByName[Member])
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+	return &member, nil
+}
+
+// FindMembersByEmail returns the profiles with the e-mail address, compared
+// case-insensitively.
+func (r *AdminRepository) FindMembersByEmail(ctx context.Context, email string) ([]Member, error) {
+	rows, err := r.db.Query(ctx,
+		memberQuery+` WHERE lower(p.email) = lower($1) AND p.deleted_at IS NULL ORDER BY p.organization_id`,
+		email,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return pgx.CollectRows(rows, pgx.RowToStructByName[Member])
+}
+
+// escapeLike escapes the wildcards of a LIKE pattern.
+func escapeLike(value string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(value)
+}
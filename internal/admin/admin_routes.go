@@ -0,0 +1,45 @@
+package admin
+
+import (
+	"hostflow/profile-service/internal/middlewares"
+	"hostflow/profile-service/pkg/lib"
+)
+
+type AdminRoutes struct {
+	logger          lib.Logger
+	router          *lib.Router
+	adminController *AdminController
+	authMiddleware  middlewares.AuthMiddleware
+}
+
+func SetAdminRoutes(
+	logger lib.Logger,
+	router *lib.Router,
+	adminController *AdminController,
+	authMiddleware middlewares.AuthMiddleware,
+) AdminRoutes {
+	return AdminRoutes{
+		logger:          logger,
+		router:          router,
+		adminController: adminController,
+		authMiddleware:  authMiddleware,
+	}
+}
+
+func (route AdminRoutes) Setup() {
+	route.logger.Info("Setting up [ADMIN] routes.")
+
+	// Platform staff only, across organizations
+	admin := route.router.Group("/admin")
+	admin.Use(route.authMiddleware.StaffHandler())
+	{
+		admin.GET("/organizations", route.adminController.ListOrganizationsHandler)
+		admin.GET("/organizations/:id", route.adminController.GetOrganizationHandler)
+		admin.POST("/organizations/:id/suspension", route.adminController.SuspendOrganizationHandler)
+		admin.DELETE("/organizations/:id/suspension", route.adminController.UnsuspendOrganizationHandler)
+		admin.GET("/users", route.adminController.LookupUsersHandler)
+		admin.GET("/users/:id", route.adminController.LookupUserHandler)
+	}
+
+	route.logger.Info("[ADMIN] routes setup complete.")
+}
@@ -0,0 +1,239 @@
+package admin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"hostflow/profile-service/internal/audit"
+	"hostflow/profile-service/internal/middlewares"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrNotFound     = errors.New("not found")
+	ErrForbidden    = errors.New("you are not allowed to perform this action")
+	ErrInvalidInput = errors.New("invalid input")
+	ErrConflict     = errors.New("the organization is not in a state that allows this")
+)
+
+// maxQueryLength bounds organization searches.
+const maxQueryLength = 100
+
+// closureStatuses are the statuses organizations can be filtered by.
+var closureStatuses = []string{
+	middlewares.OrganizationActive,
+	middlewares.OrganizationClosing,
+	middlewares.OrganizationClosed,
+}
+
+type AdminService struct {
+	repo   *AdminRepository
+	status *middlewares.OrganizationStatus
+	audit  audit.Service
+}
+
+// Service is the platform staff's view across organizations. Callers are
+// checked to be staff by the auth middleware; the staff ID is the actor of
+// the audit entries.
+type Service interface {
+	ListOrganizations(ctx context.Context, filter OrganizationFilter, after int64, limit int) (*Page, error)
+	GetOrganization(ctx context.Context, id int64) (*Organization, error)
+	Suspend(ctx context.Context, staffID string, id int64, req SuspendRequest) (*Organization, error)
+	Unsuspend(ctx context.Context, staffID string, id int64) (*Organization, error)
+	LookupUser(ctx context.Context, staffID string, userID string) (*Member, error)
+	LookupEmail(ctx context.Context, staffID string, email string) ([]Member, error)
+}
+
+func GetAdminService(repo *AdminRepository, status *middlewares.OrganizationStatus, audit audit.Service) *AdminService {
+	return &AdminService{
+		repo:   repo,
+		status: status,
+		audit:  audit,
+	}
+}
+
+// ListOrganizations returns a page of organizations matching the filter,
+// with their member counts.
+func (s *AdminService) ListOrganizations(ctx context.Context, filter OrganizationFilter, after int64, limit int) (*Page, error) {
+	filter.Query = strings.TrimSpace(filter.Query)
+	if len(filter.Query) > maxQueryLength {
+		return nil, fmt.Errorf("%w: q must not exceed %d characters", ErrInvalidInput, maxQueryLength)
+	}
+	if filter.Status != "" && !slices.Contains(closureStatuses, filter.Status) {
+		return nil, fmt.Errorf("%w: status must be one of %s", ErrInvalidInput, strings.Join(closureStatuses, ", "))
+	}
+	if limit <= 0 {
+		limit = DefaultPageSize
+	}
+	if limit > MaxPageSize {
+		return nil, fmt.Errorf("%w: limit must not exceed %d", ErrInvalidInput, MaxPageSize)
+	}
+	if after < 0 {
+		return nil, fmt.Errorf("%w: after must be a positive organization ID", ErrInvalidInput)
+	}
+
+	organizations, err := s.repo.ListOrganizations(ctx, filter, after, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	page := &Page{Organizations: organizations}
+	if page.Organizations == nil {
+		page.Organizations = []Organization{}
+	}
+	if len(organizations) == limit {
+		next := organizations[len(organizations)-1].ID
+		page.NextAfter = &next
+	}
+	return page, nil
+}
+
+// GetOrganization returns the organization with its member counts.
+func (s *AdminService) GetOrganization(ctx context.Context, id int64) (*Organization, error) {
+	return s.repo.FindOrganization(ctx, id)
+}
+
+// Suspend turns away every request of the organization's members and API
+// keys until it is unsuspended. Closed organizations cannot be suspended.
+func (s *AdminService) Suspend(ctx context.Context, staffID string, id int64, req SuspendRequest) (*Organization, error) {
+	staff, err := uuid.Parse(staffID)
+	if err != nil {
+		return nil, ErrForbidden
+	}
+	reason := strings.TrimSpace(req.Reason)
+	if reason == "" {
+		return nil, fmt.Errorf("%w: reason is required", ErrInvalidInput)
+	}
+
+	organization, err := s.repo.FindOrganization(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if organization.Status == middlewares.OrganizationClosed {
+		return nil, fmt.Errorf("%w: the organization is closed", ErrConflict)
+	}
+	suspended, err := s.repo.Suspend(ctx, id, staff, reason)
+	if err != nil {
+		return nil, err
+	}
+	if !suspended {
+		return nil, fmt.Errorf("%w: the organization is already suspended", ErrConflict)
+	}
+	s.status.Invalidate(id)
+
+	if err := s.record(ctx, staff, id, "organization.suspended", map[string]interface{}{"reason": reason}); err != nil {
+		return nil, err
+	}
+	return s.repo.FindOrganization(ctx, id)
+}
+
+// Unsuspend restores access to the organization.
+func (s *AdminService) Unsuspend(ctx context.Context, staffID string, id int64) (*Organization, error) {
+	staff, err := uuid.Parse(staffID)
+	if err != nil {
+		return nil, ErrForbidden
+	}
+
+	organization, err := s.repo.FindOrganization(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	unsuspended, err := s.repo.Unsuspend(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if !unsuspended {
+		return nil, fmt.Errorf("%w: the organization is not suspended", ErrConflict)
+	}
+	s.status.Invalidate(id)
+
+	details := map[string]interface{}{}
+	if organization.SuspensionReason != nil {
+		details["suspension_reason"] = *organization.SuspensionReason
+	}
+	if err := s.record(ctx, staff, id, "organization.unsuspended", details); err != nil {
+		return nil, err
+	}
+	return s.repo.FindOrganization(ctx, id)
+}
+
+// LookupUser returns the organization the user belongs to. The lookup is
+// recorded in that organization's audit log.
+func (s *AdminService) LookupUser(ctx context.Context, staffID string, userID string) (*Member, error) {
+	staff, err := uuid.Parse(staffID)
+	if err != nil {
+		return nil, ErrForbidden
+	}
+	id, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, ErrNotFound
+	}
+
+	member, err := s.repo.FindMember(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.recordLookup(ctx, staff, *member, nil); err != nil {
+		return nil, err
+	}
+	return member, nil
+}
+
+// LookupEmail returns the profiles with the e-mail address and their
+// organizations. Each lookup is recorded in the organization's audit log.
+func (s *AdminService) LookupEmail(ctx context.Context, staffID string, email string) ([]Member, error) {
+	staff, err := uuid.Parse(staffID)
+	if err != nil {
+		return nil, ErrForbidden
+	}
+	email = strings.TrimSpace(email)
+	if email == "" || !strings.Contains(email, "@") {
+		return nil, fmt.Errorf("%w: email must be an e-mail address", ErrInvalidInput)
+	}
+
+	members, err := s.repo.FindMembersByEmail(ctx, email)
+	if err != nil {
+		return nil, err
+	}
+	for _, member := range members {
+		if err := s.recordLookup(ctx, staff, member, map[string]interface{}{"email": email}); err != nil {
+			return nil, err
+		}
+	}
+	if members == nil {
+		members = []Member{}
+	}
+	return members, nil
+}
+
+// recordLookup writes an audit entry about staff looking up a member.
+func (s *AdminService) recordLookup(ctx context.Context, staff uuid.UUID, member Member, details map[string]interface{}) error {
+	targetID := member.UserID.String()
+	return s.audit.Record(ctx, audit.Entry{
+		OrganizationID: member.OrganizationID,
+		ActorID:        &staff,
+		Action:         "admin.user_looked_up",
+		TargetType:     "profile",
+		TargetID:       &targetID,
+		Details:        details,
+	})
+}
+
+// record writes an audit entry about the organization, with the staff member
+// as the actor.
+func (s *AdminService) record(ctx context.Context, staff uuid.UUID, orgID int64, action string, details map[string]interface{}) error {
+	targetID := strconv.FormatInt(orgID, 10)
+	return s.audit.Record(ctx, audit.Entry{
+		OrganizationID: orgID,
+		ActorID:        &staff,
+		Action:         action,
+		TargetType:     "organization",
+		TargetID:       &targetID,
+		Details:        details,
+	})
+}
@@ -0,0 +1,53 @@
+package admin
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestListOrganizations_Validation(t *testing.T) {
+	service := GetAdminService(nil, nil, nil)
+
+	cases := map[string]struct {
+		filter OrganizationFilter
+		after  int64
+		limit  int
+	}{
+		"unknown status": {filter: OrganizationFilter{Status: "SUSPENDED"}},
+		"long query":     {filter: OrganizationFilter{Query: strings.Repeat("a", maxQueryLength+1)}},
+		"large page":     {limit: MaxPageSize + 1},
+		"negative after": {after: -1},
+	}
+	for name, tc := range cases {
+		_, err := service.ListOrganizations(context.Background(), tc.filter, tc.after, tc.limit)
+		assert.True(t, errors.Is(err, ErrInvalidInput), name)
+	}
+}
+
+func TestSuspend_Validation(t *testing.T) {
+	service := GetAdminService(nil, nil, nil)
+
+	_, err := service.Suspend(context.Background(), "staff-1", 1, SuspendRequest{Reason: "Chargeback on invoice"})
+	assert.ErrorIs(t, err, ErrForbidden)
+
+	_, err = service.Suspend(context.Background(), uuid.NewString(), 1, SuspendRequest{Reason: "   "})
+	assert.ErrorIs(t, err, ErrInvalidInput)
+}
+
+func TestLookupEmail_Validation(t *testing.T) {
+	service := GetAdminService(nil, nil, nil)
+
+	for _, email := range []string{"", "  ", "ana"} {
+		_, err := service.LookupEmail(context.Background(), uuid.NewString(), email)
+		assert.ErrorIs(t, err, ErrInvalidInput, email)
+	}
+}
+
+func TestEscapeLike(t *testing.T) {
+	assert.Equal(t, `vila\_bled\%\\`, escapeLike(`vila_bled%\`))
+}
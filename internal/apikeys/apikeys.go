@@ -0,0 +1,21 @@
+package apikeys
+
+import (
+	"hostflow/profile-service/internal/middlewares"
+
+	"go.uber.org/fx"
+)
+
+// ======== EXPORTS ========
+
+// Module exports services present
+var Context = fx.Options(
+	fx.Provide(GetAPIKeysController),
+	fx.Provide(fx.Annotate(
+		GetAPIKeysService,
+		fx.As(new(Service)),
+		fx.As(new(middlewares.APIKeyVerifier)),
+	)),
+	fx.Provide(GetAPIKeysRepository),
+	fx.Provide(SetAPIKeysRoutes),
+)
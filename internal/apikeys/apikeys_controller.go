@@ -0,0 +1,127 @@
+package apikeys
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"hostflow/profile-service/internal/middlewares"
+	"hostflow/profile-service/pkg/common"
+
+	"github.com/gin-gonic/gin"
+)
+
+type APIKeysController struct {
+	service Service
+}
+
+func GetAPIKeysController(service Service) *APIKeysController {
+	return &APIKeysController{
+		service: service,
+	}
+}
+
+// CreateAPIKeyHandler godoc
+// @Summary Create an API key
+// @Description Creates an API key for integrations of the requester's organization. The key is sent in the X-API-Key header and is only returned in this response. Scopes open areas for reading or writing: users:read, availability:read, availability:write, timeoff:read, staffing:read, skills:read, scim:read and scim:write. SCIM keys are sent as a bearer token instead. Requires OWNER role.
+// @Tags api-keys
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param body body CreateAPIKeyRequest true "API key"
+// @Success 201 {object} CreatedAPIKey
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Router /organization/api-keys [post]
+func (c *APIKeysController) CreateAPIKeyHandler(ctx *gin.Context) {
+	principal := middlewares.GetPrincipal(ctx)
+
+	var body CreateAPIKeyRequest
+	if errors := common.Validation.ValidateBody(ctx, &body); errors != nil {
+		ctx.JSON(http.StatusBadRequest, errors)
+		return
+	}
+
+	key, err := c.service.Create(ctx.Request.Context(), principal.UserID, principal.OrganizationID, principal.Role, body)
+	if err != nil {
+		respondError(ctx, "Failed to create API key", err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, key)
+}
+
+// ListAPIKeysHandler godoc
+// @Summary List API keys
+// @Description Returns the API keys of the requester's organization with their prefix and last use, including revoked and expired keys. Requires OWNER role.
+// @Tags api-keys
+// @Produce json
+// @Security ApiKeyAuth
+// @Success 200 {array} APIKey
+// @Failure 403 {object} ErrorResponse
+// @Router /organization/api-keys [get]
+func (c *APIKeysController) ListAPIKeysHandler(ctx *gin.Context) {
+	principal := middlewares.GetPrincipal(ctx)
+
+	keys, err := c.service.List(ctx.Request.Context(), principal.OrganizationID, principal.Role)
+	if err != nil {
+		respondError(ctx, "Failed to fetch API keys", err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, keys)
+}
+
+// RevokeAPIKeyHandler godoc
+// @Summary Revoke an API key
+// @Description Revokes an API key of the requester's organization. Other instances may accept it for up to a minute. Requires OWNER role.
+// @Tags api-keys
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path int true "API key ID"
+// @Success 200 {object} APIKey
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /organization/api-keys/{id} [delete]
+func (c *APIKeysController) RevokeAPIKeyHandler(ctx *gin.Context) {
+	principal := middlewares.GetPrincipal(ctx)
+
+	id, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "Invalid ID format",
+			Message: "API key ID must be numeric",
+		})
+		return
+	}
+
+	key, err := c.service.Revoke(ctx.Request.Context(), principal.UserID, principal.OrganizationID, principal.Role, id)
+	if err != nil {
+		respondError(ctx, "Failed to revoke API key", err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, key)
+}
+
+// respondError maps service errors to HTTP status codes.
+func respondError(ctx *gin.Context, title string, err error) {
+	status := http.StatusInternalServerError
+	switch {
+	case errors.Is(err, ErrInvalidInput):
+		status = http.StatusBadRequest
+	case errors.Is(err, ErrForbidden):
+		status = http.StatusForbidden
+	case errors.Is(err, ErrKeyNotFound):
+		status = http.StatusNotFound
+	case errors.Is(err, ErrTooManyKeys):
+		status = http.StatusConflict
+	}
+
+	ctx.JSON(status, ErrorResponse{
+		Error:   title,
+		Message: err.Error(),
+	})
+}
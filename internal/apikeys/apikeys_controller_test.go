@@ -0,0 +1,91 @@
+package apikeys
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"hostflow/profile-service/internal/middlewares"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockAPIKeysService struct {
+	mock.Mock
+}
+
+func (m *MockAPIKeysService) VerifyAPIKey(ctx context.Context, key string) (middlewares.APIKeyGrant, error) {
+	args := m.Called(ctx, key)
+	return args.Get(0).(middlewares.APIKeyGrant), args.Error(1)
+}
+
+func (m *MockAPIKeysService) Create(ctx context.Context, userID string, orgID int64, role string, req CreateAPIKeyRequest) (*CreatedAPIKey, error) {
+	args := m.Called(ctx, userID, orgID, role, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*CreatedAPIKey), args.Error(1)
+}
+
+func (m *MockAPIKeysService) List(ctx context.Context, orgID int64, role string) ([]APIKey, error) {
+	args := m.Called(ctx, orgID, role)
+	return args.Get(0).([]APIKey), args.Error(1)
+}
+
+func (m *MockAPIKeysService) Revoke(ctx context.Context, userID string, orgID int64, role string, id int64) (*APIKey, error) {
+	args := m.Called(ctx, userID, orgID, role, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*APIKey), args.Error(1)
+}
+
+func TestCreateAPIKeyHandler_Success(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	mockSvc := new(MockAPIKeysService)
+	controller := GetAPIKeysController(mockSvc)
+
+	r := gin.New()
+	r.POST("/organization/api-keys", func(c *gin.Context) {
+		middlewares.SetPrincipal(c, middlewares.Principal{UserID: "user-1", OrganizationID: 1, Role: "OWNER"})
+		controller.CreateAPIKeyHandler(c)
+	})
+
+	req := CreateAPIKeyRequest{Name: "Channel manager", Scopes: []string{"availability:read"}}
+	mockSvc.On("Create", mock.Anything, "user-1", int64(1), "OWNER", req).
+		Return(&CreatedAPIKey{APIKey: APIKey{ID: 3, Prefix: "hf_3f9a1c2b7d4e"}, Key: "hf_3f9a1c2b7d4e_secret"}, nil)
+
+	w := httptest.NewRecorder()
+	body := `{"name":"Channel manager","scopes":["availability:read"]}`
+	httpReq, _ := http.NewRequest("POST", "/organization/api-keys", strings.NewReader(body))
+	httpReq.Header.Set("Content-Type", "application/json")
+	r.ServeHTTP(w, httpReq)
+
+	assert.Equal(t, http.StatusCreated, w.Code)
+	assert.Contains(t, w.Body.String(), `"key":"hf_3f9a1c2b7d4e_secret"`)
+	mockSvc.AssertExpectations(t)
+}
+
+func TestRevokeAPIKeyHandler_NotFound(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	mockSvc := new(MockAPIKeysService)
+	controller := GetAPIKeysController(mockSvc)
+
+	r := gin.New()
+	r.DELETE("/organization/api-keys/:id", func(c *gin.Context) {
+		middlewares.SetPrincipal(c, middlewares.Principal{UserID: "user-1", OrganizationID: 1, Role: "OWNER"})
+		controller.RevokeAPIKeyHandler(c)
+	})
+
+	mockSvc.On("Revoke", mock.Anything, "user-1", int64(1), "OWNER", int64(9)).Return(nil, ErrKeyNotFound)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("DELETE", "/organization/api-keys/9", nil)
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
@@ -0,0 +1,64 @@
+package apikeys
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// KnownScopes lists the scopes a key can be granted. Each one opens the
+// routes of an area for reading or writing, see
+// middlewares.AuthMiddleware.HandlerWithAPIKeys.
+var KnownScopes = []string{
+	"users:read",
+	"availability:read",
+	"availability:write",
+	"timeoff:read",
+	"staffing:read",
+	"skills:read",
+	"scim:read",
+	"scim:write",
+}
+
+// APIKey is an organization API key. Only its prefix is shown after
+// creation.
+type APIKey struct {
+	ID             int64      `json:"id" db:"id"`
+	OrganizationID int64      `json:"organization_id" db:"organization_id"`
+	Name           string     `json:"name" db:"name" example:"Channel manager"`
+	Prefix         string     `json:"prefix" db:"prefix" example:"hf_3f9a1c2b7d4e"`
+	Role           string     `json:"role" db:"role" example:"MEMBER"`
+	Scopes         []string   `json:"scopes" db:"scopes" example:"availability:read,users:read"`
+	CreatedBy      *uuid.UUID `json:"created_by" db:"created_by"`
+	CreatedAt      time.Time  `json:"created_at" db:"created_at"`
+	ExpiresAt      *time.Time `json:"expires_at" db:"expires_at"`
+	LastUsedAt     *time.Time `json:"last_used_at" db:"last_used_at"`
+	RevokedAt      *time.Time `json:"revoked_at,omitempty" db:"revoked_at"`
+}
+
+// keyRecord is a key with its hashed secret.
+type keyRecord struct {
+	APIKey
+	SecretHash string `db:"secret_hash"`
+}
+
+// CreatedAPIKey is returned once on creation; the key cannot be read again
+// afterwards.
+type CreatedAPIKey struct {
+	APIKey
+	Key string `json:"key" example:"hf_3f9a1c2b7d4e_Qm9vdHN0cmFw..."`
+}
+
+// CreateAPIKeyRequest creates an API key. Role defaults to MEMBER.
+type CreateAPIKeyRequest struct {
+	Name      string     `json:"name" binding:"required,max=100" example:"Channel manager"`
+	Role      string     `json:"role" binding:"omitempty,oneof=MANAGER MEMBER" example:"MEMBER"`
+	Scopes    []string   `json:"scopes" binding:"required,min=1,dive,required" example:"availability:read,users:read"`
+	ExpiresAt *time.Time `json:"expires_at" example:"2027-01-01T00:00:00Z"`
+}
+
+// ErrorResponse represents an error response
+type ErrorResponse struct {
+	Error   string `json:"error" example:"Invalid request"`
+	Message string `json:"message,omitempty" example:"The provided data is invalid"`
+}
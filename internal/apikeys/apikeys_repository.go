@@ -0,0 +1,145 @@
+package apikeys
+
+import (
+	"context"
+	"errors"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+const keyColumns = `id, organization_id, name, prefix, role, scopes, created_by, created_at, expires_at, last_used_at, revoked_at`
+
+// errPrefixTaken is returned when a generated prefix collides with an
+// existing key.
+var errPrefixTaken = errors.New("API key prefix is taken")
+
+type APIKeysRepository struct {
+	db *pgxpool.Pool
+}
+
+func GetAPIKeysRepository(db *pgxpool.Pool) *APIKeysRepository {
+	return &APIKeysRepository{
+		db: db,
+	}
+}
+
+// Create stores a key unless the organization already has limit usable
+// keys. Creations for one organization are serialized by locking its row.
+func (r *APIKeysRepository) Create(ctx context.Context, record keyRecord, limit int) (*APIKey, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	if _, err := tx.Exec(ctx, `SELECT id FROM organization WHERE id = $1 FOR UPDATE`, record.OrganizationID); err != nil {
+		return nil, err
+	}
+
+	var usable int
+	err = tx.QueryRow(ctx, `
+        SELECT count(*)
+        FROM api_keys
+        WHERE organization_id = $1
+          AND revoked_at IS NULL
+          AND (expires_at IS NULL OR expires_at > now())`,
+		record.OrganizationID,
+	).Scan(&usable)
+	if err != nil {
+		return nil, err
+	}
+	if usable >= limit {
+		return nil, ErrTooManyKeys
+	}
+
+	rows, err := tx.Query(ctx, `
+        INSERT INTO api_keys (organization_id, name, prefix, secret_hash, role, scopes, created_by, expires_at)
+        VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+        RETURNING `+keyColumns,
+		record.OrganizationID, record.Name, record.Prefix, record.SecretHash,
+		record.Role, record.Scopes, record.CreatedBy, record.ExpiresAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	key, err := pgx.CollectOneRow(rows, pgx.RowToStructByName[APIKey])
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+			return nil, errPrefixTaken
+		}
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+	return &key, nil
+}
+
+// List returns the organization's keys, newest first, including revoked and
+// expired ones.
+func (r *APIKeysRepository) List(ctx context.Context, orgID int64) ([]APIKey, error) {
+	rows, err := r.db.Query(ctx,
+		`SELECT `+keyColumns+` FROM api_keys WHERE organization_id = $1 ORDER BY created_at DESC, id DESC`,
+		orgID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return pgx.CollectRows(rows, pgx.RowToStructByName[APIKey])
+}
+
+// FindByPrefix returns the key with its secret hash, or nil if there is
+// none.
+func (r *APIKeysRepository) FindByPrefix(ctx context.Context, prefix string) (*keyRecord, error) {
+	rows, err := r.db.Query(ctx,
+		`SELECT `+keyColumns+`, secret_hash FROM api_keys WHERE prefix = $1`,
+		prefix,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	record, err := pgx.CollectOneRow(rows, pgx.RowToStructByName[keyRecord])
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &record, nil
+}
+
+// Revoke marks the key as revoked. Revoking twice keeps the first time.
+func (r *APIKeysRepository) Revoke(ctx context.Context, orgID int64, id int64) (*APIKey, error) {
+	rows, err := r.db.Query(ctx, `
+        UPDATE api_keys
+        SET revoked_at = COALESCE(revoked_at, now())
+        WHERE id = $1 AND organization_id = $2
+        RETURNING `+keyColumns,
+		id, orgID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	key, err := pgx.CollectOneRow(rows, pgx.RowToStructByName[APIKey])
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrKeyNotFound
+		}
+		return nil, err
+	}
+	return &key, nil
+}
+
+// TouchLastUsed records that the key was used.
+func (r *APIKeysRepository) TouchLastUsed(ctx context.Context, id int64) error {
+	_, err := r.db.Exec(ctx, `UPDATE api_keys SET last_used_at = now() WHERE id = $1`, id)
+	return err
+}
@@ -0,0 +1,42 @@
+package apikeys
+
+import (
+	"hostflow/profile-service/internal/middlewares"
+	"hostflow/profile-service/pkg/lib"
+)
+
+type APIKeysRoutes struct {
+	logger            lib.Logger
+	router            *lib.Router
+	apiKeysController *APIKeysController
+	authMiddleware    middlewares.AuthMiddleware
+}
+
+func SetAPIKeysRoutes(
+	logger lib.Logger,
+	router *lib.Router,
+	apiKeysController *APIKeysController,
+	authMiddleware middlewares.AuthMiddleware,
+) APIKeysRoutes {
+	return APIKeysRoutes{
+		logger:            logger,
+		router:            router,
+		apiKeysController: apiKeysController,
+		authMiddleware:    authMiddleware,
+	}
+}
+
+func (route APIKeysRoutes) Setup() {
+	route.logger.Info("Setting up [API-KEYS] routes.")
+
+	// Keys are managed with user tokens only
+	organizations := route.router.Group("/organization")
+	organizations.Use(route.authMiddleware.Handler())
+	{
+		organizations.POST("/api-keys", route.apiKeysController.CreateAPIKeyHandler)
+		organizations.GET("/api-keys", route.apiKeysController.ListAPIKeysHandler)
+		organizations.DELETE("/api-keys/:id", route.apiKeysController.RevokeAPIKeyHandler)
+	}
+
+	route.logger.Info("[API-KEYS] routes setup complete.")
+}
@@ -0,0 +1,296 @@
+package apikeys
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"hostflow/profile-service/internal/audit"
+	"hostflow/profile-service/internal/middlewares"
+	"hostflow/profile-service/pkg/common"
+	"hostflow/profile-service/pkg/lib"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrKeyNotFound  = errors.New("API key not found")
+	ErrForbidden    = errors.New("you are not allowed to perform this action")
+	ErrInvalidInput = errors.New("invalid input")
+	ErrTooManyKeys  = errors.New("the organization has reached the maximum number of API keys")
+)
+
+const (
+	// keyPrefix starts every key, so leaked keys are easy to recognize.
+	keyPrefix = "hf_"
+
+	// prefixBytes and secretBytes are the random lengths of the lookup
+	// prefix and the secret.
+	prefixBytes = 6
+	secretBytes = 32
+
+	// maxKeysPerOrganization bounds the usable keys of an organization.
+	maxKeysPerOrganization = 25
+
+	// verifiedKeyTTL is how long a verified key is served from memory, so
+	// the argon2 hash is not computed on every request. Revocations on
+	// other instances take effect within it.
+	verifiedKeyTTL = time.Minute
+)
+
+type verifiedKey struct {
+	grant   middlewares.APIKeyGrant
+	expires time.Time
+}
+
+type APIKeysService struct {
+	repo   *APIKeysRepository
+	audit  audit.Service
+	logger lib.Logger
+
+	mu       sync.Mutex
+	verified map[[sha256.Size]byte]verifiedKey
+}
+
+type Service interface {
+	middlewares.APIKeyVerifier
+	Create(ctx context.Context, userID string, orgID int64, role string, req CreateAPIKeyRequest) (*CreatedAPIKey, error)
+	List(ctx context.Context, orgID int64, role string) ([]APIKey, error)
+	Revoke(ctx context.Context, userID string, orgID int64, role string, id int64) (*APIKey, error)
+}
+
+func GetAPIKeysService(repo *APIKeysRepository, audit audit.Service, logger lib.Logger) *APIKeysService {
+	return &APIKeysService{
+		repo:     repo,
+		audit:    audit,
+		logger:   logger,
+		verified: map[[sha256.Size]byte]verifiedKey{},
+	}
+}
+
+// Create issues a key with the given scopes. The key is only returned here;
+// just its argon2 hash is stored. Requires OWNER role.
+func (s *APIKeysService) Create(ctx context.Context, userID string, orgID int64, role string, req CreateAPIKeyRequest) (*CreatedAPIKey, error) {
+	if role != "OWNER" {
+		return nil, ErrForbidden
+	}
+
+	record := keyRecord{APIKey: APIKey{
+		OrganizationID: orgID,
+		Name:           strings.TrimSpace(req.Name),
+		Role:           req.Role,
+		ExpiresAt:      req.ExpiresAt,
+		CreatedBy:      parseActor(userID),
+	}}
+	if record.Name == "" {
+		return nil, fmt.Errorf("%w: name is required", ErrInvalidInput)
+	}
+	if record.Role == "" {
+		record.Role = "MEMBER"
+	}
+	if record.ExpiresAt != nil && !record.ExpiresAt.After(time.Now()) {
+		return nil, fmt.Errorf("%w: expires_at must be in the future", ErrInvalidInput)
+	}
+	for _, scope := range req.Scopes {
+		if !slices.Contains(KnownScopes, scope) {
+			return nil, fmt.Errorf("%w: unknown scope %q", ErrInvalidInput, scope)
+		}
+		if !slices.Contains(record.Scopes, scope) {
+			record.Scopes = append(record.Scopes, scope)
+		}
+	}
+	slices.Sort(record.Scopes)
+
+	secret, err := randomString(secretBytes)
+	if err != nil {
+		return nil, err
+	}
+	record.SecretHash, err = common.Hasher.Hash(secret)
+	if err != nil {
+		return nil, err
+	}
+
+	// A random prefix rarely collides; a new one is drawn when it does
+	var key *APIKey
+	for attempt := 0; attempt < 3; attempt++ {
+		raw := make([]byte, prefixBytes)
+		if _, err := rand.Read(raw); err != nil {
+			return nil, err
+		}
+		record.Prefix = keyPrefix + hex.EncodeToString(raw)
+
+		key, err = s.repo.Create(ctx, record, maxKeysPerOrganization)
+		if !errors.Is(err, errPrefixTaken) {
+			break
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.record(ctx, userID, "api_key.created", key); err != nil {
+		return nil, err
+	}
+	return &CreatedAPIKey{APIKey: *key, Key: key.Prefix + "_" + secret}, nil
+}
+
+// List returns the organization's keys without their secrets. Requires
+// OWNER role.
+func (s *APIKeysService) List(ctx context.Context, orgID int64, role string) ([]APIKey, error) {
+	if role != "OWNER" {
+		return nil, ErrForbidden
+	}
+	keys, err := s.repo.List(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+	if keys == nil {
+		keys = []APIKey{}
+	}
+	return keys, nil
+}
+
+// Revoke stops the key from working. Requires OWNER role.
+func (s *APIKeysService) Revoke(ctx context.Context, userID string, orgID int64, role string, id int64) (*APIKey, error) {
+	if role != "OWNER" {
+		return nil, ErrForbidden
+	}
+
+	key, err := s.repo.Revoke(ctx, orgID, id)
+	if err != nil {
+		return nil, err
+	}
+	s.forget(key.ID)
+
+	if err := s.record(ctx, userID, "api_key.revoked", key); err != nil {
+		return nil, err
+	}
+	return key, nil
+}
+
+// VerifyAPIKey returns what the key may do. Unknown, revoked and expired
+// keys are reported as middlewares.ErrInvalidAPIKey.
+func (s *APIKeysService) VerifyAPIKey(ctx context.Context, key string) (middlewares.APIKeyGrant, error) {
+	prefix, secret, ok := splitKey(key)
+	if !ok {
+		return middlewares.APIKeyGrant{}, middlewares.ErrInvalidAPIKey
+	}
+
+	digest := sha256.Sum256([]byte(key))
+	s.mu.Lock()
+	entry, found := s.verified[digest]
+	s.mu.Unlock()
+	if found && time.Now().Before(entry.expires) {
+		return entry.grant, nil
+	}
+
+	record, err := s.repo.FindByPrefix(ctx, prefix)
+	if err != nil {
+		return middlewares.APIKeyGrant{}, err
+	}
+	now := time.Now()
+	if record == nil || record.RevokedAt != nil || (record.ExpiresAt != nil && !now.Before(*record.ExpiresAt)) {
+		return middlewares.APIKeyGrant{}, middlewares.ErrInvalidAPIKey
+	}
+	matches, err := common.Hasher.Compare(secret, record.SecretHash)
+	if err != nil {
+		return middlewares.APIKeyGrant{}, err
+	}
+	if !matches {
+		return middlewares.APIKeyGrant{}, middlewares.ErrInvalidAPIKey
+	}
+
+	// Usage is recorded when the key is verified against the database,
+	// which is at most once per verifiedKeyTTL and instance
+	if err := s.repo.TouchLastUsed(ctx, record.ID); err != nil {
+		s.logger.Error(fmt.Sprintf("Failed to record use of API key %d: %v", record.ID, err))
+	}
+
+	grant := middlewares.APIKeyGrant{
+		KeyID:          record.ID,
+		OrganizationID: record.OrganizationID,
+		Role:           record.Role,
+		Scopes:         record.Scopes,
+	}
+	expires := now.Add(verifiedKeyTTL)
+	if record.ExpiresAt != nil && record.ExpiresAt.Before(expires) {
+		expires = *record.ExpiresAt
+	}
+
+	s.mu.Lock()
+	s.verified[digest] = verifiedKey{grant: grant, expires: expires}
+	s.mu.Unlock()
+	return grant, nil
+}
+
+// forget drops the key from the verified keys.
+func (s *APIKeysService) forget(id int64) {
+	s.mu.Lock()
+	for digest, entry := range s.verified {
+		if entry.grant.KeyID == id {
+			delete(s.verified, digest)
+		}
+	}
+	s.mu.Unlock()
+}
+
+// record writes an audit entry about a key of the organization.
+func (s *APIKeysService) record(ctx context.Context, userID string, action string, key *APIKey) error {
+	targetID := strconv.FormatInt(key.ID, 10)
+	return s.audit.Record(ctx, audit.Entry{
+		OrganizationID:      key.OrganizationID,
+		ActorID:             parseActor(userID),
+		ActorOrganizationID: &key.OrganizationID,
+		Action:              action,
+		TargetType:          "api_key",
+		TargetID:            &targetID,
+		Details: map[string]interface{}{
+			"name":   key.Name,
+			"prefix": key.Prefix,
+			"role":   key.Role,
+			"scopes": key.Scopes,
+		},
+	})
+}
+
+// splitKey splits "hf_<prefix>_<secret>" into the stored prefix
+// ("hf_<prefix>") and the secret.
+func splitKey(key string) (string, string, bool) {
+	rest, ok := strings.CutPrefix(key, keyPrefix)
+	if !ok {
+		return "", "", false
+	}
+	prefix, secret, ok := strings.Cut(rest, "_")
+	if !ok || len(prefix) != 2*prefixBytes || secret == "" {
+		return "", "", false
+	}
+	if _, err := hex.DecodeString(prefix); err != nil {
+		return "", "", false
+	}
+	return keyPrefix + prefix, secret, true
+}
+
+func randomString(n int) (string, error) {
+	raw := make([]byte, n)
+	if _, err := rand.Read(raw); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(raw), nil
+}
+
+func parseActor(userID string) *uuid.UUID {
+	id, err := uuid.Parse(userID)
+	if err != nil {
+		return nil
+	}
+	return &id
+}
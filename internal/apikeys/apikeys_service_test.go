@@ -0,0 +1,68 @@
+package apikeys
+
+import (
+	"context"
+	"crypto/sha256"
+	"testing"
+	"time"
+
+	"hostflow/profile-service/internal/middlewares"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSplitKey(t *testing.T) {
+	prefix, secret, ok := splitKey("hf_3f9a1c2b7d4e_Qm9v_dHN0")
+	require.True(t, ok)
+	assert.Equal(t, "hf_3f9a1c2b7d4e", prefix)
+	assert.Equal(t, "Qm9v_dHN0", secret)
+
+	for _, key := range []string{
+		"",
+		"3f9a1c2b7d4e_secret",
+		"hf_3f9a1c2b7d4e",
+		"hf_3f9a1c2b7d4e_",
+		"hf_short_secret",
+		"hf_zzzzzzzzzzzz_secret",
+	} {
+		_, _, ok := splitKey(key)
+		assert.False(t, ok, key)
+	}
+}
+
+func TestVerifyAPIKey_Cached(t *testing.T) {
+	service := GetAPIKeysService(nil, nil, nil)
+	key := "hf_3f9a1c2b7d4e_secret"
+	grant := middlewares.APIKeyGrant{KeyID: 4, OrganizationID: 1, Role: "MEMBER", Scopes: []string{"users:read"}}
+	service.verified[sha256.Sum256([]byte(key))] = verifiedKey{grant: grant, expires: time.Now().Add(time.Minute)}
+
+	verified, err := service.VerifyAPIKey(context.Background(), key)
+	require.NoError(t, err)
+	assert.Equal(t, grant, verified)
+
+	// Malformed keys are turned away before any lookup
+	_, err = service.VerifyAPIKey(context.Background(), "not-a-key")
+	assert.ErrorIs(t, err, middlewares.ErrInvalidAPIKey)
+
+	service.forget(4)
+	assert.Empty(t, service.verified)
+}
+
+func TestCreate_Validation(t *testing.T) {
+	service := GetAPIKeysService(nil, nil, nil)
+	past := time.Now().Add(-time.Hour)
+
+	_, err := service.Create(context.Background(), "", 1, "MANAGER", CreateAPIKeyRequest{Name: "Sync", Scopes: []string{"users:read"}})
+	assert.ErrorIs(t, err, ErrForbidden)
+
+	cases := map[string]CreateAPIKeyRequest{
+		"blank name":    {Name: "  ", Scopes: []string{"users:read"}},
+		"unknown scope": {Name: "Sync", Scopes: []string{"billing:read"}},
+		"expired":       {Name: "Sync", Scopes: []string{"users:read"}, ExpiresAt: &past},
+	}
+	for name, req := range cases {
+		_, err := service.Create(context.Background(), "", 1, "OWNER", req)
+		assert.ErrorIs(t, err, ErrInvalidInput, name)
+	}
+}
@@ -0,0 +1,16 @@
+package audit
+
+import "go.uber.org/fx"
+
+// ======== EXPORTS ========
+
+// Module exports services present
+var Context = fx.Options(
+	fx.Provide(GetAuditController),
+	fx.Provide(fx.Annotate(
+		GetAuditService,
+		fx.As(new(Service)),
+	)),
+	fx.Provide(GetAuditRepository),
+	fx.Provide(SetAuditRoutes),
+)
@@ -0,0 +1,71 @@
+package audit
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"hostflow/profile-service/internal/middlewares"
+
+	"github.com/gin-gonic/gin"
+)
+
+type AuditController struct {
+	service Service
+}
+
+func GetAuditController(service Service) *AuditController {
+	return &AuditController{
+		service: service,
+	}
+}
+
+// ListAuditLogHandler godoc
+// @Summary List audit log
+// @Description Returns audit entries that touched the requester's organization or were performed by its members, newest first. Requires OWNER role.
+// @Tags audit
+// @Produce json
+// @Security ApiKeyAuth
+// @Param limit query int false "Page size (default 50, max 200)"
+// @Param before query int false "Return entries with an ID below this cursor"
+// @Success 200 {object} Page
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Router /organization/audit-log [get]
+func (c *AuditController) ListAuditLogHandler(ctx *gin.Context) {
+	principal := middlewares.GetPrincipal(ctx)
+
+	limit, errLimit := strconv.Atoi(ctx.DefaultQuery("limit", "0"))
+	before, errBefore := strconv.ParseInt(ctx.DefaultQuery("before", "0"), 10, 64)
+	if errLimit != nil || errBefore != nil {
+		ctx.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "Invalid query",
+			Message: "'limit' and 'before' must be numeric",
+		})
+		return
+	}
+
+	page, err := c.service.List(ctx.Request.Context(), principal.OrganizationID, principal.Role, before, limit)
+	if err != nil {
+		respondError(ctx, "Failed to fetch audit log", err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, page)
+}
+
+// respondError maps service errors to HTTP status codes.
+func respondError(ctx *gin.Context, title string, err error) {
+	status := http.StatusInternalServerError
+	switch {
+	case errors.Is(err, ErrInvalidInput):
+		status = http.StatusBadRequest
+	case errors.Is(err, ErrForbidden):
+		status = http.StatusForbidden
+	}
+
+	ctx.JSON(status, ErrorResponse{
+		Error:   title,
+		Message: err.Error(),
+	})
+}
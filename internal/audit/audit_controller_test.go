@@ -0,0 +1,77 @@
+package audit
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"hostflow/profile-service/internal/middlewares"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockAuditService struct {
+	mock.Mock
+}
+
+func (m *MockAuditService) Record(ctx context.Context, entry Entry) error {
+	return m.Called(ctx, entry).Error(0)
+}
+
+func (m *MockAuditService) RecordTx(ctx context.Context, tx pgx.Tx, entry Entry) error {
+	return m.Called(ctx, tx, entry).Error(0)
+}
+
+func (m *MockAuditService) List(ctx context.Context, orgID int64, role string, before int64, limit int) (*Page, error) {
+	args := m.Called(ctx, orgID, role, before, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*Page), args.Error(1)
+}
+
+func TestListAuditLogHandler_PassesCursor(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	mockSvc := new(MockAuditService)
+	controller := GetAuditController(mockSvc)
+
+	r := gin.New()
+	r.GET("/organization/audit-log", func(c *gin.Context) {
+		middlewares.SetPrincipal(c, middlewares.Principal{OrganizationID: 1, Role: "OWNER"})
+		controller.ListAuditLogHandler(c)
+	})
+
+	mockSvc.On("List", mock.Anything, int64(1), "OWNER", int64(120), 10).
+		Return(&Page{Entries: []Entry{{ID: 119, Action: "hierarchy.child_members_listed"}}}, nil)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/organization/audit-log?limit=10&before=120", nil)
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), "hierarchy.child_members_listed")
+}
+
+func TestListAuditLogHandler_Forbidden(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	mockSvc := new(MockAuditService)
+	controller := GetAuditController(mockSvc)
+
+	r := gin.New()
+	r.GET("/organization/audit-log", func(c *gin.Context) {
+		middlewares.SetPrincipal(c, middlewares.Principal{OrganizationID: 1, Role: "MEMBER"})
+		controller.ListAuditLogHandler(c)
+	})
+
+	mockSvc.On("List", mock.Anything, int64(1), "MEMBER", int64(0), 0).Return(nil, ErrForbidden)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/organization/audit-log", nil)
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusForbidden, w.Code)
+}
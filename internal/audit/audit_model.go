@@ -0,0 +1,33 @@
+package audit
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Entry is a single audit log record.
+type Entry struct {
+	ID                  int64                  `json:"id" db:"id"`
+	OrganizationID      int64                  `json:"organization_id" db:"organization_id"`
+	ActorID             *uuid.UUID             `json:"actor_id" db:"actor_id"`
+	ActorOrganizationID *int64                 `json:"actor_organization_id" db:"actor_organization_id"`
+	Action              string                 `json:"action" db:"action" example:"hierarchy.child_member_status_changed"`
+	TargetType          string                 `json:"target_type" db:"target_type" example:"profile"`
+	TargetID            *string                `json:"target_id" db:"target_id"`
+	Details             map[string]interface{} `json:"details" db:"details"`
+	CreatedAt           time.Time              `json:"created_at" db:"created_at"`
+}
+
+// Page is a page of entries, newest first. NextBefore is the cursor for the
+// next page, nil on the last one.
+type Page struct {
+	Entries    []Entry `json:"entries"`
+	NextBefore *int64  `json:"next_before"`
+}
+
+// ErrorResponse represents an error response
+type ErrorResponse struct {
+	Error   string `json:"error" example:"Invalid request"`
+	Message string `json:"message,omitempty" example:"The provided data is invalid"`
+}
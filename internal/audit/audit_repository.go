@@ -0,0 +1,69 @@
+package audit
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type AuditRepository struct {
+	db *pgxpool.Pool
+}
+
+func GetAuditRepository(db *pgxpool.Pool) *AuditRepository {
+	return &AuditRepository{
+		db: db,
+	}
+}
+
+// Insert appends an entry to the audit log.
+func (r *AuditRepository) Insert(ctx context.Context, entry Entry) error {
+	return insert(ctx, r.db, entry)
+}
+
+// InsertTx appends an entry to the audit log inside tx, so it is only kept
+// if the audited change is.
+func (r *AuditRepository) InsertTx(ctx context.Context, tx pgx.Tx, entry Entry) error {
+	return insert(ctx, tx, entry)
+}
+
+// execer is what insert needs of a pool or a transaction.
+type execer interface {
+	Exec(ctx context.Context, sql string, arguments ...any) (pgconn.CommandTag, error)
+}
+
+func insert(ctx context.Context, db execer, entry Entry) error {
+	details := entry.Details
+	if details == nil {
+		details = map[string]interface{}{}
+	}
+
+	_, err := db.Exec(ctx, `
+        INSERT INTO audit_log (organization_id, actor_id, actor_organization_id, action, target_type, target_id, details)
+        VALUES ($1, $2, $3, $4, $5, $6, $7)
+    `, entry.OrganizationID, entry.ActorID, entry.ActorOrganizationID, entry.Action, entry.TargetType, entry.TargetID, details)
+	return err
+}
+
+// List returns entries that touched the organization or were performed by
+// its members, newest first, with IDs below before (0 means no cursor).
+func (r *AuditRepository) List(ctx context.Context, orgID int64, before int64, limit int) ([]Entry, error) {
+	query := `
+        SELECT id, organization_id, actor_id, actor_organization_id, action, target_type, target_id, details, created_at
+        FROM audit_log
+        WHERE (organization_id = $1 OR actor_organization_id = $1)
+          AND ($2::bigint = 0 OR id < $2)
+        ORDER BY id DESC
+        LIMIT $3
+    `
+
+	rows, err := r.db.Query(ctx, query, orgID, before, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return pgx.CollectRows(rows, pgx.RowToStructByName[Entry])
+}
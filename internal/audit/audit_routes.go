@@ -0,0 +1,39 @@
+package audit
+
+import (
+	"hostflow/profile-service/internal/middlewares"
+	"hostflow/profile-service/pkg/lib"
+)
+
+type AuditRoutes struct {
+	logger          lib.Logger
+	router          *lib.Router
+	auditController *AuditController
+	authMiddleware  middlewares.AuthMiddleware
+}
+
+func SetAuditRoutes(
+	logger lib.Logger,
+	router *lib.Router,
+	auditController *AuditController,
+	authMiddleware middlewares.AuthMiddleware,
+) AuditRoutes {
+	return AuditRoutes{
+		logger:          logger,
+		router:          router,
+		auditController: auditController,
+		authMiddleware:  authMiddleware,
+	}
+}
+
+func (route AuditRoutes) Setup() {
+	route.logger.Info("Setting up [AUDIT] routes.")
+
+	organizations := route.router.Group("/organization")
+	organizations.Use(route.authMiddleware.Handler())
+	{
+		organizations.GET("/audit-log", route.auditController.ListAuditLogHandler)
+	}
+
+	route.logger.Info("[AUDIT] routes setup complete.")
+}
@@ -0,0 +1,108 @@
+package audit
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"hostflow/profile-service/internal/middlewares"
+
+	"github.com/jackc/pgx/v5"
+)
+
+const (
+	// DefaultPageSize and MaxPageSize bound audit log pages.
+	DefaultPageSize = 50
+	MaxPageSize     = 200
+)
+
+var (
+	ErrForbidden    = errors.New("you are not allowed to perform this action")
+	ErrInvalidInput = errors.New("invalid input")
+)
+
+type AuditService struct {
+	repo *AuditRepository
+}
+
+type Service interface {
+	Record(ctx context.Context, entry Entry) error
+	RecordTx(ctx context.Context, tx pgx.Tx, entry Entry) error
+	List(ctx context.Context, orgID int64, role string, before int64, limit int) (*Page, error)
+}
+
+func GetAuditService(repo *AuditRepository) *AuditService {
+	return &AuditService{
+		repo: repo,
+	}
+}
+
+// Record appends an entry. Callers treat a failure as a failure of the
+// audited action itself. Entries of actions taken in an impersonation
+// session name the staff member in the impersonated_by detail.
+func (s *AuditService) Record(ctx context.Context, entry Entry) error {
+	entry, err := prepare(ctx, entry)
+	if err != nil {
+		return err
+	}
+	return s.repo.Insert(ctx, entry)
+}
+
+// RecordTx is Record inside the transaction of the audited change, so the
+// entry is only kept when the change is committed.
+func (s *AuditService) RecordTx(ctx context.Context, tx pgx.Tx, entry Entry) error {
+	entry, err := prepare(ctx, entry)
+	if err != nil {
+		return err
+	}
+	return s.repo.InsertTx(ctx, tx, entry)
+}
+
+// prepare validates an entry and stamps the impersonation session it was
+// made in.
+func prepare(ctx context.Context, entry Entry) (Entry, error) {
+	if entry.OrganizationID == 0 || entry.Action == "" || entry.TargetType == "" {
+		return Entry{}, fmt.Errorf("%w: audit entries need an organization, action and target type", ErrInvalidInput)
+	}
+	if principal, ok := middlewares.PrincipalFromContext(ctx); ok && principal.Impersonated() {
+		details := make(map[string]interface{}, len(entry.Details)+2)
+		for key, value := range entry.Details {
+			details[key] = value
+		}
+		details["impersonated_by"] = principal.ImpersonatorID
+		details["impersonation_id"] = principal.SessionID
+		entry.Details = details
+	}
+	return entry, nil
+}
+
+// List returns a page of the organization's audit log. Requires OWNER role.
+func (s *AuditService) List(ctx context.Context, orgID int64, role string, before int64, limit int) (*Page, error) {
+	if role != "OWNER" {
+		return nil, ErrForbidden
+	}
+	if limit <= 0 {
+		limit = DefaultPageSize
+	}
+	if limit > MaxPageSize {
+		return nil, fmt.Errorf("%w: limit must not exceed %d", ErrInvalidInput, MaxPageSize)
+	}
+	if before < 0 {
+		return nil, fmt.Errorf("%w: before must be a positive entry ID", ErrInvalidInput)
+	}
+
+	entries, err := s.repo.List(ctx, orgID, before, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	page := &Page{Entries: entries}
+	if page.Entries == nil {
+		page.Entries = []Entry{}
+	}
+	if len(entries) == limit {
+		next := entries[len(entries)-1].ID
+		page.NextBefore = &next
+	}
+	return page, nil
+}
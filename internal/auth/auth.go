@@ -0,0 +1,24 @@
+package auth
+
+import (
+	"hostflow/profile-service/internal/middlewares"
+	"hostflow/profile-service/pkg/interfaces"
+
+	"go.uber.org/fx"
+)
+
+// ======== EXPORTS ========
+
+// Module exports services present
+var Context = fx.Options(
+	fx.Provide(GetAuthController),
+	fx.Provide(fx.Annotate(
+		GetAuthService,
+		fx.As(new(Service)),
+		fx.As(new(interfaces.AuthService)),
+		fx.As(new(middlewares.ServiceTokenVerifier)),
+	)),
+	fx.Provide(GetAuthRepository),
+	fx.Provide(SetAuthRoutes),
+	fx.Invoke(RegisterKeyRotationWorker),
+)
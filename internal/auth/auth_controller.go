@@ -0,0 +1,174 @@
+package auth
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"hostflow/profile-service/pkg/common"
+
+	"github.com/gin-gonic/gin"
+)
+
+type AuthController struct {
+	service Service
+}
+
+func GetAuthController(service Service) *AuthController {
+	return &AuthController{
+		service: service,
+	}
+}
+
+// IssueTokenHandler godoc
+// @Summary Issue a service token
+// @Description Exchanges the credentials of a service client for a short-lived token (client credentials grant). Credentials are taken from HTTP basic authentication or the body; the body can be JSON or form encoded. The token is accepted on internal routes that require its scopes.
+// @Tags auth
+// @Accept json,x-www-form-urlencoded
+// @Produce json
+// @Param body body TokenRequest true "Client credentials"
+// @Success 200 {object} TokenResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 503 {object} ErrorResponse
+// @Router /auth/token [post]
+func (c *AuthController) IssueTokenHandler(ctx *gin.Context) {
+	var body TokenRequest
+	if errors := common.Validation.ValidateBody(ctx, &body); errors != nil {
+		ctx.JSON(http.StatusBadRequest, errors)
+		return
+	}
+	if body.GrantType != GrantClientCredentials {
+		respondError(ctx, "Failed to issue token", ErrUnsupportedGrant)
+		return
+	}
+
+	if username, password, ok := ctx.Request.BasicAuth(); ok {
+		id, err := strconv.ParseInt(username, 10, 32)
+		if err != nil {
+			respondError(ctx, "Failed to issue token", ErrInvalidClient)
+			return
+		}
+		body.ClientID = int32(id)
+		body.ClientSecret = password
+	}
+
+	token, err := c.service.Issue(ctx.Request.Context(), body.ClientID, body.ClientSecret, body.Scope)
+	if err != nil {
+		respondError(ctx, "Failed to issue token", err)
+		return
+	}
+
+	ctx.Header("Cache-Control", "no-store")
+	ctx.JSON(http.StatusOK, token)
+}
+
+// GetJWKSHandler godoc
+// @Summary Service token keys
+// @Description Returns the public keys service tokens are signed with, as a JSON Web Key Set. New keys appear an hour before they are used.
+// @Tags auth
+// @Produce json
+// @Success 200 {object} JWKS
+// @Router /.well-known/jwks.json [get]
+func (c *AuthController) GetJWKSHandler(ctx *gin.Context) {
+	ctx.Header("Cache-Control", "public, max-age=300")
+	ctx.JSON(http.StatusOK, c.service.JWKS())
+}
+
+// RegisterClientHandler godoc
+// @Summary Register a service client (internal)
+// @Description Registers a service allowed to request tokens with the given scopes. The client secret is only returned in this response. Requires the internal API token.
+// @Tags internal
+// @Accept json
+// @Produce json
+// @Param body body RegisterClientRequest true "Service client"
+// @Success 201 {object} RegisteredClient
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Router /internal/service-clients [post]
+func (c *AuthController) RegisterClientHandler(ctx *gin.Context) {
+	var body RegisterClientRequest
+	if errors := common.Validation.ValidateBody(ctx, &body); errors != nil {
+		ctx.JSON(http.StatusBadRequest, errors)
+		return
+	}
+
+	client, err := c.service.RegisterClient(ctx.Request.Context(), body)
+	if err != nil {
+		respondError(ctx, "Failed to register service client", err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, client)
+}
+
+// ListClientsHandler godoc
+// @Summary List service clients (internal)
+// @Description Returns all service clients, including disabled ones. Requires the internal API token.
+// @Tags internal
+// @Produce json
+// @Success 200 {array} ServiceClient
+// @Failure 401 {object} ErrorResponse
+// @Router /internal/service-clients [get]
+func (c *AuthController) ListClientsHandler(ctx *gin.Context) {
+	clients, err := c.service.ListClients(ctx.Request.Context())
+	if err != nil {
+		respondError(ctx, "Failed to fetch service clients", err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, clients)
+}
+
+// DisableClientHandler godoc
+// @Summary Disable a service client (internal)
+// @Description Stops a service client from getting new tokens. Tokens already issued stay valid until they expire. Requires the internal API token.
+// @Tags internal
+// @Param id path int true "Client ID"
+// @Success 204
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /internal/service-clients/{id} [delete]
+func (c *AuthController) DisableClientHandler(ctx *gin.Context) {
+	id, err := strconv.ParseInt(ctx.Param("id"), 10, 32)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "Invalid ID format",
+			Message: "Client ID must be numeric",
+		})
+		return
+	}
+
+	if err := c.service.DisableClient(ctx.Request.Context(), int32(id)); err != nil {
+		respondError(ctx, "Failed to disable service client", err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+// respondError maps service errors to HTTP status codes.
+func respondError(ctx *gin.Context, title string, err error) {
+	status := http.StatusInternalServerError
+	switch {
+	case errors.Is(err, ErrInvalidInput),
+		errors.Is(err, ErrInvalidScope),
+		errors.Is(err, ErrUnsupportedGrant):
+		status = http.StatusBadRequest
+	case errors.Is(err, ErrInvalidClient):
+		status = http.StatusUnauthorized
+	case errors.Is(err, ErrClientNotFound):
+		status = http.StatusNotFound
+	case errors.Is(err, ErrClientExists):
+		status = http.StatusConflict
+	case errors.Is(err, ErrNoSigningKey):
+		status = http.StatusServiceUnavailable
+	}
+
+	ctx.JSON(status, ErrorResponse{
+		Error:   title,
+		Message: err.Error(),
+	})
+}
@@ -0,0 +1,139 @@
+package auth
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"hostflow/profile-service/internal/middlewares"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockAuthService struct {
+	mock.Mock
+}
+
+func (m *MockAuthService) CheckToken(tokenString string) (*int32, error) {
+	args := m.Called(tokenString)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*int32), args.Error(1)
+}
+
+func (m *MockAuthService) CreateToken(id int32) (*string, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*string), args.Error(1)
+}
+
+func (m *MockAuthService) VerifyServiceToken(tokenString string) (middlewares.ServiceCaller, error) {
+	args := m.Called(tokenString)
+	return args.Get(0).(middlewares.ServiceCaller), args.Error(1)
+}
+
+func (m *MockAuthService) Issue(ctx context.Context, clientID int32, secret string, scope string) (*TokenResponse, error) {
+	args := m.Called(ctx, clientID, secret, scope)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*TokenResponse), args.Error(1)
+}
+
+func (m *MockAuthService) JWKS() JWKS {
+	return m.Called().Get(0).(JWKS)
+}
+
+func (m *MockAuthService) RegisterClient(ctx context.Context, req RegisterClientRequest) (*RegisteredClient, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*RegisteredClient), args.Error(1)
+}
+
+func (m *MockAuthService) ListClients(ctx context.Context) ([]ServiceClient, error) {
+	args := m.Called(ctx)
+	return args.Get(0).([]ServiceClient), args.Error(1)
+}
+
+func (m *MockAuthService) DisableClient(ctx context.Context, id int32) error {
+	return m.Called(ctx, id).Error(0)
+}
+
+func (m *MockAuthService) RotateKeys(ctx context.Context) error {
+	return m.Called(ctx).Error(0)
+}
+
+func TestIssueTokenHandler_BasicAuth(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	mockSvc := new(MockAuthService)
+	controller := GetAuthController(mockSvc)
+
+	r := gin.New()
+	r.POST("/auth/token", controller.IssueTokenHandler)
+
+	mockSvc.On("Issue", mock.Anything, int32(3), "s3cret", "billing:read").
+		Return(&TokenResponse{AccessToken: "token", TokenType: "Bearer", ExpiresIn: 300, Scope: "billing:read"}, nil)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("POST", "/auth/token", strings.NewReader("grant_type=client_credentials&scope=billing:read"))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.SetBasicAuth("3", "s3cret")
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "no-store", w.Header().Get("Cache-Control"))
+	assert.Contains(t, w.Body.String(), `"access_token":"token"`)
+	mockSvc.AssertExpectations(t)
+}
+
+func TestIssueTokenHandler_Errors(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	mockSvc := new(MockAuthService)
+	controller := GetAuthController(mockSvc)
+
+	r := gin.New()
+	r.POST("/auth/token", controller.IssueTokenHandler)
+
+	mockSvc.On("Issue", mock.Anything, int32(3), "wrong", "").Return(nil, ErrInvalidClient)
+
+	cases := map[string]int{
+		`{"grant_type":"password","client_id":3,"client_secret":"wrong"}`:           http.StatusBadRequest,
+		`{"grant_type":"client_credentials","client_id":3,"client_secret":"wrong"}`: http.StatusUnauthorized,
+	}
+	for body, status := range cases {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", "/auth/token", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, status, w.Code, body)
+	}
+}
+
+func TestRegisterClientHandler_Duplicate(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	mockSvc := new(MockAuthService)
+	controller := GetAuthController(mockSvc)
+
+	r := gin.New()
+	r.POST("/internal/service-clients", controller.RegisterClientHandler)
+
+	mockSvc.On("RegisterClient", mock.Anything, RegisterClientRequest{Name: "booking-service", Scopes: []string{"billing:read"}}).
+		Return(nil, ErrClientExists)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("POST", "/internal/service-clients", strings.NewReader(`{"name":"booking-service","scopes":["billing:read"]}`))
+	req.Header.Set("Content-Type", "application/json")
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusConflict, w.Code)
+}
@@ -0,0 +1,139 @@
+package auth
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"time"
+
+	"hostflow/profile-service/pkg/common"
+)
+
+// activeKey is a signing key ready for use.
+type activeKey struct {
+	kid         string
+	private     *ecdsa.PrivateKey
+	public      json.RawMessage
+	activatesAt time.Time
+	expiresAt   *time.Time
+}
+
+// keyRing holds the signing keys of the service, oldest first.
+type keyRing []activeKey
+
+// signing returns the most recently activated key. Keys that are published
+// but not active yet are skipped.
+func (r keyRing) signing(now time.Time) (activeKey, bool) {
+	for i := len(r) - 1; i >= 0; i-- {
+		if !r[i].activatesAt.After(now) {
+			return r[i], true
+		}
+	}
+	return activeKey{}, false
+}
+
+// lookup returns the public key with the kid, unless it has expired.
+func (r keyRing) lookup(kid string, now time.Time) (*ecdsa.PublicKey, bool) {
+	for _, key := range r {
+		if key.kid == kid && (key.expiresAt == nil || now.Before(*key.expiresAt)) {
+			return &key.private.PublicKey, true
+		}
+	}
+	return nil, false
+}
+
+// jwks returns the public keys that are still in use or about to be.
+func (r keyRing) jwks(now time.Time) JWKS {
+	set := JWKS{Keys: []json.RawMessage{}}
+	for _, key := range r {
+		if key.expiresAt == nil || now.Before(*key.expiresAt) {
+			set.Keys = append(set.Keys, key.public)
+		}
+	}
+	return set
+}
+
+// generateKey creates an ES256 key pair that activates at the given time,
+// with the private key encrypted for storage. The kid is bound to the
+// ciphertext so a key cannot be swapped for another row's.
+func generateKey(cipher *common.Cipher, activatesAt time.Time) (*signingKey, error) {
+	private, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+
+	id := make([]byte, 12)
+	if _, err := rand.Read(id); err != nil {
+		return nil, err
+	}
+	kid := hex.EncodeToString(id)
+
+	der, err := x509.MarshalECPrivateKey(private)
+	if err != nil {
+		return nil, err
+	}
+	encrypted, err := cipher.Encrypt(base64.StdEncoding.EncodeToString(der), []byte(kid))
+	if err != nil {
+		return nil, err
+	}
+
+	public, err := publicJWK(kid, &private.PublicKey)
+	if err != nil {
+		return nil, err
+	}
+
+	return &signingKey{
+		KID:         kid,
+		PrivateKey:  encrypted,
+		PublicKey:   public,
+		ActivatesAt: activatesAt,
+	}, nil
+}
+
+// openKey decrypts a stored key.
+func openKey(cipher *common.Cipher, key signingKey) (activeKey, error) {
+	encoded, err := cipher.Decrypt(key.PrivateKey, []byte(key.KID))
+	if err != nil {
+		return activeKey{}, err
+	}
+	der, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return activeKey{}, err
+	}
+	private, err := x509.ParseECPrivateKey(der)
+	if err != nil {
+		return activeKey{}, err
+	}
+
+	return activeKey{
+		kid:         key.KID,
+		private:     private,
+		public:      key.PublicKey,
+		activatesAt: key.ActivatesAt,
+		expiresAt:   key.ExpiresAt,
+	}, nil
+}
+
+// publicJWK encodes the public key as a JSON Web Key.
+func publicJWK(kid string, public *ecdsa.PublicKey) (json.RawMessage, error) {
+	point, err := public.Bytes()
+	if err != nil {
+		return nil, err
+	}
+
+	// The uncompressed point is 0x04 followed by X and Y
+	encode := base64.RawURLEncoding.EncodeToString
+	return json.Marshal(map[string]string{
+		"kty": "EC",
+		"crv": "P-256",
+		"alg": "ES256",
+		"use": "sig",
+		"kid": kid,
+		"x":   encode(point[1:33]),
+		"y":   encode(point[33:65]),
+	})
+}
@@ -0,0 +1,87 @@
+package auth
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// Scopes of the internal API that service clients can be granted.
+const (
+	ScopeBillingRead = "billing:read"
+	ScopePlansRead   = "plans:read"
+	ScopePlansWrite  = "plans:write"
+	ScopeClosureRead = "closure:read"
+)
+
+// GrantClientCredentials is the only grant type of the token endpoint.
+const GrantClientCredentials = "client_credentials"
+
+// KnownScopes lists every scope a client can be registered with.
+var KnownScopes = []string{ScopeBillingRead, ScopePlansRead, ScopePlansWrite, ScopeClosureRead}
+
+// ServiceClient is another service allowed to request service tokens.
+type ServiceClient struct {
+	ID         int32      `json:"id" db:"id" example:"1"`
+	Name       string     `json:"name" db:"name" example:"booking-service"`
+	Scopes     []string   `json:"scopes" db:"scopes" example:"billing:read,plans:read"`
+	CreatedAt  time.Time  `json:"created_at" db:"created_at"`
+	DisabledAt *time.Time `json:"disabled_at,omitempty" db:"disabled_at"`
+}
+
+// clientRecord is a service client with its hashed secret.
+type clientRecord struct {
+	ServiceClient
+	SecretHash string `db:"secret_hash"`
+}
+
+// RegisteredClient is returned once on registration; the secret cannot be
+// read again afterwards.
+type RegisteredClient struct {
+	ServiceClient
+	Secret string `json:"client_secret" example:"3q2-7wQx..."`
+}
+
+// RegisterClientRequest registers a service client.
+type RegisterClientRequest struct {
+	Name   string   `json:"name" binding:"required,max=100" example:"booking-service"`
+	Scopes []string `json:"scopes" binding:"required,min=1,dive,required" example:"billing:read,plans:read"`
+}
+
+// TokenRequest exchanges client credentials for a service token. The
+// credentials can also be sent with HTTP basic authentication. Scope is a
+// space separated subset of the client's scopes; all of them when empty.
+type TokenRequest struct {
+	GrantType    string `json:"grant_type" form:"grant_type" binding:"required" example:"client_credentials"`
+	ClientID     int32  `json:"client_id" form:"client_id" example:"1"`
+	ClientSecret string `json:"client_secret" form:"client_secret"`
+	Scope        string `json:"scope" form:"scope" example:"billing:read"`
+}
+
+// TokenResponse is an issued service token.
+type TokenResponse struct {
+	AccessToken string `json:"access_token"`
+	TokenType   string `json:"token_type" example:"Bearer"`
+	ExpiresIn   int    `json:"expires_in" example:"300"`
+	Scope       string `json:"scope" example:"billing:read"`
+}
+
+// JWKS is the set of public keys service tokens are verified with.
+type JWKS struct {
+	Keys []json.RawMessage `json:"keys" swaggertype:"array,object"`
+}
+
+// signingKey is a stored key pair. The private key is encrypted.
+type signingKey struct {
+	KID         string          `db:"kid"`
+	PrivateKey  string          `db:"private_key"`
+	PublicKey   json.RawMessage `db:"public_key"`
+	CreatedAt   time.Time       `db:"created_at"`
+	ActivatesAt time.Time       `db:"activates_at"`
+	ExpiresAt   *time.Time      `db:"expires_at"`
+}
+
+// ErrorResponse represents an error response
+type ErrorResponse struct {
+	Error   string `json:"error" example:"Invalid request"`
+	Message string `json:"message,omitempty" example:"The provided data is invalid"`
+}
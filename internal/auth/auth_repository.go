@@ -0,0 +1,169 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+const (
+	clientColumns = `id, name, scopes, created_at, disabled_at`
+	keyColumns    = `kid, private_key, public_key, created_at, activates_at, expires_at`
+)
+
+type AuthRepository struct {
+	db *pgxpool.Pool
+}
+
+func GetAuthRepository(db *pgxpool.Pool) *AuthRepository {
+	return &AuthRepository{
+		db: db,
+	}
+}
+
+// CreateClient stores a new service client.
+func (r *AuthRepository) CreateClient(ctx context.Context, name string, secretHash string, scopes []string) (*ServiceClient, error) {
+	rows, err := r.db.Query(ctx, `
+        INSERT INTO service_clients (name, secret_hash, scopes)
+        VALUES ($1, $2, $3)
+        RETURNING `+clientColumns,
+		name, secretHash, scopes,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	client, err := pgx.CollectOneRow(rows, pgx.RowToStructByName[ServiceClient])
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+			return nil, ErrClientExists
+		}
+		return nil, err
+	}
+	return &client, nil
+}
+
+// FindClient returns the client with its secret hash, or nil if there is
+// none.
+func (r *AuthRepository) FindClient(ctx context.Context, id int32) (*clientRecord, error) {
+	rows, err := r.db.Query(ctx,
+		`SELECT `+clientColumns+`, secret_hash FROM service_clients WHERE id = $1`,
+		id,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	record, err := pgx.CollectOneRow(rows, pgx.RowToStructByName[clientRecord])
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &record, nil
+}
+
+// ListClients returns all clients, including disabled ones.
+func (r *AuthRepository) ListClients(ctx context.Context) ([]ServiceClient, error) {
+	rows, err := r.db.Query(ctx, `SELECT `+clientColumns+` FROM service_clients ORDER BY id`)
+	if err != nil {
+		return nil, err
+	}
+	return pgx.CollectRows(rows, pgx.RowToStructByName[ServiceClient])
+}
+
+// DisableClient stops the client from getting new tokens.
+func (r *AuthRepository) DisableClient(ctx context.Context, id int32) error {
+	tag, err := r.db.Exec(ctx,
+		`UPDATE service_clients SET disabled_at = COALESCE(disabled_at, now()) WHERE id = $1`,
+		id,
+	)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrClientNotFound
+	}
+	return nil
+}
+
+// ListKeys returns the signing keys that have not expired, oldest first.
+func (r *AuthRepository) ListKeys(ctx context.Context) ([]signingKey, error) {
+	rows, err := r.db.Query(ctx, `
+        SELECT `+keyColumns+`
+        FROM service_signing_keys
+        WHERE expires_at IS NULL OR expires_at > now()
+        ORDER BY activates_at, created_at`,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return pgx.CollectRows(rows, pgx.RowToStructByName[signingKey])
+}
+
+// RotateKeys deletes expired keys and hands the remaining ones to next. When
+// next returns a key it is stored, and the keys before it expire retention
+// after it activates. Rotation is serialized with a transaction-scoped
+// advisory lock so instances starting together add a single key.
+func (r *AuthRepository) RotateKeys(ctx context.Context, retention time.Duration, next func(keys []signingKey) (*signingKey, error)) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	if _, err := tx.Exec(ctx, `SELECT pg_advisory_xact_lock(hashtext('service_signing_keys'))`); err != nil {
+		return err
+	}
+
+	if _, err := tx.Exec(ctx, `DELETE FROM service_signing_keys WHERE expires_at <= now()`); err != nil {
+		return err
+	}
+
+	rows, err := tx.Query(ctx, `
+        SELECT `+keyColumns+`
+        FROM service_signing_keys
+        ORDER BY activates_at, created_at`,
+	)
+	if err != nil {
+		return err
+	}
+	keys, err := pgx.CollectRows(rows, pgx.RowToStructByName[signingKey])
+	if err != nil {
+		return err
+	}
+
+	key, err := next(keys)
+	if err != nil || key == nil {
+		return err
+	}
+
+	_, err = tx.Exec(ctx, `
+        UPDATE service_signing_keys
+        SET expires_at = $1
+        WHERE expires_at IS NULL`,
+		key.ActivatesAt.Add(retention),
+	)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(ctx, `
+        INSERT INTO service_signing_keys (kid, private_key, public_key, activates_at)
+        VALUES ($1, $2, $3, $4)`,
+		key.KID, key.PrivateKey, key.PublicKey, key.ActivatesAt,
+	)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
@@ -0,0 +1,47 @@
+package auth
+
+import (
+	"hostflow/profile-service/internal/middlewares"
+	"hostflow/profile-service/pkg/lib"
+)
+
+type AuthRoutes struct {
+	logger             lib.Logger
+	router             *lib.Router
+	authController     *AuthController
+	internalMiddleware middlewares.InternalMiddleware
+}
+
+func SetAuthRoutes(
+	logger lib.Logger,
+	router *lib.Router,
+	authController *AuthController,
+	internalMiddleware middlewares.InternalMiddleware,
+) AuthRoutes {
+	return AuthRoutes{
+		logger:             logger,
+		router:             router,
+		authController:     authController,
+		internalMiddleware: internalMiddleware,
+	}
+}
+
+func (route AuthRoutes) Setup() {
+	route.logger.Info("Setting up [AUTH] routes.")
+
+	// Public: clients authenticate with their credentials
+	route.router.GET("/.well-known/jwks.json", route.authController.GetJWKSHandler)
+	route.router.POST("/auth/token", route.authController.IssueTokenHandler)
+
+	// Clients are managed with the shared token only, so a service token
+	// cannot mint further clients
+	internal := route.router.Group("/internal")
+	internal.Use(route.internalMiddleware.Handler())
+	{
+		internal.POST("/service-clients", route.authController.RegisterClientHandler)
+		internal.GET("/service-clients", route.authController.ListClientsHandler)
+		internal.DELETE("/service-clients/:id", route.authController.DisableClientHandler)
+	}
+
+	route.logger.Info("[AUTH] routes setup complete.")
+}
@@ -0,0 +1,435 @@
+package auth
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"hostflow/profile-service/internal/middlewares"
+	"hostflow/profile-service/pkg/common"
+	"hostflow/profile-service/pkg/interfaces"
+	"hostflow/profile-service/pkg/lib"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+var (
+	ErrInvalidClient    = errors.New("invalid client credentials")
+	ErrInvalidScope     = errors.New("scope is not granted to the client")
+	ErrUnsupportedGrant = errors.New("grant_type must be client_credentials")
+	ErrClientNotFound   = errors.New("service client not found")
+	ErrClientExists     = errors.New("a service client with this name already exists")
+	ErrInvalidInput     = errors.New("invalid input")
+	ErrNoSigningKey     = errors.New("no signing key is available")
+	ErrUnknownKey       = errors.New("token is signed with an unknown key")
+)
+
+const (
+	// DefaultTokenIssuer is the iss and aud of service tokens, unless
+	// SERVICE_TOKEN_ISSUER says otherwise.
+	DefaultTokenIssuer = "profile-service"
+
+	// DefaultTokenTTL is how long service tokens are valid, unless
+	// SERVICE_TOKEN_TTL says otherwise.
+	DefaultTokenTTL = 5 * time.Minute
+
+	// DefaultRotationInterval is how long a signing key is used before the
+	// next one takes over, unless SERVICE_KEY_ROTATION_INTERVAL says
+	// otherwise.
+	DefaultRotationInterval = 7 * 24 * time.Hour
+
+	// maxTokenTTL keeps service tokens short-lived; they cannot be revoked.
+	maxTokenTTL = time.Hour
+
+	// keyPublishLead is how long a new key is published before it signs
+	// tokens, so verifiers caching the JWKS know it in time.
+	keyPublishLead = time.Hour
+
+	// keyRefreshInterval is how often every instance rotates and reloads
+	// its keys. It must stay well below keyPublishLead.
+	keyRefreshInterval = 10 * time.Minute
+
+	// reloadCooldown limits reloads triggered by tokens with unknown keys.
+	reloadCooldown = 30 * time.Second
+
+	// tokenLeeway is the clock skew tolerated between services.
+	tokenLeeway = 30 * time.Second
+)
+
+// Config describes the service tokens this service issues.
+type Config struct {
+	Issuer           string
+	TokenTTL         time.Duration
+	RotationInterval time.Duration
+}
+
+// LoadConfig reads the service token configuration from the environment.
+func LoadConfig() (Config, error) {
+	config := Config{Issuer: os.Getenv("SERVICE_TOKEN_ISSUER")}
+	if config.Issuer == "" {
+		config.Issuer = DefaultTokenIssuer
+	}
+
+	var err error
+	if config.TokenTTL, err = lib.DurationFromEnv("SERVICE_TOKEN_TTL", DefaultTokenTTL); err != nil {
+		return Config{}, err
+	}
+	if config.TokenTTL > maxTokenTTL {
+		return Config{}, fmt.Errorf("SERVICE_TOKEN_TTL must not exceed %s", maxTokenTTL)
+	}
+	if config.RotationInterval, err = lib.DurationFromEnv("SERVICE_KEY_ROTATION_INTERVAL", DefaultRotationInterval); err != nil {
+		return Config{}, err
+	}
+	if config.RotationInterval <= 2*keyPublishLead {
+		return Config{}, fmt.Errorf("SERVICE_KEY_ROTATION_INTERVAL must exceed %s", 2*keyPublishLead)
+	}
+	return config, nil
+}
+
+// serviceClaims are the claims of a service token. The subject is the
+// client ID.
+type serviceClaims struct {
+	Scope      string `json:"scope"`
+	ClientName string `json:"client_name,omitempty"`
+	jwt.RegisteredClaims
+}
+
+type AuthService struct {
+	repo   *AuthRepository
+	cipher *common.Cipher
+	config Config
+	parser *jwt.Parser
+	logger lib.Logger
+	now    func() time.Time
+
+	mu         sync.RWMutex
+	ring       keyRing
+	lastReload time.Time
+}
+
+type Service interface {
+	interfaces.AuthService
+	middlewares.ServiceTokenVerifier
+	Issue(ctx context.Context, clientID int32, secret string, scope string) (*TokenResponse, error)
+	JWKS() JWKS
+	RegisterClient(ctx context.Context, req RegisterClientRequest) (*RegisteredClient, error)
+	ListClients(ctx context.Context) ([]ServiceClient, error)
+	DisableClient(ctx context.Context, id int32) error
+	RotateKeys(ctx context.Context) error
+}
+
+// GetAuthService builds the service with the key from
+// SERVICE_KEY_ENCRYPTION_KEY (base64, 32 bytes), which encrypts the stored
+// signing keys. Keys are loaded when the app starts.
+func GetAuthService(repo *AuthRepository, logger lib.Logger) (*AuthService, error) {
+	cipher, err := common.NewCipherFromBase64(os.Getenv("SERVICE_KEY_ENCRYPTION_KEY"))
+	if err != nil {
+		return nil, fmt.Errorf("SERVICE_KEY_ENCRYPTION_KEY: %w", err)
+	}
+	config, err := LoadConfig()
+	if err != nil {
+		return nil, err
+	}
+	return newAuthService(repo, cipher, config, logger), nil
+}
+
+func newAuthService(repo *AuthRepository, cipher *common.Cipher, config Config, logger lib.Logger) *AuthService {
+	service := &AuthService{
+		repo:   repo,
+		cipher: cipher,
+		config: config,
+		logger: logger,
+		now:    time.Now,
+	}
+	service.parser = jwt.NewParser(
+		jwt.WithValidMethods([]string{jwt.SigningMethodES256.Alg()}),
+		jwt.WithIssuer(config.Issuer),
+		jwt.WithAudience(config.Issuer),
+		jwt.WithLeeway(tokenLeeway),
+		jwt.WithExpirationRequired(),
+		jwt.WithIssuedAt(),
+		jwt.WithTimeFunc(func() time.Time { return service.now() }),
+	)
+	return service
+}
+
+// Issue exchanges client credentials for a service token with the
+// requested scopes, or all scopes of the client when none are requested.
+func (s *AuthService) Issue(ctx context.Context, clientID int32, secret string, scope string) (*TokenResponse, error) {
+	client, err := s.repo.FindClient(ctx, clientID)
+	if err != nil {
+		return nil, err
+	}
+	if client == nil || client.DisabledAt != nil || secret == "" {
+		return nil, ErrInvalidClient
+	}
+	matches, err := common.Hasher.Compare(secret, client.SecretHash)
+	if err != nil {
+		return nil, err
+	}
+	if !matches {
+		return nil, ErrInvalidClient
+	}
+
+	scopes := client.Scopes
+	if requested := strings.Fields(scope); len(requested) > 0 {
+		for _, scope := range requested {
+			if !slices.Contains(client.Scopes, scope) {
+				return nil, fmt.Errorf("%w: %s", ErrInvalidScope, scope)
+			}
+		}
+		scopes = requested
+	}
+
+	return s.sign(client.ServiceClient, scopes)
+}
+
+// CreateToken issues a service token with all scopes of the client.
+func (s *AuthService) CreateToken(id int32) (*string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	client, err := s.repo.FindClient(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if client == nil || client.DisabledAt != nil {
+		return nil, ErrInvalidClient
+	}
+
+	response, err := s.sign(client.ServiceClient, client.Scopes)
+	if err != nil {
+		return nil, err
+	}
+	return &response.AccessToken, nil
+}
+
+// CheckToken verifies a service token and returns the client ID.
+func (s *AuthService) CheckToken(tokenString string) (*int32, error) {
+	caller, err := s.VerifyServiceToken(tokenString)
+	if err != nil {
+		return nil, err
+	}
+	return &caller.ClientID, nil
+}
+
+// VerifyServiceToken checks the signature, issuer, audience and validity of
+// a service token and returns the client behind it.
+func (s *AuthService) VerifyServiceToken(tokenString string) (middlewares.ServiceCaller, error) {
+	claims := &serviceClaims{}
+	if _, err := s.parser.ParseWithClaims(tokenString, claims, s.publicKey); err != nil {
+		return middlewares.ServiceCaller{}, err
+	}
+
+	id, err := strconv.ParseInt(claims.Subject, 10, 32)
+	if err != nil {
+		return middlewares.ServiceCaller{}, fmt.Errorf("invalid subject %q", claims.Subject)
+	}
+
+	return middlewares.ServiceCaller{
+		ClientID: int32(id),
+		Name:     claims.ClientName,
+		Scopes:   strings.Fields(claims.Scope),
+	}, nil
+}
+
+// JWKS returns the public keys of service tokens, including the next key
+// before it is used and retired keys until their tokens have expired.
+func (s *AuthService) JWKS() JWKS {
+	return s.keys().jwks(s.now())
+}
+
+// RegisterClient creates a client with a random secret, returned only once.
+func (s *AuthService) RegisterClient(ctx context.Context, req RegisterClientRequest) (*RegisteredClient, error) {
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		return nil, fmt.Errorf("%w: name is required", ErrInvalidInput)
+	}
+
+	var scopes []string
+	for _, scope := range req.Scopes {
+		if !slices.Contains(KnownScopes, scope) {
+			return nil, fmt.Errorf("%w: unknown scope %q", ErrInvalidInput, scope)
+		}
+		if !slices.Contains(scopes, scope) {
+			scopes = append(scopes, scope)
+		}
+	}
+	slices.Sort(scopes)
+
+	raw := make([]byte, 32)
+	if _, err := rand.Read(raw); err != nil {
+		return nil, err
+	}
+	secret := base64.RawURLEncoding.EncodeToString(raw)
+
+	hash, err := common.Hasher.Hash(secret)
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := s.repo.CreateClient(ctx, name, hash, scopes)
+	if err != nil {
+		return nil, err
+	}
+	return &RegisteredClient{ServiceClient: *client, Secret: secret}, nil
+}
+
+func (s *AuthService) ListClients(ctx context.Context) ([]ServiceClient, error) {
+	return s.repo.ListClients(ctx)
+}
+
+// DisableClient stops the client from getting new tokens. Tokens it holds
+// stay valid until they expire.
+func (s *AuthService) DisableClient(ctx context.Context, id int32) error {
+	return s.repo.DisableClient(ctx, id)
+}
+
+// RotateKeys adds the next signing key when the current one is due, drops
+// expired keys and reloads the key ring. Every instance runs it
+// periodically, so keys added by another instance are picked up too.
+func (s *AuthService) RotateKeys(ctx context.Context) error {
+	// Old keys are kept until the last token they signed has expired, also
+	// on instances that only learn about the new key on their next refresh
+	retention := s.config.TokenTTL + keyRefreshInterval + tokenLeeway
+
+	err := s.repo.RotateKeys(ctx, retention, func(keys []signingKey) (*signingKey, error) {
+		activatesAt, due := nextRotation(keys, s.now(), s.config.RotationInterval)
+		if !due {
+			return nil, nil
+		}
+		return generateKey(s.cipher, activatesAt)
+	})
+	if err != nil {
+		return err
+	}
+	return s.loadKeys(ctx)
+}
+
+// nextRotation decides whether a new key is due and when it activates. The
+// first key activates right away; later ones are published keyPublishLead
+// before they take over.
+func nextRotation(keys []signingKey, now time.Time, interval time.Duration) (time.Time, bool) {
+	if len(keys) == 0 {
+		return now, true
+	}
+	latest := keys[len(keys)-1]
+	if latest.ActivatesAt.After(now) {
+		return time.Time{}, false
+	}
+	if now.Before(latest.ActivatesAt.Add(interval - keyPublishLead)) {
+		return time.Time{}, false
+	}
+	return now.Add(keyPublishLead), true
+}
+
+// sign issues a token for the client with the given scopes.
+func (s *AuthService) sign(client ServiceClient, scopes []string) (*TokenResponse, error) {
+	now := s.now()
+	key, ok := s.keys().signing(now)
+	if !ok {
+		return nil, ErrNoSigningKey
+	}
+
+	id := make([]byte, 16)
+	if _, err := rand.Read(id); err != nil {
+		return nil, err
+	}
+
+	scope := strings.Join(scopes, " ")
+	token := jwt.NewWithClaims(jwt.SigningMethodES256, serviceClaims{
+		Scope:      scope,
+		ClientName: client.Name,
+		RegisteredClaims: jwt.RegisteredClaims{
+			Issuer:    s.config.Issuer,
+			Subject:   strconv.FormatInt(int64(client.ID), 10),
+			Audience:  jwt.ClaimStrings{s.config.Issuer},
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(now.Add(s.config.TokenTTL)),
+			ID:        hex.EncodeToString(id),
+		},
+	})
+	token.Header["kid"] = key.kid
+
+	signed, err := token.SignedString(key.private)
+	if err != nil {
+		return nil, err
+	}
+
+	return &TokenResponse{
+		AccessToken: signed,
+		TokenType:   "Bearer",
+		ExpiresIn:   int(s.config.TokenTTL.Seconds()),
+		Scope:       scope,
+	}, nil
+}
+
+// publicKey is the keyfunc of service tokens. A token with an unknown kid
+// may have been signed by a key another instance just added, so the ring is
+// reloaded, at most once per reloadCooldown.
+func (s *AuthService) publicKey(token *jwt.Token) (interface{}, error) {
+	kid, _ := token.Header["kid"].(string)
+	if key, ok := s.keys().lookup(kid, s.now()); ok {
+		return key, nil
+	}
+
+	s.mu.Lock()
+	due := s.now().Sub(s.lastReload) >= reloadCooldown
+	if due {
+		s.lastReload = s.now()
+	}
+	s.mu.Unlock()
+	if !due {
+		return nil, ErrUnknownKey
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := s.loadKeys(ctx); err != nil {
+		s.logger.Error(fmt.Sprintf("Failed to reload service signing keys: %v", err))
+		return nil, ErrUnknownKey
+	}
+
+	if key, ok := s.keys().lookup(kid, s.now()); ok {
+		return key, nil
+	}
+	return nil, ErrUnknownKey
+}
+
+// loadKeys replaces the key ring with the stored keys.
+func (s *AuthService) loadKeys(ctx context.Context) error {
+	stored, err := s.repo.ListKeys(ctx)
+	if err != nil {
+		return err
+	}
+
+	ring := make(keyRing, 0, len(stored))
+	for _, key := range stored {
+		opened, err := openKey(s.cipher, key)
+		if err != nil {
+			return fmt.Errorf("signing key %s: %w", key.KID, err)
+		}
+		ring = append(ring, opened)
+	}
+
+	s.mu.Lock()
+	s.ring = ring
+	s.lastReload = s.now()
+	s.mu.Unlock()
+	return nil
+}
+
+func (s *AuthService) keys() keyRing {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.ring
+}
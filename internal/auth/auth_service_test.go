@@ -0,0 +1,181 @@
+package auth
+
+import (
+	"testing"
+	"time"
+
+	"hostflow/profile-service/pkg/common"
+	"hostflow/profile-service/pkg/lib"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testConfig = Config{
+	Issuer:           DefaultTokenIssuer,
+	TokenTTL:         DefaultTokenTTL,
+	RotationInterval: DefaultRotationInterval,
+}
+
+// newTestService returns a service whose ring holds a key that activated an
+// hour ago. Reloads are held off, since there is no database.
+func newTestService(t *testing.T, now time.Time) (*AuthService, *common.Cipher) {
+	cipher, err := common.NewCipher(make([]byte, 32))
+	require.NoError(t, err)
+
+	service := newAuthService(nil, cipher, testConfig, lib.GetLogger())
+	service.now = func() time.Time { return now }
+	service.lastReload = now
+	service.ring = keyRing{testKey(t, cipher, now.Add(-time.Hour))}
+	return service, cipher
+}
+
+func testKey(t *testing.T, cipher *common.Cipher, activatesAt time.Time) activeKey {
+	stored, err := generateKey(cipher, activatesAt)
+	require.NoError(t, err)
+	key, err := openKey(cipher, *stored)
+	require.NoError(t, err)
+	return key
+}
+
+func TestAuthService_SignAndVerify(t *testing.T) {
+	service, _ := newTestService(t, time.Now())
+
+	token, err := service.sign(ServiceClient{ID: 7, Name: "booking-service"}, []string{ScopeBillingRead, ScopePlansRead})
+	require.NoError(t, err)
+	assert.Equal(t, "Bearer", token.TokenType)
+	assert.Equal(t, 300, token.ExpiresIn)
+	assert.Equal(t, "billing:read plans:read", token.Scope)
+
+	caller, err := service.VerifyServiceToken(token.AccessToken)
+	require.NoError(t, err)
+	assert.Equal(t, int32(7), caller.ClientID)
+	assert.Equal(t, "booking-service", caller.Name)
+	assert.True(t, caller.HasScopes(ScopeBillingRead, ScopePlansRead))
+	assert.False(t, caller.HasScopes(ScopePlansWrite))
+
+	id, err := service.CheckToken(token.AccessToken)
+	require.NoError(t, err)
+	assert.Equal(t, int32(7), *id)
+}
+
+func TestAuthService_VerifyRejects(t *testing.T) {
+	now := time.Now()
+	service, cipher := newTestService(t, now)
+	token, err := service.sign(ServiceClient{ID: 7}, []string{ScopeBillingRead})
+	require.NoError(t, err)
+
+	// Expired
+	later := now.Add(DefaultTokenTTL + time.Minute)
+	service.now = func() time.Time { return later }
+	service.lastReload = later
+	_, err = service.VerifyServiceToken(token.AccessToken)
+	assert.Error(t, err)
+
+	// Signed by a key this service does not know
+	other, _ := newTestService(t, now)
+	service.now = func() time.Time { return now }
+	service.lastReload = now
+	foreign, err := other.sign(ServiceClient{ID: 7}, nil)
+	require.NoError(t, err)
+	_, err = service.VerifyServiceToken(foreign.AccessToken)
+	assert.ErrorIs(t, err, ErrUnknownKey)
+
+	// Issued for another service
+	key := testKey(t, cipher, now.Add(-time.Hour))
+	service.ring = keyRing{key}
+	claims := jwt.MapClaims{
+		"iss": "other-service",
+		"aud": DefaultTokenIssuer,
+		"sub": "7",
+		"iat": now.Unix(),
+		"exp": now.Add(time.Minute).Unix(),
+	}
+	forged := jwt.NewWithClaims(jwt.SigningMethodES256, claims)
+	forged.Header["kid"] = key.kid
+	signed, err := forged.SignedString(key.private)
+	require.NoError(t, err)
+	_, err = service.VerifyServiceToken(signed)
+	assert.Error(t, err)
+}
+
+func TestAuthService_SignWithoutKey(t *testing.T) {
+	now := time.Now()
+	service, cipher := newTestService(t, now)
+
+	// A published key is not used before it activates
+	service.ring = keyRing{testKey(t, cipher, now.Add(time.Hour))}
+	_, err := service.sign(ServiceClient{ID: 7}, nil)
+	assert.ErrorIs(t, err, ErrNoSigningKey)
+	assert.Len(t, service.JWKS().Keys, 1)
+}
+
+func TestKeyRing(t *testing.T) {
+	now := time.Now()
+	_, cipher := newTestService(t, now)
+
+	expired := now.Add(-time.Minute)
+	retiring := now.Add(time.Minute)
+	old := testKey(t, cipher, now.Add(-48*time.Hour))
+	old.expiresAt = &expired
+	current := testKey(t, cipher, now.Add(-24*time.Hour))
+	current.expiresAt = &retiring
+	next := testKey(t, cipher, now.Add(time.Hour))
+	ring := keyRing{old, current, next}
+
+	signing, ok := ring.signing(now)
+	require.True(t, ok)
+	assert.Equal(t, current.kid, signing.kid)
+
+	_, ok = ring.lookup(old.kid, now)
+	assert.False(t, ok)
+	_, ok = ring.lookup(current.kid, now)
+	assert.True(t, ok)
+	_, ok = ring.lookup(next.kid, now)
+	assert.True(t, ok)
+
+	assert.Len(t, ring.jwks(now).Keys, 2)
+}
+
+func TestNextRotation(t *testing.T) {
+	now := time.Now()
+	interval := DefaultRotationInterval
+
+	activatesAt, due := nextRotation(nil, now, interval)
+	assert.True(t, due)
+	assert.Equal(t, now, activatesAt)
+
+	fresh := []signingKey{{ActivatesAt: now.Add(-time.Hour)}}
+	_, due = nextRotation(fresh, now, interval)
+	assert.False(t, due)
+
+	old := []signingKey{{ActivatesAt: now.Add(-interval)}}
+	activatesAt, due = nextRotation(old, now, interval)
+	assert.True(t, due)
+	assert.Equal(t, now.Add(keyPublishLead), activatesAt)
+
+	// The next key is already published
+	pending := append(old, signingKey{ActivatesAt: now.Add(time.Minute)})
+	_, due = nextRotation(pending, now, interval)
+	assert.False(t, due)
+}
+
+func TestLoadConfig(t *testing.T) {
+	t.Setenv("SERVICE_TOKEN_ISSUER", "")
+	t.Setenv("SERVICE_TOKEN_TTL", "")
+	t.Setenv("SERVICE_KEY_ROTATION_INTERVAL", "")
+
+	config, err := LoadConfig()
+	require.NoError(t, err)
+	assert.Equal(t, testConfig, config)
+
+	t.Setenv("SERVICE_TOKEN_TTL", "2h")
+	_, err = LoadConfig()
+	assert.Error(t, err)
+
+	t.Setenv("SERVICE_TOKEN_TTL", "")
+	t.Setenv("SERVICE_KEY_ROTATION_INTERVAL", "30m")
+	_, err = LoadConfig()
+	assert.Error(t, err)
+}
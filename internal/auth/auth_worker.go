@@ -0,0 +1,31 @@
+package auth
+
+import (
+	"context"
+	"fmt"
+
+	"hostflow/profile-service/pkg/lib"
+
+	"go.uber.org/fx"
+)
+
+// RegisterKeyRotationWorker loads the signing keys when the app starts and
+// rotates and reloads them in the background. A failed first load is only
+// logged: the internal API still takes the shared token, and the next run
+// retries.
+func RegisterKeyRotationWorker(lifecycle fx.Lifecycle, service Service, logger lib.Logger) {
+	lifecycle.Append(fx.Hook{
+		OnStart: func(ctx context.Context) error {
+			if err := service.RotateKeys(ctx); err != nil {
+				logger.Error(fmt.Sprintf("Failed to load service signing keys: %v", err))
+			}
+			return nil
+		},
+	})
+
+	lib.RunPeriodically(lifecycle, keyRefreshInterval, func(ctx context.Context) {
+		if err := service.RotateKeys(ctx); err != nil {
+			logger.Error(fmt.Sprintf("Service signing key rotation failed: %v", err))
+		}
+	})
+}
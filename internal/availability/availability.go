@@ -0,0 +1,16 @@
+package availability
+
+import "go.uber.org/fx"
+
+// ======== EXPORTS ========
+
+// Module exports services present
+var Context = fx.Options(
+	fx.Provide(GetAvailabilityController),
+	fx.Provide(fx.Annotate(
+		GetAvailabilityService,
+		fx.As(new(Service)),
+	)),
+	fx.Provide(GetAvailabilityRepository),
+	fx.Provide(SetAvailabilityRoutes),
+)
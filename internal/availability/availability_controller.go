@@ -0,0 +1,254 @@
+package availability
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"hostflow/profile-service/internal/middlewares"
+	"hostflow/profile-service/pkg/common"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type AvailabilityController struct {
+	service Service
+}
+
+func GetAvailabilityController(service Service) *AvailabilityController {
+	return &AvailabilityController{
+		service: service,
+	}
+}
+
+// GetAvailabilityHandler godoc
+// @Summary Get expanded availability
+// @Description Expands a user's weekly schedule and date overrides into concrete UTC intervals within [from, to), minus approved time off. Local times follow the user's timezone, including DST transitions.
+// @Tags availability
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "User ID (UUID)"
+// @Param from query string true "Range start (RFC3339)"
+// @Param to query string true "Range end (RFC3339)"
+// @Success 200 {array} Interval
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /users/{id}/availability [get]
+func (c *AvailabilityController) GetAvailabilityHandler(ctx *gin.Context) {
+	principal := middlewares.GetPrincipal(ctx)
+
+	profileID, ok := parseProfileID(ctx)
+	if !ok {
+		return
+	}
+
+	from, errFrom := time.Parse(time.RFC3339, ctx.Query("from"))
+	to, errTo := time.Parse(time.RFC3339, ctx.Query("to"))
+	if errFrom != nil || errTo != nil {
+		ctx.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "Invalid range",
+			Message: "'from' and 'to' must be RFC3339 timestamps",
+		})
+		return
+	}
+
+	intervals, err := c.service.GetAvailability(ctx.Request.Context(), profileID, principal.OrganizationID, from, to)
+	if err != nil {
+		respondError(ctx, "Failed to fetch availability", err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, intervals)
+}
+
+// GetScheduleHandler godoc
+// @Summary Get availability schedule
+// @Description Returns a user's timezone, recurring weekly slots and upcoming date overrides.
+// @Tags availability
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "User ID (UUID)"
+// @Success 200 {object} Schedule
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /users/{id}/availability/schedule [get]
+func (c *AvailabilityController) GetScheduleHandler(ctx *gin.Context) {
+	principal := middlewares.GetPrincipal(ctx)
+
+	profileID, ok := parseProfileID(ctx)
+	if !ok {
+		return
+	}
+
+	schedule, err := c.service.GetSchedule(ctx.Request.Context(), profileID, principal.OrganizationID)
+	if err != nil {
+		respondError(ctx, "Failed to fetch schedule", err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, schedule)
+}
+
+// UpdateScheduleHandler godoc
+// @Summary Replace availability schedule
+// @Description Replaces a user's timezone and recurring weekly slots. Allowed for the user themselves or an OWNER.
+// @Tags availability
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "User ID (UUID)"
+// @Param body body UpdateScheduleRequest true "Weekly schedule"
+// @Success 200 {object} Schedule
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /users/{id}/availability/schedule [put]
+func (c *AvailabilityController) UpdateScheduleHandler(ctx *gin.Context) {
+	principal := middlewares.GetPrincipal(ctx)
+
+	profileID, ok := parseProfileID(ctx)
+	if !ok {
+		return
+	}
+
+	var body UpdateScheduleRequest
+	if errors := common.Validation.ValidateBody(ctx, &body); errors != nil {
+		ctx.JSON(http.StatusBadRequest, errors)
+		return
+	}
+
+	schedule, err := c.service.UpdateSchedule(
+		ctx.Request.Context(),
+		profileID,
+		principal.UserID,
+		principal.OrganizationID,
+		principal.Role,
+		body,
+	)
+	if err != nil {
+		respondError(ctx, "Failed to update schedule", err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, schedule)
+}
+
+// SetOverrideHandler godoc
+// @Summary Set a date override
+// @Description Replaces a user's availability for one local date. An empty slot list marks the day as unavailable.
+// @Tags availability
+// @Accept json
+// @Security ApiKeyAuth
+// @Param id path string true "User ID (UUID)"
+// @Param date path string true "Local date (YYYY-MM-DD)"
+// @Param body body SetOverrideRequest true "Override slots"
+// @Success 204 "No Content"
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /users/{id}/availability/overrides/{date} [put]
+func (c *AvailabilityController) SetOverrideHandler(ctx *gin.Context) {
+	principal := middlewares.GetPrincipal(ctx)
+
+	profileID, ok := parseProfileID(ctx)
+	if !ok {
+		return
+	}
+
+	var body SetOverrideRequest
+	if err := ctx.ShouldBindJSON(&body); err != nil {
+		ctx.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "Invalid request",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	err := c.service.SetOverride(
+		ctx.Request.Context(),
+		profileID,
+		principal.UserID,
+		principal.OrganizationID,
+		principal.Role,
+		ctx.Param("date"),
+		body.Slots,
+	)
+	if err != nil {
+		respondError(ctx, "Failed to set override", err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+// DeleteOverrideHandler godoc
+// @Summary Delete a date override
+// @Description Removes the override for a local date so the weekly schedule applies again.
+// @Tags availability
+// @Security ApiKeyAuth
+// @Param id path string true "User ID (UUID)"
+// @Param date path string true "Local date (YYYY-MM-DD)"
+// @Success 204 "No Content"
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /users/{id}/availability/overrides/{date} [delete]
+func (c *AvailabilityController) DeleteOverrideHandler(ctx *gin.Context) {
+	principal := middlewares.GetPrincipal(ctx)
+
+	profileID, ok := parseProfileID(ctx)
+	if !ok {
+		return
+	}
+
+	err := c.service.DeleteOverride(
+		ctx.Request.Context(),
+		profileID,
+		principal.UserID,
+		principal.OrganizationID,
+		principal.Role,
+		ctx.Param("date"),
+	)
+	if err != nil {
+		respondError(ctx, "Failed to delete override", err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+// parseProfileID reads the :id path parameter and aborts with 400 when it
+// is not a UUID.
+func parseProfileID(ctx *gin.Context) (uuid.UUID, bool) {
+	id, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "Invalid ID format",
+			Message: "ID must be a valid UUID",
+		})
+		return uuid.Nil, false
+	}
+	return id, true
+}
+
+// respondError maps service errors to HTTP status codes.
+func respondError(ctx *gin.Context, title string, err error) {
+	status := http.StatusInternalServerError
+	switch {
+	case errors.Is(err, ErrInvalidInput):
+		status = http.StatusBadRequest
+	case errors.Is(err, ErrForbidden):
+		status = http.StatusForbidden
+	case errors.Is(err, ErrProfileNotFound), errors.Is(err, ErrOverrideNotFound):
+		status = http.StatusNotFound
+	}
+
+	ctx.JSON(status, ErrorResponse{
+		Error:   title,
+		Message: err.Error(),
+	})
+}
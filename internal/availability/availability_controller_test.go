@@ -0,0 +1,119 @@
+package availability
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"hostflow/profile-service/internal/middlewares"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockAvailabilityService struct {
+	mock.Mock
+}
+
+func (m *MockAvailabilityService) GetSchedule(ctx context.Context, profileID uuid.UUID, orgID int64) (*Schedule, error) {
+	args := m.Called(ctx, profileID, orgID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*Schedule), args.Error(1)
+}
+
+func (m *MockAvailabilityService) UpdateSchedule(ctx context.Context, profileID uuid.UUID, requesterID string, orgID int64, role string, req UpdateScheduleRequest) (*Schedule, error) {
+	args := m.Called(ctx, profileID, requesterID, orgID, role, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*Schedule), args.Error(1)
+}
+
+func (m *MockAvailabilityService) SetOverride(ctx context.Context, profileID uuid.UUID, requesterID string, orgID int64, role string, date string, slots []TimeRange) error {
+	return m.Called(ctx, profileID, requesterID, orgID, role, date, slots).Error(0)
+}
+
+func (m *MockAvailabilityService) DeleteOverride(ctx context.Context, profileID uuid.UUID, requesterID string, orgID int64, role string, date string) error {
+	return m.Called(ctx, profileID, requesterID, orgID, role, date).Error(0)
+}
+
+func (m *MockAvailabilityService) GetAvailability(ctx context.Context, profileID uuid.UUID, orgID int64, from, to time.Time) ([]Interval, error) {
+	args := m.Called(ctx, profileID, orgID, from, to)
+	return args.Get(0).([]Interval), args.Error(1)
+}
+
+func (m *MockAvailabilityService) FindFree(ctx context.Context, profileIDs []uuid.UUID, start, end time.Time) (map[uuid.UUID]bool, error) {
+	args := m.Called(ctx, profileIDs, start, end)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(map[uuid.UUID]bool), args.Error(1)
+}
+
+func TestGetAvailabilityHandler_Success(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	mockSvc := new(MockAvailabilityService)
+	controller := GetAvailabilityController(mockSvc)
+	profileID := uuid.New()
+
+	r := gin.New()
+	r.GET("/users/:id/availability", func(c *gin.Context) {
+		middlewares.SetPrincipal(c, middlewares.Principal{OrganizationID: 1})
+		controller.GetAvailabilityHandler(c)
+	})
+
+	from := utc("2026-03-23T00:00:00Z")
+	to := utc("2026-03-24T00:00:00Z")
+	mockSvc.On("GetAvailability", mock.Anything, profileID, int64(1), from, to).
+		Return([]Interval{{Start: utc("2026-03-23T07:00:00Z"), End: utc("2026-03-23T15:00:00Z")}}, nil)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/users/"+profileID.String()+"/availability?from=2026-03-23T00:00:00Z&to=2026-03-24T00:00:00Z", nil)
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), "2026-03-23T07:00:00Z")
+}
+
+func TestGetAvailabilityHandler_InvalidRange(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	controller := GetAvailabilityController(new(MockAvailabilityService))
+
+	r := gin.New()
+	r.GET("/users/:id/availability", controller.GetAvailabilityHandler)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/users/"+uuid.NewString()+"/availability?from=yesterday", nil)
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), "RFC3339")
+}
+
+func TestDeleteOverrideHandler_ForbiddenForOtherMember(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	mockSvc := new(MockAvailabilityService)
+	controller := GetAvailabilityController(mockSvc)
+	profileID := uuid.New()
+
+	r := gin.New()
+	r.DELETE("/users/:id/availability/overrides/:date", func(c *gin.Context) {
+		middlewares.SetPrincipal(c, middlewares.Principal{UserID: "someone-else", OrganizationID: 1, Role: "MEMBER"})
+		controller.DeleteOverrideHandler(c)
+	})
+
+	mockSvc.On("DeleteOverride", mock.Anything, profileID, "someone-else", int64(1), "MEMBER", "2026-12-24").
+		Return(ErrForbidden)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("DELETE", "/users/"+profileID.String()+"/availability/overrides/2026-12-24", nil)
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusForbidden, w.Code)
+}
@@ -0,0 +1,240 @@
+package availability
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+)
+
+// ======== TYPES ========
+
+// minuteRange is a window expressed in minutes since local midnight.
+type minuteRange struct {
+	start int
+	end   int
+}
+
+// compiledSchedule is a validated schedule ready to be expanded.
+type compiledSchedule struct {
+	location  *time.Location
+	weekly    [7][]minuteRange
+	overrides map[string][]minuteRange
+}
+
+const dateLayout = "2006-01-02"
+
+// ======== PUBLIC METHODS ========
+
+// Expand turns a schedule into concrete UTC intervals within [from, to).
+// Local wall-clock times are resolved per date in the schedule's timezone,
+// so a 08:00-16:00 slot stays 08:00-16:00 locally across DST transitions
+// and a slot crossing a transition is shortened or lengthened accordingly.
+func Expand(schedule Schedule, from, to time.Time) ([]Interval, error) {
+	compiled, err := compile(schedule)
+	if err != nil {
+		return nil, err
+	}
+	return compiled.expand(from, to), nil
+}
+
+// Merge sorts intervals and joins the ones that overlap or touch.
+func Merge(intervals []Interval) []Interval {
+	if len(intervals) == 0 {
+		return []Interval{}
+	}
+
+	sorted := make([]Interval, len(intervals))
+	copy(sorted, intervals)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].Start.Before(sorted[j].Start)
+	})
+
+	merged := []Interval{sorted[0]}
+	for _, current := range sorted[1:] {
+		last := &merged[len(merged)-1]
+		if !current.Start.After(last.End) {
+			if current.End.After(last.End) {
+				last.End = current.End
+			}
+			continue
+		}
+		merged = append(merged, current)
+	}
+	return merged
+}
+
+// Subtract removes every blocked window from the given intervals, splitting
+// intervals that a block only partially covers.
+func Subtract(intervals []Interval, blocked []Interval) []Interval {
+	result := []Interval{}
+	blocked = Merge(blocked)
+
+	for _, interval := range Merge(intervals) {
+		remaining := interval
+		for _, block := range blocked {
+			if !block.End.After(remaining.Start) || !block.Start.Before(remaining.End) {
+				continue
+			}
+			if block.Start.After(remaining.Start) {
+				result = append(result, Interval{Start: remaining.Start, End: block.Start})
+			}
+			remaining.Start = block.End
+			if !remaining.End.After(remaining.Start) {
+				break
+			}
+		}
+		if remaining.End.After(remaining.Start) {
+			result = append(result, remaining)
+		}
+	}
+
+	return result
+}
+
+// Covers reports whether merged intervals, as Expand and Subtract return
+// them, cover the whole window [from, to).
+func Covers(intervals []Interval, from, to time.Time) bool {
+	return len(intervals) == 1 && !intervals[0].Start.After(from) && !intervals[0].End.Before(to)
+}
+
+// ======== PRIVATE METHODS ========
+
+// compile validates a schedule and converts its clock strings to minutes.
+func compile(schedule Schedule) (*compiledSchedule, error) {
+	location, err := loadLocation(schedule.Timezone)
+	if err != nil {
+		return nil, err
+	}
+
+	compiled := &compiledSchedule{
+		location:  location,
+		overrides: make(map[string][]minuteRange),
+	}
+
+	for _, slot := range schedule.Weekly {
+		if slot.Weekday < 0 || slot.Weekday > 6 {
+			return nil, fmt.Errorf("invalid weekday %d: must be between 0 (Sunday) and 6 (Saturday)", slot.Weekday)
+		}
+		r, err := parseRange(slot.Start, slot.End)
+		if err != nil {
+			return nil, err
+		}
+		compiled.weekly[slot.Weekday] = append(compiled.weekly[slot.Weekday], r)
+	}
+
+	for _, override := range schedule.Overrides {
+		if _, err := time.Parse(dateLayout, override.Date); err != nil {
+			return nil, fmt.Errorf("invalid override date %q: expected YYYY-MM-DD", override.Date)
+		}
+		ranges := []minuteRange{}
+		for _, slot := range override.Slots {
+			r, err := parseRange(slot.Start, slot.End)
+			if err != nil {
+				return nil, err
+			}
+			ranges = append(ranges, r)
+		}
+		compiled.overrides[override.Date] = append(compiled.overrides[override.Date], ranges...)
+	}
+
+	return compiled, nil
+}
+
+// expand walks every local date touching [from, to) and resolves its slots.
+func (c *compiledSchedule) expand(from, to time.Time) []Interval {
+	if !to.After(from) {
+		return []Interval{}
+	}
+
+	// Dates are iterated as plain calendar days so the walk itself is not
+	// affected by DST; the location is only applied when building instants.
+	// One extra day on each side covers slots ending at 24:00 and offsets.
+	localFrom := from.In(c.location)
+	localTo := to.In(c.location)
+	day := time.Date(localFrom.Year(), localFrom.Month(), localFrom.Day()-1, 0, 0, 0, 0, time.UTC)
+	last := time.Date(localTo.Year(), localTo.Month(), localTo.Day()+1, 0, 0, 0, 0, time.UTC)
+
+	intervals := []Interval{}
+	for ; !day.After(last); day = day.AddDate(0, 0, 1) {
+		ranges, overridden := c.overrides[day.Format(dateLayout)]
+		if !overridden {
+			ranges = c.weekly[day.Weekday()]
+		}
+
+		for _, r := range ranges {
+			start := time.Date(day.Year(), day.Month(), day.Day(), 0, r.start, 0, 0, c.location).UTC()
+			end := time.Date(day.Year(), day.Month(), day.Day(), 0, r.end, 0, 0, c.location).UTC()
+
+			if start.Before(from) {
+				start = from.UTC()
+			}
+			if end.After(to) {
+				end = to.UTC()
+			}
+			if end.After(start) {
+				intervals = append(intervals, Interval{Start: start, End: end})
+			}
+		}
+	}
+
+	return Merge(intervals)
+}
+
+// loadLocation resolves an IANA timezone name.
+func loadLocation(name string) (*time.Location, error) {
+	if name == "" {
+		return nil, errors.New("timezone is required")
+	}
+	location, err := time.LoadLocation(name)
+	if err != nil {
+		return nil, fmt.Errorf("unknown timezone %q", name)
+	}
+	return location, nil
+}
+
+// parseRange parses a start/end pair of "HH:MM" clock strings.
+func parseRange(start, end string) (minuteRange, error) {
+	s, err := parseClock(start)
+	if err != nil {
+		return minuteRange{}, err
+	}
+	e, err := parseClock(end)
+	if err != nil {
+		return minuteRange{}, err
+	}
+	if e <= s {
+		return minuteRange{}, fmt.Errorf("invalid range %s-%s: end must be after start", start, end)
+	}
+	return minuteRange{start: s, end: e}, nil
+}
+
+// parseClock parses "HH:MM" into minutes since midnight. "24:00" is
+// accepted so a slot can run until the end of the day.
+func parseClock(value string) (int, error) {
+	if len(value) != 5 || value[2] != ':' || !isDigits(value[:2]) || !isDigits(value[3:]) {
+		return 0, fmt.Errorf("invalid time %q: expected HH:MM", value)
+	}
+	hours := int(value[0]-'0')*10 + int(value[1]-'0')
+	minutes := int(value[3]-'0')*10 + int(value[4]-'0')
+	total := hours*60 + minutes
+	if minutes > 59 || total > 24*60 {
+		return 0, fmt.Errorf("invalid time %q: out of range", value)
+	}
+	return total, nil
+}
+
+// isDigits reports whether value consists of ASCII digits only.
+func isDigits(value string) bool {
+	for i := 0; i < len(value); i++ {
+		if value[i] < '0' || value[i] > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// formatClock formats minutes since midnight as "HH:MM".
+func formatClock(minutes int) string {
+	return fmt.Sprintf("%02d:%02d", minutes/60, minutes%60)
+}
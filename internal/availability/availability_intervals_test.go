@@ -0,0 +1,161 @@
+package availability
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func utc(value string) time.Time {
+	t, _ := time.Parse(time.RFC3339, value)
+	return t
+}
+
+func TestExpand_WeeklySlotsFollowLocalTime(t *testing.T) {
+	schedule := Schedule{
+		Timezone: "Europe/Ljubljana",
+		Weekly: []WeeklySlot{
+			{Weekday: int(time.Monday), Start: "08:00", End: "16:00"},
+		},
+	}
+
+	// Monday before and Monday after the spring-forward transition (29 March 2026)
+	intervals, err := Expand(schedule, utc("2026-03-23T00:00:00Z"), utc("2026-04-01T00:00:00Z"))
+	require.NoError(t, err)
+
+	assert.Equal(t, []Interval{
+		{Start: utc("2026-03-23T07:00:00Z"), End: utc("2026-03-23T15:00:00Z")},
+		{Start: utc("2026-03-30T06:00:00Z"), End: utc("2026-03-30T14:00:00Z")},
+	}, intervals)
+}
+
+func TestExpand_SlotAcrossDSTTransitions(t *testing.T) {
+	schedule := Schedule{
+		Timezone: "Europe/Ljubljana",
+		Weekly: []WeeklySlot{
+			{Weekday: int(time.Sunday), Start: "01:00", End: "04:00"},
+		},
+	}
+
+	// Spring forward: 02:00 -> 03:00, so the slot only lasts two hours
+	spring, err := Expand(schedule, utc("2026-03-28T00:00:00Z"), utc("2026-03-30T00:00:00Z"))
+	require.NoError(t, err)
+	require.Len(t, spring, 1)
+	assert.Equal(t, utc("2026-03-29T00:00:00Z"), spring[0].Start)
+	assert.Equal(t, 2*time.Hour, spring[0].End.Sub(spring[0].Start))
+
+	// Fall back: 03:00 -> 02:00, so the slot lasts four hours
+	autumn, err := Expand(schedule, utc("2026-10-24T00:00:00Z"), utc("2026-10-26T00:00:00Z"))
+	require.NoError(t, err)
+	require.Len(t, autumn, 1)
+	assert.Equal(t, utc("2026-10-24T23:00:00Z"), autumn[0].Start)
+	assert.Equal(t, 4*time.Hour, autumn[0].End.Sub(autumn[0].Start))
+}
+
+func TestExpand_OverridesReplaceWeeklySlots(t *testing.T) {
+	schedule := Schedule{
+		Timezone: "UTC",
+		Weekly: []WeeklySlot{
+			{Weekday: int(time.Thursday), Start: "08:00", End: "16:00"},
+			{Weekday: int(time.Friday), Start: "08:00", End: "16:00"},
+		},
+		Overrides: []Override{
+			{Date: "2026-12-24", Slots: []TimeRange{{Start: "10:00", End: "12:00"}}},
+			{Date: "2026-12-25", Slots: []TimeRange{}},
+		},
+	}
+
+	intervals, err := Expand(schedule, utc("2026-12-24T00:00:00Z"), utc("2026-12-26T00:00:00Z"))
+	require.NoError(t, err)
+
+	assert.Equal(t, []Interval{
+		{Start: utc("2026-12-24T10:00:00Z"), End: utc("2026-12-24T12:00:00Z")},
+	}, intervals)
+}
+
+func TestExpand_ClipsAndMergesAcrossMidnight(t *testing.T) {
+	schedule := Schedule{
+		Timezone: "UTC",
+		Weekly: []WeeklySlot{
+			{Weekday: int(time.Monday), Start: "20:00", End: "24:00"},
+			{Weekday: int(time.Tuesday), Start: "00:00", End: "06:00"},
+		},
+	}
+
+	intervals, err := Expand(schedule, utc("2026-01-05T21:00:00Z"), utc("2026-01-06T05:00:00Z"))
+	require.NoError(t, err)
+
+	assert.Equal(t, []Interval{
+		{Start: utc("2026-01-05T21:00:00Z"), End: utc("2026-01-06T05:00:00Z")},
+	}, intervals)
+}
+
+func TestExpand_InvalidInput(t *testing.T) {
+	_, err := Expand(Schedule{Timezone: "Mars/Olympus"}, utc("2026-01-01T00:00:00Z"), utc("2026-01-02T00:00:00Z"))
+	assert.Error(t, err)
+
+	_, err = Expand(Schedule{
+		Timezone: "UTC",
+		Weekly:   []WeeklySlot{{Weekday: 7, Start: "08:00", End: "09:00"}},
+	}, utc("2026-01-01T00:00:00Z"), utc("2026-01-02T00:00:00Z"))
+	assert.Error(t, err)
+
+	_, err = Expand(Schedule{
+		Timezone: "UTC",
+		Weekly:   []WeeklySlot{{Weekday: 1, Start: "16:00", End: "08:00"}},
+	}, utc("2026-01-01T00:00:00Z"), utc("2026-01-02T00:00:00Z"))
+	assert.Error(t, err)
+}
+
+func TestSubtract_SplitsAndRemovesBlockedTime(t *testing.T) {
+	intervals := []Interval{
+		{Start: utc("2026-01-05T08:00:00Z"), End: utc("2026-01-05T16:00:00Z")},
+		{Start: utc("2026-01-06T08:00:00Z"), End: utc("2026-01-06T16:00:00Z")},
+		{Start: utc("2026-01-07T08:00:00Z"), End: utc("2026-01-07T16:00:00Z")},
+	}
+	leave := []Interval{
+		// Doctor's appointment in the middle of Monday
+		{Start: utc("2026-01-05T10:00:00Z"), End: utc("2026-01-05T12:00:00Z")},
+		// Whole Tuesday off, running into Wednesday morning
+		{Start: utc("2026-01-06T00:00:00Z"), End: utc("2026-01-07T09:00:00Z")},
+	}
+
+	assert.Equal(t, []Interval{
+		{Start: utc("2026-01-05T08:00:00Z"), End: utc("2026-01-05T10:00:00Z")},
+		{Start: utc("2026-01-05T12:00:00Z"), End: utc("2026-01-05T16:00:00Z")},
+		{Start: utc("2026-01-07T09:00:00Z"), End: utc("2026-01-07T16:00:00Z")},
+	}, Subtract(intervals, leave))
+}
+
+func TestCovers(t *testing.T) {
+	from, to := utc("2026-01-05T08:00:00Z"), utc("2026-01-05T16:00:00Z")
+	shift := []Interval{{Start: from, End: to}}
+
+	assert.True(t, Covers(shift, from, to))
+	assert.False(t, Covers(nil, from, to))
+	assert.False(t, Covers(Subtract(shift, []Interval{
+		{Start: utc("2026-01-05T10:00:00Z"), End: utc("2026-01-05T12:00:00Z")},
+	}), from, to))
+	assert.False(t, Covers([]Interval{{Start: utc("2026-01-05T09:00:00Z"), End: to}}, from, to))
+}
+
+func TestParseClock(t *testing.T) {
+	valid := map[string]int{
+		"00:00": 0,
+		"08:30": 8*60 + 30,
+		"23:59": 23*60 + 59,
+		"24:00": 24 * 60,
+	}
+	for value, want := range valid {
+		got, err := parseClock(value)
+		require.NoError(t, err, value)
+		assert.Equal(t, want, got, value)
+	}
+
+	for _, value := range []string{"+1:00", " 9:00", "9:00", "09:0 ", "09:-1", "-1:00", "0x:00", "09.00", "24:01", "12:60", "", "009:00"} {
+		_, err := parseClock(value)
+		assert.Error(t, err, value)
+	}
+}
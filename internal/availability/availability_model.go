@@ -0,0 +1,59 @@
+package availability
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Schedule is a profile's recurring weekly availability together with its
+// date-specific overrides. All times are local to Timezone.
+type Schedule struct {
+	ProfileID uuid.UUID    `json:"profile_id"`
+	Timezone  string       `json:"timezone" example:"Europe/Ljubljana"`
+	Weekly    []WeeklySlot `json:"weekly"`
+	Overrides []Override   `json:"overrides"`
+}
+
+// WeeklySlot is a recurring window on a given weekday (0 = Sunday).
+type WeeklySlot struct {
+	Weekday int    `json:"weekday" example:"1"`
+	Start   string `json:"start" example:"08:00"`
+	End     string `json:"end" example:"16:00"`
+}
+
+// TimeRange is a window within a single local day. End may be "24:00".
+type TimeRange struct {
+	Start string `json:"start" example:"08:00"`
+	End   string `json:"end" example:"12:00"`
+}
+
+// Override replaces the weekly slots for one local date. An override
+// without slots marks the whole day as unavailable.
+type Override struct {
+	Date  string      `json:"date" example:"2026-12-24"`
+	Slots []TimeRange `json:"slots"`
+}
+
+// Interval is a concrete availability window in UTC.
+type Interval struct {
+	Start time.Time `json:"start"`
+	End   time.Time `json:"end"`
+}
+
+// UpdateScheduleRequest is the body for replacing a weekly schedule.
+type UpdateScheduleRequest struct {
+	Timezone string       `json:"timezone" binding:"required" example:"Europe/Ljubljana"`
+	Weekly   []WeeklySlot `json:"weekly"`
+}
+
+// SetOverrideRequest is the body for setting a date override.
+type SetOverrideRequest struct {
+	Slots []TimeRange `json:"slots"`
+}
+
+// ErrorResponse represents an error response
+type ErrorResponse struct {
+	Error   string `json:"error" example:"Invalid request"`
+	Message string `json:"message,omitempty" example:"The provided data is invalid"`
+}
@@ -0,0 +1,285 @@
+package availability
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type AvailabilityRepository struct {
+	db *pgxpool.Pool
+}
+
+func GetAvailabilityRepository(db *pgxpool.Pool) *AvailabilityRepository {
+	return &AvailabilityRepository{
+		db: db,
+	}
+}
+
+// ProfileInOrganization reports whether the profile belongs to the organization.
+func (r *AvailabilityRepository) ProfileInOrganization(ctx context.Context, profileID uuid.UUID, orgID int64) (bool, error) {
+	var exists bool
+	query := `SELECT EXISTS (SELECT 1 FROM "profiles" WHERE id = $1 AND organization_id = $2)`
+
+	if err := r.db.QueryRow(ctx, query, profileID, orgID).Scan(&exists); err != nil {
+		return false, err
+	}
+	return exists, nil
+}
+
+// GetSchedule loads the weekly slots of a profile and its overrides for the
+// local dates between fromDate and toDate (inclusive). Profiles without a
+// stored schedule get an empty one in UTC.
+func (r *AvailabilityRepository) GetSchedule(ctx context.Context, profileID uuid.UUID, fromDate, toDate time.Time) (*Schedule, error) {
+	schedules, err := r.GetSchedules(ctx, []uuid.UUID{profileID}, fromDate, toDate)
+	if err != nil {
+		return nil, err
+	}
+	return schedules[profileID], nil
+}
+
+// GetSchedules is GetSchedule for many profiles at once, keyed by profile.
+// Every profile gets a schedule, whatever the number of profiles it takes
+// three queries.
+func (r *AvailabilityRepository) GetSchedules(ctx context.Context, profileIDs []uuid.UUID, fromDate, toDate time.Time) (map[uuid.UUID]*Schedule, error) {
+	schedules := make(map[uuid.UUID]*Schedule, len(profileIDs))
+	for _, id := range profileIDs {
+		schedules[id] = &Schedule{
+			ProfileID: id,
+			Timezone:  "UTC",
+			Weekly:    []WeeklySlot{},
+			Overrides: []Override{},
+		}
+	}
+
+	timezoneRows, err := r.db.Query(ctx,
+		`SELECT profile_id, timezone FROM availability_schedules WHERE profile_id = ANY($1)`,
+		profileIDs,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer timezoneRows.Close()
+
+	for timezoneRows.Next() {
+		var id uuid.UUID
+		var timezone string
+		if err := timezoneRows.Scan(&id, &timezone); err != nil {
+			return nil, err
+		}
+		schedules[id].Timezone = timezone
+	}
+	if err := timezoneRows.Err(); err != nil {
+		return nil, err
+	}
+
+	rows, err := r.db.Query(ctx, `
+        SELECT profile_id, weekday, start_minute, end_minute
+        FROM availability_weekly_slots
+        WHERE profile_id = ANY($1)
+        ORDER BY profile_id, weekday, start_minute
+    `, profileIDs)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var id uuid.UUID
+		var weekday, start, end int16
+		if err := rows.Scan(&id, &weekday, &start, &end); err != nil {
+			return nil, err
+		}
+		schedules[id].Weekly = append(schedules[id].Weekly, WeeklySlot{
+			Weekday: int(weekday),
+			Start:   formatClock(int(start)),
+			End:     formatClock(int(end)),
+		})
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	overrideRows, err := r.db.Query(ctx, `
+        SELECT profile_id, date, start_minute, end_minute
+        FROM availability_overrides
+        WHERE profile_id = ANY($1) AND date BETWEEN $2 AND $3
+        ORDER BY profile_id, date, start_minute NULLS FIRST
+    `, profileIDs, fromDate, toDate)
+	if err != nil {
+		return nil, err
+	}
+	defer overrideRows.Close()
+
+	byDate := map[uuid.UUID]map[string]int{}
+	for overrideRows.Next() {
+		var id uuid.UUID
+		var date time.Time
+		var start, end *int16
+		if err := overrideRows.Scan(&id, &date, &start, &end); err != nil {
+			return nil, err
+		}
+
+		schedule := schedules[id]
+		if byDate[id] == nil {
+			byDate[id] = map[string]int{}
+		}
+		key := date.Format(dateLayout)
+		idx, seen := byDate[id][key]
+		if !seen {
+			schedule.Overrides = append(schedule.Overrides, Override{Date: key, Slots: []TimeRange{}})
+			idx = len(schedule.Overrides) - 1
+			byDate[id][key] = idx
+		}
+		if start != nil && end != nil {
+			schedule.Overrides[idx].Slots = append(schedule.Overrides[idx].Slots, TimeRange{
+				Start: formatClock(int(*start)),
+				End:   formatClock(int(*end)),
+			})
+		}
+	}
+	if err := overrideRows.Err(); err != nil {
+		return nil, err
+	}
+
+	return schedules, nil
+}
+
+// ReplaceWeekly stores the timezone and replaces all weekly slots of a profile.
+func (r *AvailabilityRepository) ReplaceWeekly(ctx context.Context, profileID uuid.UUID, timezone string, slots []WeeklySlot) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	_, err = tx.Exec(ctx, `
+        INSERT INTO availability_schedules (profile_id, timezone, updated_at)
+        VALUES ($1, $2, now())
+        ON CONFLICT (profile_id) DO UPDATE SET timezone = EXCLUDED.timezone, updated_at = now()
+    `, profileID, timezone)
+	if err != nil {
+		return err
+	}
+
+	if _, err := tx.Exec(ctx, `DELETE FROM availability_weekly_slots WHERE profile_id = $1`, profileID); err != nil {
+		return err
+	}
+
+	for _, slot := range slots {
+		window, err := parseRange(slot.Start, slot.End)
+		if err != nil {
+			return err
+		}
+		_, err = tx.Exec(ctx, `
+            INSERT INTO availability_weekly_slots (profile_id, weekday, start_minute, end_minute)
+            VALUES ($1, $2, $3, $4)
+        `, profileID, slot.Weekday, window.start, window.end)
+		if err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit(ctx)
+}
+
+// SetOverride replaces the override windows of a profile for a single date.
+// An empty slot list stores a single row marking the day as unavailable.
+func (r *AvailabilityRepository) SetOverride(ctx context.Context, profileID uuid.UUID, date time.Time, slots []TimeRange) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	_, err = tx.Exec(ctx, `DELETE FROM availability_overrides WHERE profile_id = $1 AND date = $2`, profileID, date)
+	if err != nil {
+		return err
+	}
+
+	if len(slots) == 0 {
+		_, err = tx.Exec(ctx, `
+            INSERT INTO availability_overrides (profile_id, date, start_minute, end_minute)
+            VALUES ($1, $2, NULL, NULL)
+        `, profileID, date)
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, slot := range slots {
+		window, err := parseRange(slot.Start, slot.End)
+		if err != nil {
+			return err
+		}
+		_, err = tx.Exec(ctx, `
+            INSERT INTO availability_overrides (profile_id, date, start_minute, end_minute)
+            VALUES ($1, $2, $3, $4)
+        `, profileID, date, window.start, window.end)
+		if err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit(ctx)
+}
+
+// DeleteOverride removes the override for a date, restoring the weekly slots.
+func (r *AvailabilityRepository) DeleteOverride(ctx context.Context, profileID uuid.UUID, date time.Time) error {
+	result, err := r.db.Exec(ctx,
+		`DELETE FROM availability_overrides WHERE profile_id = $1 AND date = $2`,
+		profileID, date,
+	)
+	if err != nil {
+		return err
+	}
+
+	if result.RowsAffected() == 0 {
+		return ErrOverrideNotFound
+	}
+	return nil
+}
+
+// GetApprovedLeave returns the approved time off of a profile overlapping
+// [from, to).
+func (r *AvailabilityRepository) GetApprovedLeave(ctx context.Context, profileID uuid.UUID, from, to time.Time) ([]Interval, error) {
+	leave, err := r.GetApprovedLeaves(ctx, []uuid.UUID{profileID}, from, to)
+	if err != nil {
+		return nil, err
+	}
+	if leave[profileID] == nil {
+		return []Interval{}, nil
+	}
+	return leave[profileID], nil
+}
+
+// GetApprovedLeaves is GetApprovedLeave for many profiles at once, keyed by
+// profile. Profiles without time off are left out.
+func (r *AvailabilityRepository) GetApprovedLeaves(ctx context.Context, profileIDs []uuid.UUID, from, to time.Time) (map[uuid.UUID][]Interval, error) {
+	rows, err := r.db.Query(ctx, `
+        SELECT profile_id, starts_at, ends_at
+        FROM time_off_requests
+        WHERE profile_id = ANY($1)
+          AND status = 'APPROVED'
+          AND starts_at < $3
+          AND ends_at > $2
+        ORDER BY profile_id, starts_at
+    `, profileIDs, from, to)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	leave := map[uuid.UUID][]Interval{}
+	for rows.Next() {
+		var id uuid.UUID
+		var interval Interval
+		if err := rows.Scan(&id, &interval.Start, &interval.End); err != nil {
+			return nil, err
+		}
+		leave[id] = append(leave[id], interval)
+	}
+	return leave, rows.Err()
+}
@@ -0,0 +1,43 @@
+package availability
+
+import (
+	"hostflow/profile-service/internal/middlewares"
+	"hostflow/profile-service/pkg/lib"
+)
+
+type AvailabilityRoutes struct {
+	logger                 lib.Logger
+	router                 *lib.Router
+	availabilityController *AvailabilityController
+	authMiddleware         middlewares.AuthMiddleware
+}
+
+func SetAvailabilityRoutes(
+	logger lib.Logger,
+	router *lib.Router,
+	availabilityController *AvailabilityController,
+	authMiddleware middlewares.AuthMiddleware,
+) AvailabilityRoutes {
+	return AvailabilityRoutes{
+		logger:                 logger,
+		router:                 router,
+		availabilityController: availabilityController,
+		authMiddleware:         authMiddleware,
+	}
+}
+
+func (route AvailabilityRoutes) Setup() {
+	route.logger.Info("Setting up [AVAILABILITY] routes.")
+
+	availability := route.router.Group("/users/:id/availability")
+	availability.Use(route.authMiddleware.HandlerWithAPIKeys("availability"))
+	{
+		availability.GET("", route.availabilityController.GetAvailabilityHandler)
+		availability.GET("/schedule", route.availabilityController.GetScheduleHandler)
+		availability.PUT("/schedule", route.availabilityController.UpdateScheduleHandler)
+		availability.PUT("/overrides/:date", route.availabilityController.SetOverrideHandler)
+		availability.DELETE("/overrides/:date", route.availabilityController.DeleteOverrideHandler)
+	}
+
+	route.logger.Info("[AVAILABILITY] routes setup complete.")
+}
@@ -0,0 +1,185 @@
+package availability
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// MaxExpansionRange limits how far a single availability query may reach.
+const MaxExpansionRange = 93 * 24 * time.Hour
+
+var (
+	ErrProfileNotFound  = errors.New("profile not found in your organization")
+	ErrForbidden        = errors.New("only the profile owner or an organization owner can change availability")
+	ErrOverrideNotFound = errors.New("no override found for this date")
+	ErrInvalidInput     = errors.New("invalid input")
+)
+
+type AvailabilityService struct {
+	repo *AvailabilityRepository
+}
+
+type Service interface {
+	GetSchedule(ctx context.Context, profileID uuid.UUID, orgID int64) (*Schedule, error)
+	UpdateSchedule(ctx context.Context, profileID uuid.UUID, requesterID string, orgID int64, role string, req UpdateScheduleRequest) (*Schedule, error)
+	SetOverride(ctx context.Context, profileID uuid.UUID, requesterID string, orgID int64, role string, date string, slots []TimeRange) error
+	DeleteOverride(ctx context.Context, profileID uuid.UUID, requesterID string, orgID int64, role string, date string) error
+	GetAvailability(ctx context.Context, profileID uuid.UUID, orgID int64, from, to time.Time) ([]Interval, error)
+	FindFree(ctx context.Context, profileIDs []uuid.UUID, start, end time.Time) (map[uuid.UUID]bool, error)
+}
+
+func GetAvailabilityService(repo *AvailabilityRepository) *AvailabilityService {
+	return &AvailabilityService{
+		repo: repo,
+	}
+}
+
+// GetSchedule returns the weekly schedule with overrides for the coming year.
+func (s *AvailabilityService) GetSchedule(ctx context.Context, profileID uuid.UUID, orgID int64) (*Schedule, error) {
+	if err := s.ensureMember(ctx, profileID, orgID); err != nil {
+		return nil, err
+	}
+
+	today := time.Now().UTC().Truncate(24 * time.Hour)
+	return s.repo.GetSchedule(ctx, profileID, today.AddDate(0, 0, -1), today.AddDate(1, 0, 0))
+}
+
+// UpdateSchedule replaces the timezone and weekly slots of a profile.
+func (s *AvailabilityService) UpdateSchedule(ctx context.Context, profileID uuid.UUID, requesterID string, orgID int64, role string, req UpdateScheduleRequest) (*Schedule, error) {
+	if err := s.ensureCanEdit(ctx, profileID, requesterID, orgID, role); err != nil {
+		return nil, err
+	}
+
+	// Validate the whole schedule up front so nothing is stored on error
+	if _, err := compile(Schedule{Timezone: req.Timezone, Weekly: req.Weekly}); err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidInput, err)
+	}
+
+	if err := s.repo.ReplaceWeekly(ctx, profileID, req.Timezone, req.Weekly); err != nil {
+		return nil, err
+	}
+
+	return s.GetSchedule(ctx, profileID, orgID)
+}
+
+// SetOverride replaces the availability of a profile for one local date.
+func (s *AvailabilityService) SetOverride(ctx context.Context, profileID uuid.UUID, requesterID string, orgID int64, role string, date string, slots []TimeRange) error {
+	if err := s.ensureCanEdit(ctx, profileID, requesterID, orgID, role); err != nil {
+		return err
+	}
+
+	day, err := time.Parse(dateLayout, date)
+	if err != nil {
+		return fmt.Errorf("%w: date must be in YYYY-MM-DD format", ErrInvalidInput)
+	}
+	for _, slot := range slots {
+		if _, err := parseRange(slot.Start, slot.End); err != nil {
+			return fmt.Errorf("%w: %s", ErrInvalidInput, err)
+		}
+	}
+
+	return s.repo.SetOverride(ctx, profileID, day, slots)
+}
+
+// DeleteOverride removes a date override of a profile.
+func (s *AvailabilityService) DeleteOverride(ctx context.Context, profileID uuid.UUID, requesterID string, orgID int64, role string, date string) error {
+	if err := s.ensureCanEdit(ctx, profileID, requesterID, orgID, role); err != nil {
+		return err
+	}
+
+	day, err := time.Parse(dateLayout, date)
+	if err != nil {
+		return fmt.Errorf("%w: date must be in YYYY-MM-DD format", ErrInvalidInput)
+	}
+
+	return s.repo.DeleteOverride(ctx, profileID, day)
+}
+
+// GetAvailability expands the schedule of a profile into UTC intervals,
+// minus any approved time off.
+func (s *AvailabilityService) GetAvailability(ctx context.Context, profileID uuid.UUID, orgID int64, from, to time.Time) ([]Interval, error) {
+	if !to.After(from) {
+		return nil, fmt.Errorf("%w: 'to' must be after 'from'", ErrInvalidInput)
+	}
+	if to.Sub(from) > MaxExpansionRange {
+		return nil, fmt.Errorf("%w: range must not exceed %d days", ErrInvalidInput, int(MaxExpansionRange.Hours()/24))
+	}
+
+	if err := s.ensureMember(ctx, profileID, orgID); err != nil {
+		return nil, err
+	}
+
+	// Overrides are keyed by local date and Expand walks one extra local
+	// date on each side, so load two days of margin: one for the UTC offset
+	// and one for that walk.
+	schedule, err := s.repo.GetSchedule(ctx, profileID, from.AddDate(0, 0, -2), to.AddDate(0, 0, 2))
+	if err != nil {
+		return nil, err
+	}
+
+	intervals, err := Expand(*schedule, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	leave, err := s.repo.GetApprovedLeave(ctx, profileID, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	return Subtract(intervals, leave), nil
+}
+
+// FindFree reports which of the profiles are available for the whole
+// window [start, end). Schedules and time off of all profiles are loaded
+// together, so the number of queries does not grow with the profiles.
+// Callers are responsible for organization scoping.
+func (s *AvailabilityService) FindFree(ctx context.Context, profileIDs []uuid.UUID, start, end time.Time) (map[uuid.UUID]bool, error) {
+	free := make(map[uuid.UUID]bool, len(profileIDs))
+	if len(profileIDs) == 0 {
+		return free, nil
+	}
+
+	schedules, err := s.repo.GetSchedules(ctx, profileIDs, start.AddDate(0, 0, -2), end.AddDate(0, 0, 2))
+	if err != nil {
+		return nil, err
+	}
+	leave, err := s.repo.GetApprovedLeaves(ctx, profileIDs, start, end)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, id := range profileIDs {
+		intervals, err := Expand(*schedules[id], start, end)
+		if err != nil {
+			return nil, err
+		}
+		free[id] = Covers(Subtract(intervals, leave[id]), start, end)
+	}
+	return free, nil
+}
+
+// ensureMember checks that the profile belongs to the requester's organization.
+func (s *AvailabilityService) ensureMember(ctx context.Context, profileID uuid.UUID, orgID int64) error {
+	ok, err := s.repo.ProfileInOrganization(ctx, profileID, orgID)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return ErrProfileNotFound
+	}
+	return nil
+}
+
+// ensureCanEdit allows members to edit their own availability and owners to
+// edit anyone's within the organization.
+func (s *AvailabilityService) ensureCanEdit(ctx context.Context, profileID uuid.UUID, requesterID string, orgID int64, role string) error {
+	if role != "OWNER" && requesterID != profileID.String() {
+		return ErrForbidden
+	}
+	return s.ensureMember(ctx, profileID, orgID)
+}
@@ -0,0 +1,16 @@
+package billing
+
+import "go.uber.org/fx"
+
+// ======== EXPORTS ========
+
+// Module exports services present
+var Context = fx.Options(
+	fx.Provide(GetBillingController),
+	fx.Provide(fx.Annotate(
+		GetBillingService,
+		fx.As(new(Service)),
+	)),
+	fx.Provide(GetBillingRepository),
+	fx.Provide(SetBillingRoutes),
+)
@@ -0,0 +1,126 @@
+package billing
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"hostflow/profile-service/internal/middlewares"
+	"hostflow/profile-service/internal/organization"
+	"hostflow/profile-service/pkg/common"
+
+	"github.com/gin-gonic/gin"
+)
+
+type BillingController struct {
+	service Service
+}
+
+func GetBillingController(service Service) *BillingController {
+	return &BillingController{
+		service: service,
+	}
+}
+
+// GetBillingHandler godoc
+// @Summary Get billing profile
+// @Description Returns the invoicing details of the requester's organization with a masked IBAN. Requires OWNER or MANAGER role.
+// @Tags billing
+// @Produce json
+// @Security ApiKeyAuth
+// @Success 200 {object} BillingProfile
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /organization/billing [get]
+func (c *BillingController) GetBillingHandler(ctx *gin.Context) {
+	principal := middlewares.GetPrincipal(ctx)
+
+	profile, err := c.service.Get(ctx.Request.Context(), principal.OrganizationID, principal.Role)
+	if err != nil {
+		respondError(ctx, "Failed to fetch billing profile", err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, profile)
+}
+
+// UpsertBillingHandler godoc
+// @Summary Set billing profile
+// @Description Creates or replaces the invoicing details of the requester's organization. VAT IDs and IBANs are validated offline (format and check digits); the IBAN is stored encrypted. Requires OWNER role.
+// @Tags billing
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param body body UpsertBillingRequest true "Billing profile"
+// @Success 200 {object} BillingProfile
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Router /organization/billing [put]
+func (c *BillingController) UpsertBillingHandler(ctx *gin.Context) {
+	principal := middlewares.GetPrincipal(ctx)
+
+	var body UpsertBillingRequest
+	if errors := common.Validation.ValidateBody(ctx, &body); errors != nil {
+		ctx.JSON(http.StatusBadRequest, errors)
+		return
+	}
+
+	profile, err := c.service.Upsert(ctx.Request.Context(), principal.OrganizationID, principal.Role, body)
+	if err != nil {
+		respondError(ctx, "Failed to save billing profile", err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, profile)
+}
+
+// GetInternalBillingHandler godoc
+// @Summary Get billing profile (internal)
+// @Description Returns the invoicing details of an organization including the full IBAN. For other services only; requires a service token with the billing:read scope.
+// @Tags internal
+// @Produce json
+// @Param id path int true "Organization ID"
+// @Success 200 {object} BillingProfile
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /internal/organizations/{id}/billing [get]
+func (c *BillingController) GetInternalBillingHandler(ctx *gin.Context) {
+	orgID, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "Invalid ID format",
+			Message: "Organization ID must be numeric",
+		})
+		return
+	}
+
+	profile, err := c.service.GetInternal(ctx.Request.Context(), orgID)
+	if err != nil {
+		respondError(ctx, "Failed to fetch billing profile", err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, profile)
+}
+
+// respondError maps service errors to HTTP status codes.
+func respondError(ctx *gin.Context, title string, err error) {
+	status := http.StatusInternalServerError
+	switch {
+	case errors.Is(err, ErrInvalidInput),
+		errors.Is(err, organization.ErrInvalidInput),
+		errors.Is(err, common.ErrInvalidVAT),
+		errors.Is(err, common.ErrInvalidIBAN):
+		status = http.StatusBadRequest
+	case errors.Is(err, ErrForbidden):
+		status = http.StatusForbidden
+	case errors.Is(err, ErrBillingNotFound):
+		status = http.StatusNotFound
+	}
+
+	ctx.JSON(status, ErrorResponse{
+		Error:   title,
+		Message: err.Error(),
+	})
+}
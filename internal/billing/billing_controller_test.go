@@ -0,0 +1,85 @@
+package billing
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"hostflow/profile-service/internal/middlewares"
+	"hostflow/profile-service/pkg/common"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockBillingService struct {
+	mock.Mock
+}
+
+func (m *MockBillingService) Get(ctx context.Context, orgID int64, role string) (*BillingProfile, error) {
+	args := m.Called(ctx, orgID, role)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*BillingProfile), args.Error(1)
+}
+
+func (m *MockBillingService) Upsert(ctx context.Context, orgID int64, role string, req UpsertBillingRequest) (*BillingProfile, error) {
+	args := m.Called(ctx, orgID, role, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*BillingProfile), args.Error(1)
+}
+
+func (m *MockBillingService) GetInternal(ctx context.Context, orgID int64) (*BillingProfile, error) {
+	args := m.Called(ctx, orgID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*BillingProfile), args.Error(1)
+}
+
+func TestUpsertBillingHandler_InvalidVAT(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	mockSvc := new(MockBillingService)
+	controller := GetBillingController(mockSvc)
+
+	r := gin.New()
+	r.PUT("/organization/billing", func(c *gin.Context) {
+		middlewares.SetPrincipal(c, middlewares.Principal{OrganizationID: 1, Role: "OWNER"})
+		controller.UpsertBillingHandler(c)
+	})
+
+	mockSvc.On("Upsert", mock.Anything, int64(1), "OWNER", mock.Anything).Return(nil, common.ErrInvalidVAT)
+
+	w := httptest.NewRecorder()
+	body := `{"legal_name":"Vila Bled d.o.o.","vat_id":"SI15012558","invoice_prefix":"VB-"}`
+	req, _ := http.NewRequest("PUT", "/organization/billing", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestGetInternalBillingHandler_Success(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	mockSvc := new(MockBillingService)
+	controller := GetBillingController(mockSvc)
+
+	r := gin.New()
+	r.GET("/internal/organizations/:id/billing", controller.GetInternalBillingHandler)
+
+	iban := "SI56191000000123438"
+	mockSvc.On("GetInternal", mock.Anything, int64(7)).Return(&BillingProfile{OrganizationID: 7, IBAN: &iban}, nil)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/internal/organizations/7/billing", nil)
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), iban)
+}
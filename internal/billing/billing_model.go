@@ -0,0 +1,50 @@
+package billing
+
+import (
+	"time"
+
+	"hostflow/profile-service/internal/organization"
+)
+
+// BillingProfile holds the legal entity data used on invoices. IBAN is
+// masked everywhere except on the internal endpoint.
+type BillingProfile struct {
+	OrganizationID int64                `json:"organization_id"`
+	LegalName      string               `json:"legal_name" example:"Vila Bled d.o.o."`
+	Address        organization.Address `json:"address"`
+	VATID          *string              `json:"vat_id" example:"SI15012557"`
+	TaxID          *string              `json:"tax_id"`
+	IBAN           *string              `json:"iban" example:"SI56***********3438"`
+	InvoicePrefix  string               `json:"invoice_prefix" example:"VB-"`
+	UpdatedAt      time.Time            `json:"updated_at"`
+}
+
+// billingRecord is the stored form of a billing profile.
+type billingRecord struct {
+	OrganizationID int64                `db:"organization_id"`
+	LegalName      string               `db:"legal_name"`
+	Address        organization.Address `db:"address"`
+	VATID          *string              `db:"vat_id"`
+	TaxID          *string              `db:"tax_id"`
+	IBANEncrypted  *string              `db:"iban_encrypted"`
+	IBANMasked     *string              `db:"iban_masked"`
+	InvoicePrefix  string               `db:"invoice_prefix"`
+	UpdatedAt      time.Time            `db:"updated_at"`
+}
+
+// UpsertBillingRequest creates or replaces a billing profile. An omitted
+// iban keeps the stored one, an empty string removes it.
+type UpsertBillingRequest struct {
+	LegalName     string               `json:"legal_name" binding:"required,max=200"`
+	Address       organization.Address `json:"address"`
+	VATID         string               `json:"vat_id" binding:"max=20" example:"SI15012557"`
+	TaxID         string               `json:"tax_id" binding:"max=30"`
+	IBAN          *string              `json:"iban" binding:"omitempty,max=42" example:"SI56 1910 0000 0123 438"`
+	InvoicePrefix string               `json:"invoice_prefix" binding:"required,max=10" example:"VB-"`
+}
+
+// ErrorResponse represents an error response
+type ErrorResponse struct {
+	Error   string `json:"error" example:"Invalid request"`
+	Message string `json:"message,omitempty" example:"The provided data is invalid"`
+}
@@ -0,0 +1,81 @@
+package billing
+
+import (
+	"context"
+	"errors"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+const billingColumns = `organization_id, legal_name, address, vat_id, tax_id, iban_encrypted, iban_masked, invoice_prefix, updated_at`
+
+type BillingRepository struct {
+	db *pgxpool.Pool
+}
+
+func GetBillingRepository(db *pgxpool.Pool) *BillingRepository {
+	return &BillingRepository{
+		db: db,
+	}
+}
+
+// Get returns the billing profile of an organization, or nil if none exists.
+func (r *BillingRepository) Get(ctx context.Context, orgID int64) (*billingRecord, error) {
+	rows, err := r.db.Query(ctx, `SELECT `+billingColumns+` FROM billing_profiles WHERE organization_id = $1`, orgID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	record, err := pgx.CollectOneRow(rows, pgx.RowToStructByName[billingRecord])
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &record, nil
+}
+
+// Upsert creates or replaces a billing profile. When keepIBAN is set the
+// stored IBAN columns are left untouched.
+func (r *BillingRepository) Upsert(ctx context.Context, record billingRecord, keepIBAN bool) (*billingRecord, error) {
+	query := `
+        INSERT INTO billing_profiles (
+            organization_id, legal_name, address, vat_id, tax_id, iban_encrypted, iban_masked, invoice_prefix
+        )
+        VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+        ON CONFLICT (organization_id) DO UPDATE SET
+            legal_name     = EXCLUDED.legal_name,
+            address        = EXCLUDED.address,
+            vat_id         = EXCLUDED.vat_id,
+            tax_id         = EXCLUDED.tax_id,
+            iban_encrypted = CASE WHEN $9 THEN billing_profiles.iban_encrypted ELSE EXCLUDED.iban_encrypted END,
+            iban_masked    = CASE WHEN $9 THEN billing_profiles.iban_masked ELSE EXCLUDED.iban_masked END,
+            invoice_prefix = EXCLUDED.invoice_prefix,
+            updated_at     = now()
+        RETURNING ` + billingColumns
+
+	rows, err := r.db.Query(ctx, query,
+		record.OrganizationID,
+		record.LegalName,
+		record.Address,
+		record.VATID,
+		record.TaxID,
+		record.IBANEncrypted,
+		record.IBANMasked,
+		record.InvoicePrefix,
+		keepIBAN,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	saved, err := pgx.CollectOneRow(rows, pgx.RowToStructByName[billingRecord])
+	if err != nil {
+		return nil, err
+	}
+	return &saved, nil
+}
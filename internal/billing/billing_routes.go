@@ -0,0 +1,49 @@
+package billing
+
+import (
+	"hostflow/profile-service/internal/auth"
+	"hostflow/profile-service/internal/middlewares"
+	"hostflow/profile-service/pkg/lib"
+)
+
+type BillingRoutes struct {
+	logger             lib.Logger
+	router             *lib.Router
+	billingController  *BillingController
+	authMiddleware     middlewares.AuthMiddleware
+	internalMiddleware middlewares.InternalMiddleware
+}
+
+func SetBillingRoutes(
+	logger lib.Logger,
+	router *lib.Router,
+	billingController *BillingController,
+	authMiddleware middlewares.AuthMiddleware,
+	internalMiddleware middlewares.InternalMiddleware,
+) BillingRoutes {
+	return BillingRoutes{
+		logger:             logger,
+		router:             router,
+		billingController:  billingController,
+		authMiddleware:     authMiddleware,
+		internalMiddleware: internalMiddleware,
+	}
+}
+
+func (route BillingRoutes) Setup() {
+	route.logger.Info("Setting up [BILLING] routes.")
+
+	organizations := route.router.Group("/organization")
+	organizations.Use(route.authMiddleware.Handler())
+	{
+		organizations.GET("/billing", route.billingController.GetBillingHandler)
+		organizations.PUT("/billing", route.billingController.UpsertBillingHandler)
+	}
+
+	internal := route.router.Group("/internal")
+	{
+		internal.GET("/organizations/:id/billing", route.internalMiddleware.Handler(auth.ScopeBillingRead), route.billingController.GetInternalBillingHandler)
+	}
+
+	route.logger.Info("[BILLING] routes setup complete.")
+}
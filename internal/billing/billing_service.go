@@ -0,0 +1,177 @@
+package billing
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"hostflow/profile-service/pkg/common"
+)
+
+var (
+	ErrBillingNotFound = errors.New("billing profile not found")
+	ErrForbidden       = errors.New("you are not allowed to perform this action")
+	ErrInvalidInput    = errors.New("invalid input")
+)
+
+// invoicePrefixPattern allows short upper-case prefixes such as "VB-" or "2026/".
+var invoicePrefixPattern = regexp.MustCompile(`^[A-Z0-9][A-Z0-9/-]{0,9}$`)
+
+type BillingService struct {
+	repo   *BillingRepository
+	cipher *common.Cipher
+}
+
+type Service interface {
+	Get(ctx context.Context, orgID int64, role string) (*BillingProfile, error)
+	Upsert(ctx context.Context, orgID int64, role string, req UpsertBillingRequest) (*BillingProfile, error)
+	GetInternal(ctx context.Context, orgID int64) (*BillingProfile, error)
+}
+
+// GetBillingService builds the service with the key from
+// BILLING_ENCRYPTION_KEY (base64, 32 bytes). Start-up fails without it so
+// bank data is never written in clear text.
+func GetBillingService(repo *BillingRepository) (*BillingService, error) {
+	cipher, err := common.NewCipherFromBase64(os.Getenv("BILLING_ENCRYPTION_KEY"))
+	if err != nil {
+		return nil, fmt.Errorf("BILLING_ENCRYPTION_KEY: %w", err)
+	}
+
+	return &BillingService{
+		repo:   repo,
+		cipher: cipher,
+	}, nil
+}
+
+// Get returns the billing profile with a masked IBAN. Requires OWNER or
+// MANAGER role.
+func (s *BillingService) Get(ctx context.Context, orgID int64, role string) (*BillingProfile, error) {
+	if role != "OWNER" && role != "MANAGER" {
+		return nil, ErrForbidden
+	}
+
+	record, err := s.repo.Get(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+	if record == nil {
+		return nil, ErrBillingNotFound
+	}
+	return toProfile(record, record.IBANMasked), nil
+}
+
+// Upsert validates and stores the billing profile. Requires OWNER role.
+func (s *BillingService) Upsert(ctx context.Context, orgID int64, role string, req UpsertBillingRequest) (*BillingProfile, error) {
+	if role != "OWNER" {
+		return nil, ErrForbidden
+	}
+
+	record, err := normalize(orgID, req)
+	if err != nil {
+		return nil, err
+	}
+
+	keepIBAN := req.IBAN == nil
+	if !keepIBAN && *req.IBAN != "" {
+		iban := common.IBAN.Normalize(*req.IBAN)
+		if err := common.IBAN.Validate(iban); err != nil {
+			return nil, err
+		}
+		encrypted, err := s.cipher.Encrypt(iban, associatedData(orgID))
+		if err != nil {
+			return nil, err
+		}
+		masked := common.IBAN.Mask(iban)
+		record.IBANEncrypted = &encrypted
+		record.IBANMasked = &masked
+	}
+
+	saved, err := s.repo.Upsert(ctx, *record, keepIBAN)
+	if err != nil {
+		return nil, err
+	}
+	return toProfile(saved, saved.IBANMasked), nil
+}
+
+// GetInternal returns the billing profile with the decrypted IBAN for
+// other services. Callers are authenticated by the internal middleware.
+func (s *BillingService) GetInternal(ctx context.Context, orgID int64) (*BillingProfile, error) {
+	record, err := s.repo.Get(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+	if record == nil {
+		return nil, ErrBillingNotFound
+	}
+
+	var iban *string
+	if record.IBANEncrypted != nil {
+		plain, err := s.cipher.Decrypt(*record.IBANEncrypted, associatedData(orgID))
+		if err != nil {
+			return nil, err
+		}
+		iban = &plain
+	}
+	return toProfile(record, iban), nil
+}
+
+// normalize validates a request and turns it into a record without bank data.
+func normalize(orgID int64, req UpsertBillingRequest) (*billingRecord, error) {
+	record := &billingRecord{
+		OrganizationID: orgID,
+		LegalName:      strings.TrimSpace(req.LegalName),
+		Address:        req.Address,
+		InvoicePrefix:  strings.ToUpper(strings.TrimSpace(req.InvoicePrefix)),
+	}
+
+	if record.LegalName == "" {
+		return nil, fmt.Errorf("%w: legal_name must not be blank", ErrInvalidInput)
+	}
+
+	if err := record.Address.Normalize(); err != nil {
+		return nil, err
+	}
+	if record.Address.IsEmpty() {
+		return nil, fmt.Errorf("%w: a registered address is required", ErrInvalidInput)
+	}
+
+	if vatID := common.VAT.Normalize(req.VATID); vatID != "" {
+		if err := common.VAT.Validate(vatID); err != nil {
+			return nil, err
+		}
+		record.VATID = &vatID
+	}
+
+	if taxID := strings.TrimSpace(req.TaxID); taxID != "" {
+		record.TaxID = &taxID
+	}
+
+	if !invoicePrefixPattern.MatchString(record.InvoicePrefix) {
+		return nil, fmt.Errorf("%w: invoice_prefix may only contain A-Z, 0-9, '-' and '/'", ErrInvalidInput)
+	}
+
+	return record, nil
+}
+
+// associatedData binds an encrypted IBAN to its organization so a
+// ciphertext copied to another row fails to decrypt.
+func associatedData(orgID int64) []byte {
+	return []byte("billing_profiles:" + strconv.FormatInt(orgID, 10))
+}
+
+func toProfile(record *billingRecord, iban *string) *BillingProfile {
+	return &BillingProfile{
+		OrganizationID: record.OrganizationID,
+		LegalName:      record.LegalName,
+		Address:        record.Address,
+		VATID:          record.VATID,
+		TaxID:          record.TaxID,
+		IBAN:           iban,
+		InvoicePrefix:  record.InvoicePrefix,
+		UpdatedAt:      record.UpdatedAt,
+	}
+}
@@ -0,0 +1,47 @@
+package billing
+
+import (
+	"testing"
+
+	"hostflow/profile-service/internal/organization"
+	"hostflow/profile-service/pkg/common"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func validRequest() UpsertBillingRequest {
+	return UpsertBillingRequest{
+		LegalName:     " Vila Bled d.o.o. ",
+		Address:       organization.Address{Line1: "Cesta svobode 26", City: "Bled", PostalCode: "4260", Country: "si"},
+		VATID:         "si 15012557",
+		InvoicePrefix: "vb-",
+	}
+}
+
+func TestNormalize_Valid(t *testing.T) {
+	record, err := normalize(1, validRequest())
+
+	assert.NoError(t, err)
+	assert.Equal(t, "Vila Bled d.o.o.", record.LegalName)
+	assert.Equal(t, "SI15012557", *record.VATID)
+	assert.Equal(t, "VB-", record.InvoicePrefix)
+	assert.Equal(t, "SI", record.Address.Country)
+	assert.Nil(t, record.TaxID)
+}
+
+func TestNormalize_Invalid(t *testing.T) {
+	req := validRequest()
+	req.VATID = "SI15012558"
+	_, err := normalize(1, req)
+	assert.ErrorIs(t, err, common.ErrInvalidVAT)
+
+	req = validRequest()
+	req.Address = organization.Address{}
+	_, err = normalize(1, req)
+	assert.ErrorIs(t, err, ErrInvalidInput)
+
+	req = validRequest()
+	req.InvoicePrefix = "INV #"
+	_, err = normalize(1, req)
+	assert.ErrorIs(t, err, ErrInvalidInput)
+}
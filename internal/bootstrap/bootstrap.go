@@ -36,9 +36,33 @@ package bootstrap
 import (
 	"context"
 	"fmt"
+	"hostflow/profile-service/internal/admin"
+	"hostflow/profile-service/internal/apikeys"
+	"hostflow/profile-service/internal/audit"
+	"hostflow/profile-service/internal/auth"
+	"hostflow/profile-service/internal/availability"
+	"hostflow/profile-service/internal/billing"
+	"hostflow/profile-service/internal/closure"
+	"hostflow/profile-service/internal/domains"
+	"hostflow/profile-service/internal/hierarchy"
+	"hostflow/profile-service/internal/iamsync"
+	"hostflow/profile-service/internal/impersonation"
+	"hostflow/profile-service/internal/joinrequests"
 	"hostflow/profile-service/internal/middlewares"
+	"hostflow/profile-service/internal/organization"
+	"hostflow/profile-service/internal/orgchart"
+	"hostflow/profile-service/internal/plans"
 	"hostflow/profile-service/internal/profile"
+	"hostflow/profile-service/internal/scim"
+	"hostflow/profile-service/internal/security"
+	"hostflow/profile-service/internal/signup"
+	"hostflow/profile-service/internal/skills"
+	"hostflow/profile-service/internal/staffing"
+	"hostflow/profile-service/internal/timeoff"
+	"hostflow/profile-service/internal/webhooks"
+	"hostflow/profile-service/pkg/iam"
 	"hostflow/profile-service/pkg/lib"
+	"hostflow/profile-service/pkg/notify"
 	"os"
 
 	"go.uber.org/fx"
@@ -100,9 +124,33 @@ var Module = fx.Options(
 	// Module exports
 	lib.Module,
 	middlewares.Module,
+	iam.Module,
+	notify.Module,
 
 	// Context exports
 	profile.Context,
+	availability.Context,
+	timeoff.Context,
+	staffing.Context,
+	skills.Context,
+	orgchart.Context,
+	organization.Context,
+	billing.Context,
+	signup.Context,
+	audit.Context,
+	hierarchy.Context,
+	plans.Context,
+	closure.Context,
+	domains.Context,
+	joinrequests.Context,
+	auth.Context,
+	apikeys.Context,
+	iamsync.Context,
+	webhooks.Context,
+	scim.Context,
+	security.Context,
+	impersonation.Context,
+	admin.Context,
 
 	// Bootstrap exports
 	fx.Provide(GetRoutes),
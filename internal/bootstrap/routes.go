@@ -32,7 +32,28 @@ SOFTWARE.
 package bootstrap
 
 import (
+	"hostflow/profile-service/internal/admin"
+	"hostflow/profile-service/internal/apikeys"
+	"hostflow/profile-service/internal/audit"
+	"hostflow/profile-service/internal/auth"
+	"hostflow/profile-service/internal/availability"
+	"hostflow/profile-service/internal/billing"
+	"hostflow/profile-service/internal/closure"
+	"hostflow/profile-service/internal/domains"
+	"hostflow/profile-service/internal/hierarchy"
+	"hostflow/profile-service/internal/impersonation"
+	"hostflow/profile-service/internal/joinrequests"
+	"hostflow/profile-service/internal/organization"
+	"hostflow/profile-service/internal/orgchart"
+	"hostflow/profile-service/internal/plans"
 	"hostflow/profile-service/internal/profile"
+	"hostflow/profile-service/internal/scim"
+	"hostflow/profile-service/internal/security"
+	"hostflow/profile-service/internal/signup"
+	"hostflow/profile-service/internal/skills"
+	"hostflow/profile-service/internal/staffing"
+	"hostflow/profile-service/internal/timeoff"
+	"hostflow/profile-service/internal/webhooks"
 )
 
 // ======== TYPES ========
@@ -50,9 +71,51 @@ type Routes []Route
 // GetRoutes provides all the routes
 func GetRoutes(
 	profileRoutes profile.ProfileRoutes,
+	availabilityRoutes availability.AvailabilityRoutes,
+	timeOffRoutes timeoff.TimeOffRoutes,
+	staffingRoutes staffing.StaffingRoutes,
+	skillsRoutes skills.SkillsRoutes,
+	orgChartRoutes orgchart.OrgChartRoutes,
+	organizationRoutes organization.OrganizationRoutes,
+	billingRoutes billing.BillingRoutes,
+	signupRoutes signup.SignupRoutes,
+	auditRoutes audit.AuditRoutes,
+	hierarchyRoutes hierarchy.HierarchyRoutes,
+	plansRoutes plans.PlansRoutes,
+	closureRoutes closure.ClosureRoutes,
+	domainsRoutes domains.DomainsRoutes,
+	joinRequestsRoutes joinrequests.JoinRequestsRoutes,
+	authRoutes auth.AuthRoutes,
+	apiKeysRoutes apikeys.APIKeysRoutes,
+	webhooksRoutes webhooks.WebhooksRoutes,
+	scimRoutes scim.SCIMRoutes,
+	securityRoutes security.SecurityRoutes,
+	impersonationRoutes impersonation.ImpersonationRoutes,
+	adminRoutes admin.AdminRoutes,
 ) Routes {
 	return Routes{
 		profileRoutes,
+		availabilityRoutes,
+		timeOffRoutes,
+		staffingRoutes,
+		skillsRoutes,
+		orgChartRoutes,
+		organizationRoutes,
+		billingRoutes,
+		signupRoutes,
+		auditRoutes,
+		hierarchyRoutes,
+		plansRoutes,
+		closureRoutes,
+		domainsRoutes,
+		joinRequestsRoutes,
+		authRoutes,
+		apiKeysRoutes,
+		webhooksRoutes,
+		scimRoutes,
+		securityRoutes,
+		impersonationRoutes,
+		adminRoutes,
 	}
 }
 
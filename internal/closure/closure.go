@@ -0,0 +1,17 @@
+package closure
+
+import "go.uber.org/fx"
+
+// ======== EXPORTS ========
+
+// Module exports services present
+var Context = fx.Options(
+	fx.Provide(GetClosureController),
+	fx.Provide(fx.Annotate(
+		GetClosureService,
+		fx.As(new(Service)),
+	)),
+	fx.Provide(GetClosureRepository),
+	fx.Provide(SetClosureRoutes),
+	fx.Invoke(RegisterPurgeWorker),
+)
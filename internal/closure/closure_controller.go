@@ -0,0 +1,146 @@
+package closure
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"hostflow/profile-service/internal/middlewares"
+	"hostflow/profile-service/pkg/common"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ClosureController struct {
+	service Service
+}
+
+func GetClosureController(service Service) *ClosureController {
+	return &ClosureController{
+		service: service,
+	}
+}
+
+// GetClosureHandler godoc
+// @Summary Get organization closure
+// @Description Returns the closure state of the requester's organization, including when a pending closure will be purged. Requires OWNER role.
+// @Tags closure
+// @Produce json
+// @Security ApiKeyAuth
+// @Success 200 {object} Closure
+// @Failure 403 {object} ErrorResponse
+// @Router /organization/closure [get]
+func (c *ClosureController) GetClosureHandler(ctx *gin.Context) {
+	principal := middlewares.GetPrincipal(ctx)
+
+	closure, err := c.service.Get(ctx.Request.Context(), principal.OrganizationID, principal.Role)
+	if err != nil {
+		respondError(ctx, "Failed to fetch closure", err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, closure)
+}
+
+// RequestClosureHandler godoc
+// @Summary Close the organization
+// @Description Starts closing the requester's organization. Members lose access immediately; the closure can be cancelled during the grace period, after which all profiles and organization data are purged. Requires OWNER role and the organization's name as confirmation.
+// @Tags closure
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param body body RequestClosureRequest true "Confirmation"
+// @Success 202 {object} Closure
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Router /organization/closure [post]
+func (c *ClosureController) RequestClosureHandler(ctx *gin.Context) {
+	principal := middlewares.GetPrincipal(ctx)
+
+	var body RequestClosureRequest
+	if errors := common.Validation.ValidateBody(ctx, &body); errors != nil {
+		ctx.JSON(http.StatusBadRequest, errors)
+		return
+	}
+
+	closure, err := c.service.Request(ctx.Request.Context(), principal.UserID, principal.OrganizationID, principal.Role, body)
+	if err != nil {
+		respondError(ctx, "Failed to close organization", err)
+		return
+	}
+
+	ctx.JSON(http.StatusAccepted, closure)
+}
+
+// CancelClosureHandler godoc
+// @Summary Cancel organization closure
+// @Description Cancels a pending closure during its grace period and restores access for all members. Requires OWNER role.
+// @Tags closure
+// @Produce json
+// @Security ApiKeyAuth
+// @Success 200 {object} Closure
+// @Failure 403 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Router /organization/closure [delete]
+func (c *ClosureController) CancelClosureHandler(ctx *gin.Context) {
+	principal := middlewares.GetPrincipal(ctx)
+
+	closure, err := c.service.Cancel(ctx.Request.Context(), principal.UserID, principal.OrganizationID, principal.Role)
+	if err != nil {
+		respondError(ctx, "Failed to cancel closure", err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, closure)
+}
+
+// GetInternalReportHandler godoc
+// @Summary Get closure report (internal)
+// @Description Returns the final report of a purged organization. For other services only; requires a service token with the closure:read scope.
+// @Tags internal
+// @Produce json
+// @Param id path int true "Organization ID"
+// @Success 200 {object} Report
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /internal/organizations/{id}/closure-report [get]
+func (c *ClosureController) GetInternalReportHandler(ctx *gin.Context) {
+	orgID, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "Invalid ID format",
+			Message: "Organization ID must be numeric",
+		})
+		return
+	}
+
+	report, err := c.service.GetReport(ctx.Request.Context(), orgID)
+	if err != nil {
+		respondError(ctx, "Failed to fetch closure report", err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, report)
+}
+
+// respondError maps service errors to HTTP status codes.
+func respondError(ctx *gin.Context, title string, err error) {
+	status := http.StatusInternalServerError
+	switch {
+	case errors.Is(err, ErrInvalidInput), errors.Is(err, ErrNameMismatch):
+		status = http.StatusBadRequest
+	case errors.Is(err, ErrForbidden):
+		status = http.StatusForbidden
+	case errors.Is(err, ErrOrganizationNotFound), errors.Is(err, ErrReportNotFound):
+		status = http.StatusNotFound
+	case errors.Is(err, ErrNotActive), errors.Is(err, ErrNotCancellable):
+		status = http.StatusConflict
+	}
+
+	ctx.JSON(status, ErrorResponse{
+		Error:   title,
+		Message: err.Error(),
+	})
+}
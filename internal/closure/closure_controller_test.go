@@ -0,0 +1,131 @@
+package closure
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"hostflow/profile-service/internal/middlewares"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockClosureService struct {
+	mock.Mock
+}
+
+func (m *MockClosureService) Get(ctx context.Context, orgID int64, role string) (*Closure, error) {
+	args := m.Called(ctx, orgID, role)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*Closure), args.Error(1)
+}
+
+func (m *MockClosureService) Request(ctx context.Context, userID string, orgID int64, role string, req RequestClosureRequest) (*Closure, error) {
+	args := m.Called(ctx, userID, orgID, role, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*Closure), args.Error(1)
+}
+
+func (m *MockClosureService) Cancel(ctx context.Context, userID string, orgID int64, role string) (*Closure, error) {
+	args := m.Called(ctx, userID, orgID, role)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*Closure), args.Error(1)
+}
+
+func (m *MockClosureService) GetReport(ctx context.Context, orgID int64) (*Report, error) {
+	args := m.Called(ctx, orgID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*Report), args.Error(1)
+}
+
+func (m *MockClosureService) PurgeDue(ctx context.Context) (int, error) {
+	args := m.Called(ctx)
+	return args.Int(0), args.Error(1)
+}
+
+func setupClosureRouter(controller *ClosureController) *gin.Engine {
+	r := gin.New()
+	r.Use(func(c *gin.Context) {
+		middlewares.SetPrincipal(c, middlewares.Principal{UserID: "owner", OrganizationID: 1, Role: "OWNER"})
+	})
+	r.POST("/organization/closure", controller.RequestClosureHandler)
+	r.DELETE("/organization/closure", controller.CancelClosureHandler)
+	return r
+}
+
+func TestRequestClosureHandler_Accepted(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	mockSvc := new(MockClosureService)
+	r := setupClosureRouter(GetClosureController(mockSvc))
+
+	purgeAfter := time.Date(2026, 11, 18, 0, 0, 0, 0, time.UTC)
+	mockSvc.On("Request", mock.Anything, "owner", int64(1), "OWNER", RequestClosureRequest{ConfirmName: "Villa Bled"}).
+		Return(&Closure{OrganizationID: 1, Status: "CLOSING", PurgeAfter: &purgeAfter}, nil)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("POST", "/organization/closure", bytes.NewBufferString(`{"confirm_name":"Villa Bled"}`))
+	req.Header.Set("Content-Type", "application/json")
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusAccepted, w.Code)
+	assert.Contains(t, w.Body.String(), `"status":"CLOSING"`)
+}
+
+func TestRequestClosureHandler_NameMismatch(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	mockSvc := new(MockClosureService)
+	r := setupClosureRouter(GetClosureController(mockSvc))
+
+	mockSvc.On("Request", mock.Anything, "owner", int64(1), "OWNER", RequestClosureRequest{ConfirmName: "Other"}).
+		Return(nil, ErrNameMismatch)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("POST", "/organization/closure", bytes.NewBufferString(`{"confirm_name":"Other"}`))
+	req.Header.Set("Content-Type", "application/json")
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestCancelClosureHandler_GracePeriodOver(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	mockSvc := new(MockClosureService)
+	r := setupClosureRouter(GetClosureController(mockSvc))
+
+	mockSvc.On("Cancel", mock.Anything, "owner", int64(1), "OWNER").Return(nil, ErrNotCancellable)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("DELETE", "/organization/closure", nil)
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusConflict, w.Code)
+}
+
+func TestGetClosureService_GracePeriod(t *testing.T) {
+	t.Setenv("CLOSURE_GRACE_PERIOD", "")
+	service, err := GetClosureService(nil, nil, nil, nil, nil, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, DefaultGracePeriod, service.gracePeriod)
+
+	t.Setenv("CLOSURE_GRACE_PERIOD", "48h")
+	service, err = GetClosureService(nil, nil, nil, nil, nil, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, 48*time.Hour, service.gracePeriod)
+
+	t.Setenv("CLOSURE_GRACE_PERIOD", "30d")
+	_, err = GetClosureService(nil, nil, nil, nil, nil, nil)
+	assert.Error(t, err)
+}
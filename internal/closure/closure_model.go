@@ -0,0 +1,49 @@
+package closure
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Closure is the closure state of an organization.
+type Closure struct {
+	OrganizationID int64      `json:"organization_id" db:"id"`
+	Status         string     `json:"status" db:"status" example:"CLOSING"`
+	RequestedAt    *time.Time `json:"requested_at" db:"closure_requested_at"`
+	RequestedBy    *uuid.UUID `json:"requested_by" db:"closure_requested_by"`
+	Reason         *string    `json:"reason" db:"closure_reason"`
+	PurgeAfter     *time.Time `json:"purge_after" db:"purge_after"`
+	ClosedAt       *time.Time `json:"closed_at" db:"closed_at"`
+}
+
+// Report is the final record of a purged organization.
+type Report struct {
+	OrganizationID int64          `json:"organization_id" db:"organization_id"`
+	RequestedBy    *uuid.UUID     `json:"requested_by" db:"requested_by"`
+	RequestedAt    time.Time      `json:"requested_at" db:"requested_at"`
+	Reason         *string        `json:"reason" db:"reason"`
+	PurgedAt       time.Time      `json:"purged_at" db:"purged_at"`
+	Counts         map[string]int `json:"counts" db:"counts"`
+	Identities     IdentityReport `json:"identities" db:"identities"`
+}
+
+// IdentityReport counts the members queued to have the organization
+// cleared from their identity metadata, and those that could not be queued.
+type IdentityReport struct {
+	Queued int `json:"queued"`
+	Failed int `json:"failed"`
+}
+
+// RequestClosureRequest confirms the closure by repeating the
+// organization's name.
+type RequestClosureRequest struct {
+	ConfirmName string `json:"confirm_name" binding:"required" example:"Villa Bled d.o.o."`
+	Reason      string `json:"reason" binding:"max=1000" example:"Moving to another tool"`
+}
+
+// ErrorResponse represents an error response
+type ErrorResponse struct {
+	Error   string `json:"error" example:"Invalid request"`
+	Message string `json:"message,omitempty" example:"The provided data is invalid"`
+}
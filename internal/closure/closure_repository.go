@@ -0,0 +1,258 @@
+package closure
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+const closureColumns = `id, status, closure_requested_at, closure_requested_by, closure_reason, purge_after, closed_at`
+
+// purgeSteps delete the organization's data in dependency order. Each step
+// is reported under its name in the closure report. Tables that hang off
+// profiles (availability, skills and languages of a profile) go with them.
+var purgeSteps = []struct {
+	name  string
+	query string
+}{
+	{"time_off_requests", `DELETE FROM time_off_requests WHERE organization_id = $1`},
+	{"property_assignments", `DELETE FROM property_assignments WHERE organization_id = $1`},
+	{"profile_tags", `DELETE FROM profile_tags WHERE organization_id = $1`},
+	{"skills", `DELETE FROM skills WHERE organization_id = $1`},
+	{"billing_profiles", `DELETE FROM billing_profiles WHERE organization_id = $1`},
+	{"security_policies", `DELETE FROM organization_security_policies WHERE organization_id = $1`},
+	// The organization row is kept, so ON DELETE CASCADE never fires: the
+	// verified domain would stay claimed, join requests keep names and
+	// e-mail addresses, API keys their hashes and creators, and
+	// impersonation sessions the reasons and impersonated users
+	{"organization_domains", `DELETE FROM organization_domains WHERE organization_id = $1`},
+	{"join_requests", `DELETE FROM join_requests WHERE organization_id = $1`},
+	{"api_keys", `DELETE FROM api_keys WHERE organization_id = $1`},
+	{"impersonation_sessions", `DELETE FROM impersonation_sessions WHERE organization_id = $1`},
+	{"child_organizations_detached", `
+        UPDATE organization SET parent_id = NULL, pending_parent_id = NULL
+        WHERE parent_id = $1 OR pending_parent_id = $1
+    `},
+	{"teams", `DELETE FROM teams WHERE organization_id = $1`},
+	{"profiles", `DELETE FROM "profiles" WHERE organization_id = $1`},
+}
+
+type ClosureRepository struct {
+	db *pgxpool.Pool
+}
+
+func GetClosureRepository(db *pgxpool.Pool) *ClosureRepository {
+	return &ClosureRepository{
+		db: db,
+	}
+}
+
+// Get returns the closure state and name of an organization.
+func (r *ClosureRepository) Get(ctx context.Context, orgID int64) (*Closure, string, error) {
+	var name string
+	if err := r.db.QueryRow(ctx, `SELECT name FROM organization WHERE id = $1`, orgID).Scan(&name); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, "", ErrOrganizationNotFound
+		}
+		return nil, "", err
+	}
+
+	rows, err := r.db.Query(ctx, `SELECT `+closureColumns+` FROM organization WHERE id = $1`, orgID)
+	if err != nil {
+		return nil, "", err
+	}
+	closure, err := pgx.CollectOneRow(rows, pgx.RowToStructByName[Closure])
+	if err != nil {
+		return nil, "", err
+	}
+	return &closure, name, nil
+}
+
+// Request moves an ACTIVE organization to CLOSING. ErrNotActive is returned
+// when it is already closing or closed.
+func (r *ClosureRepository) Request(ctx context.Context, orgID int64, userID uuid.UUID, reason *string, purgeAfter time.Time) (*Closure, error) {
+	rows, err := r.db.Query(ctx, `
+        UPDATE organization
+        SET status = 'CLOSING',
+            closure_requested_at = now(),
+            closure_requested_by = $2,
+            closure_reason = $3,
+            purge_after = $4
+        WHERE id = $1 AND status = 'ACTIVE'
+        RETURNING `+closureColumns,
+		orgID, userID, reason, purgeAfter,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	closure, err := pgx.CollectOneRow(rows, pgx.RowToStructByName[Closure])
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrNotActive
+		}
+		return nil, err
+	}
+	return &closure, nil
+}
+
+// Cancel moves a CLOSING organization back to ACTIVE while its grace period
+// lasts. ErrNotCancellable is returned otherwise.
+func (r *ClosureRepository) Cancel(ctx context.Context, orgID int64) (*Closure, error) {
+	rows, err := r.db.Query(ctx, `
+        UPDATE organization
+        SET status = 'ACTIVE',
+            closure_requested_at = NULL,
+            closure_requested_by = NULL,
+            closure_reason = NULL,
+            purge_after = NULL
+        WHERE id = $1 AND status = 'CLOSING' AND purge_after > now()
+        RETURNING `+closureColumns,
+		orgID,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	closure, err := pgx.CollectOneRow(rows, pgx.RowToStructByName[Closure])
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrNotCancellable
+		}
+		return nil, err
+	}
+	return &closure, nil
+}
+
+// ListDue returns organizations whose grace period has ended.
+func (r *ClosureRepository) ListDue(ctx context.Context, limit int) ([]int64, error) {
+	rows, err := r.db.Query(ctx, `
+        SELECT id FROM organization
+        WHERE status = 'CLOSING' AND purge_after <= now()
+        ORDER BY purge_after
+        LIMIT $1
+    `, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return pgx.CollectRows(rows, pgx.RowTo[int64])
+}
+
+// Purge deletes the organization's data, anonymizes the organization row,
+// marks it CLOSED and stores the closure report, all in one transaction.
+// It returns the report and the IDs of the removed members. ErrNotDue is
+// returned when the organization is no longer due, e.g. because another
+// instance purged it or the closure was cancelled.
+func (r *ClosureRepository) Purge(ctx context.Context, orgID int64) (*Report, []uuid.UUID, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	report := Report{OrganizationID: orgID, Counts: map[string]int{}}
+	err = tx.QueryRow(ctx, `
+        SELECT closure_requested_by, closure_requested_at, closure_reason
+        FROM organization
+        WHERE id = $1 AND status = 'CLOSING' AND purge_after <= now()
+        FOR UPDATE SKIP LOCKED
+    `, orgID).Scan(&report.RequestedBy, &report.RequestedAt, &report.Reason)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil, ErrNotDue
+		}
+		return nil, nil, err
+	}
+
+	rows, err := tx.Query(ctx, `SELECT id FROM "profiles" WHERE organization_id = $1`, orgID)
+	if err != nil {
+		return nil, nil, err
+	}
+	members, err := pgx.CollectRows(rows, pgx.RowTo[uuid.UUID])
+	if err != nil {
+		return nil, nil, err
+	}
+
+	for _, step := range purgeSteps {
+		tag, err := tx.Exec(ctx, step.query, orgID)
+		if err != nil {
+			return nil, nil, err
+		}
+		report.Counts[step.name] = int(tag.RowsAffected())
+	}
+
+	_, err = tx.Exec(ctx, `
+        UPDATE organization
+        SET status = 'CLOSED',
+            closed_at = now(),
+            name = 'Closed organization ' || id,
+            slug = NULL,
+            legal_name = NULL,
+            address = NULL,
+            contact_email = NULL,
+            settings = '{}'::jsonb,
+            parent_id = NULL,
+            pending_parent_id = NULL,
+            created_by = NULL,
+            closure_requested_by = NULL,
+            closure_reason = NULL,
+            purge_after = NULL
+        WHERE id = $1
+    `, orgID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	err = tx.QueryRow(ctx, `
+        INSERT INTO organization_closure_reports (organization_id, requested_by, requested_at, reason, counts)
+        VALUES ($1, $2, $3, $4, $5)
+        ON CONFLICT (organization_id) DO UPDATE
+        SET requested_by = EXCLUDED.requested_by, requested_at = EXCLUDED.requested_at,
+            reason = EXCLUDED.reason, counts = EXCLUDED.counts, purged_at = now()
+        RETURNING purged_at
+    `, orgID, report.RequestedBy, report.RequestedAt, report.Reason, report.Counts).Scan(&report.PurgedAt)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, nil, err
+	}
+	return &report, members, nil
+}
+
+// SetIdentities records how clearing the members' identity metadata went.
+func (r *ClosureRepository) SetIdentities(ctx context.Context, orgID int64, identities IdentityReport) error {
+	_, err := r.db.Exec(ctx,
+		`UPDATE organization_closure_reports SET identities = $1 WHERE organization_id = $2`,
+		identities, orgID,
+	)
+	return err
+}
+
+// GetReport returns the closure report of a purged organization.
+func (r *ClosureRepository) GetReport(ctx context.Context, orgID int64) (*Report, error) {
+	rows, err := r.db.Query(ctx, `
+        SELECT organization_id, requested_by, requested_at, reason, purged_at, counts, identities
+        FROM organization_closure_reports
+        WHERE organization_id = $1
+    `, orgID)
+	if err != nil {
+		return nil, err
+	}
+
+	report, err := pgx.CollectOneRow(rows, pgx.RowToStructByName[Report])
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrReportNotFound
+		}
+		return nil, err
+	}
+	return &report, nil
+}
@@ -0,0 +1,41 @@
+package closure
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// purgeStepNames returns the names the closure report counts under.
+func purgeStepNames() []string {
+	names := make([]string, len(purgeSteps))
+	for i, step := range purgeSteps {
+		names[i] = step.name
+	}
+	return names
+}
+
+func TestPurgeSteps_ReportDomainsAndJoinRequests(t *testing.T) {
+	names := purgeStepNames()
+	assert.Contains(t, names, "organization_domains")
+	assert.Contains(t, names, "join_requests")
+}
+
+func TestPurgeSteps_ReportAPIKeys(t *testing.T) {
+	assert.Contains(t, purgeStepNames(), "api_keys")
+}
+
+func TestPurgeSteps_ReportImpersonationSessions(t *testing.T) {
+	assert.Contains(t, purgeStepNames(), "impersonation_sessions")
+}
+
+func TestPurgeSteps_ProfilesLast(t *testing.T) {
+	names := purgeStepNames()
+	assert.Equal(t, "profiles", names[len(names)-1])
+
+	seen := map[string]bool{}
+	for _, name := range names {
+		assert.False(t, seen[name], "duplicate step %s", name)
+		seen[name] = true
+	}
+}
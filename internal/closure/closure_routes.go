@@ -0,0 +1,51 @@
+package closure
+
+import (
+	"hostflow/profile-service/internal/auth"
+	"hostflow/profile-service/internal/middlewares"
+	"hostflow/profile-service/pkg/lib"
+)
+
+type ClosureRoutes struct {
+	logger             lib.Logger
+	router             *lib.Router
+	closureController  *ClosureController
+	authMiddleware     middlewares.AuthMiddleware
+	internalMiddleware middlewares.InternalMiddleware
+}
+
+func SetClosureRoutes(
+	logger lib.Logger,
+	router *lib.Router,
+	closureController *ClosureController,
+	authMiddleware middlewares.AuthMiddleware,
+	internalMiddleware middlewares.InternalMiddleware,
+) ClosureRoutes {
+	return ClosureRoutes{
+		logger:             logger,
+		router:             router,
+		closureController:  closureController,
+		authMiddleware:     authMiddleware,
+		internalMiddleware: internalMiddleware,
+	}
+}
+
+func (route ClosureRoutes) Setup() {
+	route.logger.Info("Setting up [CLOSURE] routes.")
+
+	// Owners of a closing organization keep access here to cancel
+	organizations := route.router.Group("/organization")
+	organizations.Use(route.authMiddleware.ClosingHandler())
+	{
+		organizations.GET("/closure", route.closureController.GetClosureHandler)
+		organizations.POST("/closure", route.closureController.RequestClosureHandler)
+		organizations.DELETE("/closure", route.closureController.CancelClosureHandler)
+	}
+
+	internal := route.router.Group("/internal")
+	{
+		internal.GET("/organizations/:id/closure-report", route.internalMiddleware.Handler(auth.ScopeClosureRead), route.closureController.GetInternalReportHandler)
+	}
+
+	route.logger.Info("[CLOSURE] routes setup complete.")
+}
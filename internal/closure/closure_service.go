@@ -0,0 +1,224 @@
+package closure
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"hostflow/profile-service/internal/audit"
+	"hostflow/profile-service/internal/iamsync"
+	"hostflow/profile-service/internal/middlewares"
+	"hostflow/profile-service/pkg/lib"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// DefaultGracePeriod is how long a closure can be cancelled, unless
+	// CLOSURE_GRACE_PERIOD says otherwise.
+	DefaultGracePeriod = 30 * 24 * time.Hour
+
+	// purgeBatchSize bounds how many organizations one run purges.
+	purgeBatchSize = 20
+)
+
+var (
+	ErrOrganizationNotFound = errors.New("organization not found")
+	ErrReportNotFound       = errors.New("closure report not found")
+	ErrNotActive            = errors.New("the organization is already being closed")
+	ErrNotCancellable       = errors.New("the organization has no closure that can still be cancelled")
+	ErrNotDue               = errors.New("the organization is not due for purge")
+	ErrNameMismatch         = errors.New("the confirmation does not match the organization's name")
+	ErrForbidden            = errors.New("you are not allowed to perform this action")
+	ErrInvalidInput         = errors.New("invalid input")
+)
+
+type ClosureService struct {
+	repo        *ClosureRepository
+	audit       audit.Service
+	identity    iamsync.Service
+	status      *middlewares.OrganizationStatus
+	memberships *middlewares.Memberships
+	logger      lib.Logger
+	gracePeriod time.Duration
+}
+
+type Service interface {
+	Get(ctx context.Context, orgID int64, role string) (*Closure, error)
+	Request(ctx context.Context, userID string, orgID int64, role string, req RequestClosureRequest) (*Closure, error)
+	Cancel(ctx context.Context, userID string, orgID int64, role string) (*Closure, error)
+	GetReport(ctx context.Context, orgID int64) (*Report, error)
+	PurgeDue(ctx context.Context) (int, error)
+}
+
+func GetClosureService(
+	repo *ClosureRepository,
+	audit audit.Service,
+	identity iamsync.Service,
+	status *middlewares.OrganizationStatus,
+	memberships *middlewares.Memberships,
+	logger lib.Logger,
+) (*ClosureService, error) {
+	gracePeriod, err := lib.DurationFromEnv("CLOSURE_GRACE_PERIOD", DefaultGracePeriod)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ClosureService{
+		repo:        repo,
+		audit:       audit,
+		identity:    identity,
+		status:      status,
+		memberships: memberships,
+		logger:      logger,
+		gracePeriod: gracePeriod,
+	}, nil
+}
+
+// Get returns the closure state of the organization. Requires OWNER role.
+func (s *ClosureService) Get(ctx context.Context, orgID int64, role string) (*Closure, error) {
+	if role != "OWNER" {
+		return nil, ErrForbidden
+	}
+
+	closure, _, err := s.repo.Get(ctx, orgID)
+	return closure, err
+}
+
+// Request starts closing the organization. Members lose access at once and
+// the data is purged when the grace period ends unless the closure is
+// cancelled. Requires OWNER role and the organization's name as
+// confirmation.
+func (s *ClosureService) Request(ctx context.Context, userID string, orgID int64, role string, req RequestClosureRequest) (*Closure, error) {
+	if role != "OWNER" {
+		return nil, ErrForbidden
+	}
+
+	actorID, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid user ID", ErrInvalidInput)
+	}
+
+	_, name, err := s.repo.Get(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+	if !strings.EqualFold(strings.TrimSpace(req.ConfirmName), strings.TrimSpace(name)) {
+		return nil, ErrNameMismatch
+	}
+
+	var reason *string
+	if trimmed := strings.TrimSpace(req.Reason); trimmed != "" {
+		reason = &trimmed
+	}
+
+	closure, err := s.repo.Request(ctx, orgID, actorID, reason, time.Now().Add(s.gracePeriod))
+	if err != nil {
+		return nil, err
+	}
+	s.status.Invalidate(orgID)
+
+	details := map[string]interface{}{"purge_after": closure.PurgeAfter}
+	if err := s.record(ctx, &actorID, orgID, "organization.closure_requested", details); err != nil {
+		return nil, err
+	}
+	return closure, nil
+}
+
+// Cancel stops a pending closure and restores access. Requires OWNER role.
+func (s *ClosureService) Cancel(ctx context.Context, userID string, orgID int64, role string) (*Closure, error) {
+	if role != "OWNER" {
+		return nil, ErrForbidden
+	}
+
+	closure, err := s.repo.Cancel(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+	s.status.Invalidate(orgID)
+
+	var actorID *uuid.UUID
+	if id, err := uuid.Parse(userID); err == nil {
+		actorID = &id
+	}
+	if err := s.record(ctx, actorID, orgID, "organization.closure_cancelled", nil); err != nil {
+		return nil, err
+	}
+	return closure, nil
+}
+
+// GetReport returns the closure report of a purged organization. Used by
+// other services.
+func (s *ClosureService) GetReport(ctx context.Context, orgID int64) (*Report, error) {
+	return s.repo.GetReport(ctx, orgID)
+}
+
+// PurgeDue purges organizations whose grace period has ended and returns
+// how many were purged. A failure on one organization is logged and does
+// not stop the others.
+func (s *ClosureService) PurgeDue(ctx context.Context) (int, error) {
+	due, err := s.repo.ListDue(ctx, purgeBatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	purged := 0
+	for _, orgID := range due {
+		if err := s.purge(ctx, orgID); err != nil {
+			if !errors.Is(err, ErrNotDue) {
+				s.logger.Error(fmt.Sprintf("Failed to purge organization %d: %v", orgID, err))
+			}
+			continue
+		}
+		purged++
+	}
+	return purged, nil
+}
+
+// purge removes one organization's data and then clears the organization
+// from its former members' identities so their tokens stop carrying it.
+func (s *ClosureService) purge(ctx context.Context, orgID int64) error {
+	report, members, err := s.repo.Purge(ctx, orgID)
+	if err != nil {
+		return err
+	}
+	s.status.Invalidate(orgID)
+	s.memberships.InvalidateOrganization(orgID)
+
+	// The members have no profile left, so the sync clears the organization
+	userIDs := make([]string, len(members))
+	for i, member := range members {
+		userIDs[i] = member.String()
+	}
+	identities := IdentityReport{Queued: len(members)}
+	if err := s.identity.Sync(ctx, userIDs...); err != nil {
+		identities = IdentityReport{Failed: len(members)}
+		s.logger.Error(fmt.Sprintf("Failed to queue identity sync of closed organization %d: %v", orgID, err))
+	}
+	if err := s.repo.SetIdentities(ctx, orgID, identities); err != nil {
+		return err
+	}
+
+	details := map[string]interface{}{"counts": report.Counts, "identities": identities}
+	return s.record(ctx, nil, orgID, "organization.purged", details)
+}
+
+// record writes an audit entry about the organization's closure.
+func (s *ClosureService) record(ctx context.Context, actorID *uuid.UUID, orgID int64, action string, details map[string]interface{}) error {
+	targetID := strconv.FormatInt(orgID, 10)
+	entry := audit.Entry{
+		OrganizationID: orgID,
+		ActorID:        actorID,
+		Action:         action,
+		TargetType:     "organization",
+		TargetID:       &targetID,
+		Details:        details,
+	}
+	if actorID != nil {
+		entry.ActorOrganizationID = &orgID
+	}
+	return s.audit.Record(ctx, entry)
+}
@@ -0,0 +1,35 @@
+package closure
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"hostflow/profile-service/pkg/lib"
+
+	"go.uber.org/fx"
+)
+
+// DefaultPurgeInterval is how often due closures are purged, unless
+// CLOSURE_PURGE_INTERVAL says otherwise.
+const DefaultPurgeInterval = time.Hour
+
+// RegisterPurgeWorker runs the purge of due closures in the background for
+// the lifetime of the app.
+func RegisterPurgeWorker(lifecycle fx.Lifecycle, service Service, logger lib.Logger) error {
+	interval, err := lib.DurationFromEnv("CLOSURE_PURGE_INTERVAL", DefaultPurgeInterval)
+	if err != nil {
+		return err
+	}
+
+	lib.RunPeriodically(lifecycle, interval, func(ctx context.Context) {
+		purged, err := service.PurgeDue(ctx)
+		if err != nil {
+			logger.Error(fmt.Sprintf("Closure purge failed: %v", err))
+		} else if purged > 0 {
+			logger.Info(fmt.Sprintf("Purged %d closed organizations.", purged))
+		}
+	})
+
+	return nil
+}
@@ -0,0 +1,20 @@
+package domains
+
+import "go.uber.org/fx"
+
+// ======== EXPORTS ========
+
+// Module exports services present
+var Context = fx.Options(
+	fx.Provide(GetDomainsController),
+	fx.Provide(fx.Annotate(
+		GetDomainsService,
+		fx.As(new(Service)),
+	)),
+	fx.Provide(GetDomainsRepository),
+	fx.Provide(fx.Annotate(
+		NewNetResolver,
+		fx.As(new(Resolver)),
+	)),
+	fx.Provide(SetDomainsRoutes),
+)
@@ -0,0 +1,204 @@
+package domains
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"hostflow/profile-service/internal/middlewares"
+	"hostflow/profile-service/pkg/common"
+
+	"github.com/gin-gonic/gin"
+)
+
+type DomainsController struct {
+	service Service
+}
+
+func GetDomainsController(service Service) *DomainsController {
+	return &DomainsController{
+		service: service,
+	}
+}
+
+// ListDomainsHandler godoc
+// @Summary List email domains
+// @Description Returns the email domains claimed by the requester's organization with the TXT record that verifies each. Requires OWNER role.
+// @Tags domains
+// @Produce json
+// @Security ApiKeyAuth
+// @Success 200 {array} Domain
+// @Failure 403 {object} ErrorResponse
+// @Router /organization/domains [get]
+func (c *DomainsController) ListDomainsHandler(ctx *gin.Context) {
+	principal := middlewares.GetPrincipal(ctx)
+
+	domains, err := c.service.List(ctx.Request.Context(), principal.OrganizationID, principal.Role)
+	if err != nil {
+		respondError(ctx, "Failed to fetch domains", err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, domains)
+}
+
+// ClaimDomainHandler godoc
+// @Summary Claim an email domain
+// @Description Starts claiming an email domain. Publish the returned TXT record and call verify. Public email providers cannot be claimed. Requires OWNER role.
+// @Tags domains
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param body body ClaimDomainRequest true "Domain"
+// @Success 201 {object} Domain
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Router /organization/domains [post]
+func (c *DomainsController) ClaimDomainHandler(ctx *gin.Context) {
+	principal := middlewares.GetPrincipal(ctx)
+
+	var body ClaimDomainRequest
+	if errors := common.Validation.ValidateBody(ctx, &body); errors != nil {
+		ctx.JSON(http.StatusBadRequest, errors)
+		return
+	}
+
+	domain, err := c.service.Claim(ctx.Request.Context(), principal.UserID, principal.OrganizationID, principal.Role, body.Domain)
+	if err != nil {
+		respondError(ctx, "Failed to claim domain", err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, domain)
+}
+
+// VerifyDomainHandler godoc
+// @Summary Verify an email domain
+// @Description Looks up the domain's verification TXT record and marks the domain verified when it carries the token. Requires OWNER role.
+// @Tags domains
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path int true "Domain ID"
+// @Success 200 {object} Domain
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 502 {object} ErrorResponse
+// @Router /organization/domains/{id}/verify [post]
+func (c *DomainsController) VerifyDomainHandler(ctx *gin.Context) {
+	principal := middlewares.GetPrincipal(ctx)
+
+	id, ok := parseDomainID(ctx)
+	if !ok {
+		return
+	}
+
+	domain, err := c.service.Verify(ctx.Request.Context(), principal.UserID, principal.OrganizationID, principal.Role, id)
+	if err != nil {
+		respondError(ctx, "Failed to verify domain", err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, domain)
+}
+
+// UpdateDomainHandler godoc
+// @Summary Set the join rule of a domain
+// @Description Sets what happens to new users with an email on a verified domain: NONE, AUTO_JOIN with join_role, or APPROVAL by an owner. Requires OWNER role.
+// @Tags domains
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path int true "Domain ID"
+// @Param body body UpdateDomainRequest true "Join rule"
+// @Success 200 {object} Domain
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Router /organization/domains/{id} [patch]
+func (c *DomainsController) UpdateDomainHandler(ctx *gin.Context) {
+	principal := middlewares.GetPrincipal(ctx)
+
+	id, ok := parseDomainID(ctx)
+	if !ok {
+		return
+	}
+
+	var body UpdateDomainRequest
+	if errors := common.Validation.ValidateBody(ctx, &body); errors != nil {
+		ctx.JSON(http.StatusBadRequest, errors)
+		return
+	}
+
+	domain, err := c.service.UpdateRule(ctx.Request.Context(), principal.UserID, principal.OrganizationID, principal.Role, id, body)
+	if err != nil {
+		respondError(ctx, "Failed to update domain", err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, domain)
+}
+
+// DeleteDomainHandler godoc
+// @Summary Remove an email domain
+// @Description Drops the claim and its join rule. Existing members are not affected. Requires OWNER role.
+// @Tags domains
+// @Security ApiKeyAuth
+// @Param id path int true "Domain ID"
+// @Success 204 "No Content"
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /organization/domains/{id} [delete]
+func (c *DomainsController) DeleteDomainHandler(ctx *gin.Context) {
+	principal := middlewares.GetPrincipal(ctx)
+
+	id, ok := parseDomainID(ctx)
+	if !ok {
+		return
+	}
+
+	if err := c.service.Delete(ctx.Request.Context(), principal.UserID, principal.OrganizationID, principal.Role, id); err != nil {
+		respondError(ctx, "Failed to delete domain", err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+// parseDomainID reads the numeric :id path parameter of a domain.
+func parseDomainID(ctx *gin.Context) (int64, bool) {
+	id, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "Invalid ID format",
+			Message: "Domain ID must be numeric",
+		})
+		return 0, false
+	}
+	return id, true
+}
+
+// respondError maps service errors to HTTP status codes.
+func respondError(ctx *gin.Context, title string, err error) {
+	status := http.StatusInternalServerError
+	switch {
+	case errors.Is(err, ErrInvalidInput):
+		status = http.StatusBadRequest
+	case errors.Is(err, ErrForbidden):
+		status = http.StatusForbidden
+	case errors.Is(err, ErrDomainNotFound):
+		status = http.StatusNotFound
+	case errors.Is(err, ErrDomainExists), errors.Is(err, ErrDomainTaken),
+		errors.Is(err, ErrNotVerified), errors.Is(err, ErrVerificationFailed):
+		status = http.StatusConflict
+	case errors.Is(err, ErrLookupFailed):
+		status = http.StatusBadGateway
+	}
+
+	ctx.JSON(status, ErrorResponse{
+		Error:   title,
+		Message: err.Error(),
+	})
+}
@@ -0,0 +1,52 @@
+package domains
+
+import (
+	"time"
+)
+
+// Join policies of a verified domain.
+const (
+	PolicyNone     = "NONE"
+	PolicyAutoJoin = "AUTO_JOIN"
+	PolicyApproval = "APPROVAL"
+)
+
+// Domain is an email domain claimed by an organization. RecordName and
+// RecordValue describe the DNS TXT record that proves the claim.
+type Domain struct {
+	ID                int64      `json:"id" db:"id"`
+	OrganizationID    int64      `json:"organization_id" db:"organization_id"`
+	Domain            string     `json:"domain" db:"domain" example:"villabled.si"`
+	VerificationToken string     `json:"-" db:"verification_token"`
+	VerifiedAt        *time.Time `json:"verified_at" db:"verified_at"`
+	JoinPolicy        string     `json:"join_policy" db:"join_policy" example:"AUTO_JOIN"`
+	JoinRole          string     `json:"join_role" db:"join_role" example:"MEMBER"`
+	CreatedAt         time.Time  `json:"created_at" db:"created_at"`
+	RecordName        string     `json:"record_name" db:"-" example:"_hostflow-challenge.villabled.si"`
+	RecordValue       string     `json:"record_value" db:"-" example:"hostflow-domain-verification=3f9a..."`
+}
+
+// Rule is what happens to a new user whose email is on a verified domain.
+type Rule struct {
+	OrganizationID int64  `db:"organization_id"`
+	DomainID       int64  `db:"id"`
+	Policy         string `db:"join_policy"`
+	Role           string `db:"join_role"`
+}
+
+// ClaimDomainRequest starts claiming an email domain.
+type ClaimDomainRequest struct {
+	Domain string `json:"domain" binding:"required" example:"villabled.si"`
+}
+
+// UpdateDomainRequest changes the join rule of a verified domain.
+type UpdateDomainRequest struct {
+	JoinPolicy *string `json:"join_policy" binding:"omitempty,oneof=NONE AUTO_JOIN APPROVAL" example:"APPROVAL"`
+	JoinRole   *string `json:"join_role" binding:"omitempty,oneof=MANAGER MEMBER" example:"MEMBER"`
+}
+
+// ErrorResponse represents an error response
+type ErrorResponse struct {
+	Error   string `json:"error" example:"Invalid request"`
+	Message string `json:"message,omitempty" example:"The provided data is invalid"`
+}
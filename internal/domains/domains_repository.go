@@ -0,0 +1,142 @@
+package domains
+
+import (
+	"context"
+	"errors"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+const domainColumns = `id, organization_id, domain, verification_token, verified_at, join_policy, join_role, created_at`
+
+type DomainsRepository struct {
+	db *pgxpool.Pool
+}
+
+func GetDomainsRepository(db *pgxpool.Pool) *DomainsRepository {
+	return &DomainsRepository{
+		db: db,
+	}
+}
+
+// List returns the domains claimed by an organization.
+func (r *DomainsRepository) List(ctx context.Context, orgID int64) ([]Domain, error) {
+	rows, err := r.db.Query(ctx, `SELECT `+domainColumns+` FROM organization_domains WHERE organization_id = $1 ORDER BY domain`, orgID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return pgx.CollectRows(rows, pgx.RowToStructByName[Domain])
+}
+
+// Get returns a domain claimed by the organization.
+func (r *DomainsRepository) Get(ctx context.Context, id int64, orgID int64) (*Domain, error) {
+	rows, err := r.db.Query(ctx, `SELECT `+domainColumns+` FROM organization_domains WHERE id = $1 AND organization_id = $2`, id, orgID)
+	if err != nil {
+		return nil, err
+	}
+	return collectDomain(rows)
+}
+
+// Create records a claim. ErrDomainExists is returned when the organization
+// already claimed the domain.
+func (r *DomainsRepository) Create(ctx context.Context, orgID int64, domain string, token string) (*Domain, error) {
+	rows, err := r.db.Query(ctx, `
+        INSERT INTO organization_domains (organization_id, domain, verification_token)
+        VALUES ($1, $2, $3)
+        RETURNING `+domainColumns,
+		orgID, domain, token,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return collectDomain(rows)
+}
+
+// MarkVerified marks a claim as verified. ErrDomainTaken is returned when
+// another organization verified the domain first.
+func (r *DomainsRepository) MarkVerified(ctx context.Context, id int64, orgID int64) (*Domain, error) {
+	rows, err := r.db.Query(ctx, `
+        UPDATE organization_domains SET verified_at = now()
+        WHERE id = $1 AND organization_id = $2
+        RETURNING `+domainColumns,
+		id, orgID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return collectDomain(rows)
+}
+
+// UpdateRule sets the join rule of a domain.
+func (r *DomainsRepository) UpdateRule(ctx context.Context, id int64, orgID int64, policy string, role string) (*Domain, error) {
+	rows, err := r.db.Query(ctx, `
+        UPDATE organization_domains SET join_policy = $3, join_role = $4
+        WHERE id = $1 AND organization_id = $2
+        RETURNING `+domainColumns,
+		id, orgID, policy, role,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return collectDomain(rows)
+}
+
+// Delete removes a claim.
+func (r *DomainsRepository) Delete(ctx context.Context, id int64, orgID int64) error {
+	tag, err := r.db.Exec(ctx, `DELETE FROM organization_domains WHERE id = $1 AND organization_id = $2`, id, orgID)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrDomainNotFound
+	}
+	return nil
+}
+
+// FindRule returns the join rule of a verified domain, or nil when the
+// domain is not verified or lets nobody in.
+func (r *DomainsRepository) FindRule(ctx context.Context, domain string) (*Rule, error) {
+	rows, err := r.db.Query(ctx, `
+        SELECT d.id, d.organization_id, d.join_policy, d.join_role
+        FROM organization_domains d
+        JOIN organization o ON o.id = d.organization_id
+        WHERE d.domain = $1 AND d.verified_at IS NOT NULL
+          AND d.join_policy <> 'NONE' AND o.status = 'ACTIVE'
+    `, domain)
+	if err != nil {
+		return nil, err
+	}
+
+	rule, err := pgx.CollectOneRow(rows, pgx.RowToStructByName[Rule])
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &rule, nil
+}
+
+// collectDomain reads a single domain row and maps unique violations: the
+// per-organization constraint to ErrDomainExists and the verified-domain
+// index to ErrDomainTaken.
+func collectDomain(rows pgx.Rows) (*Domain, error) {
+	domain, err := pgx.CollectOneRow(rows, pgx.RowToStructByName[Domain])
+	if err != nil {
+		var pgErr *pgconn.PgError
+		switch {
+		case errors.Is(err, pgx.ErrNoRows):
+			return nil, ErrDomainNotFound
+		case errors.As(err, &pgErr) && pgErr.Code == "23505" && pgErr.ConstraintName == "organization_domains_verified_idx":
+			return nil, ErrDomainTaken
+		case errors.As(err, &pgErr) && pgErr.Code == "23505":
+			return nil, ErrDomainExists
+		}
+		return nil, err
+	}
+	return &domain, nil
+}
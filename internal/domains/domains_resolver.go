@@ -0,0 +1,49 @@
+package domains
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+)
+
+// Resolver looks up DNS TXT records. It is an interface so tests can
+// replace it.
+type Resolver interface {
+	LookupTXT(ctx context.Context, name string) ([]string, error)
+}
+
+// NetResolver resolves with the system resolver.
+type NetResolver struct {
+	resolver *net.Resolver
+}
+
+func NewNetResolver() *NetResolver {
+	return &NetResolver{
+		resolver: net.DefaultResolver,
+	}
+}
+
+// LookupTXT returns the TXT records of name. A missing name or record is
+// not an error; it yields no records.
+func (r *NetResolver) LookupTXT(ctx context.Context, name string) ([]string, error) {
+	records, err := r.resolver.LookupTXT(ctx, name)
+	var dnsErr *net.DNSError
+	if errors.As(err, &dnsErr) && dnsErr.IsNotFound {
+		return nil, nil
+	}
+	return records, err
+}
+
+// checkRecord looks up the verification TXT record of a claimed domain and
+// checks that it carries the claim's token.
+func checkRecord(ctx context.Context, resolver Resolver, domain Domain) error {
+	records, err := resolver.LookupTXT(ctx, recordPrefix+domain.Domain)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrLookupFailed, err)
+	}
+	if !hasToken(records, domain.VerificationToken) {
+		return ErrVerificationFailed
+	}
+	return nil
+}
@@ -0,0 +1,43 @@
+package domains
+
+import (
+	"hostflow/profile-service/internal/middlewares"
+	"hostflow/profile-service/pkg/lib"
+)
+
+type DomainsRoutes struct {
+	logger            lib.Logger
+	router            *lib.Router
+	domainsController *DomainsController
+	authMiddleware    middlewares.AuthMiddleware
+}
+
+func SetDomainsRoutes(
+	logger lib.Logger,
+	router *lib.Router,
+	domainsController *DomainsController,
+	authMiddleware middlewares.AuthMiddleware,
+) DomainsRoutes {
+	return DomainsRoutes{
+		logger:            logger,
+		router:            router,
+		domainsController: domainsController,
+		authMiddleware:    authMiddleware,
+	}
+}
+
+func (route DomainsRoutes) Setup() {
+	route.logger.Info("Setting up [DOMAINS] routes.")
+
+	organizations := route.router.Group("/organization")
+	organizations.Use(route.authMiddleware.Handler())
+	{
+		organizations.GET("/domains", route.domainsController.ListDomainsHandler)
+		organizations.POST("/domains", route.domainsController.ClaimDomainHandler)
+		organizations.POST("/domains/:id/verify", route.domainsController.VerifyDomainHandler)
+		organizations.PATCH("/domains/:id", route.domainsController.UpdateDomainHandler)
+		organizations.DELETE("/domains/:id", route.domainsController.DeleteDomainHandler)
+	}
+
+	route.logger.Info("[DOMAINS] routes setup complete.")
+}
@@ -0,0 +1,216 @@
+package domains
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"hostflow/profile-service/internal/audit"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrDomainNotFound     = errors.New("domain not found")
+	ErrDomainExists       = errors.New("your organization already claimed this domain")
+	ErrDomainTaken        = errors.New("another organization already verified this domain")
+	ErrNotVerified        = errors.New("the domain has to be verified first")
+	ErrVerificationFailed = errors.New("the verification TXT record was not found")
+	ErrLookupFailed       = errors.New("the DNS lookup failed")
+	ErrForbidden          = errors.New("you are not allowed to perform this action")
+	ErrInvalidInput       = errors.New("invalid input")
+)
+
+type DomainsService struct {
+	repo     *DomainsRepository
+	resolver Resolver
+	audit    audit.Service
+}
+
+type Service interface {
+	List(ctx context.Context, orgID int64, role string) ([]Domain, error)
+	Claim(ctx context.Context, userID string, orgID int64, role string, domain string) (*Domain, error)
+	Verify(ctx context.Context, userID string, orgID int64, role string, id int64) (*Domain, error)
+	UpdateRule(ctx context.Context, userID string, orgID int64, role string, id int64, req UpdateDomainRequest) (*Domain, error)
+	Delete(ctx context.Context, userID string, orgID int64, role string, id int64) error
+	FindRule(ctx context.Context, email string) (*Rule, error)
+}
+
+func GetDomainsService(repo *DomainsRepository, resolver Resolver, audit audit.Service) *DomainsService {
+	return &DomainsService{
+		repo:     repo,
+		resolver: resolver,
+		audit:    audit,
+	}
+}
+
+// List returns the organization's domains with their TXT records. Requires
+// OWNER role.
+func (s *DomainsService) List(ctx context.Context, orgID int64, role string) ([]Domain, error) {
+	if role != "OWNER" {
+		return nil, ErrForbidden
+	}
+
+	domains, err := s.repo.List(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]Domain, 0, len(domains))
+	for _, domain := range domains {
+		result = append(result, withRecord(domain))
+	}
+	return result, nil
+}
+
+// Claim starts claiming a domain and returns the TXT record to publish.
+// Requires OWNER role.
+func (s *DomainsService) Claim(ctx context.Context, userID string, orgID int64, role string, domain string) (*Domain, error) {
+	if role != "OWNER" {
+		return nil, ErrForbidden
+	}
+
+	normalized, err := NormalizeDomain(domain)
+	if err != nil {
+		return nil, err
+	}
+
+	token := make([]byte, 24)
+	if _, err := rand.Read(token); err != nil {
+		return nil, err
+	}
+
+	claimed, err := s.repo.Create(ctx, orgID, normalized, hex.EncodeToString(token))
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.record(ctx, userID, orgID, "domain.claimed", claimed, nil); err != nil {
+		return nil, err
+	}
+	result := withRecord(*claimed)
+	return &result, nil
+}
+
+// Verify looks up the TXT record of a claimed domain and marks it verified
+// when the token is published. Requires OWNER role.
+func (s *DomainsService) Verify(ctx context.Context, userID string, orgID int64, role string, id int64) (*Domain, error) {
+	if role != "OWNER" {
+		return nil, ErrForbidden
+	}
+
+	domain, err := s.repo.Get(ctx, id, orgID)
+	if err != nil {
+		return nil, err
+	}
+	if domain.VerifiedAt != nil {
+		result := withRecord(*domain)
+		return &result, nil
+	}
+
+	if err := checkRecord(ctx, s.resolver, *domain); err != nil {
+		return nil, err
+	}
+
+	verified, err := s.repo.MarkVerified(ctx, id, orgID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.record(ctx, userID, orgID, "domain.verified", verified, nil); err != nil {
+		return nil, err
+	}
+	result := withRecord(*verified)
+	return &result, nil
+}
+
+// UpdateRule changes what happens to new users of a verified domain.
+// Requires OWNER role.
+func (s *DomainsService) UpdateRule(ctx context.Context, userID string, orgID int64, role string, id int64, req UpdateDomainRequest) (*Domain, error) {
+	if role != "OWNER" {
+		return nil, ErrForbidden
+	}
+	if req.JoinPolicy == nil && req.JoinRole == nil {
+		return nil, fmt.Errorf("%w: nothing to update", ErrInvalidInput)
+	}
+
+	domain, err := s.repo.Get(ctx, id, orgID)
+	if err != nil {
+		return nil, err
+	}
+
+	policy, joinRole := domain.JoinPolicy, domain.JoinRole
+	if req.JoinPolicy != nil {
+		policy = *req.JoinPolicy
+	}
+	if req.JoinRole != nil {
+		joinRole = *req.JoinRole
+	}
+	if policy != PolicyNone && domain.VerifiedAt == nil {
+		return nil, ErrNotVerified
+	}
+
+	updated, err := s.repo.UpdateRule(ctx, id, orgID, policy, joinRole)
+	if err != nil {
+		return nil, err
+	}
+
+	details := map[string]interface{}{"join_policy": policy, "join_role": joinRole}
+	if err := s.record(ctx, userID, orgID, "domain.rule_updated", updated, details); err != nil {
+		return nil, err
+	}
+	result := withRecord(*updated)
+	return &result, nil
+}
+
+// Delete drops a domain claim and its join rule. Requires OWNER role.
+func (s *DomainsService) Delete(ctx context.Context, userID string, orgID int64, role string, id int64) error {
+	if role != "OWNER" {
+		return ErrForbidden
+	}
+
+	domain, err := s.repo.Get(ctx, id, orgID)
+	if err != nil {
+		return err
+	}
+	if err := s.repo.Delete(ctx, id, orgID); err != nil {
+		return err
+	}
+
+	return s.record(ctx, userID, orgID, "domain.deleted", domain, nil)
+}
+
+// FindRule returns the join rule for the domain of an email address, or nil
+// when no verified domain lets its users in.
+func (s *DomainsService) FindRule(ctx context.Context, email string) (*Rule, error) {
+	domain := EmailDomain(email)
+	if domain == "" {
+		return nil, nil
+	}
+	return s.repo.FindRule(ctx, domain)
+}
+
+// record writes an audit entry about a domain of the organization.
+func (s *DomainsService) record(ctx context.Context, userID string, orgID int64, action string, domain *Domain, details map[string]interface{}) error {
+	if details == nil {
+		details = map[string]interface{}{}
+	}
+	details["domain"] = domain.Domain
+
+	targetID := strconv.FormatInt(domain.ID, 10)
+	entry := audit.Entry{
+		OrganizationID:      orgID,
+		ActorOrganizationID: &orgID,
+		Action:              action,
+		TargetType:          "domain",
+		TargetID:            &targetID,
+		Details:             details,
+	}
+	if actorID, err := uuid.Parse(userID); err == nil {
+		entry.ActorID = &actorID
+	}
+	return s.audit.Record(ctx, entry)
+}
@@ -0,0 +1,102 @@
+package domains
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"golang.org/x/net/idna"
+)
+
+const (
+	// recordPrefix is prepended to the domain to form the TXT record name.
+	recordPrefix = "_hostflow-challenge."
+
+	// recordValuePrefix precedes the token in the TXT record value.
+	recordValuePrefix = "hostflow-domain-verification="
+)
+
+var labelPattern = regexp.MustCompile(`^[a-z0-9]([a-z0-9-]{0,61}[a-z0-9])?$`)
+
+// publicDomains are mailbox providers shared by unrelated people; nobody
+// can claim them.
+var publicDomains = map[string]bool{
+	"gmail.com":      true,
+	"googlemail.com": true,
+	"outlook.com":    true,
+	"hotmail.com":    true,
+	"live.com":       true,
+	"yahoo.com":      true,
+	"icloud.com":     true,
+	"me.com":         true,
+	"aol.com":        true,
+	"proton.me":      true,
+	"protonmail.com": true,
+	"gmx.com":        true,
+	"gmx.net":        true,
+	"siol.net":       true,
+	"t-2.net":        true,
+	"amis.net":       true,
+	"guest.arnes.si": true,
+	"email.si":       true,
+	"gmail.si":       true,
+}
+
+// NormalizeDomain lowercases a domain, converts internationalized names to
+// their ASCII form and checks that it is a registrable-looking hostname.
+func NormalizeDomain(domain string) (string, error) {
+	domain = strings.TrimSuffix(strings.ToLower(strings.TrimSpace(domain)), ".")
+
+	ascii, err := idna.Lookup.ToASCII(domain)
+	if err != nil || len(ascii) > 253 {
+		return "", fmt.Errorf("%w: %q is not a valid domain", ErrInvalidInput, domain)
+	}
+
+	labels := strings.Split(ascii, ".")
+	if len(labels) < 2 {
+		return "", fmt.Errorf("%w: %q is not a valid domain", ErrInvalidInput, domain)
+	}
+	for _, label := range labels {
+		if !labelPattern.MatchString(label) {
+			return "", fmt.Errorf("%w: %q is not a valid domain", ErrInvalidInput, domain)
+		}
+	}
+
+	if publicDomains[ascii] {
+		return "", fmt.Errorf("%w: %s is a public email provider", ErrInvalidInput, ascii)
+	}
+	return ascii, nil
+}
+
+// EmailDomain returns the normalized domain of an email address, or an
+// empty string when it has none.
+func EmailDomain(email string) string {
+	at := strings.LastIndex(email, "@")
+	if at < 0 {
+		return ""
+	}
+
+	domain, err := idna.Lookup.ToASCII(strings.TrimSuffix(strings.ToLower(strings.TrimSpace(email[at+1:])), "."))
+	if err != nil {
+		return ""
+	}
+	return domain
+}
+
+// withRecord fills in the TXT record that verifies the domain.
+func withRecord(domain Domain) Domain {
+	domain.RecordName = recordPrefix + domain.Domain
+	domain.RecordValue = recordValuePrefix + domain.VerificationToken
+	return domain
+}
+
+// hasToken reports whether any TXT record carries the verification token.
+func hasToken(records []string, token string) bool {
+	expected := recordValuePrefix + token
+	for _, record := range records {
+		if strings.TrimSpace(record) == expected {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,62 @@
+package domains
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeResolver struct {
+	records map[string][]string
+	err     error
+}
+
+func (r fakeResolver) LookupTXT(ctx context.Context, name string) ([]string, error) {
+	return r.records[name], r.err
+}
+
+func TestNormalizeDomain(t *testing.T) {
+	cases := map[string]string{
+		"VillaBled.si":      "villabled.si",
+		" villabled.si. ":   "villabled.si",
+		"mail.villabled.si": "mail.villabled.si",
+		"čebelica.si":       "xn--ebelica-i6a.si",
+	}
+
+	for input, expected := range cases {
+		domain, err := NormalizeDomain(input)
+		assert.NoError(t, err, input)
+		assert.Equal(t, expected, domain, input)
+	}
+}
+
+func TestNormalizeDomain_Invalid(t *testing.T) {
+	for _, input := range []string{"", "localhost", "-bad.si", "bad_label.si", "villa bled.si", "gmail.com", "Siol.net"} {
+		_, err := NormalizeDomain(input)
+		assert.True(t, errors.Is(err, ErrInvalidInput), input)
+	}
+}
+
+func TestEmailDomain(t *testing.T) {
+	assert.Equal(t, "villabled.si", EmailDomain("Maja@VillaBled.si"))
+	assert.Equal(t, "", EmailDomain("no-at-sign"))
+}
+
+func TestCheckRecord(t *testing.T) {
+	domain := Domain{Domain: "villabled.si", VerificationToken: "abc123"}
+
+	resolver := fakeResolver{records: map[string][]string{
+		"_hostflow-challenge.villabled.si": {"v=spf1 -all", "hostflow-domain-verification=abc123"},
+	}}
+	assert.NoError(t, checkRecord(context.Background(), resolver, domain))
+
+	resolver = fakeResolver{records: map[string][]string{
+		"_hostflow-challenge.villabled.si": {"hostflow-domain-verification=other"},
+	}}
+	assert.True(t, errors.Is(checkRecord(context.Background(), resolver, domain), ErrVerificationFailed))
+
+	resolver = fakeResolver{err: errors.New("timeout")}
+	assert.True(t, errors.Is(checkRecord(context.Background(), resolver, domain), ErrLookupFailed))
+}
@@ -0,0 +1,16 @@
+package hierarchy
+
+import "go.uber.org/fx"
+
+// ======== EXPORTS ========
+
+// Module exports services present
+var Context = fx.Options(
+	fx.Provide(GetHierarchyController),
+	fx.Provide(fx.Annotate(
+		GetHierarchyService,
+		fx.As(new(Service)),
+	)),
+	fx.Provide(GetHierarchyRepository),
+	fx.Provide(SetHierarchyRoutes),
+)
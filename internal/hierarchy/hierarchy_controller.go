@@ -0,0 +1,348 @@
+package hierarchy
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"hostflow/profile-service/internal/middlewares"
+	"hostflow/profile-service/internal/plans"
+	"hostflow/profile-service/pkg/common"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type HierarchyController struct {
+	service Service
+}
+
+func GetHierarchyController(service Service) *HierarchyController {
+	return &HierarchyController{
+		service: service,
+	}
+}
+
+// SetParentHandler godoc
+// @Summary Join or leave a parent organization
+// @Description Asks to join the given parent organization (its OWNER has to accept), or detaches from the current parent when parent_id is null. Requires OWNER role.
+// @Tags hierarchy
+// @Accept json
+// @Security ApiKeyAuth
+// @Param body body SetParentRequest true "Parent organization"
+// @Success 204 "No Content"
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Router /organization/parent [put]
+func (c *HierarchyController) SetParentHandler(ctx *gin.Context) {
+	principal := middlewares.GetPrincipal(ctx)
+
+	var body SetParentRequest
+	if !bindJSON(ctx, &body) {
+		return
+	}
+
+	err := c.service.SetParent(ctx.Request.Context(), principal.UserID, principal.OrganizationID, principal.Role, body.ParentID)
+	if err != nil {
+		respondError(ctx, "Failed to set parent organization", err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+// ListParentRequestsHandler godoc
+// @Summary List organizations asking to join
+// @Description Returns organizations that asked to become children of the requester's organization. Requires OWNER role.
+// @Tags hierarchy
+// @Produce json
+// @Security ApiKeyAuth
+// @Success 200 {array} ChildOrganization
+// @Failure 403 {object} ErrorResponse
+// @Router /organization/parent-requests [get]
+func (c *HierarchyController) ListParentRequestsHandler(ctx *gin.Context) {
+	principal := middlewares.GetPrincipal(ctx)
+
+	requests, err := c.service.ListParentRequests(ctx.Request.Context(), principal.OrganizationID, principal.Role)
+	if err != nil {
+		respondError(ctx, "Failed to fetch requests", err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, requests)
+}
+
+// AcceptChildHandler godoc
+// @Summary Accept a child organization
+// @Description Accepts the request of an organization to become a child of the requester's. Requires OWNER role.
+// @Tags hierarchy
+// @Security ApiKeyAuth
+// @Param childId path int true "Child organization ID"
+// @Success 204 "No Content"
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Router /organization/children/{childId}/accept [post]
+func (c *HierarchyController) AcceptChildHandler(ctx *gin.Context) {
+	principal := middlewares.GetPrincipal(ctx)
+
+	childID, ok := parseChildID(ctx)
+	if !ok {
+		return
+	}
+
+	err := c.service.AcceptChild(ctx.Request.Context(), principal.UserID, principal.OrganizationID, principal.Role, childID)
+	if err != nil {
+		respondError(ctx, "Failed to accept child organization", err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+// DetachChildHandler godoc
+// @Summary Detach a child organization
+// @Description Removes a direct child organization or rejects its pending request. Requires OWNER role.
+// @Tags hierarchy
+// @Security ApiKeyAuth
+// @Param childId path int true "Child organization ID"
+// @Success 204 "No Content"
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /organization/children/{childId} [delete]
+func (c *HierarchyController) DetachChildHandler(ctx *gin.Context) {
+	principal := middlewares.GetPrincipal(ctx)
+
+	childID, ok := parseChildID(ctx)
+	if !ok {
+		return
+	}
+
+	err := c.service.DetachChild(ctx.Request.Context(), principal.UserID, principal.OrganizationID, principal.Role, childID)
+	if err != nil {
+		respondError(ctx, "Failed to detach child organization", err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+// ListChildrenHandler godoc
+// @Summary List child organizations
+// @Description Returns the direct child organizations, or all organizations below when transitive=true. Requires OWNER or MANAGER role.
+// @Tags hierarchy
+// @Produce json
+// @Security ApiKeyAuth
+// @Param transitive query bool false "Include indirect children"
+// @Success 200 {array} ChildOrganization
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Router /organization/children [get]
+func (c *HierarchyController) ListChildrenHandler(ctx *gin.Context) {
+	principal := middlewares.GetPrincipal(ctx)
+
+	transitive, err := strconv.ParseBool(ctx.DefaultQuery("transitive", "false"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "Invalid query",
+			Message: "'transitive' must be a boolean",
+		})
+		return
+	}
+
+	children, err := c.service.ListChildren(ctx.Request.Context(), principal.OrganizationID, principal.Role, transitive)
+	if err != nil {
+		respondError(ctx, "Failed to fetch child organizations", err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, children)
+}
+
+// GetSettingsHandler godoc
+// @Summary Get organization settings
+// @Description Returns the organization's own settings, the effective settings after inheritance from parent organizations and where each inherited key comes from.
+// @Tags hierarchy
+// @Produce json
+// @Security ApiKeyAuth
+// @Success 200 {object} Settings
+// @Failure 404 {object} ErrorResponse
+// @Router /organization/settings [get]
+func (c *HierarchyController) GetSettingsHandler(ctx *gin.Context) {
+	principal := middlewares.GetPrincipal(ctx)
+
+	settings, err := c.service.GetSettings(ctx.Request.Context(), principal.OrganizationID)
+	if err != nil {
+		respondError(ctx, "Failed to fetch settings", err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, settings)
+}
+
+// UpdateSettingsHandler godoc
+// @Summary Update organization settings
+// @Description Sets the given keys on the organization; a null value removes the key so the inherited value applies again. Requires OWNER role.
+// @Tags hierarchy
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param body body object true "Settings to set or remove"
+// @Success 200 {object} Settings
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Router /organization/settings [patch]
+func (c *HierarchyController) UpdateSettingsHandler(ctx *gin.Context) {
+	principal := middlewares.GetPrincipal(ctx)
+
+	var body UpdateSettingsRequest
+	if !bindJSON(ctx, &body) {
+		return
+	}
+
+	settings, err := c.service.UpdateSettings(ctx.Request.Context(), principal.UserID, principal.OrganizationID, principal.Role, body)
+	if err != nil {
+		respondError(ctx, "Failed to update settings", err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, settings)
+}
+
+// ListChildMembersHandler godoc
+// @Summary List members of a child organization
+// @Description Returns the members of an organization below the requester's. Requires OWNER role. Every call is recorded in the audit log of both organizations.
+// @Tags hierarchy
+// @Produce json
+// @Security ApiKeyAuth
+// @Param childId path int true "Child organization ID"
+// @Success 200 {array} Member
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /organization/children/{childId}/members [get]
+func (c *HierarchyController) ListChildMembersHandler(ctx *gin.Context) {
+	principal := middlewares.GetPrincipal(ctx)
+
+	childID, ok := parseChildID(ctx)
+	if !ok {
+		return
+	}
+
+	members, err := c.service.ListChildMembers(ctx.Request.Context(), principal.UserID, principal.OrganizationID, principal.Role, childID)
+	if err != nil {
+		respondError(ctx, "Failed to fetch members", err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, members)
+}
+
+// SetChildMemberStatusHandler godoc
+// @Summary Activate or deactivate a member of a child organization
+// @Description Sets the status of a member of an organization below the requester's. Reactivating a member needs a free seat on the child's plan, and the child's last active OWNER cannot be deactivated. Requires OWNER role. Every change is recorded in the audit log of both organizations.
+// @Tags hierarchy
+// @Accept json
+// @Security ApiKeyAuth
+// @Param childId path int true "Child organization ID"
+// @Param id path string true "User ID (UUID)"
+// @Param body body SetMemberStatusRequest true "Status"
+// @Success 204 "No Content"
+// @Failure 400 {object} ErrorResponse
+// @Failure 402 {object} plans.SeatLimitResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Router /organization/children/{childId}/members/{id}/status [put]
+func (c *HierarchyController) SetChildMemberStatusHandler(ctx *gin.Context) {
+	principal := middlewares.GetPrincipal(ctx)
+
+	childID, ok := parseChildID(ctx)
+	if !ok {
+		return
+	}
+
+	profileID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "Invalid ID format",
+			Message: "ID must be a valid UUID",
+		})
+		return
+	}
+
+	var body SetMemberStatusRequest
+	if errors := common.Validation.ValidateBody(ctx, &body); errors != nil {
+		ctx.JSON(http.StatusBadRequest, errors)
+		return
+	}
+
+	err = c.service.SetChildMemberStatus(
+		ctx.Request.Context(),
+		principal.UserID,
+		principal.OrganizationID,
+		principal.Role,
+		childID,
+		profileID,
+		body.Status,
+	)
+	if err != nil {
+		respondError(ctx, "Failed to update member status", err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+// bindJSON binds a JSON body and aborts with 400 when it is malformed.
+func bindJSON(ctx *gin.Context, body interface{}) bool {
+	if err := ctx.ShouldBindJSON(body); err != nil {
+		ctx.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "Invalid request",
+			Message: err.Error(),
+		})
+		return false
+	}
+	return true
+}
+
+// parseChildID reads the numeric :childId path parameter.
+func parseChildID(ctx *gin.Context) (int64, bool) {
+	id, err := strconv.ParseInt(ctx.Param("childId"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "Invalid ID format",
+			Message: "Organization ID must be numeric",
+		})
+		return 0, false
+	}
+	return id, true
+}
+
+// respondError maps service errors to HTTP status codes.
+func respondError(ctx *gin.Context, title string, err error) {
+	var seatErr *plans.SeatLimitError
+	if errors.As(err, &seatErr) {
+		ctx.JSON(http.StatusPaymentRequired, plans.NewSeatLimitResponse(title, seatErr))
+		return
+	}
+
+	status := http.StatusInternalServerError
+	switch {
+	case errors.Is(err, ErrInvalidInput):
+		status = http.StatusBadRequest
+	case errors.Is(err, ErrForbidden):
+		status = http.StatusForbidden
+	case errors.Is(err, ErrOrganizationNotFound), errors.Is(err, ErrParentNotFound),
+		errors.Is(err, ErrChildNotFound), errors.Is(err, ErrRequestNotFound), errors.Is(err, ErrMemberNotFound):
+		status = http.StatusNotFound
+	case errors.Is(err, ErrCycle), errors.Is(err, ErrTooDeep), errors.Is(err, ErrLastOwner):
+		status = http.StatusConflict
+	}
+
+	ctx.JSON(status, ErrorResponse{
+		Error:   title,
+		Message: err.Error(),
+	})
+}
@@ -0,0 +1,204 @@
+package hierarchy
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"hostflow/profile-service/internal/middlewares"
+	"hostflow/profile-service/internal/plans"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockHierarchyService struct {
+	mock.Mock
+}
+
+func (m *MockHierarchyService) SetParent(ctx context.Context, userID string, orgID int64, role string, parentID *int64) error {
+	return m.Called(ctx, userID, orgID, role, parentID).Error(0)
+}
+
+func (m *MockHierarchyService) ListParentRequests(ctx context.Context, orgID int64, role string) ([]ChildOrganization, error) {
+	args := m.Called(ctx, orgID, role)
+	return args.Get(0).([]ChildOrganization), args.Error(1)
+}
+
+func (m *MockHierarchyService) AcceptChild(ctx context.Context, userID string, orgID int64, role string, childID int64) error {
+	return m.Called(ctx, userID, orgID, role, childID).Error(0)
+}
+
+func (m *MockHierarchyService) DetachChild(ctx context.Context, userID string, orgID int64, role string, childID int64) error {
+	return m.Called(ctx, userID, orgID, role, childID).Error(0)
+}
+
+func (m *MockHierarchyService) ListChildren(ctx context.Context, orgID int64, role string, transitive bool) ([]ChildOrganization, error) {
+	args := m.Called(ctx, orgID, role, transitive)
+	return args.Get(0).([]ChildOrganization), args.Error(1)
+}
+
+func (m *MockHierarchyService) GetSettings(ctx context.Context, orgID int64) (*Settings, error) {
+	args := m.Called(ctx, orgID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*Settings), args.Error(1)
+}
+
+func (m *MockHierarchyService) UpdateSettings(ctx context.Context, userID string, orgID int64, role string, patch UpdateSettingsRequest) (*Settings, error) {
+	args := m.Called(ctx, userID, orgID, role, patch)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*Settings), args.Error(1)
+}
+
+func (m *MockHierarchyService) ListChildMembers(ctx context.Context, userID string, orgID int64, role string, childID int64) ([]Member, error) {
+	args := m.Called(ctx, userID, orgID, role, childID)
+	return args.Get(0).([]Member), args.Error(1)
+}
+
+func (m *MockHierarchyService) SetChildMemberStatus(ctx context.Context, userID string, orgID int64, role string, childID int64, profileID uuid.UUID, status string) error {
+	return m.Called(ctx, userID, orgID, role, childID, profileID, status).Error(0)
+}
+
+func withOwner(handler gin.HandlerFunc) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		middlewares.SetPrincipal(c, middlewares.Principal{UserID: "owner", OrganizationID: 1, Role: "OWNER"})
+		handler(c)
+	}
+}
+
+func TestSetParentHandler_Null(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	mockSvc := new(MockHierarchyService)
+	controller := GetHierarchyController(mockSvc)
+
+	r := gin.New()
+	r.PUT("/organization/parent", withOwner(controller.SetParentHandler))
+
+	mockSvc.On("SetParent", mock.Anything, "owner", int64(1), "OWNER", (*int64)(nil)).Return(nil)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("PUT", "/organization/parent", bytes.NewBufferString(`{"parent_id":null}`))
+	req.Header.Set("Content-Type", "application/json")
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusNoContent, w.Code)
+	mockSvc.AssertExpectations(t)
+}
+
+func TestAcceptChildHandler_TooDeep(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	mockSvc := new(MockHierarchyService)
+	controller := GetHierarchyController(mockSvc)
+
+	r := gin.New()
+	r.POST("/organization/children/:childId/accept", withOwner(controller.AcceptChildHandler))
+
+	mockSvc.On("AcceptChild", mock.Anything, "owner", int64(1), "OWNER", int64(9)).Return(ErrTooDeep)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("POST", "/organization/children/9/accept", nil)
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusConflict, w.Code)
+}
+
+func TestListChildMembersHandler_NotBelow(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	mockSvc := new(MockHierarchyService)
+	controller := GetHierarchyController(mockSvc)
+
+	r := gin.New()
+	r.GET("/organization/children/:childId/members", withOwner(controller.ListChildMembersHandler))
+
+	mockSvc.On("ListChildMembers", mock.Anything, "owner", int64(1), "OWNER", int64(5)).Return([]Member(nil), ErrChildNotFound)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/organization/children/5/members", nil)
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestSetChildMemberStatusHandler_InvalidStatus(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	controller := GetHierarchyController(new(MockHierarchyService))
+
+	r := gin.New()
+	r.PUT("/organization/children/:childId/members/:id/status", withOwner(controller.SetChildMemberStatusHandler))
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("PUT", "/organization/children/5/members/"+uuid.NewString()+"/status", bytes.NewBufferString(`{"status":"BANNED"}`))
+	req.Header.Set("Content-Type", "application/json")
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestUpdateSettingsHandler(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	mockSvc := new(MockHierarchyService)
+	controller := GetHierarchyController(mockSvc)
+
+	r := gin.New()
+	r.PATCH("/organization/settings", withOwner(controller.UpdateSettingsHandler))
+
+	mockSvc.On("UpdateSettings", mock.Anything, "owner", int64(1), "OWNER", mock.AnythingOfType("UpdateSettingsRequest")).
+		Return(&Settings{Own: map[string]interface{}{"locale": "sl"}}, nil)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("PATCH", "/organization/settings", bytes.NewBufferString(`{"locale":"sl"}`))
+	req.Header.Set("Content-Type", "application/json")
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"locale":"sl"`)
+}
+
+func TestSetChildMemberStatusHandler_SeatLimit(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	mockSvc := new(MockHierarchyService)
+	controller := GetHierarchyController(mockSvc)
+	profileID := uuid.New()
+
+	r := gin.New()
+	r.PUT("/organization/children/:childId/members/:id/status", withOwner(controller.SetChildMemberStatusHandler))
+
+	mockSvc.On("SetChildMemberStatus", mock.Anything, "owner", int64(1), "OWNER", int64(5), profileID, "ACTIVE").
+		Return(&plans.SeatLimitError{Plan: "starter", Limit: 5, Used: 5})
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("PUT", "/organization/children/5/members/"+profileID.String()+"/status", bytes.NewBufferString(`{"status":"ACTIVE"}`))
+	req.Header.Set("Content-Type", "application/json")
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusPaymentRequired, w.Code)
+	assert.Contains(t, w.Body.String(), `"code":"seat_limit_reached"`)
+}
+
+func TestSetChildMemberStatusHandler_LastOwner(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	mockSvc := new(MockHierarchyService)
+	controller := GetHierarchyController(mockSvc)
+	profileID := uuid.New()
+
+	r := gin.New()
+	r.PUT("/organization/children/:childId/members/:id/status", withOwner(controller.SetChildMemberStatusHandler))
+
+	mockSvc.On("SetChildMemberStatus", mock.Anything, "owner", int64(1), "OWNER", int64(5), profileID, "INACTIVE").
+		Return(ErrLastOwner)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("PUT", "/organization/children/5/members/"+profileID.String()+"/status", bytes.NewBufferString(`{"status":"INACTIVE"}`))
+	req.Header.Set("Content-Type", "application/json")
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusConflict, w.Code)
+}
@@ -0,0 +1,65 @@
+package hierarchy
+
+import (
+	"encoding/json"
+
+	"github.com/google/uuid"
+)
+
+// ChildOrganization is an organization below the requester's. Depth is 1
+// for direct children.
+type ChildOrganization struct {
+	ID       int64   `json:"id" db:"id"`
+	Name     string  `json:"name" db:"name"`
+	Slug     *string `json:"slug" db:"slug"`
+	ParentID *int64  `json:"parent_id" db:"parent_id"`
+	Depth    int     `json:"depth" db:"depth"`
+}
+
+// Member is a profile of a child organization as seen by a parent admin.
+type Member struct {
+	ID     uuid.UUID `json:"id" db:"id"`
+	Name   string    `json:"name" db:"full_name"`
+	Role   string    `json:"role" db:"role"`
+	Email  string    `json:"email" db:"email"`
+	Status string    `json:"status" db:"status"`
+}
+
+// chainLink is one organization on the path from an organization up to its
+// root. Depth is 0 for the organization itself.
+type chainLink struct {
+	ID       int64                  `db:"id"`
+	ParentID *int64                 `db:"parent_id"`
+	Settings map[string]interface{} `db:"settings"`
+	Depth    int                    `db:"depth"`
+}
+
+// Settings shows an organization's own settings, the effective settings
+// after inheritance and, for every inherited key, which ancestor it
+// comes from.
+type Settings struct {
+	Own           map[string]interface{} `json:"own"`
+	Effective     map[string]interface{} `json:"effective"`
+	InheritedFrom map[string]int64       `json:"inherited_from"`
+}
+
+// SetParentRequest asks to join a parent organization, or detaches from the
+// current parent when parent_id is null.
+type SetParentRequest struct {
+	ParentID *int64 `json:"parent_id" example:"12"`
+}
+
+// UpdateSettingsRequest sets the given keys; a null value removes the key
+// so the inherited value applies again.
+type UpdateSettingsRequest map[string]json.RawMessage
+
+// SetMemberStatusRequest changes the status of a child organization's member.
+type SetMemberStatusRequest struct {
+	Status string `json:"status" binding:"required,oneof=ACTIVE INACTIVE" example:"INACTIVE"`
+}
+
+// ErrorResponse represents an error response
+type ErrorResponse struct {
+	Error   string `json:"error" example:"Invalid request"`
+	Message string `json:"message,omitempty" example:"The provided data is invalid"`
+}
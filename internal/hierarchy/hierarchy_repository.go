@@ -0,0 +1,339 @@
+package hierarchy
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+
+	"hostflow/profile-service/internal/plans"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type HierarchyRepository struct {
+	db *pgxpool.Pool
+}
+
+func GetHierarchyRepository(db *pgxpool.Pool) *HierarchyRepository {
+	return &HierarchyRepository{
+		db: db,
+	}
+}
+
+// chainQuery walks from an organization up to its root. The depth bound
+// keeps the walk finite even if a cycle slipped into the data.
+const chainQuery = `
+    WITH RECURSIVE chain AS (
+        SELECT id, parent_id, settings, 0 AS depth
+        FROM organization
+        WHERE id = $1
+        UNION ALL
+        SELECT o.id, o.parent_id, o.settings, c.depth + 1
+        FROM organization o
+        JOIN chain c ON o.id = c.parent_id
+        WHERE c.depth <= $2
+    )
+    SELECT id, parent_id, settings, depth
+    FROM chain
+    ORDER BY depth
+`
+
+// subtreeHeightQuery returns how many levels exist below an organization.
+const subtreeHeightQuery = `
+    WITH RECURSIVE subtree AS (
+        SELECT id, 0 AS depth
+        FROM organization
+        WHERE id = $1
+        UNION ALL
+        SELECT o.id, s.depth + 1
+        FROM organization o
+        JOIN subtree s ON o.parent_id = s.id
+        WHERE s.depth <= $2
+    )
+    SELECT coalesce(max(depth), 0) FROM subtree
+`
+
+// Exists reports whether the organization exists.
+func (r *HierarchyRepository) Exists(ctx context.Context, orgID int64) (bool, error) {
+	var exists bool
+	if err := r.db.QueryRow(ctx, `SELECT EXISTS (SELECT 1 FROM organization WHERE id = $1)`, orgID).Scan(&exists); err != nil {
+		return false, err
+	}
+	return exists, nil
+}
+
+// GetChain returns the organization and its ancestors, nearest first.
+func (r *HierarchyRepository) GetChain(ctx context.Context, orgID int64) ([]chainLink, error) {
+	rows, err := r.db.Query(ctx, chainQuery, orgID, MaxDepth)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return pgx.CollectRows(rows, pgx.RowToStructByName[chainLink])
+}
+
+// RequestParent records that the organization asks to join parentID.
+func (r *HierarchyRepository) RequestParent(ctx context.Context, orgID int64, parentID int64) error {
+	tag, err := r.db.Exec(ctx, `UPDATE organization SET pending_parent_id = $1 WHERE id = $2`, parentID, orgID)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrOrganizationNotFound
+	}
+	return nil
+}
+
+// ListParentRequests returns organizations waiting for parentID to accept them.
+func (r *HierarchyRepository) ListParentRequests(ctx context.Context, parentID int64) ([]ChildOrganization, error) {
+	query := `
+        SELECT id, name, slug, parent_id, 1 AS depth
+        FROM organization
+        WHERE pending_parent_id = $1
+        ORDER BY name
+    `
+
+	rows, err := r.db.Query(ctx, query, parentID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return pgx.CollectRows(rows, pgx.RowToStructByName[ChildOrganization])
+}
+
+// AcceptChild makes parentID the parent of childID if the child asked for
+// it. Hierarchy changes are serialized with a transaction-scoped advisory
+// lock so two concurrent moves cannot together close a loop or exceed the
+// depth limit.
+func (r *HierarchyRepository) AcceptChild(ctx context.Context, parentID int64, childID int64) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	if _, err := tx.Exec(ctx, `SELECT pg_advisory_xact_lock(hashtext('organization_hierarchy'))`); err != nil {
+		return err
+	}
+
+	var pending bool
+	err = tx.QueryRow(ctx,
+		`SELECT EXISTS (SELECT 1 FROM organization WHERE id = $1 AND pending_parent_id = $2)`,
+		childID, parentID,
+	).Scan(&pending)
+	if err != nil {
+		return err
+	}
+	if !pending {
+		return ErrRequestNotFound
+	}
+
+	rows, err := tx.Query(ctx, chainQuery, parentID, MaxDepth)
+	if err != nil {
+		return err
+	}
+	chain, err := pgx.CollectRows(rows, pgx.RowToStructByName[chainLink])
+	if err != nil {
+		return err
+	}
+
+	parentDepth := 0
+	for _, link := range chain {
+		if link.ID == childID {
+			return ErrCycle
+		}
+		parentDepth = link.Depth
+	}
+
+	var height int
+	if err := tx.QueryRow(ctx, subtreeHeightQuery, childID, MaxDepth).Scan(&height); err != nil {
+		return err
+	}
+	if parentDepth+1+height > MaxDepth {
+		return ErrTooDeep
+	}
+
+	_, err = tx.Exec(ctx,
+		`UPDATE organization SET parent_id = $1, pending_parent_id = NULL WHERE id = $2`,
+		parentID, childID,
+	)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+// Detach removes the organization from its parent and withdraws any
+// pending request. When parentID is set, only a child of that parent is
+// detached.
+func (r *HierarchyRepository) Detach(ctx context.Context, orgID int64, parentID *int64) error {
+	tag, err := r.db.Exec(ctx, `
+        UPDATE organization
+        SET parent_id = NULL, pending_parent_id = NULL
+        WHERE id = $1 AND ($2::bigint IS NULL OR parent_id = $2 OR pending_parent_id = $2)
+    `, orgID, parentID)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrChildNotFound
+	}
+	return nil
+}
+
+// ListDescendants returns the organizations below orgID up to maxDepth
+// levels, ordered by depth and name.
+func (r *HierarchyRepository) ListDescendants(ctx context.Context, orgID int64, maxDepth int) ([]ChildOrganization, error) {
+	query := `
+        WITH RECURSIVE descendants AS (
+            SELECT id, name, slug, parent_id, 1 AS depth
+            FROM organization
+            WHERE parent_id = $1
+            UNION ALL
+            SELECT o.id, o.name, o.slug, o.parent_id, d.depth + 1
+            FROM organization o
+            JOIN descendants d ON o.parent_id = d.id
+            WHERE d.depth < $2
+        )
+        SELECT id, name, slug, parent_id, depth
+        FROM descendants
+        ORDER BY depth, name
+    `
+
+	rows, err := r.db.Query(ctx, query, orgID, maxDepth)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return pgx.CollectRows(rows, pgx.RowToStructByName[ChildOrganization])
+}
+
+// IsDescendant reports whether childID is strictly below ancestorID.
+func (r *HierarchyRepository) IsDescendant(ctx context.Context, childID int64, ancestorID int64) (bool, error) {
+	var exists bool
+	query := `
+        WITH RECURSIVE chain AS (
+            SELECT parent_id, 1 AS depth FROM organization WHERE id = $1
+            UNION ALL
+            SELECT o.parent_id, c.depth + 1
+            FROM organization o
+            JOIN chain c ON o.id = c.parent_id
+            WHERE c.depth <= $3
+        )
+        SELECT EXISTS (SELECT 1 FROM chain WHERE parent_id = $2)
+    `
+
+	if err := r.db.QueryRow(ctx, query, childID, ancestorID, MaxDepth).Scan(&exists); err != nil {
+		return false, err
+	}
+	return exists, nil
+}
+
+// UpdateSettings merges values into the organization's own settings and
+// drops the removed keys.
+func (r *HierarchyRepository) UpdateSettings(ctx context.Context, orgID int64, values map[string]json.RawMessage, remove []string) error {
+	patch, err := json.Marshal(values)
+	if err != nil {
+		return err
+	}
+
+	tag, err := r.db.Exec(ctx,
+		`UPDATE organization SET settings = (settings || $1::jsonb) - $2::text[] WHERE id = $3`,
+		string(patch), remove, orgID,
+	)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrOrganizationNotFound
+	}
+	return nil
+}
+
+// ListMembers returns every profile of an organization.
+func (r *HierarchyRepository) ListMembers(ctx context.Context, orgID int64) ([]Member, error) {
+	query := `
+        SELECT id, full_name, role, email, status
+        FROM "profiles"
+        WHERE organization_id = $1 AND deleted_at IS NULL
+        ORDER BY full_name
+    `
+
+	rows, err := r.db.Query(ctx, query, orgID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return pgx.CollectRows(rows, pgx.RowToStructByName[Member])
+}
+
+// UpdateMemberStatus sets the status of a profile in the organization and
+// calls record inside the same transaction, so the change and its audit
+// entry are kept together. Reactivating an INACTIVE member takes a seat, so
+// the plan's seat limit is checked in the same transaction. Status changes
+// within an organization are serialized by locking its row, so its last
+// active OWNER cannot be deactivated (ErrLastOwner). ErrMemberNotFound is
+// returned when the profile is not a member.
+func (r *HierarchyRepository) UpdateMemberStatus(ctx context.Context, profileID uuid.UUID, orgID int64, status string, record func(tx pgx.Tx) error) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	if _, err := tx.Exec(ctx, `SELECT 1 FROM organization WHERE id = $1 FOR NO KEY UPDATE`, orgID); err != nil {
+		return err
+	}
+
+	var current, role string
+	err = tx.QueryRow(ctx,
+		`SELECT status, role FROM "profiles" WHERE id = $1 AND organization_id = $2 AND deleted_at IS NULL FOR UPDATE`,
+		profileID, orgID,
+	).Scan(&current, &role)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return ErrMemberNotFound
+		}
+		return err
+	}
+
+	if current == "INACTIVE" && status != "INACTIVE" {
+		if err := plans.ReserveSeat(ctx, tx, orgID); err != nil {
+			return err
+		}
+	}
+
+	if role == "OWNER" && current == "ACTIVE" && status == "INACTIVE" {
+		var owners int
+		err := tx.QueryRow(ctx, `
+            SELECT count(*) FROM "profiles"
+            WHERE organization_id = $1 AND role = 'OWNER' AND status = 'ACTIVE' AND deleted_at IS NULL AND id <> $2
+        `, orgID, profileID).Scan(&owners)
+		if err != nil {
+			return err
+		}
+		if owners == 0 {
+			return ErrLastOwner
+		}
+	}
+
+	_, err = tx.Exec(ctx,
+		`UPDATE "profiles" SET status = $1, updated_at = now() WHERE id = $2 AND organization_id = $3`,
+		status, profileID, orgID,
+	)
+	if err != nil {
+		return err
+	}
+
+	if err := record(tx); err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
+}
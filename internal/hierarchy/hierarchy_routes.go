@@ -0,0 +1,47 @@
+package hierarchy
+
+import (
+	"hostflow/profile-service/internal/middlewares"
+	"hostflow/profile-service/pkg/lib"
+)
+
+type HierarchyRoutes struct {
+	logger              lib.Logger
+	router              *lib.Router
+	hierarchyController *HierarchyController
+	authMiddleware      middlewares.AuthMiddleware
+}
+
+func SetHierarchyRoutes(
+	logger lib.Logger,
+	router *lib.Router,
+	hierarchyController *HierarchyController,
+	authMiddleware middlewares.AuthMiddleware,
+) HierarchyRoutes {
+	return HierarchyRoutes{
+		logger:              logger,
+		router:              router,
+		hierarchyController: hierarchyController,
+		authMiddleware:      authMiddleware,
+	}
+}
+
+func (route HierarchyRoutes) Setup() {
+	route.logger.Info("Setting up [HIERARCHY] routes.")
+
+	organizations := route.router.Group("/organization")
+	organizations.Use(route.authMiddleware.Handler())
+	{
+		organizations.PUT("/parent", route.hierarchyController.SetParentHandler)
+		organizations.GET("/parent-requests", route.hierarchyController.ListParentRequestsHandler)
+		organizations.GET("/children", route.hierarchyController.ListChildrenHandler)
+		organizations.POST("/children/:childId/accept", route.hierarchyController.AcceptChildHandler)
+		organizations.DELETE("/children/:childId", route.hierarchyController.DetachChildHandler)
+		organizations.GET("/children/:childId/members", route.hierarchyController.ListChildMembersHandler)
+		organizations.PUT("/children/:childId/members/:id/status", route.hierarchyController.SetChildMemberStatusHandler)
+		organizations.GET("/settings", route.hierarchyController.GetSettingsHandler)
+		organizations.PATCH("/settings", route.hierarchyController.UpdateSettingsHandler)
+	}
+
+	route.logger.Info("[HIERARCHY] routes setup complete.")
+}
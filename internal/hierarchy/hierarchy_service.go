@@ -0,0 +1,314 @@
+package hierarchy
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+
+	"hostflow/profile-service/internal/audit"
+	"hostflow/profile-service/internal/iamsync"
+	"hostflow/profile-service/internal/middlewares"
+	"hostflow/profile-service/pkg/lib"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+// MaxDepth is the number of levels allowed below a root organization.
+const MaxDepth = 3
+
+var (
+	ErrOrganizationNotFound = errors.New("organization not found")
+	ErrParentNotFound       = errors.New("parent organization not found")
+	ErrChildNotFound        = errors.New("organization is not below yours")
+	ErrRequestNotFound      = errors.New("organization has not asked to join yours")
+	ErrMemberNotFound       = errors.New("profile not found in the child organization")
+	ErrLastOwner            = errors.New("the child organization needs at least one active owner")
+	ErrCycle                = errors.New("this change would create an organization cycle")
+	ErrTooDeep              = errors.New("this change would exceed the organization depth limit")
+	ErrForbidden            = errors.New("you are not allowed to perform this action")
+	ErrInvalidInput         = errors.New("invalid input")
+)
+
+type HierarchyService struct {
+	repo        *HierarchyRepository
+	audit       audit.Service
+	memberships *middlewares.Memberships
+	revocations *middlewares.Revocations
+	identity    iamsync.Service
+	logger      lib.Logger
+}
+
+type Service interface {
+	SetParent(ctx context.Context, userID string, orgID int64, role string, parentID *int64) error
+	ListParentRequests(ctx context.Context, orgID int64, role string) ([]ChildOrganization, error)
+	AcceptChild(ctx context.Context, userID string, orgID int64, role string, childID int64) error
+	DetachChild(ctx context.Context, userID string, orgID int64, role string, childID int64) error
+	ListChildren(ctx context.Context, orgID int64, role string, transitive bool) ([]ChildOrganization, error)
+	GetSettings(ctx context.Context, orgID int64) (*Settings, error)
+	UpdateSettings(ctx context.Context, userID string, orgID int64, role string, patch UpdateSettingsRequest) (*Settings, error)
+	ListChildMembers(ctx context.Context, userID string, orgID int64, role string, childID int64) ([]Member, error)
+	SetChildMemberStatus(ctx context.Context, userID string, orgID int64, role string, childID int64, profileID uuid.UUID, status string) error
+}
+
+func GetHierarchyService(
+	repo *HierarchyRepository,
+	audit audit.Service,
+	memberships *middlewares.Memberships,
+	revocations *middlewares.Revocations,
+	identity iamsync.Service,
+	logger lib.Logger,
+) *HierarchyService {
+	return &HierarchyService{
+		repo:        repo,
+		audit:       audit,
+		memberships: memberships,
+		revocations: revocations,
+		identity:    identity,
+		logger:      logger,
+	}
+}
+
+// SetParent asks to join parentID, or detaches from the current parent when
+// parentID is nil. The parent's OWNER has to accept the request before it
+// takes effect. Requires OWNER role.
+func (s *HierarchyService) SetParent(ctx context.Context, userID string, orgID int64, role string, parentID *int64) error {
+	if role != "OWNER" {
+		return ErrForbidden
+	}
+
+	if parentID == nil {
+		if err := s.repo.Detach(ctx, orgID, nil); err != nil && !errors.Is(err, ErrChildNotFound) {
+			return err
+		}
+		return s.record(ctx, userID, orgID, orgID, "hierarchy.parent_detached", "organization", strconv.FormatInt(orgID, 10), nil)
+	}
+
+	if *parentID == orgID {
+		return ErrCycle
+	}
+	exists, err := s.repo.Exists(ctx, *parentID)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return ErrParentNotFound
+	}
+
+	if err := s.repo.RequestParent(ctx, orgID, *parentID); err != nil {
+		return err
+	}
+	return s.record(ctx, userID, orgID, orgID, "hierarchy.parent_requested", "organization", strconv.FormatInt(*parentID, 10), nil)
+}
+
+// ListParentRequests returns organizations waiting to join the requester's.
+// Requires OWNER role.
+func (s *HierarchyService) ListParentRequests(ctx context.Context, orgID int64, role string) ([]ChildOrganization, error) {
+	if role != "OWNER" {
+		return nil, ErrForbidden
+	}
+
+	requests, err := s.repo.ListParentRequests(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+	if requests == nil {
+		return []ChildOrganization{}, nil
+	}
+	return requests, nil
+}
+
+// AcceptChild accepts a pending request of childID to join the requester's
+// organization. Requires OWNER role.
+func (s *HierarchyService) AcceptChild(ctx context.Context, userID string, orgID int64, role string, childID int64) error {
+	if role != "OWNER" {
+		return ErrForbidden
+	}
+
+	if err := s.repo.AcceptChild(ctx, orgID, childID); err != nil {
+		return err
+	}
+	return s.record(ctx, userID, orgID, childID, "hierarchy.child_accepted", "organization", strconv.FormatInt(childID, 10), nil)
+}
+
+// DetachChild removes a direct child, or rejects its pending request.
+// Requires OWNER role.
+func (s *HierarchyService) DetachChild(ctx context.Context, userID string, orgID int64, role string, childID int64) error {
+	if role != "OWNER" {
+		return ErrForbidden
+	}
+
+	if err := s.repo.Detach(ctx, childID, &orgID); err != nil {
+		return err
+	}
+	return s.record(ctx, userID, orgID, childID, "hierarchy.child_detached", "organization", strconv.FormatInt(childID, 10), nil)
+}
+
+// ListChildren returns the direct children of the requester's organization,
+// or every organization below it when transitive is set. Requires OWNER or
+// MANAGER role.
+func (s *HierarchyService) ListChildren(ctx context.Context, orgID int64, role string, transitive bool) ([]ChildOrganization, error) {
+	if role != "OWNER" && role != "MANAGER" {
+		return nil, ErrForbidden
+	}
+
+	depth := 1
+	if transitive {
+		depth = MaxDepth
+	}
+
+	children, err := s.repo.ListDescendants(ctx, orgID, depth)
+	if err != nil {
+		return nil, err
+	}
+	if children == nil {
+		return []ChildOrganization{}, nil
+	}
+	return children, nil
+}
+
+// GetSettings returns the organization's own settings together with the
+// ones inherited from its ancestors.
+func (s *HierarchyService) GetSettings(ctx context.Context, orgID int64) (*Settings, error) {
+	chain, err := s.repo.GetChain(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+	if len(chain) == 0 {
+		return nil, ErrOrganizationNotFound
+	}
+
+	settings := MergeSettings(chain)
+	return &settings, nil
+}
+
+// UpdateSettings sets or removes (null) the organization's own settings.
+// Requires OWNER role.
+func (s *HierarchyService) UpdateSettings(ctx context.Context, userID string, orgID int64, role string, patch UpdateSettingsRequest) (*Settings, error) {
+	if role != "OWNER" {
+		return nil, ErrForbidden
+	}
+	if len(patch) == 0 {
+		return nil, fmt.Errorf("%w: no settings to update", ErrInvalidInput)
+	}
+
+	values, remove, err := splitSettingsPatch(patch)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.UpdateSettings(ctx, orgID, values, remove); err != nil {
+		return nil, err
+	}
+
+	keys := make([]string, 0, len(patch))
+	for key := range patch {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	if err := s.record(ctx, userID, orgID, orgID, "hierarchy.settings_updated", "organization", strconv.FormatInt(orgID, 10), map[string]interface{}{"keys": keys}); err != nil {
+		return nil, err
+	}
+
+	return s.GetSettings(ctx, orgID)
+}
+
+// ListChildMembers returns the members of an organization below the
+// requester's. Requires OWNER role; the access is audited before any data
+// is read.
+func (s *HierarchyService) ListChildMembers(ctx context.Context, userID string, orgID int64, role string, childID int64) ([]Member, error) {
+	if err := s.ensureChild(ctx, orgID, role, childID); err != nil {
+		return nil, err
+	}
+
+	if err := s.record(ctx, userID, orgID, childID, "hierarchy.child_members_listed", "organization", strconv.FormatInt(childID, 10), nil); err != nil {
+		return nil, err
+	}
+
+	members, err := s.repo.ListMembers(ctx, childID)
+	if err != nil {
+		return nil, err
+	}
+	if members == nil {
+		return []Member{}, nil
+	}
+	return members, nil
+}
+
+// SetChildMemberStatus activates or deactivates a member of an organization
+// below the requester's. Requires OWNER role; the action is audited before
+// it is performed.
+func (s *HierarchyService) SetChildMemberStatus(ctx context.Context, userID string, orgID int64, role string, childID int64, profileID uuid.UUID, status string) error {
+	if status != "ACTIVE" && status != "INACTIVE" {
+		return fmt.Errorf("%w: status must be ACTIVE or INACTIVE", ErrInvalidInput)
+	}
+	if err := s.ensureChild(ctx, orgID, role, childID); err != nil {
+		return err
+	}
+
+	details := map[string]interface{}{"status": status}
+	entry := newEntry(userID, orgID, childID, "hierarchy.child_member_status_changed", "profile", profileID.String(), details)
+	err := s.repo.UpdateMemberStatus(ctx, profileID, childID, status, func(tx pgx.Tx) error {
+		return s.audit.RecordTx(ctx, tx, entry)
+	})
+	if err != nil {
+		return err
+	}
+	s.memberships.Invalidate(profileID.String())
+	if status == "INACTIVE" {
+		if err := s.revocations.Revoke(ctx, middlewares.RevokeUser, profileID.String(), "deactivated"); err != nil {
+			return err
+		}
+	}
+
+	// Bans or unbans the identity along with the status
+	if err := s.identity.Sync(ctx, profileID.String()); err != nil {
+		s.logger.Error(fmt.Sprintf("Failed to queue identity sync of %s: %v", profileID, err))
+	}
+	return nil
+}
+
+// ensureChild checks that the requester is an OWNER of an organization
+// strictly above childID.
+func (s *HierarchyService) ensureChild(ctx context.Context, orgID int64, role string, childID int64) error {
+	if role != "OWNER" {
+		return ErrForbidden
+	}
+	if childID == orgID {
+		return ErrChildNotFound
+	}
+
+	below, err := s.repo.IsDescendant(ctx, childID, orgID)
+	if err != nil {
+		return err
+	}
+	if !below {
+		return ErrChildNotFound
+	}
+	return nil
+}
+
+// record writes an audit entry for an action of the requester's
+// organization on targetOrgID.
+func (s *HierarchyService) record(ctx context.Context, userID string, actorOrgID int64, targetOrgID int64, action string, targetType string, targetID string, details map[string]interface{}) error {
+	return s.audit.Record(ctx, newEntry(userID, actorOrgID, targetOrgID, action, targetType, targetID, details))
+}
+
+// newEntry builds the audit entry of an action of the requester's
+// organization on targetOrgID.
+func newEntry(userID string, actorOrgID int64, targetOrgID int64, action string, targetType string, targetID string, details map[string]interface{}) audit.Entry {
+	entry := audit.Entry{
+		OrganizationID:      targetOrgID,
+		ActorOrganizationID: &actorOrgID,
+		Action:              action,
+		TargetType:          targetType,
+		TargetID:            &targetID,
+		Details:             details,
+	}
+	if actorID, err := uuid.Parse(userID); err == nil {
+		entry.ActorID = &actorID
+	}
+	return entry
+}
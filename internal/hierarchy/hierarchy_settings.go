@@ -0,0 +1,69 @@
+package hierarchy
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+)
+
+// settingKeyPattern restricts setting keys to dotted snake_case names such
+// as "checkin.default_time".
+var settingKeyPattern = regexp.MustCompile(`^[a-z][a-z0-9_]*(\.[a-z][a-z0-9_]*)*$`)
+
+// MaxSettingKeyLength limits the length of a setting key.
+const MaxSettingKeyLength = 64
+
+// MergeSettings resolves inheritance along a chain ordered from the
+// organization itself (depth 0) up to its root: the nearest organization
+// that sets a key wins.
+func MergeSettings(chain []chainLink) Settings {
+	settings := Settings{
+		Own:           map[string]interface{}{},
+		Effective:     map[string]interface{}{},
+		InheritedFrom: map[string]int64{},
+	}
+
+	sorted := make([]chainLink, len(chain))
+	copy(sorted, chain)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].Depth > sorted[j].Depth
+	})
+
+	// Walk from the root down so nearer organizations overwrite
+	for _, link := range sorted {
+		for key, value := range link.Settings {
+			settings.Effective[key] = value
+			if link.Depth == 0 {
+				settings.Own[key] = value
+				delete(settings.InheritedFrom, key)
+			} else {
+				settings.InheritedFrom[key] = link.ID
+			}
+		}
+	}
+
+	return settings
+}
+
+// splitSettingsPatch validates a patch and separates keys to set from keys
+// to remove (null values).
+func splitSettingsPatch(patch UpdateSettingsRequest) (map[string]json.RawMessage, []string, error) {
+	set := map[string]json.RawMessage{}
+	remove := []string{}
+
+	for key, value := range patch {
+		if len(key) > MaxSettingKeyLength || !settingKeyPattern.MatchString(key) {
+			return nil, nil, fmt.Errorf("%w: invalid setting key %q", ErrInvalidInput, key)
+		}
+		if bytes.Equal(bytes.TrimSpace(value), []byte("null")) {
+			remove = append(remove, key)
+			continue
+		}
+		set[key] = value
+	}
+
+	sort.Strings(remove)
+	return set, remove, nil
+}
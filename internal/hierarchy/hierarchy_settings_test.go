@@ -0,0 +1,55 @@
+package hierarchy
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMergeSettings_NearestWins(t *testing.T) {
+	chain := []chainLink{
+		{ID: 3, Depth: 0, Settings: map[string]interface{}{"checkin.time": "15:00"}},
+		{ID: 2, Depth: 1, Settings: map[string]interface{}{"checkin.time": "14:00", "currency": "EUR"}},
+		{ID: 1, Depth: 2, Settings: map[string]interface{}{"currency": "USD", "locale": "sl"}},
+	}
+
+	settings := MergeSettings(chain)
+
+	assert.Equal(t, map[string]interface{}{"checkin.time": "15:00"}, settings.Own)
+	assert.Equal(t, map[string]interface{}{
+		"checkin.time": "15:00",
+		"currency":     "EUR",
+		"locale":       "sl",
+	}, settings.Effective)
+	assert.Equal(t, map[string]int64{"currency": 2, "locale": 1}, settings.InheritedFrom)
+}
+
+func TestMergeSettings_Root(t *testing.T) {
+	settings := MergeSettings([]chainLink{{ID: 1, Depth: 0}})
+
+	assert.Empty(t, settings.Own)
+	assert.Empty(t, settings.Effective)
+	assert.Empty(t, settings.InheritedFrom)
+}
+
+func TestSplitSettingsPatch(t *testing.T) {
+	patch := UpdateSettingsRequest{
+		"checkin.time": json.RawMessage(`"15:00"`),
+		"locale":       json.RawMessage(` null `),
+	}
+
+	set, remove, err := splitSettingsPatch(patch)
+
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]json.RawMessage{"checkin.time": json.RawMessage(`"15:00"`)}, set)
+	assert.Equal(t, []string{"locale"}, remove)
+}
+
+func TestSplitSettingsPatch_InvalidKey(t *testing.T) {
+	for _, key := range []string{"", "Locale", "1st", "a..b", "trailing.", "with space"} {
+		_, _, err := splitSettingsPatch(UpdateSettingsRequest{key: json.RawMessage(`1`)})
+		assert.True(t, errors.Is(err, ErrInvalidInput), key)
+	}
+}
@@ -0,0 +1,15 @@
+package iamsync
+
+import "go.uber.org/fx"
+
+// ======== EXPORTS ========
+
+// Module exports services present
+var Context = fx.Options(
+	fx.Provide(fx.Annotate(
+		GetIAMSyncService,
+		fx.As(new(Service)),
+	)),
+	fx.Provide(GetIAMSyncRepository),
+	fx.Invoke(RegisterSyncWorker),
+)
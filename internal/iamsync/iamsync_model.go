@@ -0,0 +1,18 @@
+package iamsync
+
+import "github.com/google/uuid"
+
+// outboxEntry is a user waiting to be synced. Version changes whenever the
+// user is queued again.
+type outboxEntry struct {
+	UserID   uuid.UUID `db:"user_id"`
+	Version  int64     `db:"version"`
+	Attempts int       `db:"attempts"`
+}
+
+// memberState is what the identity has to reflect of the user's profile.
+type memberState struct {
+	OrganizationID *int64  `db:"organization_id"`
+	Role           *string `db:"role"`
+	Status         *string `db:"status"`
+}
@@ -0,0 +1,126 @@
+package iamsync
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type IAMSyncRepository struct {
+	db *pgxpool.Pool
+}
+
+func GetIAMSyncRepository(db *pgxpool.Pool) *IAMSyncRepository {
+	return &IAMSyncRepository{
+		db: db,
+	}
+}
+
+// Enqueue queues the users for a sync. Users already queued are due again
+// right away, with a new version.
+func (r *IAMSyncRepository) Enqueue(ctx context.Context, userIDs []uuid.UUID) error {
+	_, err := r.db.Exec(ctx, `
+        INSERT INTO iam_outbox (user_id)
+        SELECT DISTINCT unnest($1::uuid[])
+        ON CONFLICT (user_id) DO UPDATE SET
+            version         = iam_outbox.version + 1,
+            attempts        = 0,
+            next_attempt_at = now(),
+            last_error      = NULL,
+            updated_at      = now()`,
+		uuidStrings(userIDs),
+	)
+	return err
+}
+
+// Claim leases up to limit due entries until leasedUntil, so no other
+// instance syncs the same users meanwhile. When userIDs is not nil only
+// those users are claimed.
+func (r *IAMSyncRepository) Claim(ctx context.Context, userIDs []uuid.UUID, limit int, leasedUntil time.Time) ([]outboxEntry, error) {
+	var filter []string
+	if userIDs != nil {
+		filter = uuidStrings(userIDs)
+	}
+
+	rows, err := r.db.Query(ctx, `
+        UPDATE iam_outbox SET leased_until = $3
+        WHERE user_id IN (
+            SELECT user_id
+            FROM iam_outbox
+            WHERE next_attempt_at <= now()
+              AND (leased_until IS NULL OR leased_until < now())
+              AND ($1::uuid[] IS NULL OR user_id = ANY($1))
+            ORDER BY next_attempt_at
+            LIMIT $2
+            FOR UPDATE SKIP LOCKED
+        )
+        RETURNING user_id, version, attempts`,
+		filter, limit, leasedUntil,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return pgx.CollectRows(rows, pgx.RowToStructByName[outboxEntry])
+}
+
+// Complete removes a synced entry, unless the user was queued again while
+// it was synced; then only the lease is released.
+func (r *IAMSyncRepository) Complete(ctx context.Context, entry outboxEntry) error {
+	tag, err := r.db.Exec(ctx,
+		`DELETE FROM iam_outbox WHERE user_id = $1 AND version = $2`,
+		entry.UserID, entry.Version,
+	)
+	if err != nil || tag.RowsAffected() > 0 {
+		return err
+	}
+	_, err = r.db.Exec(ctx, `UPDATE iam_outbox SET leased_until = NULL WHERE user_id = $1`, entry.UserID)
+	return err
+}
+
+// Fail releases the lease and schedules the next attempt. An entry queued
+// again meanwhile stays due right away.
+func (r *IAMSyncRepository) Fail(ctx context.Context, entry outboxEntry, nextAttemptAt time.Time, reason string) error {
+	_, err := r.db.Exec(ctx, `
+        UPDATE iam_outbox SET
+            attempts        = CASE WHEN version = $2 THEN attempts + 1 ELSE attempts END,
+            next_attempt_at = CASE WHEN version = $2 THEN $3 ELSE next_attempt_at END,
+            last_error      = $4,
+            leased_until    = NULL,
+            updated_at      = now()
+        WHERE user_id = $1`,
+		entry.UserID, entry.Version, nextAttemptAt, reason,
+	)
+	return err
+}
+
+// FindState returns the user's organization, role and status, or nil if the
+// user has no profile.
+func (r *IAMSyncRepository) FindState(ctx context.Context, userID uuid.UUID) (*memberState, error) {
+	rows, err := r.db.Query(ctx,
+		`SELECT organization_id, role, status FROM "profiles" WHERE id = $1`,
+		userID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	state, err := pgx.CollectOneRow(rows, pgx.RowToStructByName[memberState])
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &state, nil
+}
+
+func uuidStrings(userIDs []uuid.UUID) []string {
+	values := make([]string, len(userIDs))
+	for i, id := range userIDs {
+		values[i] = id.String()
+	}
+	return values
+}
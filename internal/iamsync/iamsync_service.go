@@ -0,0 +1,170 @@
+package iamsync
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"hostflow/profile-service/pkg/iam"
+	"hostflow/profile-service/pkg/lib"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// batchSize is how many users a worker run claims at a time.
+	batchSize = 50
+
+	// leaseDuration is how long a claimed user is left to one instance. It
+	// only matters when an instance stops in the middle of a sync.
+	leaseDuration = 2 * time.Minute
+
+	// minRetryDelay and maxRetryDelay bound the delay before a failed sync
+	// is tried again. It doubles with every attempt.
+	minRetryDelay = 30 * time.Second
+	maxRetryDelay = time.Hour
+)
+
+// IAMSyncService brings the users' identities in Supabase Auth in line with
+// their profiles: organization, role and status go into app_metadata, and
+// deactivated users are banned. Changes are queued in an outbox and
+// retried until they succeed, so a Supabase outage only delays them.
+type IAMSyncService struct {
+	repo     *IAMSyncRepository
+	identity iam.IdentityProvider
+	logger   lib.Logger
+	now      func() time.Time
+}
+
+type Service interface {
+	Sync(ctx context.Context, userIDs ...string) error
+	ProcessDue(ctx context.Context) (int, error)
+}
+
+func GetIAMSyncService(repo *IAMSyncRepository, identity iam.IdentityProvider, logger lib.Logger) *IAMSyncService {
+	return &IAMSyncService{
+		repo:     repo,
+		identity: identity,
+		logger:   logger,
+		now:      time.Now,
+	}
+}
+
+// Sync queues the users and syncs them right away. Call it after the
+// profile change is committed. Only a failure to queue is returned; failed
+// syncs are retried in the background.
+func (s *IAMSyncService) Sync(ctx context.Context, userIDs ...string) error {
+	ids := make([]uuid.UUID, 0, len(userIDs))
+	for _, userID := range userIDs {
+		id, err := uuid.Parse(userID)
+		if err != nil {
+			return fmt.Errorf("invalid user ID %q: %w", userID, err)
+		}
+		ids = append(ids, id)
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+
+	if err := s.repo.Enqueue(ctx, ids); err != nil {
+		return err
+	}
+
+	entries, err := s.repo.Claim(ctx, ids, len(ids), s.now().Add(leaseDuration))
+	if err != nil {
+		s.logger.Error(fmt.Sprintf("Failed to claim identity sync, leaving it to the worker: %v", err))
+		return nil
+	}
+	s.deliver(ctx, entries)
+	return nil
+}
+
+// ProcessDue syncs the users whose sync is due and returns how many
+// succeeded.
+func (s *IAMSyncService) ProcessDue(ctx context.Context) (int, error) {
+	synced := 0
+	for ctx.Err() == nil {
+		entries, err := s.repo.Claim(ctx, nil, batchSize, s.now().Add(leaseDuration))
+		if err != nil {
+			return synced, err
+		}
+		synced += s.deliver(ctx, entries)
+		if len(entries) < batchSize {
+			break
+		}
+	}
+	return synced, nil
+}
+
+// deliver syncs the claimed users and records the outcome of each.
+func (s *IAMSyncService) deliver(ctx context.Context, entries []outboxEntry) int {
+	synced := 0
+	for _, entry := range entries {
+		err := s.push(ctx, entry.UserID)
+		if errors.Is(err, iam.ErrUserNotFound) {
+			// A deleted account has nothing left to sync
+			s.logger.Info(fmt.Sprintf("Identity %s no longer exists, dropping its sync.", entry.UserID))
+			err = nil
+		}
+
+		if err == nil {
+			if err := s.repo.Complete(ctx, entry); err != nil {
+				s.logger.Error(fmt.Sprintf("Failed to complete identity sync of %s: %v", entry.UserID, err))
+			}
+			synced++
+			continue
+		}
+
+		delay := retryDelay(entry.Attempts)
+		s.logger.Error(fmt.Sprintf("Failed to sync identity %s (attempt %d), retrying in %s: %v", entry.UserID, entry.Attempts+1, delay, err))
+		if err := s.repo.Fail(ctx, entry, s.now().Add(delay), err.Error()); err != nil {
+			s.logger.Error(fmt.Sprintf("Failed to reschedule identity sync of %s: %v", entry.UserID, err))
+		}
+	}
+	return synced
+}
+
+// push writes the user's current state to the identity provider. Both calls
+// are idempotent, so a repeated sync does no harm.
+func (s *IAMSyncService) push(ctx context.Context, userID uuid.UUID) error {
+	state, err := s.repo.FindState(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	if err := s.identity.SetBanned(ctx, userID.String(), banned(state)); err != nil {
+		return err
+	}
+	return s.identity.UpdateAppMetadata(ctx, userID.String(), appMetadata(state))
+}
+
+// appMetadata is the app_metadata of a user in the given state. Users
+// without a profile have their organization cleared.
+func appMetadata(state *memberState) map[string]interface{} {
+	metadata := map[string]interface{}{
+		"organization_id": nil,
+		"role":            nil,
+		"status":          nil,
+	}
+	if state != nil {
+		metadata["organization_id"] = state.OrganizationID
+		metadata["role"] = state.Role
+		metadata["status"] = state.Status
+	}
+	return metadata
+}
+
+// banned reports whether a user in the given state must not sign in.
+func banned(state *memberState) bool {
+	return state != nil && state.Status != nil && *state.Status == "INACTIVE"
+}
+
+// retryDelay is the delay after the given number of failed attempts.
+func retryDelay(attempts int) time.Duration {
+	delay := minRetryDelay
+	for i := 0; i < attempts && delay < maxRetryDelay; i++ {
+		delay *= 2
+	}
+	return min(delay, maxRetryDelay)
+}
@@ -0,0 +1,39 @@
+package iamsync
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAppMetadata(t *testing.T) {
+	orgID, role, status := int64(7), "MANAGER", "INACTIVE"
+	state := &memberState{OrganizationID: &orgID, Role: &role, Status: &status}
+
+	assert.Equal(t, map[string]interface{}{
+		"organization_id": &orgID,
+		"role":            &role,
+		"status":          &status,
+	}, appMetadata(state))
+	assert.True(t, banned(state))
+
+	// Users without a profile have everything cleared and are not banned
+	assert.Equal(t, map[string]interface{}{
+		"organization_id": nil,
+		"role":            nil,
+		"status":          nil,
+	}, appMetadata(nil))
+	assert.False(t, banned(nil))
+
+	active := "ACTIVE"
+	assert.False(t, banned(&memberState{Status: &active}))
+}
+
+func TestRetryDelay(t *testing.T) {
+	assert.Equal(t, 30*time.Second, retryDelay(0))
+	assert.Equal(t, time.Minute, retryDelay(1))
+	assert.Equal(t, 8*time.Minute, retryDelay(4))
+	assert.Equal(t, time.Hour, retryDelay(7))
+	assert.Equal(t, time.Hour, retryDelay(1000))
+}
@@ -0,0 +1,35 @@
+package iamsync
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"hostflow/profile-service/pkg/lib"
+
+	"go.uber.org/fx"
+)
+
+// DefaultSyncInterval is how often due identity syncs are retried, unless
+// IAM_SYNC_INTERVAL says otherwise.
+const DefaultSyncInterval = 15 * time.Second
+
+// RegisterSyncWorker retries failed identity syncs in the background for
+// the lifetime of the app.
+func RegisterSyncWorker(lifecycle fx.Lifecycle, service Service, logger lib.Logger) error {
+	interval, err := lib.DurationFromEnv("IAM_SYNC_INTERVAL", DefaultSyncInterval)
+	if err != nil {
+		return err
+	}
+
+	lib.RunPeriodically(lifecycle, interval, func(ctx context.Context) {
+		synced, err := service.ProcessDue(ctx)
+		if err != nil {
+			logger.Error(fmt.Sprintf("Identity sync failed: %v", err))
+		} else if synced > 0 {
+			logger.Info(fmt.Sprintf("Synced %d identities.", synced))
+		}
+	})
+
+	return nil
+}
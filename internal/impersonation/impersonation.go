@@ -0,0 +1,21 @@
+package impersonation
+
+import (
+	"hostflow/profile-service/internal/middlewares"
+
+	"go.uber.org/fx"
+)
+
+// ======== EXPORTS ========
+
+// Module exports services present
+var Context = fx.Options(
+	fx.Provide(GetImpersonationController),
+	fx.Provide(fx.Annotate(
+		GetImpersonationService,
+		fx.As(new(Service)),
+		fx.As(new(middlewares.ImpersonationVerifier)),
+	)),
+	fx.Provide(GetImpersonationRepository),
+	fx.Provide(SetImpersonationRoutes),
+)
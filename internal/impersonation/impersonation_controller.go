@@ -0,0 +1,135 @@
+package impersonation
+
+import (
+	"errors"
+	"net/http"
+
+	"hostflow/profile-service/internal/middlewares"
+	"hostflow/profile-service/pkg/common"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ImpersonationController struct {
+	service Service
+}
+
+func GetImpersonationController(service Service) *ImpersonationController {
+	return &ImpersonationController{
+		service: service,
+	}
+}
+
+// StartImpersonationHandler godoc
+// @Summary Start an impersonation session
+// @Description Starts a time-boxed support session in which the requesting platform staff member acts as the user within their organization. The token is sent as a bearer token and is only returned in this response; responses to its requests carry the X-Impersonation header. Sessions are read-only unless allow_writes is set: other requests are refused with 403 and the code impersonation_read_only. Closing the organization, managing API keys, revoking sessions and changing billing are refused with impersonation_denied even then. The start, the end and every write are recorded in the organization's audit log, and its owner can list the sessions. Requires platform staff signed in with a second factor.
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param body body StartRequest true "Impersonation session"
+// @Success 201 {object} StartedSession
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /admin/impersonations [post]
+func (c *ImpersonationController) StartImpersonationHandler(ctx *gin.Context) {
+	principal := middlewares.GetPrincipal(ctx)
+
+	var body StartRequest
+	if errors := common.Validation.ValidateBody(ctx, &body); errors != nil {
+		ctx.JSON(http.StatusBadRequest, errors)
+		return
+	}
+
+	session, err := c.service.Start(ctx.Request.Context(), principal.UserID, body)
+	if err != nil {
+		respondError(ctx, "Failed to start impersonation session", err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, session)
+}
+
+// ListImpersonationsHandler godoc
+// @Summary List impersonation sessions
+// @Description Returns the impersonation sessions the requesting staff member started, newest first. Requires platform staff signed in with a second factor.
+// @Tags admin
+// @Produce json
+// @Security ApiKeyAuth
+// @Success 200 {array} Session
+// @Failure 403 {object} ErrorResponse
+// @Router /admin/impersonations [get]
+func (c *ImpersonationController) ListImpersonationsHandler(ctx *gin.Context) {
+	principal := middlewares.GetPrincipal(ctx)
+
+	sessions, err := c.service.List(ctx.Request.Context(), principal.UserID)
+	if err != nil {
+		respondError(ctx, "Failed to fetch impersonation sessions", err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, sessions)
+}
+
+// EndImpersonationHandler godoc
+// @Summary End an impersonation session
+// @Description Ends an impersonation session on every instance. Sessions also end on their own when they expire. Requires platform staff signed in with a second factor.
+// @Tags admin
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "Session ID"
+// @Success 200 {object} Session
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /admin/impersonations/{id} [delete]
+func (c *ImpersonationController) EndImpersonationHandler(ctx *gin.Context) {
+	principal := middlewares.GetPrincipal(ctx)
+
+	session, err := c.service.End(ctx.Request.Context(), principal.UserID, ctx.Param("id"))
+	if err != nil {
+		respondError(ctx, "Failed to end impersonation session", err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, session)
+}
+
+// ListOrganizationImpersonationsHandler godoc
+// @Summary List impersonation sessions of the organization
+// @Description Returns the support sessions in which platform staff acted as members of the requester's organization, with their reason and duration, newest first. Requires OWNER role.
+// @Tags organization
+// @Produce json
+// @Security ApiKeyAuth
+// @Success 200 {array} Session
+// @Failure 403 {object} ErrorResponse
+// @Router /organization/impersonations [get]
+func (c *ImpersonationController) ListOrganizationImpersonationsHandler(ctx *gin.Context) {
+	principal := middlewares.GetPrincipal(ctx)
+
+	sessions, err := c.service.ListForOrganization(ctx.Request.Context(), principal.OrganizationID, principal.Role)
+	if err != nil {
+		respondError(ctx, "Failed to fetch impersonation sessions", err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, sessions)
+}
+
+// respondError maps service errors to HTTP status codes.
+func respondError(ctx *gin.Context, title string, err error) {
+	status := http.StatusInternalServerError
+	switch {
+	case errors.Is(err, ErrInvalidInput):
+		status = http.StatusBadRequest
+	case errors.Is(err, ErrForbidden):
+		status = http.StatusForbidden
+	case errors.Is(err, ErrNotFound):
+		status = http.StatusNotFound
+	}
+
+	ctx.JSON(status, ErrorResponse{
+		Error:   title,
+		Message: err.Error(),
+	})
+}
@@ -0,0 +1,66 @@
+package impersonation
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// DefaultDurationMinutes and MaxDurationMinutes bound how long a session
+	// lasts.
+	DefaultDurationMinutes = 30
+	MaxDurationMinutes     = 60
+
+	// maxListed bounds the sessions returned by a list.
+	maxListed = 100
+)
+
+// Session is a support session in which a platform staff member acts as a
+// user of an organization.
+type Session struct {
+	ID             uuid.UUID `json:"id" db:"id"`
+	StaffUserID    uuid.UUID `json:"staff_user_id" db:"staff_user_id"`
+	UserID         uuid.UUID `json:"user_id" db:"user_id"`
+	OrganizationID int64     `json:"organization_id" db:"organization_id"`
+	Reason         string    `json:"reason" db:"reason" example:"Ticket 4821: host cannot see next week's shifts"`
+	// AllowWrites admits changes; sessions are read-only otherwise.
+	AllowWrites bool       `json:"allow_writes" db:"allow_writes"`
+	StartedAt   time.Time  `json:"started_at" db:"started_at"`
+	ExpiresAt   time.Time  `json:"expires_at" db:"expires_at"`
+	EndedAt     *time.Time `json:"ended_at" db:"ended_at"`
+	EndedBy     *uuid.UUID `json:"ended_by" db:"ended_by"`
+}
+
+// Active reports whether the session can still be used.
+func (s Session) Active(now time.Time) bool {
+	return s.EndedAt == nil && now.Before(s.ExpiresAt)
+}
+
+// sessionRecord is a session with its hashed secret.
+type sessionRecord struct {
+	Session
+	SecretHash string `db:"secret_hash"`
+}
+
+// StartedSession is returned once on start; the token cannot be read again
+// afterwards.
+type StartedSession struct {
+	Session
+	Token string `json:"token" example:"hfimp_6f1c0b7e2d9a4c3e8b5f1a2d3c4e5f60_Qm9vdHN0cmFw..."`
+}
+
+// StartRequest starts an impersonation session. DurationMinutes defaults to
+// DefaultDurationMinutes.
+type StartRequest struct {
+	UserID          string `json:"user_id" binding:"required,uuid" example:"0b0f8a52-4d7e-4f67-9a43-6f4bd1a2c3d4"`
+	Reason          string `json:"reason" binding:"required,min=10,max=500" example:"Ticket 4821: host cannot see next week's shifts"`
+	DurationMinutes int    `json:"duration_minutes" binding:"omitempty,min=5,max=60" example:"30"`
+	AllowWrites     bool   `json:"allow_writes" example:"false"`
+}
+
+// ErrorResponse represents an error response
+type ErrorResponse struct {
+	Error   string `json:"error" example:"Invalid request"`
+	Message string `json:"message,omitempty" example:"The provided data is invalid"`
+}
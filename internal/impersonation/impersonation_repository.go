@@ -0,0 +1,118 @@
+package impersonation
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+const sessionColumns = `id, staff_user_id, user_id, organization_id, reason, allow_writes, started_at, expires_at, ended_at, ended_by`
+
+type ImpersonationRepository struct {
+	db *pgxpool.Pool
+}
+
+func GetImpersonationRepository(db *pgxpool.Pool) *ImpersonationRepository {
+	return &ImpersonationRepository{
+		db: db,
+	}
+}
+
+// Create stores a session.
+func (r *ImpersonationRepository) Create(ctx context.Context, record sessionRecord) (*Session, error) {
+	rows, err := r.db.Query(ctx, `
+        INSERT INTO impersonation_sessions
+            (id, staff_user_id, user_id, organization_id, reason, allow_writes, secret_hash, started_at, expires_at)
+        VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+        RETURNING `+sessionColumns,
+		record.ID, record.StaffUserID, record.UserID, record.OrganizationID, record.Reason,
+		record.AllowWrites, record.SecretHash, record.StartedAt, record.ExpiresAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	session, err := pgx.CollectOneRow(rows, pgx.RowToStructByName[Session])
+	if err != nil {
+		return nil, err
+	}
+	return &session, nil
+}
+
+// Find returns the session with its secret hash, or nil if there is none.
+func (r *ImpersonationRepository) Find(ctx context.Context, id uuid.UUID) (*sessionRecord, error) {
+	rows, err := r.db.Query(ctx,
+		`SELECT `+sessionColumns+`, secret_hash FROM impersonation_sessions WHERE id = $1`,
+		id,
+	)
+	if err != nil {
+		return nil, err
+	}
+	record, err := pgx.CollectOneRow(rows, pgx.RowToStructByName[sessionRecord])
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &record, nil
+}
+
+// ListByStaff returns the sessions started by the staff member, newest
+// first.
+func (r *ImpersonationRepository) ListByStaff(ctx context.Context, staffID uuid.UUID, limit int) ([]Session, error) {
+	rows, err := r.db.Query(ctx, `
+        SELECT `+sessionColumns+`
+        FROM impersonation_sessions
+        WHERE staff_user_id = $1
+        ORDER BY started_at DESC, id
+        LIMIT $2`,
+		staffID, limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return pgx.CollectRows(rows, pgx.RowToStructByName[Session])
+}
+
+// ListByOrganization returns the sessions of the organization's users,
+// newest first.
+func (r *ImpersonationRepository) ListByOrganization(ctx context.Context, orgID int64, limit int) ([]Session, error) {
+	rows, err := r.db.Query(ctx, `
+        SELECT `+sessionColumns+`
+        FROM impersonation_sessions
+        WHERE organization_id = $1
+        ORDER BY started_at DESC, id
+        LIMIT $2`,
+		orgID, limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return pgx.CollectRows(rows, pgx.RowToStructByName[Session])
+}
+
+// End marks the session as ended. Ending twice keeps the first time.
+func (r *ImpersonationRepository) End(ctx context.Context, id uuid.UUID, staffID uuid.UUID) (*Session, error) {
+	rows, err := r.db.Query(ctx, `
+        UPDATE impersonation_sessions
+        SET ended_at = COALESCE(ended_at, now()),
+            ended_by = COALESCE(ended_by, $2)
+        WHERE id = $1
+        RETURNING `+sessionColumns,
+		id, staffID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	session, err := pgx.CollectOneRow(rows, pgx.RowToStructByName[Session])
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+	return &session, nil
+}
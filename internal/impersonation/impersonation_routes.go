@@ -0,0 +1,47 @@
+package impersonation
+
+import (
+	"hostflow/profile-service/internal/middlewares"
+	"hostflow/profile-service/pkg/lib"
+)
+
+type ImpersonationRoutes struct {
+	logger                  lib.Logger
+	router                  *lib.Router
+	impersonationController *ImpersonationController
+	authMiddleware          middlewares.AuthMiddleware
+}
+
+func SetImpersonationRoutes(
+	logger lib.Logger,
+	router *lib.Router,
+	impersonationController *ImpersonationController,
+	authMiddleware middlewares.AuthMiddleware,
+) ImpersonationRoutes {
+	return ImpersonationRoutes{
+		logger:                  logger,
+		router:                  router,
+		impersonationController: impersonationController,
+		authMiddleware:          authMiddleware,
+	}
+}
+
+func (route ImpersonationRoutes) Setup() {
+	route.logger.Info("Setting up [IMPERSONATION] routes.")
+
+	admin := route.router.Group("/admin")
+	admin.Use(route.authMiddleware.StaffHandler())
+	{
+		admin.POST("/impersonations", route.impersonationController.StartImpersonationHandler)
+		admin.GET("/impersonations", route.impersonationController.ListImpersonationsHandler)
+		admin.DELETE("/impersonations/:id", route.impersonationController.EndImpersonationHandler)
+	}
+
+	organizations := route.router.Group("/organization")
+	organizations.Use(route.authMiddleware.Handler())
+	{
+		organizations.GET("/impersonations", route.impersonationController.ListOrganizationImpersonationsHandler)
+	}
+
+	route.logger.Info("[IMPERSONATION] routes setup complete.")
+}
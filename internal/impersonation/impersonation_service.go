@@ -0,0 +1,374 @@
+package impersonation
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"hostflow/profile-service/internal/audit"
+	"hostflow/profile-service/internal/middlewares"
+	"hostflow/profile-service/pkg/common"
+	"hostflow/profile-service/pkg/lib"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrNotFound     = errors.New("impersonation session not found")
+	ErrForbidden    = errors.New("you are not allowed to perform this action")
+	ErrInvalidInput = errors.New("invalid input")
+)
+
+const (
+	// secretBytes is the random length of a token's secret.
+	secretBytes = 32
+
+	// verifiedTTL is how long a verified token is served from memory, so the
+	// argon2 hash is not computed on every request. Sessions ended on other
+	// instances are turned away at once through the session's revocation.
+	verifiedTTL = time.Minute
+)
+
+type verifiedSession struct {
+	impersonation middlewares.Impersonation
+	expires       time.Time
+}
+
+type ImpersonationService struct {
+	repo        *ImpersonationRepository
+	memberships *middlewares.Memberships
+	staff       *middlewares.PlatformStaff
+	revocations *middlewares.Revocations
+	audit       audit.Service
+	logger      lib.Logger
+
+	mu       sync.Mutex
+	verified map[[sha256.Size]byte]verifiedSession
+}
+
+type Service interface {
+	middlewares.ImpersonationVerifier
+	Start(ctx context.Context, staffID string, req StartRequest) (*StartedSession, error)
+	List(ctx context.Context, staffID string) ([]Session, error)
+	End(ctx context.Context, staffID string, id string) (*Session, error)
+	ListForOrganization(ctx context.Context, orgID int64, role string) ([]Session, error)
+}
+
+func GetImpersonationService(
+	repo *ImpersonationRepository,
+	memberships *middlewares.Memberships,
+	staff *middlewares.PlatformStaff,
+	revocations *middlewares.Revocations,
+	audit audit.Service,
+	logger lib.Logger,
+) *ImpersonationService {
+	return &ImpersonationService{
+		repo:        repo,
+		memberships: memberships,
+		staff:       staff,
+		revocations: revocations,
+		audit:       audit,
+		logger:      logger,
+		verified:    map[[sha256.Size]byte]verifiedSession{},
+	}
+}
+
+// Start opens a session in which the staff member acts as the user, in the
+// user's current organization. The token is only returned here; just its
+// argon2 hash is stored. Staff cannot impersonate themselves, other staff or
+// deactivated users.
+func (s *ImpersonationService) Start(ctx context.Context, staffID string, req StartRequest) (*StartedSession, error) {
+	staff, err := uuid.Parse(staffID)
+	if err != nil {
+		return nil, ErrForbidden
+	}
+	user, err := uuid.Parse(req.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: user_id must be a UUID", ErrInvalidInput)
+	}
+	reason := strings.TrimSpace(req.Reason)
+	if reason == "" {
+		return nil, fmt.Errorf("%w: reason is required", ErrInvalidInput)
+	}
+	duration := req.DurationMinutes
+	if duration == 0 {
+		duration = DefaultDurationMinutes
+	}
+	if duration < 0 || duration > MaxDurationMinutes {
+		return nil, fmt.Errorf("%w: duration_minutes must be at most %d", ErrInvalidInput, MaxDurationMinutes)
+	}
+	if user == staff {
+		return nil, fmt.Errorf("%w: you cannot impersonate yourself", ErrInvalidInput)
+	}
+
+	isStaff, err := s.staff.IsStaff(ctx, user.String())
+	if err != nil {
+		return nil, err
+	}
+	if isStaff {
+		return nil, fmt.Errorf("%w: platform staff cannot be impersonated", ErrForbidden)
+	}
+	member, found, err := s.memberships.Lookup(ctx, user.String())
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, fmt.Errorf("%w: the user does not belong to an organization", ErrNotFound)
+	}
+	if member.Status == middlewares.ProfileInactive {
+		return nil, fmt.Errorf("%w: the user has been deactivated", ErrInvalidInput)
+	}
+
+	secret, err := randomString(secretBytes)
+	if err != nil {
+		return nil, err
+	}
+	record := sessionRecord{Session: Session{
+		ID:             uuid.New(),
+		StaffUserID:    staff,
+		UserID:         user,
+		OrganizationID: member.OrganizationID,
+		Reason:         reason,
+		AllowWrites:    req.AllowWrites,
+	}}
+	record.SecretHash, err = common.Hasher.Hash(secret)
+	if err != nil {
+		return nil, err
+	}
+	record.StartedAt = time.Now()
+	record.ExpiresAt = record.StartedAt.Add(time.Duration(duration) * time.Minute)
+
+	session, err := s.repo.Create(ctx, record)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.record(ctx, session, "impersonation.started", map[string]interface{}{
+		"reason":       session.Reason,
+		"allow_writes": session.AllowWrites,
+		"expires_at":   session.ExpiresAt,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &StartedSession{Session: *session, Token: formatToken(session.ID, secret)}, nil
+}
+
+// List returns the sessions the staff member started, newest first.
+func (s *ImpersonationService) List(ctx context.Context, staffID string) ([]Session, error) {
+	staff, err := uuid.Parse(staffID)
+	if err != nil {
+		return nil, ErrForbidden
+	}
+	sessions, err := s.repo.ListByStaff(ctx, staff, maxListed)
+	if err != nil {
+		return nil, err
+	}
+	if sessions == nil {
+		sessions = []Session{}
+	}
+	return sessions, nil
+}
+
+// End stops the session on every instance. Any staff member can end any
+// session; ending one that is already over changes nothing.
+func (s *ImpersonationService) End(ctx context.Context, staffID string, id string) (*Session, error) {
+	staff, err := uuid.Parse(staffID)
+	if err != nil {
+		return nil, ErrForbidden
+	}
+	sessionID, err := uuid.Parse(id)
+	if err != nil {
+		return nil, ErrNotFound
+	}
+
+	record, err := s.repo.Find(ctx, sessionID)
+	if err != nil {
+		return nil, err
+	}
+	if record == nil {
+		return nil, ErrNotFound
+	}
+	if !record.Active(time.Now()) {
+		return &record.Session, nil
+	}
+
+	session, err := s.repo.End(ctx, sessionID, staff)
+	if err != nil {
+		return nil, err
+	}
+	s.forget(session.ID.String())
+	if err := s.revocations.Revoke(ctx, middlewares.RevokeSession, session.ID.String(), "impersonation ended"); err != nil {
+		return nil, err
+	}
+
+	err = s.record(ctx, session, "impersonation.ended", map[string]interface{}{
+		"ended_by": staff,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return session, nil
+}
+
+// ListForOrganization returns the sessions in which staff acted as users of
+// the organization, newest first. Requires OWNER role.
+func (s *ImpersonationService) ListForOrganization(ctx context.Context, orgID int64, role string) ([]Session, error) {
+	if role != "OWNER" {
+		return nil, ErrForbidden
+	}
+	sessions, err := s.repo.ListByOrganization(ctx, orgID, maxListed)
+	if err != nil {
+		return nil, err
+	}
+	if sessions == nil {
+		sessions = []Session{}
+	}
+	return sessions, nil
+}
+
+// VerifyImpersonation returns the session of the token. Unknown, ended and
+// expired sessions are reported as middlewares.ErrInvalidImpersonation.
+func (s *ImpersonationService) VerifyImpersonation(ctx context.Context, token string) (middlewares.Impersonation, error) {
+	id, secret, ok := splitToken(token)
+	if !ok {
+		return middlewares.Impersonation{}, middlewares.ErrInvalidImpersonation
+	}
+
+	digest := sha256.Sum256([]byte(token))
+	s.mu.Lock()
+	entry, found := s.verified[digest]
+	s.mu.Unlock()
+	if found && time.Now().Before(entry.expires) {
+		return entry.impersonation, nil
+	}
+
+	record, err := s.repo.Find(ctx, id)
+	if err != nil {
+		return middlewares.Impersonation{}, err
+	}
+	now := time.Now()
+	if record == nil || !record.Active(now) {
+		return middlewares.Impersonation{}, middlewares.ErrInvalidImpersonation
+	}
+	matches, err := common.Hasher.Compare(secret, record.SecretHash)
+	if err != nil {
+		return middlewares.Impersonation{}, err
+	}
+	if !matches {
+		return middlewares.Impersonation{}, middlewares.ErrInvalidImpersonation
+	}
+
+	impersonation := middlewares.Impersonation{
+		ID:             record.ID.String(),
+		StaffUserID:    record.StaffUserID.String(),
+		UserID:         record.UserID.String(),
+		OrganizationID: record.OrganizationID,
+		AllowWrites:    record.AllowWrites,
+		StartedAt:      record.StartedAt,
+		ExpiresAt:      record.ExpiresAt,
+	}
+	expires := now.Add(verifiedTTL)
+	if record.ExpiresAt.Before(expires) {
+		expires = record.ExpiresAt
+	}
+
+	s.mu.Lock()
+	s.verified[digest] = verifiedSession{impersonation: impersonation, expires: expires}
+	s.mu.Unlock()
+	return impersonation, nil
+}
+
+// RecordImpersonatedRequest writes an audit entry about a request made in
+// the session. The request has already been served, so failures are only
+// logged.
+func (s *ImpersonationService) RecordImpersonatedRequest(ctx context.Context, impersonation middlewares.Impersonation, method string, path string, status int) {
+	staff, err := uuid.Parse(impersonation.StaffUserID)
+	if err != nil {
+		return
+	}
+	targetID := impersonation.ID
+	err = s.audit.Record(ctx, audit.Entry{
+		OrganizationID: impersonation.OrganizationID,
+		ActorID:        &staff,
+		Action:         "impersonation.request",
+		TargetType:     "impersonation_session",
+		TargetID:       &targetID,
+		Details: map[string]interface{}{
+			"user_id": impersonation.UserID,
+			"method":  method,
+			"path":    path,
+			"status":  status,
+		},
+	})
+	if err != nil {
+		s.logger.Error(fmt.Sprintf("Failed to record request of impersonation session %s: %v", impersonation.ID, err))
+	}
+}
+
+// forget drops the session from the verified tokens.
+func (s *ImpersonationService) forget(id string) {
+	s.mu.Lock()
+	for digest, entry := range s.verified {
+		if entry.impersonation.ID == id {
+			delete(s.verified, digest)
+		}
+	}
+	s.mu.Unlock()
+}
+
+// record writes an audit entry about a session to the log of the
+// impersonated user's organization, with the staff member as the actor.
+func (s *ImpersonationService) record(ctx context.Context, session *Session, action string, details map[string]interface{}) error {
+	targetID := session.ID.String()
+	details["user_id"] = session.UserID
+	return s.audit.Record(ctx, audit.Entry{
+		OrganizationID: session.OrganizationID,
+		ActorID:        &session.StaffUserID,
+		Action:         action,
+		TargetType:     "impersonation_session",
+		TargetID:       &targetID,
+		Details:        details,
+	})
+}
+
+// formatToken builds "hfimp_<id without dashes>_<secret>".
+func formatToken(id uuid.UUID, secret string) string {
+	return middlewares.ImpersonationTokenPrefix + hex.EncodeToString(id[:]) + "_" + secret
+}
+
+// splitToken splits a token into the session ID and the secret.
+func splitToken(token string) (uuid.UUID, string, bool) {
+	rest, ok := strings.CutPrefix(token, middlewares.ImpersonationTokenPrefix)
+	if !ok {
+		return uuid.UUID{}, "", false
+	}
+	encoded, secret, ok := strings.Cut(rest, "_")
+	if !ok || len(encoded) != 2*len(uuid.UUID{}) || secret == "" {
+		return uuid.UUID{}, "", false
+	}
+	raw, err := hex.DecodeString(encoded)
+	if err != nil {
+		return uuid.UUID{}, "", false
+	}
+	id, err := uuid.FromBytes(raw)
+	if err != nil {
+		return uuid.UUID{}, "", false
+	}
+	return id, secret, true
+}
+
+func randomString(n int) (string, error) {
+	raw := make([]byte, n)
+	if _, err := rand.Read(raw); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(raw), nil
+}
@@ -0,0 +1,88 @@
+package impersonation
+
+import (
+	"context"
+	"crypto/sha256"
+	"errors"
+	"testing"
+	"time"
+
+	"hostflow/profile-service/internal/middlewares"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSplitToken(t *testing.T) {
+	id := uuid.MustParse("6f1c0b7e-2d9a-4c3e-8b5f-1a2d3c4e5f60")
+	token := formatToken(id, "Qm9v_dHN0")
+	assert.Equal(t, "hfimp_6f1c0b7e2d9a4c3e8b5f1a2d3c4e5f60_Qm9v_dHN0", token)
+
+	parsed, secret, ok := splitToken(token)
+	require.True(t, ok)
+	assert.Equal(t, id, parsed)
+	assert.Equal(t, "Qm9v_dHN0", secret)
+
+	for _, token := range []string{
+		"",
+		"hf_3f9a1c2b7d4e_secret",
+		"hfimp_6f1c0b7e2d9a4c3e8b5f1a2d3c4e5f60",
+		"hfimp_6f1c0b7e2d9a4c3e8b5f1a2d3c4e5f60_",
+		"hfimp_6f1c0b7e_secret",
+		"hfimp_zf1c0b7e2d9a4c3e8b5f1a2d3c4e5f60_secret",
+	} {
+		_, _, ok := splitToken(token)
+		assert.False(t, ok, token)
+	}
+}
+
+func TestVerifyImpersonation_Cached(t *testing.T) {
+	service := GetImpersonationService(nil, nil, nil, nil, nil, nil)
+	token := formatToken(uuid.New(), "secret")
+	impersonation := middlewares.Impersonation{ID: "session-1", UserID: "user-1", OrganizationID: 1}
+	service.verified[sha256.Sum256([]byte(token))] = verifiedSession{impersonation: impersonation, expires: time.Now().Add(time.Minute)}
+
+	verified, err := service.VerifyImpersonation(context.Background(), token)
+	require.NoError(t, err)
+	assert.Equal(t, impersonation, verified)
+
+	// Malformed tokens are turned away before any lookup
+	_, err = service.VerifyImpersonation(context.Background(), "hfimp_nope")
+	assert.ErrorIs(t, err, middlewares.ErrInvalidImpersonation)
+
+	service.forget("session-1")
+	assert.Empty(t, service.verified)
+}
+
+func TestStart_Validation(t *testing.T) {
+	service := GetImpersonationService(nil, nil, nil, nil, nil, nil)
+	staff := uuid.NewString()
+
+	cases := map[string]StartRequest{
+		"self":         {UserID: staff, Reason: "Ticket 4821: cannot see shifts"},
+		"blank reason": {UserID: uuid.NewString(), Reason: "   "},
+		"too long":     {UserID: uuid.NewString(), Reason: "Ticket 4821: cannot see shifts", DurationMinutes: MaxDurationMinutes + 1},
+		"bad user":     {UserID: "user-1", Reason: "Ticket 4821: cannot see shifts"},
+	}
+	for name, req := range cases {
+		_, err := service.Start(context.Background(), staff, req)
+		assert.True(t, errors.Is(err, ErrInvalidInput), name)
+	}
+}
+
+func TestSession_Active(t *testing.T) {
+	now := time.Now()
+	session := Session{ExpiresAt: now.Add(time.Minute)}
+	assert.True(t, session.Active(now))
+	assert.False(t, session.Active(now.Add(time.Minute)))
+
+	session.EndedAt = &now
+	assert.False(t, session.Active(now))
+}
+
+func TestListForOrganization_RequiresOwner(t *testing.T) {
+	service := GetImpersonationService(nil, nil, nil, nil, nil, nil)
+	_, err := service.ListForOrganization(context.Background(), 1, "MANAGER")
+	assert.ErrorIs(t, err, ErrForbidden)
+}
@@ -0,0 +1,22 @@
+package joinrequests
+
+import (
+	"hostflow/profile-service/internal/middlewares"
+
+	"go.uber.org/fx"
+)
+
+// ======== EXPORTS ========
+
+// Module exports services present
+var Context = fx.Options(
+	fx.Provide(GetJoinRequestsController),
+	fx.Provide(fx.Annotate(
+		GetJoinRequestsService,
+		fx.As(new(Service)),
+		fx.As(new(middlewares.Onboarding)),
+	)),
+	fx.Provide(GetJoinRequestsRepository),
+	fx.Provide(SetJoinRequestsRoutes),
+	fx.Invoke(RegisterExpiryWorker),
+)
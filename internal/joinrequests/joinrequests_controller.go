@@ -0,0 +1,234 @@
+package joinrequests
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+
+	"hostflow/profile-service/internal/middlewares"
+	"hostflow/profile-service/internal/plans"
+
+	"github.com/gin-gonic/gin"
+)
+
+type JoinRequestsController struct {
+	service Service
+}
+
+func GetJoinRequestsController(service Service) *JoinRequestsController {
+	return &JoinRequestsController{
+		service: service,
+	}
+}
+
+// CreateJoinRequestHandler godoc
+// @Summary Ask to join an organization
+// @Description Files a request to join the organization with the slug as a MEMBER. Its owners are notified and the request expires if nobody decides it in time. Asking again returns the pending request.
+// @Tags join-requests
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param slug path string true "Organization slug"
+// @Param request body CreateJoinRequest false "Name and a note for the approvers"
+// @Success 200 {object} JoinRequest
+// @Success 201 {object} JoinRequest
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 429 {object} ErrorResponse
+// @Router /organizations/{slug}/join-requests [post]
+func (c *JoinRequestsController) CreateJoinRequestHandler(ctx *gin.Context) {
+	principal := middlewares.GetPrincipal(ctx)
+	// The body is optional
+	var req CreateJoinRequest
+	if ctx.Request.ContentLength != 0 {
+		if err := ctx.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
+			ctx.JSON(http.StatusBadRequest, ErrorResponse{
+				Error:   "Invalid request body",
+				Message: err.Error(),
+			})
+			return
+		}
+	}
+
+	request, created, err := c.service.Request(ctx.Request.Context(), principal.UserID, principal.Email, ctx.Param("slug"), req)
+	if err != nil {
+		respondError(ctx, "Failed to request to join", err)
+		return
+	}
+
+	status := http.StatusOK
+	if created {
+		status = http.StatusCreated
+	}
+	ctx.JSON(status, request)
+}
+
+// ListMyJoinRequestsHandler godoc
+// @Summary List my join requests
+// @Description Returns the requester's own requests to join organizations, newest first.
+// @Tags join-requests
+// @Produce json
+// @Security ApiKeyAuth
+// @Success 200 {array} JoinRequest
+// @Router /join-requests [get]
+func (c *JoinRequestsController) ListMyJoinRequestsHandler(ctx *gin.Context) {
+	principal := middlewares.GetPrincipal(ctx)
+
+	requests, err := c.service.ListMine(ctx.Request.Context(), principal.UserID)
+	if err != nil {
+		respondError(ctx, "Failed to fetch join requests", err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, requests)
+}
+
+// CancelJoinRequestHandler godoc
+// @Summary Withdraw a join request
+// @Description Cancels one of the requester's own pending requests.
+// @Tags join-requests
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path int true "Join request ID"
+// @Success 200 {object} JoinRequest
+// @Failure 404 {object} ErrorResponse
+// @Router /join-requests/{id} [delete]
+func (c *JoinRequestsController) CancelJoinRequestHandler(ctx *gin.Context) {
+	principal := middlewares.GetPrincipal(ctx)
+
+	id, ok := parseRequestID(ctx)
+	if !ok {
+		return
+	}
+
+	request, err := c.service.Cancel(ctx.Request.Context(), principal.UserID, id)
+	if err != nil {
+		respondError(ctx, "Failed to cancel join request", err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, request)
+}
+
+// ListJoinRequestsHandler godoc
+// @Summary List join requests
+// @Description Returns users asking to join the requester's organization. Requires OWNER or MANAGER role.
+// @Tags join-requests
+// @Produce json
+// @Security ApiKeyAuth
+// @Param status query string false "PENDING (default), APPROVED, REJECTED, CANCELLED or EXPIRED"
+// @Success 200 {array} JoinRequest
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Router /organization/join-requests [get]
+func (c *JoinRequestsController) ListJoinRequestsHandler(ctx *gin.Context) {
+	principal := middlewares.GetPrincipal(ctx)
+
+	requests, err := c.service.List(ctx.Request.Context(), principal.OrganizationID, principal.Role, ctx.Query("status"))
+	if err != nil {
+		respondError(ctx, "Failed to fetch join requests", err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, requests)
+}
+
+// ApproveJoinRequestHandler godoc
+// @Summary Approve a join request
+// @Description Adds the requesting user to the organization with the requested role. Needs a free seat on the plan. Requires OWNER role.
+// @Tags join-requests
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path int true "Join request ID"
+// @Success 200 {object} JoinRequest
+// @Failure 402 {object} plans.SeatLimitResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Router /organization/join-requests/{id}/approve [post]
+func (c *JoinRequestsController) ApproveJoinRequestHandler(ctx *gin.Context) {
+	principal := middlewares.GetPrincipal(ctx)
+
+	id, ok := parseRequestID(ctx)
+	if !ok {
+		return
+	}
+
+	request, err := c.service.Approve(ctx.Request.Context(), principal.UserID, principal.OrganizationID, principal.Role, id)
+	if err != nil {
+		respondError(ctx, "Failed to approve join request", err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, request)
+}
+
+// RejectJoinRequestHandler godoc
+// @Summary Reject a join request
+// @Tags join-requests
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path int true "Join request ID"
+// @Success 200 {object} JoinRequest
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /organization/join-requests/{id}/reject [post]
+func (c *JoinRequestsController) RejectJoinRequestHandler(ctx *gin.Context) {
+	principal := middlewares.GetPrincipal(ctx)
+
+	id, ok := parseRequestID(ctx)
+	if !ok {
+		return
+	}
+
+	request, err := c.service.Reject(ctx.Request.Context(), principal.UserID, principal.OrganizationID, principal.Role, id)
+	if err != nil {
+		respondError(ctx, "Failed to reject join request", err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, request)
+}
+
+// parseRequestID reads the numeric :id path parameter of a join request.
+func parseRequestID(ctx *gin.Context) (int64, bool) {
+	id, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "Invalid ID format",
+			Message: "Join request ID must be numeric",
+		})
+		return 0, false
+	}
+	return id, true
+}
+
+// respondError maps service errors to HTTP status codes.
+func respondError(ctx *gin.Context, title string, err error) {
+	var seatErr *plans.SeatLimitError
+	if errors.As(err, &seatErr) {
+		ctx.JSON(http.StatusPaymentRequired, plans.NewSeatLimitResponse(title, seatErr))
+		return
+	}
+
+	status := http.StatusInternalServerError
+	switch {
+	case errors.Is(err, ErrInvalidInput):
+		status = http.StatusBadRequest
+	case errors.Is(err, ErrForbidden):
+		status = http.StatusForbidden
+	case errors.Is(err, ErrRequestNotFound), errors.Is(err, ErrOrganizationNotFound):
+		status = http.StatusNotFound
+	case errors.Is(err, ErrAlreadyMember), errors.Is(err, ErrProfileDeleted):
+		status = http.StatusConflict
+	case errors.Is(err, ErrTooManyRequests):
+		status = http.StatusTooManyRequests
+	}
+
+	ctx.JSON(status, ErrorResponse{
+		Error:   title,
+		Message: err.Error(),
+	})
+}
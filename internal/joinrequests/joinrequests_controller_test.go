@@ -0,0 +1,213 @@
+package joinrequests
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"hostflow/profile-service/internal/middlewares"
+	"hostflow/profile-service/internal/plans"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockJoinRequestsService struct {
+	mock.Mock
+}
+
+func (m *MockJoinRequestsService) Onboard(ctx context.Context, identity middlewares.Identity) (*middlewares.Membership, error) {
+	args := m.Called(ctx, identity)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*middlewares.Membership), args.Error(1)
+}
+
+func (m *MockJoinRequestsService) Request(ctx context.Context, userID string, email string, slug string, req CreateJoinRequest) (*JoinRequest, bool, error) {
+	args := m.Called(ctx, userID, email, slug, req)
+	if args.Get(0) == nil {
+		return nil, false, args.Error(2)
+	}
+	return args.Get(0).(*JoinRequest), args.Bool(1), args.Error(2)
+}
+
+func (m *MockJoinRequestsService) ListMine(ctx context.Context, userID string) ([]JoinRequest, error) {
+	args := m.Called(ctx, userID)
+	return args.Get(0).([]JoinRequest), args.Error(1)
+}
+
+func (m *MockJoinRequestsService) Cancel(ctx context.Context, userID string, id int64) (*JoinRequest, error) {
+	args := m.Called(ctx, userID, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*JoinRequest), args.Error(1)
+}
+
+func (m *MockJoinRequestsService) ExpireDue(ctx context.Context) (int, error) {
+	args := m.Called(ctx)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockJoinRequestsService) List(ctx context.Context, orgID int64, role string, status string) ([]JoinRequest, error) {
+	args := m.Called(ctx, orgID, role, status)
+	return args.Get(0).([]JoinRequest), args.Error(1)
+}
+
+func (m *MockJoinRequestsService) Approve(ctx context.Context, userID string, orgID int64, role string, id int64) (*JoinRequest, error) {
+	args := m.Called(ctx, userID, orgID, role, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*JoinRequest), args.Error(1)
+}
+
+func (m *MockJoinRequestsService) Reject(ctx context.Context, userID string, orgID int64, role string, id int64) (*JoinRequest, error) {
+	args := m.Called(ctx, userID, orgID, role, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*JoinRequest), args.Error(1)
+}
+
+func setupJoinRequestsRouter(controller *JoinRequestsController) *gin.Engine {
+	r := gin.New()
+	r.Use(func(c *gin.Context) {
+		middlewares.SetPrincipal(c, middlewares.Principal{UserID: "owner", OrganizationID: 1, Role: "OWNER"})
+	})
+	r.GET("/organization/join-requests", controller.ListJoinRequestsHandler)
+	r.POST("/organization/join-requests/:id/approve", controller.ApproveJoinRequestHandler)
+	return r
+}
+
+func TestListJoinRequestsHandler_PassesStatus(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	mockSvc := new(MockJoinRequestsService)
+	r := setupJoinRequestsRouter(GetJoinRequestsController(mockSvc))
+
+	mockSvc.On("List", mock.Anything, int64(1), "OWNER", "REJECTED").
+		Return([]JoinRequest{{ID: 4, Email: "maja@villabled.si", Status: "REJECTED"}}, nil)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/organization/join-requests?status=REJECTED", nil)
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), "maja@villabled.si")
+}
+
+func TestApproveJoinRequestHandler_SeatLimit(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	mockSvc := new(MockJoinRequestsService)
+	r := setupJoinRequestsRouter(GetJoinRequestsController(mockSvc))
+
+	mockSvc.On("Approve", mock.Anything, "owner", int64(1), "OWNER", int64(4)).
+		Return(nil, &plans.SeatLimitError{Plan: "starter", Limit: 3, Used: 3})
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("POST", "/organization/join-requests/4/approve", nil)
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusPaymentRequired, w.Code)
+	assert.Contains(t, w.Body.String(), `"limit":3`)
+}
+
+func TestApproveJoinRequestHandler_AlreadyMember(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	mockSvc := new(MockJoinRequestsService)
+	r := setupJoinRequestsRouter(GetJoinRequestsController(mockSvc))
+
+	mockSvc.On("Approve", mock.Anything, "owner", int64(1), "OWNER", int64(5)).Return(nil, ErrAlreadyMember)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("POST", "/organization/join-requests/5/approve", nil)
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusConflict, w.Code)
+}
+
+func setupRequesterRouter(controller *JoinRequestsController) *gin.Engine {
+	r := gin.New()
+	r.Use(func(c *gin.Context) {
+		middlewares.SetPrincipal(c, middlewares.Principal{UserID: "newcomer", Email: "maja@gmail.com"})
+	})
+	r.POST("/organizations/:slug/join-requests", controller.CreateJoinRequestHandler)
+	r.DELETE("/join-requests/:id", controller.CancelJoinRequestHandler)
+	return r
+}
+
+func TestCreateJoinRequestHandler_Created(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	mockSvc := new(MockJoinRequestsService)
+	r := setupRequesterRouter(GetJoinRequestsController(mockSvc))
+
+	body := CreateJoinRequest{FullName: "Maja Novak", Message: "Front desk"}
+	mockSvc.On("Request", mock.Anything, "newcomer", "maja@gmail.com", "vila-bled", body).
+		Return(&JoinRequest{ID: 9, Status: "PENDING", Source: SourceSlug}, true, nil)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("POST", "/organizations/vila-bled/join-requests", bytes.NewBufferString(`{"full_name":"Maja Novak","message":"Front desk"}`))
+	req.Header.Set("Content-Type", "application/json")
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusCreated, w.Code)
+	assert.Contains(t, w.Body.String(), `"source":"SLUG"`)
+}
+
+func TestCreateJoinRequestHandler_ExistingWithoutBody(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	mockSvc := new(MockJoinRequestsService)
+	r := setupRequesterRouter(GetJoinRequestsController(mockSvc))
+
+	mockSvc.On("Request", mock.Anything, "newcomer", "maja@gmail.com", "vila-bled", CreateJoinRequest{}).
+		Return(&JoinRequest{ID: 9, Status: "PENDING"}, false, nil)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("POST", "/organizations/vila-bled/join-requests", nil)
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+}
+
+func TestCreateJoinRequestHandler_Errors(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	cases := map[string]struct {
+		err    error
+		status int
+	}{
+		"unknown":  {ErrOrganizationNotFound, http.StatusNotFound},
+		"member":   {ErrAlreadyMember, http.StatusConflict},
+		"deleted":  {ErrProfileDeleted, http.StatusConflict},
+		"too-many": {ErrTooManyRequests, http.StatusTooManyRequests},
+	}
+	for slug, tc := range cases {
+		mockSvc := new(MockJoinRequestsService)
+		r := setupRequesterRouter(GetJoinRequestsController(mockSvc))
+		mockSvc.On("Request", mock.Anything, "newcomer", "maja@gmail.com", slug, CreateJoinRequest{}).Return(nil, false, tc.err)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", "/organizations/"+slug+"/join-requests", nil)
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, tc.status, w.Code, slug)
+	}
+}
+
+func TestCancelJoinRequestHandler_NotFound(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	mockSvc := new(MockJoinRequestsService)
+	r := setupRequesterRouter(GetJoinRequestsController(mockSvc))
+
+	mockSvc.On("Cancel", mock.Anything, "newcomer", int64(3)).Return(nil, ErrRequestNotFound)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("DELETE", "/join-requests/3", nil)
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
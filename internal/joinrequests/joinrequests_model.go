@@ -0,0 +1,43 @@
+package joinrequests
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Sources of a join request.
+const (
+	SourceDomain = "DOMAIN"
+	SourceSlug   = "SLUG"
+)
+
+// JoinRequest is a user waiting to be let into an organization.
+type JoinRequest struct {
+	ID             int64      `json:"id" db:"id"`
+	OrganizationID int64      `json:"organization_id" db:"organization_id"`
+	UserID         uuid.UUID  `json:"user_id" db:"user_id"`
+	Email          string     `json:"email" db:"email"`
+	FullName       string     `json:"full_name" db:"full_name"`
+	Role           string     `json:"role" db:"role" example:"MEMBER"`
+	Source         string     `json:"source" db:"source" example:"DOMAIN"`
+	Message        *string    `json:"message" db:"message"`
+	Status         string     `json:"status" db:"status" example:"PENDING"`
+	CreatedAt      time.Time  `json:"created_at" db:"created_at"`
+	ExpiresAt      time.Time  `json:"expires_at" db:"expires_at"`
+	DecidedAt      *time.Time `json:"decided_at" db:"decided_at"`
+	DecidedBy      *uuid.UUID `json:"decided_by" db:"decided_by"`
+}
+
+// CreateJoinRequest is the body of a request to join an organization. The
+// name defaults to the local part of the email address.
+type CreateJoinRequest struct {
+	FullName string `json:"full_name" binding:"max=200" example:"Maja Novak"`
+	Message  string `json:"message" binding:"max=1000" example:"I manage the front desk at Vila Bled."`
+}
+
+// ErrorResponse represents an error response
+type ErrorResponse struct {
+	Error   string `json:"error" example:"Invalid request"`
+	Message string `json:"message,omitempty" example:"The provided data is invalid"`
+}
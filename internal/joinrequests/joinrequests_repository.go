@@ -0,0 +1,403 @@
+package joinrequests
+
+import (
+	"context"
+	"errors"
+
+	"hostflow/profile-service/internal/plans"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+const requestColumns = `id, organization_id, user_id, email, full_name, role, source, message, status, created_at, expires_at, decided_at, decided_by`
+
+type JoinRequestsRepository struct {
+	db *pgxpool.Pool
+}
+
+func GetJoinRequestsRepository(db *pgxpool.Pool) *JoinRequestsRepository {
+	return &JoinRequestsRepository{
+		db: db,
+	}
+}
+
+// FindMembership returns the organization, role and status of the user's
+// profile, or found=false when the user has none.
+func (r *JoinRequestsRepository) FindMembership(ctx context.Context, userID uuid.UUID) (orgID int64, role string, status string, found bool, err error) {
+	err = r.db.QueryRow(ctx,
+		`SELECT organization_id, role, status FROM "profiles" WHERE id = $1 AND deleted_at IS NULL`,
+		userID,
+	).Scan(&orgID, &role, &status)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return 0, "", "", false, nil
+	}
+	if err != nil {
+		return 0, "", "", false, err
+	}
+	return orgID, role, status, true, nil
+}
+
+// Join adds the user to the organization with the given role, taking a seat
+// of the organization's plan. ErrAlreadyMember is returned when the user
+// has a profile, ErrProfileDeleted when it was deleted from another
+// organization.
+func (r *JoinRequestsRepository) Join(ctx context.Context, userID uuid.UUID, orgID int64, email string, fullName string, role string) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	if err := addMember(ctx, tx, userID, orgID, email, fullName, role); err != nil {
+		return err
+	}
+	if err := cancelOtherRequests(ctx, tx, userID, 0); err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
+}
+
+// FindOrganizationBySlug returns the ID and name of the active organization
+// with the slug.
+func (r *JoinRequestsRepository) FindOrganizationBySlug(ctx context.Context, slug string) (int64, string, error) {
+	var id int64
+	var name string
+	err := r.db.QueryRow(ctx,
+		`SELECT id, name FROM organization WHERE slug = $1 AND status = 'ACTIVE'`,
+		slug,
+	).Scan(&id, &name)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return 0, "", ErrOrganizationNotFound
+	}
+	if err != nil {
+		return 0, "", err
+	}
+	return id, name, nil
+}
+
+// CreateRequest stores a pending request unless the user already has one
+// for the organization, in which case that one is returned. It reports
+// whether a new request was created.
+func (r *JoinRequestsRepository) CreateRequest(ctx context.Context, req JoinRequest) (*JoinRequest, bool, error) {
+	rows, err := r.db.Query(ctx, `
+        INSERT INTO join_requests (organization_id, user_id, email, full_name, role, source, message, expires_at)
+        VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+        ON CONFLICT (organization_id, user_id) WHERE status = 'PENDING' DO NOTHING
+        RETURNING `+requestColumns,
+		req.OrganizationID, req.UserID, req.Email, req.FullName, req.Role, req.Source, req.Message, req.ExpiresAt,
+	)
+	if err != nil {
+		return nil, false, err
+	}
+
+	created, err := pgx.CollectOneRow(rows, pgx.RowToStructByName[JoinRequest])
+	if err == nil {
+		return &created, true, nil
+	}
+	if !errors.Is(err, pgx.ErrNoRows) {
+		return nil, false, err
+	}
+
+	rows, err = r.db.Query(ctx, `
+        SELECT `+requestColumns+`
+        FROM join_requests
+        WHERE organization_id = $1 AND user_id = $2 AND status = 'PENDING'
+    `, req.OrganizationID, req.UserID)
+	if err != nil {
+		return nil, false, err
+	}
+
+	existing, err := pgx.CollectOneRow(rows, pgx.RowToStructByName[JoinRequest])
+	if err != nil {
+		return nil, false, err
+	}
+	return &existing, false, nil
+}
+
+// CountPendingForUser returns how many requests of the user await a decision.
+func (r *JoinRequestsRepository) CountPendingForUser(ctx context.Context, userID uuid.UUID) (int, error) {
+	var count int
+	err := r.db.QueryRow(ctx,
+		`SELECT count(*) FROM join_requests WHERE user_id = $1 AND status = 'PENDING'`,
+		userID,
+	).Scan(&count)
+	return count, err
+}
+
+// FindPendingOrganization returns the organization of the user's oldest
+// pending request, or found=false when there is none.
+func (r *JoinRequestsRepository) FindPendingOrganization(ctx context.Context, userID uuid.UUID) (orgID int64, found bool, err error) {
+	err = r.db.QueryRow(ctx, `
+        SELECT organization_id FROM join_requests
+        WHERE user_id = $1 AND status = 'PENDING'
+        ORDER BY created_at
+        LIMIT 1
+    `, userID).Scan(&orgID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return 0, false, nil
+	}
+	if err != nil {
+		return 0, false, err
+	}
+	return orgID, true, nil
+}
+
+// ListForUser returns the user's own requests, newest first.
+func (r *JoinRequestsRepository) ListForUser(ctx context.Context, userID uuid.UUID) ([]JoinRequest, error) {
+	rows, err := r.db.Query(ctx, `
+        SELECT `+requestColumns+`
+        FROM join_requests
+        WHERE user_id = $1
+        ORDER BY created_at DESC
+        LIMIT 50
+    `, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return pgx.CollectRows(rows, pgx.RowToStructByName[JoinRequest])
+}
+
+// Cancel withdraws a pending request of the user.
+func (r *JoinRequestsRepository) Cancel(ctx context.Context, id int64, userID uuid.UUID) (*JoinRequest, error) {
+	rows, err := r.db.Query(ctx, `
+        UPDATE join_requests SET status = 'CANCELLED', decided_at = now(), decided_by = $2
+        WHERE id = $1 AND user_id = $2 AND status = 'PENDING'
+        RETURNING `+requestColumns,
+		id, userID,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	request, err := pgx.CollectOneRow(rows, pgx.RowToStructByName[JoinRequest])
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrRequestNotFound
+		}
+		return nil, err
+	}
+	return &request, nil
+}
+
+// ExpireDue marks up to limit pending requests past their expiry EXPIRED
+// and returns them.
+func (r *JoinRequestsRepository) ExpireDue(ctx context.Context, limit int) ([]JoinRequest, error) {
+	rows, err := r.db.Query(ctx, `
+        UPDATE join_requests SET status = 'EXPIRED', decided_at = now()
+        WHERE id IN (
+            SELECT id FROM join_requests
+            WHERE status = 'PENDING' AND expires_at <= now()
+            ORDER BY expires_at
+            LIMIT $1
+            FOR UPDATE SKIP LOCKED
+        )
+        RETURNING `+requestColumns,
+		limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return pgx.CollectRows(rows, pgx.RowToStructByName[JoinRequest])
+}
+
+// ListApproverEmails returns the email addresses of the active owners, who
+// decide the organization's join requests.
+func (r *JoinRequestsRepository) ListApproverEmails(ctx context.Context, orgID int64) ([]string, error) {
+	rows, err := r.db.Query(ctx, `
+        SELECT email FROM "profiles"
+        WHERE organization_id = $1 AND role = 'OWNER' AND status = 'ACTIVE' AND email IS NOT NULL
+        ORDER BY email
+    `, orgID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return pgx.CollectRows(rows, pgx.RowTo[string])
+}
+
+// List returns the organization's requests with the given status, oldest
+// first.
+func (r *JoinRequestsRepository) List(ctx context.Context, orgID int64, status string) ([]JoinRequest, error) {
+	rows, err := r.db.Query(ctx, `
+        SELECT `+requestColumns+`
+        FROM join_requests
+        WHERE organization_id = $1 AND status = $2
+        ORDER BY created_at
+    `, orgID, status)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return pgx.CollectRows(rows, pgx.RowToStructByName[JoinRequest])
+}
+
+// Approve adds the requesting user to the organization and marks the
+// request APPROVED. If the user joined an organization in the meantime the
+// request is CANCELLED and ErrAlreadyMember returned, as is ErrProfileDeleted
+// for users deleted from another organization.
+func (r *JoinRequestsRepository) Approve(ctx context.Context, id int64, orgID int64, deciderID *uuid.UUID) (*JoinRequest, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	request, err := lockPending(ctx, tx, id, orgID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = addMember(ctx, tx, request.UserID, orgID, request.Email, request.FullName, request.Role)
+	if errors.Is(err, ErrAlreadyMember) || errors.Is(err, ErrProfileDeleted) {
+		if _, err := decide(ctx, tx, id, "CANCELLED", deciderID); err != nil {
+			return nil, err
+		}
+		if err := tx.Commit(ctx); err != nil {
+			return nil, err
+		}
+		return nil, err
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := cancelOtherRequests(ctx, tx, request.UserID, id); err != nil {
+		return nil, err
+	}
+
+	approved, err := decide(ctx, tx, id, "APPROVED", deciderID)
+	if err != nil {
+		return nil, err
+	}
+	return approved, tx.Commit(ctx)
+}
+
+// Reject marks a pending request REJECTED.
+func (r *JoinRequestsRepository) Reject(ctx context.Context, id int64, orgID int64, deciderID *uuid.UUID) (*JoinRequest, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	if _, err := lockPending(ctx, tx, id, orgID); err != nil {
+		return nil, err
+	}
+
+	rejected, err := decide(ctx, tx, id, "REJECTED", deciderID)
+	if err != nil {
+		return nil, err
+	}
+	return rejected, tx.Commit(ctx)
+}
+
+// lockPending locks a pending request of the organization.
+func lockPending(ctx context.Context, tx pgx.Tx, id int64, orgID int64) (*JoinRequest, error) {
+	rows, err := tx.Query(ctx, `
+        SELECT `+requestColumns+`
+        FROM join_requests
+        WHERE id = $1 AND organization_id = $2 AND status = 'PENDING'
+        FOR UPDATE
+    `, id, orgID)
+	if err != nil {
+		return nil, err
+	}
+
+	request, err := pgx.CollectOneRow(rows, pgx.RowToStructByName[JoinRequest])
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrRequestNotFound
+		}
+		return nil, err
+	}
+	return &request, nil
+}
+
+// decide sets the final status of a request.
+func decide(ctx context.Context, tx pgx.Tx, id int64, status string, deciderID *uuid.UUID) (*JoinRequest, error) {
+	rows, err := tx.Query(ctx, `
+        UPDATE join_requests SET status = $2, decided_at = now(), decided_by = $3
+        WHERE id = $1
+        RETURNING `+requestColumns,
+		id, status, deciderID,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	request, err := pgx.CollectOneRow(rows, pgx.RowToStructByName[JoinRequest])
+	if err != nil {
+		return nil, err
+	}
+	return &request, nil
+}
+
+// cancelOtherRequests withdraws the user's pending requests other than
+// keepID once the user has joined an organization.
+func cancelOtherRequests(ctx context.Context, tx pgx.Tx, userID uuid.UUID, keepID int64) error {
+	_, err := tx.Exec(ctx, `
+        UPDATE join_requests SET status = 'CANCELLED', decided_at = now()
+        WHERE user_id = $1 AND status = 'PENDING' AND id <> $2
+    `, userID, keepID)
+	return err
+}
+
+// addMember creates the user's profile in the organization inside tx.
+// Additions for the same user are serialized with an advisory lock, the
+// same one sign-up takes, so a user cannot end up in two organizations. A
+// profile deleted from the same organization is restored, as SCIM does;
+// one deleted from another organization keeps the user out with
+// ErrProfileDeleted.
+func addMember(ctx context.Context, tx pgx.Tx, userID uuid.UUID, orgID int64, email string, fullName string, role string) error {
+	if _, err := tx.Exec(ctx, `SELECT pg_advisory_xact_lock(hashtext('signup:' || $1::text))`, userID); err != nil {
+		return err
+	}
+
+	var existingOrg int64
+	var deleted bool
+	err := tx.QueryRow(ctx,
+		`SELECT organization_id, deleted_at IS NOT NULL FROM "profiles" WHERE id = $1`,
+		userID,
+	).Scan(&existingOrg, &deleted)
+	exists := err == nil
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		return err
+	}
+	if exists && !deleted {
+		return ErrAlreadyMember
+	}
+	if exists && existingOrg != orgID {
+		return ErrProfileDeleted
+	}
+
+	if err := plans.ReserveSeat(ctx, tx, orgID); err != nil {
+		return err
+	}
+
+	if exists {
+		_, err = tx.Exec(ctx, `
+            UPDATE "profiles" SET
+                full_name  = $2,
+                email      = $3,
+                role       = $4,
+                status     = 'ACTIVE',
+                deleted_at = NULL,
+                updated_at = now()
+            WHERE id = $1
+        `, userID, fullName, email, role)
+		return err
+	}
+	_, err = tx.Exec(ctx, `
+        INSERT INTO "profiles" (id, organization_id, full_name, role, email, status, created_at, updated_at)
+        VALUES ($1, $2, $3, $4, $5, 'ACTIVE', now(), now())
+    `, userID, orgID, fullName, role, email)
+	return err
+}
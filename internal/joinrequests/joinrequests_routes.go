@@ -0,0 +1,50 @@
+package joinrequests
+
+import (
+	"hostflow/profile-service/internal/middlewares"
+	"hostflow/profile-service/pkg/lib"
+)
+
+type JoinRequestsRoutes struct {
+	logger                 lib.Logger
+	router                 *lib.Router
+	joinRequestsController *JoinRequestsController
+	authMiddleware         middlewares.AuthMiddleware
+}
+
+func SetJoinRequestsRoutes(
+	logger lib.Logger,
+	router *lib.Router,
+	joinRequestsController *JoinRequestsController,
+	authMiddleware middlewares.AuthMiddleware,
+) JoinRequestsRoutes {
+	return JoinRequestsRoutes{
+		logger:                 logger,
+		router:                 router,
+		joinRequestsController: joinRequestsController,
+		authMiddleware:         authMiddleware,
+	}
+}
+
+func (route JoinRequestsRoutes) Setup() {
+	route.logger.Info("Setting up [JOIN REQUESTS] routes.")
+
+	organizations := route.router.Group("/organization")
+	organizations.Use(route.authMiddleware.Handler())
+	{
+		organizations.GET("/join-requests", route.joinRequestsController.ListJoinRequestsHandler)
+		organizations.POST("/join-requests/:id/approve", route.joinRequestsController.ApproveJoinRequestHandler)
+		organizations.POST("/join-requests/:id/reject", route.joinRequestsController.RejectJoinRequestHandler)
+	}
+
+	// Users without an organization ask to join one and follow their requests
+	requesters := route.router.Group("")
+	requesters.Use(route.authMiddleware.IdentityHandler())
+	{
+		requesters.POST("/organizations/:slug/join-requests", route.joinRequestsController.CreateJoinRequestHandler)
+		requesters.GET("/join-requests", route.joinRequestsController.ListMyJoinRequestsHandler)
+		requesters.DELETE("/join-requests/:id", route.joinRequestsController.CancelJoinRequestHandler)
+	}
+
+	route.logger.Info("[JOIN REQUESTS] routes setup complete.")
+}
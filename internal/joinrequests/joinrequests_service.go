@@ -0,0 +1,454 @@
+package joinrequests
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"hostflow/profile-service/internal/audit"
+	"hostflow/profile-service/internal/domains"
+	"hostflow/profile-service/internal/iamsync"
+	"hostflow/profile-service/internal/middlewares"
+	"hostflow/profile-service/internal/plans"
+	"hostflow/profile-service/pkg/lib"
+	"hostflow/profile-service/pkg/notify"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// DefaultRequestTTL is how long a request waits for a decision before it
+	// expires, unless JOIN_REQUEST_TTL says otherwise.
+	DefaultRequestTTL = 14 * 24 * time.Hour
+
+	// maxPendingPerUser bounds how many organizations a user can be waiting
+	// on at once, so owners cannot be flooded with requests.
+	maxPendingPerUser = 5
+
+	// expiryBatchSize bounds how many requests one expiry run handles.
+	expiryBatchSize = 200
+)
+
+var (
+	ErrRequestNotFound      = errors.New("join request not found")
+	ErrOrganizationNotFound = errors.New("organization not found")
+	ErrAlreadyMember        = errors.New("the user already belongs to an organization")
+	ErrProfileDeleted       = errors.New("the user's profile was deleted from another organization")
+	ErrTooManyRequests      = errors.New("too many pending join requests")
+	ErrForbidden            = errors.New("you are not allowed to perform this action")
+	ErrInvalidInput         = errors.New("invalid input")
+)
+
+type JoinRequestsService struct {
+	repo       *JoinRequestsRepository
+	domains    domains.Service
+	identity   iamsync.Service
+	notifier   notify.Notifier
+	audit      audit.Service
+	logger     lib.Logger
+	requestTTL time.Duration
+}
+
+type Service interface {
+	Onboard(ctx context.Context, identity middlewares.Identity) (*middlewares.Membership, error)
+	Request(ctx context.Context, userID string, email string, slug string, req CreateJoinRequest) (*JoinRequest, bool, error)
+	ListMine(ctx context.Context, userID string) ([]JoinRequest, error)
+	Cancel(ctx context.Context, userID string, id int64) (*JoinRequest, error)
+	ExpireDue(ctx context.Context) (int, error)
+	List(ctx context.Context, orgID int64, role string, status string) ([]JoinRequest, error)
+	Approve(ctx context.Context, userID string, orgID int64, role string, id int64) (*JoinRequest, error)
+	Reject(ctx context.Context, userID string, orgID int64, role string, id int64) (*JoinRequest, error)
+}
+
+func GetJoinRequestsService(
+	repo *JoinRequestsRepository,
+	domains domains.Service,
+	identity iamsync.Service,
+	notifier notify.Notifier,
+	audit audit.Service,
+	logger lib.Logger,
+) (*JoinRequestsService, error) {
+	requestTTL, err := lib.DurationFromEnv("JOIN_REQUEST_TTL", DefaultRequestTTL)
+	if err != nil {
+		return nil, err
+	}
+
+	return &JoinRequestsService{
+		repo:       repo,
+		domains:    domains,
+		identity:   identity,
+		notifier:   notifier,
+		audit:      audit,
+		logger:     logger,
+		requestTTL: requestTTL,
+	}, nil
+}
+
+// Onboard places a user whose token carries no organization. A user who
+// already has a profile (e.g. the token predates sign-up) gets that
+// membership back. Otherwise the join rule of the verified email domain
+// applies: AUTO_JOIN adds the user right away, APPROVAL (or AUTO_JOIN on a
+// plan without free seats) files a pending request. Users waiting on a
+// request they filed themselves are reported as pending.
+func (s *JoinRequestsService) Onboard(ctx context.Context, identity middlewares.Identity) (*middlewares.Membership, error) {
+	userID, err := uuid.Parse(identity.UserID)
+	if err != nil {
+		return nil, nil
+	}
+
+	orgID, role, status, found, err := s.repo.FindMembership(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if found {
+		if status == "INACTIVE" {
+			return nil, nil
+		}
+		return &middlewares.Membership{OrganizationID: orgID, Role: role}, nil
+	}
+
+	var rule *domains.Rule
+	if identity.EmailVerified && identity.Email != "" {
+		rule, err = s.domains.FindRule(ctx, identity.Email)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if rule == nil {
+		pendingOrgID, pending, err := s.repo.FindPendingOrganization(ctx, userID)
+		if err != nil || !pending {
+			return nil, err
+		}
+		return &middlewares.Membership{OrganizationID: pendingOrgID, Pending: true}, nil
+	}
+
+	email := strings.ToLower(identity.Email)
+	fullName := defaultName(identity.Name, email)
+
+	if rule.Policy == domains.PolicyAutoJoin {
+		err := s.repo.Join(ctx, userID, rule.OrganizationID, email, fullName, rule.Role)
+		switch {
+		case err == nil:
+			s.syncIdentity(ctx, identity.UserID, rule.OrganizationID)
+			if err := s.record(ctx, &userID, rule.OrganizationID, "join.auto_joined", identity.UserID, map[string]interface{}{"role": rule.Role}); err != nil {
+				return nil, err
+			}
+			return &middlewares.Membership{OrganizationID: rule.OrganizationID, Role: rule.Role}, nil
+		case errors.Is(err, ErrAlreadyMember):
+			// A concurrent request of the same user got there first
+			return s.Onboard(ctx, identity)
+		case !errors.Is(err, plans.ErrSeatLimitReached):
+			return nil, err
+		}
+	}
+
+	request, created, err := s.repo.CreateRequest(ctx, JoinRequest{
+		OrganizationID: rule.OrganizationID,
+		UserID:         userID,
+		Email:          email,
+		FullName:       fullName,
+		Role:           rule.Role,
+		Source:         SourceDomain,
+		ExpiresAt:      time.Now().Add(s.requestTTL),
+	})
+	if err != nil {
+		return nil, err
+	}
+	if created {
+		if err := s.filed(ctx, request); err != nil {
+			return nil, err
+		}
+	}
+	return &middlewares.Membership{OrganizationID: rule.OrganizationID, Pending: true}, nil
+}
+
+// Request files a request of a user without an organization to join the
+// active organization with the slug as a MEMBER. Asking again while a
+// request is pending returns that request with created=false.
+func (s *JoinRequestsService) Request(ctx context.Context, userID string, email string, slug string, req CreateJoinRequest) (*JoinRequest, bool, error) {
+	id, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, false, fmt.Errorf("%w: the token has no user", ErrInvalidInput)
+	}
+	email = strings.ToLower(strings.TrimSpace(email))
+	if !strings.Contains(email, "@") {
+		return nil, false, fmt.Errorf("%w: the token has no email address", ErrInvalidInput)
+	}
+
+	if _, _, _, found, err := s.repo.FindMembership(ctx, id); err != nil {
+		return nil, false, err
+	} else if found {
+		return nil, false, ErrAlreadyMember
+	}
+
+	orgID, _, err := s.repo.FindOrganizationBySlug(ctx, strings.ToLower(strings.TrimSpace(slug)))
+	if err != nil {
+		return nil, false, err
+	}
+
+	pending, err := s.repo.CountPendingForUser(ctx, id)
+	if err != nil {
+		return nil, false, err
+	}
+
+	request := JoinRequest{
+		OrganizationID: orgID,
+		UserID:         id,
+		Email:          email,
+		FullName:       defaultName(req.FullName, email),
+		Role:           "MEMBER",
+		Source:         SourceSlug,
+		ExpiresAt:      time.Now().Add(s.requestTTL),
+	}
+	if message := strings.TrimSpace(req.Message); message != "" {
+		request.Message = &message
+	}
+
+	// A repeated request is answered with the pending one, so only new
+	// requests count against the limit
+	if pending >= maxPendingPerUser {
+		existing, err := s.findPending(ctx, id, orgID)
+		if err != nil {
+			return nil, false, err
+		}
+		if existing == nil {
+			return nil, false, ErrTooManyRequests
+		}
+		return existing, false, nil
+	}
+
+	stored, created, err := s.repo.CreateRequest(ctx, request)
+	if err != nil {
+		return nil, false, err
+	}
+	if created {
+		if err := s.filed(ctx, stored); err != nil {
+			return nil, false, err
+		}
+	}
+	return stored, created, nil
+}
+
+// ListMine returns the requests the user filed or had filed on their
+// behalf.
+func (s *JoinRequestsService) ListMine(ctx context.Context, userID string) ([]JoinRequest, error) {
+	id, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: the token has no user", ErrInvalidInput)
+	}
+
+	requests, err := s.repo.ListForUser(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if requests == nil {
+		return []JoinRequest{}, nil
+	}
+	return requests, nil
+}
+
+// Cancel withdraws one of the user's pending requests.
+func (s *JoinRequestsService) Cancel(ctx context.Context, userID string, id int64) (*JoinRequest, error) {
+	requesterID, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: the token has no user", ErrInvalidInput)
+	}
+
+	request, err := s.repo.Cancel(ctx, id, requesterID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.recordRequester(ctx, request, "join.cancelled"); err != nil {
+		return nil, err
+	}
+	return request, nil
+}
+
+// ExpireDue expires the pending requests nobody decided in time and returns
+// how many there were.
+func (s *JoinRequestsService) ExpireDue(ctx context.Context) (int, error) {
+	expired, err := s.repo.ExpireDue(ctx, expiryBatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	for _, request := range expired {
+		if err := s.record(ctx, nil, request.OrganizationID, "join.expired", request.UserID.String(), map[string]interface{}{"source": request.Source}); err != nil {
+			return 0, err
+		}
+	}
+	return len(expired), nil
+}
+
+// List returns the organization's join requests with the given status
+// (PENDING by default). Requires OWNER or MANAGER role.
+func (s *JoinRequestsService) List(ctx context.Context, orgID int64, role string, status string) ([]JoinRequest, error) {
+	if role != "OWNER" && role != "MANAGER" {
+		return nil, ErrForbidden
+	}
+
+	if status == "" {
+		status = "PENDING"
+	}
+	switch status {
+	case "PENDING", "APPROVED", "REJECTED", "CANCELLED", "EXPIRED":
+	default:
+		return nil, fmt.Errorf("%w: unknown status %q", ErrInvalidInput, status)
+	}
+
+	requests, err := s.repo.List(ctx, orgID, status)
+	if err != nil {
+		return nil, err
+	}
+	if requests == nil {
+		return []JoinRequest{}, nil
+	}
+	return requests, nil
+}
+
+// Approve lets the requesting user in with the role of the request.
+// Requires OWNER role.
+func (s *JoinRequestsService) Approve(ctx context.Context, userID string, orgID int64, role string, id int64) (*JoinRequest, error) {
+	if role != "OWNER" {
+		return nil, ErrForbidden
+	}
+
+	deciderID := parseActor(userID)
+	request, err := s.repo.Approve(ctx, id, orgID, deciderID)
+	if err != nil {
+		return nil, err
+	}
+
+	s.syncIdentity(ctx, request.UserID.String(), orgID)
+	if err := s.record(ctx, deciderID, orgID, "join.approved", request.UserID.String(), map[string]interface{}{"role": request.Role}); err != nil {
+		return nil, err
+	}
+	return request, nil
+}
+
+// Reject turns a request down. Requires OWNER role.
+func (s *JoinRequestsService) Reject(ctx context.Context, userID string, orgID int64, role string, id int64) (*JoinRequest, error) {
+	if role != "OWNER" {
+		return nil, ErrForbidden
+	}
+
+	deciderID := parseActor(userID)
+	request, err := s.repo.Reject(ctx, id, orgID, deciderID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.record(ctx, deciderID, orgID, "join.rejected", request.UserID.String(), nil); err != nil {
+		return nil, err
+	}
+	return request, nil
+}
+
+// findPending returns the user's pending request to the organization, or
+// nil when there is none.
+func (s *JoinRequestsService) findPending(ctx context.Context, userID uuid.UUID, orgID int64) (*JoinRequest, error) {
+	requests, err := s.repo.ListForUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	for _, request := range requests {
+		if request.OrganizationID == orgID && request.Status == "PENDING" {
+			return &request, nil
+		}
+	}
+	return nil, nil
+}
+
+// filed audits a new request and lets the approvers know about it.
+func (s *JoinRequestsService) filed(ctx context.Context, request *JoinRequest) error {
+	if err := s.recordRequester(ctx, request, "join.requested"); err != nil {
+		return err
+	}
+
+	go s.notifyApprovers(context.WithoutCancel(ctx), *request)
+	return nil
+}
+
+// notifyApprovers tells the organization's owners that a request awaits
+// their decision. Failures are logged, the request stays in the queue.
+func (s *JoinRequestsService) notifyApprovers(ctx context.Context, request JoinRequest) {
+	recipients, err := s.repo.ListApproverEmails(ctx, request.OrganizationID)
+	if err == nil {
+		err = s.notifier.Notify(ctx, notify.Notification{
+			Type:       "join_request.created",
+			Recipients: recipients,
+			Data: map[string]interface{}{
+				"request_id":      request.ID,
+				"organization_id": request.OrganizationID,
+				"email":           request.Email,
+				"full_name":       request.FullName,
+				"message":         request.Message,
+				"source":          request.Source,
+				"expires_at":      request.ExpiresAt,
+			},
+		})
+	}
+	if err != nil {
+		s.logger.Error(fmt.Sprintf("Failed to notify approvers of join request %d: %v", request.ID, err))
+	}
+}
+
+// syncIdentity queues the new membership for the user's app metadata so
+// future tokens carry it. A failure only costs a profile lookup per request
+// until the next sync, since onboarding resolves existing profiles.
+func (s *JoinRequestsService) syncIdentity(ctx context.Context, userID string, orgID int64) {
+	if err := s.identity.Sync(ctx, userID); err != nil {
+		s.logger.Error(fmt.Sprintf("Failed to sync identity %s after joining organization %d: %v", userID, orgID, err))
+	}
+}
+
+// record writes an audit entry about a user joining the organization.
+func (s *JoinRequestsService) record(ctx context.Context, actorID *uuid.UUID, orgID int64, action string, targetID string, details map[string]interface{}) error {
+	entry := audit.Entry{
+		OrganizationID: orgID,
+		ActorID:        actorID,
+		Action:         action,
+		TargetType:     "profile",
+		TargetID:       &targetID,
+		Details:        details,
+	}
+	if actorID != nil {
+		entry.ActorOrganizationID = &orgID
+	}
+	return s.audit.Record(ctx, entry)
+}
+
+// recordRequester writes an audit entry about an action the requesting
+// user took, who is not a member of the organization yet.
+func (s *JoinRequestsService) recordRequester(ctx context.Context, request *JoinRequest, action string) error {
+	targetID := request.UserID.String()
+	return s.audit.Record(ctx, audit.Entry{
+		OrganizationID: request.OrganizationID,
+		ActorID:        &request.UserID,
+		Action:         action,
+		TargetType:     "profile",
+		TargetID:       &targetID,
+		Details:        map[string]interface{}{"source": request.Source, "request_id": request.ID},
+	})
+}
+
+// defaultName returns the trimmed name, or the local part of the email
+// address when there is none.
+func defaultName(name string, email string) string {
+	if name = strings.TrimSpace(name); name != "" {
+		return name
+	}
+	return email[:strings.LastIndex(email, "@")]
+}
+
+// parseActor returns the UUID of the acting user, or nil for tokens without one.
+func parseActor(userID string) *uuid.UUID {
+	id, err := uuid.Parse(userID)
+	if err != nil {
+		return nil
+	}
+	return &id
+}
@@ -0,0 +1,35 @@
+package joinrequests
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"hostflow/profile-service/pkg/lib"
+
+	"go.uber.org/fx"
+)
+
+// DefaultExpiryInterval is how often stale requests are expired, unless
+// JOIN_REQUEST_EXPIRY_INTERVAL says otherwise.
+const DefaultExpiryInterval = time.Hour
+
+// RegisterExpiryWorker expires stale join requests in the background for
+// the lifetime of the app.
+func RegisterExpiryWorker(lifecycle fx.Lifecycle, service Service, logger lib.Logger) error {
+	interval, err := lib.DurationFromEnv("JOIN_REQUEST_EXPIRY_INTERVAL", DefaultExpiryInterval)
+	if err != nil {
+		return err
+	}
+
+	lib.RunPeriodically(lifecycle, interval, func(ctx context.Context) {
+		expired, err := service.ExpireDue(ctx)
+		if err != nil {
+			logger.Error(fmt.Sprintf("Join request expiry failed: %v", err))
+		} else if expired > 0 {
+			logger.Info(fmt.Sprintf("Expired %d join requests.", expired))
+		}
+	})
+
+	return nil
+}
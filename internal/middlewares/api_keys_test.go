@@ -0,0 +1,118 @@
+package middlewares
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeAPIKeys map[string]APIKeyGrant
+
+func (f fakeAPIKeys) VerifyAPIKey(ctx context.Context, key string) (APIKeyGrant, error) {
+	grant, ok := f[key]
+	if !ok {
+		return APIKeyGrant{}, ErrInvalidAPIKey
+	}
+	return grant, nil
+}
+
+func TestAPIKeyScope(t *testing.T) {
+	assert.Equal(t, "availability:read", APIKeyScope("availability", http.MethodGet))
+	assert.Equal(t, "availability:read", APIKeyScope("availability", http.MethodHead))
+	assert.Equal(t, "availability:write", APIKeyScope("availability", http.MethodPut))
+	assert.Equal(t, "availability:write", APIKeyScope("availability", http.MethodDelete))
+}
+
+func TestAuthMiddleware_APIKeys(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	status := NewOrganizationStatus(nil)
+	expires := time.Now().Add(time.Minute)
+	status.entries[1] = statusEntry{status: OrganizationActive, expires: expires}
+	status.entries[2] = statusEntry{status: OrganizationClosed, expires: expires}
+
+	m := AuthMiddleware{
+		organizationStatus: status,
+		securityPolicies:   loadedPolicies(map[int64]SecurityPolicy{1: {}, 2: {}}),
+		apiKeys: fakeAPIKeys{
+			"reader": {KeyID: 5, OrganizationID: 1, Role: "MEMBER", Scopes: []string{"availability:read"}},
+			"closed": {KeyID: 6, OrganizationID: 2, Role: "MEMBER", Scopes: []string{"availability:read"}},
+		},
+		logger: &recordingLogger{},
+	}
+
+	r := gin.New()
+	respond := func(c *gin.Context) {
+		principal := GetPrincipal(c)
+		c.JSON(http.StatusOK, gin.H{"key": principal.APIKeyID, "org": principal.OrganizationID, "user": principal.UserID})
+	}
+	r.GET("/availability", m.HandlerWithAPIKeys("availability"), respond)
+	r.PUT("/availability", m.HandlerWithAPIKeys("availability"), respond)
+	r.GET("/billing", m.Handler(), respond)
+
+	cases := []struct {
+		method, path, key string
+		status            int
+		body              string
+	}{
+		{http.MethodGet, "/availability", "reader", http.StatusOK, `"key":5`},
+		{http.MethodPut, "/availability", "reader", http.StatusForbidden, "availability:write"},
+		{http.MethodGet, "/availability", "unknown", http.StatusUnauthorized, "Invalid API key"},
+		{http.MethodGet, "/availability", "closed", http.StatusForbidden, "Organization closed"},
+		{http.MethodGet, "/billing", "reader", http.StatusForbidden, "API key not accepted"},
+	}
+	for _, tc := range cases {
+		req := httptest.NewRequest(tc.method, tc.path, nil)
+		req.Header.Set(APIKeyHeader, tc.key)
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, tc.status, w.Code, "%s %s with %s", tc.method, tc.path, tc.key)
+		assert.True(t, strings.Contains(w.Body.String(), tc.body), w.Body.String())
+	}
+}
+
+func TestAuthMiddleware_APIKeyBearer(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	status := NewOrganizationStatus(nil)
+	status.entries[1] = statusEntry{status: OrganizationActive, expires: time.Now().Add(time.Minute)}
+
+	m := AuthMiddleware{
+		organizationStatus: status,
+		securityPolicies:   loadedPolicies(map[int64]SecurityPolicy{1: {}, 2: {}}),
+		apiKeys: fakeAPIKeys{
+			"provisioner": {KeyID: 7, OrganizationID: 1, Role: "MEMBER", Scopes: []string{"scim:read", "scim:write"}},
+		},
+		logger: &recordingLogger{},
+	}
+
+	r := gin.New()
+	r.GET("/scim/v2/Users", m.APIKeyBearerHandler("scim"), func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{"key": GetPrincipal(c).APIKeyID})
+	})
+
+	cases := []struct {
+		header, value string
+		status        int
+	}{
+		{"Authorization", "Bearer provisioner", http.StatusOK},
+		{APIKeyHeader, "provisioner", http.StatusOK},
+		{"Authorization", "Bearer a-user-jwt", http.StatusUnauthorized},
+		{"Authorization", "", http.StatusUnauthorized},
+	}
+	for _, tc := range cases {
+		req := httptest.NewRequest(http.MethodGet, "/scim/v2/Users", nil)
+		req.Header.Set(tc.header, tc.value)
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, tc.status, w.Code, "%s: %s", tc.header, tc.value)
+	}
+}
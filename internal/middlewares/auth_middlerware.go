@@ -1,62 +1,273 @@
 package middlewares
 
 import (
+	"errors"
 	"fmt"
 	"net/http"
 	"strings"
-	"time"
 
-	"github.com/MicahParks/keyfunc/v3" // Use this for JWKS
+	"hostflow/profile-service/pkg/lib"
+
 	"github.com/gin-gonic/gin"
-	"github.com/golang-jwt/jwt/v5"
 )
 
 type AuthMiddleware struct {
-	jwksURL string
+	verifier           *TokenVerifier
+	memberships        *Memberships
+	revocations        *Revocations
+	organizationStatus *OrganizationStatus
+	securityPolicies   *SecurityPolicies
+	platformStaff      *PlatformStaff
+	apiKeys            APIKeyVerifier
+	impersonations     ImpersonationVerifier
+	onboarding         Onboarding
+	logger             lib.Logger
+}
+
+// authError is the body of requests the middlewares turn away, in the
+// service's usual error format. Code is set where clients need to tell
+// rejections apart, e.g. to prompt for a second factor.
+type authError struct {
+	Error   string `json:"error"`
+	Message string `json:"message,omitempty"`
+	Code    string `json:"code,omitempty"`
 }
 
-func NewAuthMiddleware() AuthMiddleware {
+func NewAuthMiddleware(
+	verifier *TokenVerifier,
+	memberships *Memberships,
+	revocations *Revocations,
+	organizationStatus *OrganizationStatus,
+	securityPolicies *SecurityPolicies,
+	platformStaff *PlatformStaff,
+	apiKeys APIKeyVerifier,
+	impersonations ImpersonationVerifier,
+	onboarding Onboarding,
+	logger lib.Logger,
+) AuthMiddleware {
 	return AuthMiddleware{
-		jwksURL: "https://frauwrkbphmjngymcdyk.supabase.co/auth/v1/.well-known/jwks.json",
+		verifier:           verifier,
+		memberships:        memberships,
+		revocations:        revocations,
+		organizationStatus: organizationStatus,
+		securityPolicies:   securityPolicies,
+		platformStaff:      platformStaff,
+		apiKeys:            apiKeys,
+		impersonations:     impersonations,
+		onboarding:         onboarding,
+		logger:             logger,
 	}
 }
 
+// Handler authenticates the request and requires the user to belong to an
+// active organization.
 func (m AuthMiddleware) Handler() gin.HandlerFunc {
-	// Initialize the key function (it handles caching the public key for you)
-	k, err := keyfunc.NewDefault([]string{m.jwksURL})
+	return m.handler(true, false, "")
+}
+
+// HandlerWithAPIKeys is Handler that also admits organization API keys
+// granted the scope of the area for the request method, e.g.
+// "availability:read" for GET requests when area is "availability".
+func (m AuthMiddleware) HandlerWithAPIKeys(area string) gin.HandlerFunc {
+	return m.handler(true, false, area)
+}
+
+// IdentityHandler authenticates the request without requiring an
+// organization, for endpoints such as sign-up that users call before they
+// have one. Organization claims are still set when present.
+func (m AuthMiddleware) IdentityHandler() gin.HandlerFunc {
+	return m.handler(false, false, "")
+}
+
+// ClosingHandler is Handler that also admits members of an organization
+// whose closure is pending, so its owner can review or cancel it.
+func (m AuthMiddleware) ClosingHandler() gin.HandlerFunc {
+	return m.handler(true, true, "")
+}
+
+func (m AuthMiddleware) handler(requireOrganization bool, allowClosing bool, apiKeyArea string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if key := c.GetHeader(APIKeyHeader); key != "" {
+			if m.authenticateAPIKey(c, key, apiKeyArea, allowClosing) {
+				c.Next()
+			}
+			return
+		}
+
+		tokenString, ok := bearerToken(c.GetHeader("Authorization"))
+		if !ok {
+			abortUnauthorized(c, "Missing token", "Send a bearer token in the Authorization header")
+			return
+		}
+
+		if isImpersonationToken(tokenString) {
+			impersonation, ok := m.authenticateImpersonation(c, tokenString, allowClosing)
+			if ok {
+				c.Next()
+				m.recordImpersonatedRequest(c, impersonation)
+			}
+			return
+		}
+
+		principal, ok := m.authenticateToken(c, tokenString)
+		if !ok || !m.checkRevocation(c, principal) {
+			return
+		}
+		if !m.resolveMembership(c, &principal) {
+			return
+		}
+		if !m.setOrganization(c, &principal, requireOrganization, allowClosing) {
+			return
+		}
+		if !m.checkSecurityPolicy(c, principal) {
+			return
+		}
+		SetPrincipal(c, principal)
+
+		c.Next()
+	}
+}
+
+// authenticateToken verifies a user token and reads its principal. It
+// aborts the request and returns false for invalid tokens.
+func (m AuthMiddleware) authenticateToken(c *gin.Context, tokenString string) (Principal, bool) {
+	// The reason is logged for debugging, the token itself never is
+	claims, err := m.verifier.Verify(tokenString)
 	if err != nil {
-		panic(fmt.Sprintf("Failed to fetch JWKS: %v", err))
+		m.logger.Info(fmt.Sprintf("Rejected token: %v", err))
+		abortUnauthorized(c, "Invalid token", "The token is invalid or has expired")
+		return Principal{}, false
 	}
 
-	return func(c *gin.Context) {
-		authHeader := c.GetHeader("Authorization")
-		tokenString := strings.TrimPrefix(authHeader, "Bearer ")
+	principal, err := principalFromClaims(claims)
+	if err != nil {
+		m.logger.Info(fmt.Sprintf("Rejected token: %v", err))
+		abortUnauthorized(c, "Invalid token", "The token does not identify a user")
+		return Principal{}, false
+	}
+	return principal, true
+}
+
+// checkRevocation aborts the request and returns false when the token was
+// issued before its user or session was revoked.
+func (m AuthMiddleware) checkRevocation(c *gin.Context, principal Principal) bool {
+	revoked, err := m.revocations.Revoked(principal.UserID, principal.SessionID, principal.IssuedAt)
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusServiceUnavailable, authError{
+			Error: "Failed to verify session",
+		})
+		return false
+	}
+	if revoked {
+		abortUnauthorized(c, "Session revoked", "Refresh the session or sign in again")
+		return false
+	}
+	return true
+}
 
-		token, err := jwt.Parse(tokenString, k.Keyfunc,
-			jwt.WithValidMethods([]string{"ES256"}),
-			jwt.WithLeeway(5*time.Minute),
-		)
+// resolveMembership sets the principal's organization and role from the
+// user's profile. It aborts the request and returns false for deactivated
+// users.
+func (m AuthMiddleware) resolveMembership(c *gin.Context, principal *Principal) bool {
+	member, found, err := m.memberships.Lookup(c.Request.Context(), principal.UserID)
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusServiceUnavailable, authError{
+			Error: "Failed to resolve organization",
+		})
+		return false
+	}
+	if !found {
+		return true
+	}
+	if member.Status == ProfileInactive {
+		abortForbidden(c, "Account deactivated", "The user has been deactivated in their organization")
+		return false
+	}
+
+	principal.OrganizationID, principal.Role = member.OrganizationID, member.Role
+	return true
+}
+
+// setOrganization completes the principal's organization and role. Users
+// without a profile are handed to onboarding, which may place
+// them by their email domain. It aborts the request and returns false when
+// an organization is required but missing or not active.
+func (m AuthMiddleware) setOrganization(c *gin.Context, principal *Principal, requireOrganization bool, allowClosing bool) bool {
+	if !requireOrganization {
+		return true
+	}
 
+	if !principal.HasOrganization() {
+		membership, err := m.onboarding.Onboard(c.Request.Context(), principal.Identity())
 		if err != nil {
-			fmt.Println("--- JWT DEBUG START ---")
-			fmt.Printf("Error: %v\n", err)
-			fmt.Printf("Token String: %s\n", tokenString)
-			fmt.Println("--- JWT DEBUG END ---")
-
-			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
-				"error":   "Invalid Token Signature",
-				"details": err.Error(), // Send the real error back to Postman for a moment
+			c.AbortWithStatusJSON(http.StatusServiceUnavailable, authError{
+				Error: "Failed to resolve organization",
 			})
-			return
+			return false
+		}
+		if membership != nil && membership.Pending {
+			abortForbidden(c, "Join request pending", "An owner of the organization has to approve your request")
+			return false
 		}
+		if membership == nil {
+			abortForbidden(c, "No organization", "The user does not belong to an organization yet")
+			return false
+		}
+		principal.OrganizationID, principal.Role = membership.OrganizationID, membership.Role
+	}
 
-		claims := token.Claims.(jwt.MapClaims)
-		userMeta := claims["user_metadata"].(map[string]interface{})
+	return m.checkOrganizationStatus(c, principal.OrganizationID, allowClosing)
+}
+
+// checkOrganizationStatus aborts the request when the organization is closed
+// or suspended, or pending closure unless allowClosing is set.
+func (m AuthMiddleware) checkOrganizationStatus(c *gin.Context, orgID int64, allowClosing bool) bool {
+	status, err := m.organizationStatus.Get(c.Request.Context(), orgID)
+	if err != nil {
+		if errors.Is(err, errOrganizationNotFound) {
+			abortForbidden(c, "No organization", "The organization no longer exists")
+			return false
+		}
+		c.AbortWithStatusJSON(http.StatusServiceUnavailable, authError{
+			Error: "Failed to verify organization",
+		})
+		return false
+	}
 
-		// Pass the data to your controller
-		c.Set("organization_id", int64(userMeta["organization_id"].(float64)))
-		c.Set("role", userMeta["role"].(string))
+	switch {
+	case status == OrganizationActive, status == OrganizationClosing && allowClosing:
+		return true
+	case status == OrganizationClosing:
+		abortForbidden(c, "Organization suspended", "The organization is being closed")
+	case status == OrganizationSuspended:
+		c.AbortWithStatusJSON(http.StatusForbidden, authError{
+			Error:   "Organization suspended",
+			Message: "The organization has been suspended; contact support",
+			Code:    CodeOrganizationSuspended,
+		})
+	default:
+		abortForbidden(c, "Organization closed", "The organization has been closed")
+	}
+	return false
+}
 
-		c.Next()
+// bearerToken extracts the token of a "Bearer <token>" header.
+func bearerToken(header string) (string, bool) {
+	scheme, token, found := strings.Cut(strings.TrimSpace(header), " ")
+	if !found || !strings.EqualFold(scheme, "Bearer") {
+		return "", false
 	}
+	token = strings.TrimSpace(token)
+	return token, token != ""
+}
+
+// abortUnauthorized rejects a request whose caller is not authenticated.
+func abortUnauthorized(c *gin.Context, title string, message string) {
+	c.Header("WWW-Authenticate", `Bearer realm="profile-service"`)
+	c.AbortWithStatusJSON(http.StatusUnauthorized, authError{Error: title, Message: message})
+}
+
+// abortForbidden rejects a request the authenticated caller may not make.
+func abortForbidden(c *gin.Context, title string, message string) {
+	c.AbortWithStatusJSON(http.StatusForbidden, authError{Error: title, Message: message})
 }
@@ -0,0 +1,177 @@
+package middlewares
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	// ImpersonationTokenPrefix starts every impersonation token, so they are
+	// told apart from user tokens and easy to recognize when leaked.
+	ImpersonationTokenPrefix = "hfimp_"
+
+	// ImpersonationHeader flags responses to impersonated requests with the
+	// ID of the impersonation session.
+	ImpersonationHeader = "X-Impersonation"
+
+	// CodeImpersonationReadOnly is the code of write requests in read-only
+	// impersonation sessions.
+	CodeImpersonationReadOnly = "impersonation_read_only"
+
+	// CodeImpersonationDenied is the code of changes impersonation sessions
+	// may never make, even when they allow writes.
+	CodeImpersonationDenied = "impersonation_denied"
+)
+
+// impersonationDeniedRoutes are the routes whose changes are destructive or
+// hard to undo: closing the organization, API keys, revoking sessions and
+// billing. Impersonation sessions can only read them.
+var impersonationDeniedRoutes = []string{
+	"/organization/closure",
+	"/organization/api-keys",
+	"/organization/billing",
+	"/sessions",
+}
+
+// ErrInvalidImpersonation is returned by verifiers for unknown, ended and
+// expired impersonation tokens.
+var ErrInvalidImpersonation = errors.New("invalid impersonation token")
+
+// Impersonation is a support session in which platform staff act as a user.
+type Impersonation struct {
+	ID             string
+	StaffUserID    string
+	UserID         string
+	OrganizationID int64
+	// AllowWrites admits requests other than GET, HEAD and OPTIONS; without
+	// it the session is read-only.
+	AllowWrites bool
+	StartedAt   time.Time
+	ExpiresAt   time.Time
+}
+
+// ImpersonationVerifier checks impersonation tokens and records what is done
+// with them.
+type ImpersonationVerifier interface {
+	VerifyImpersonation(ctx context.Context, token string) (Impersonation, error)
+	RecordImpersonatedRequest(ctx context.Context, impersonation Impersonation, method string, path string, status int)
+}
+
+// isImpersonationToken reports whether the bearer token is an impersonation
+// token rather than a user token.
+func isImpersonationToken(token string) bool {
+	return strings.HasPrefix(token, ImpersonationTokenPrefix)
+}
+
+// impersonationDenied reports whether an impersonation session may never
+// make the request, whatever it allows.
+func impersonationDenied(method string, route string) bool {
+	if isSafeMethod(method) {
+		return false
+	}
+	for _, denied := range impersonationDeniedRoutes {
+		if route == denied || strings.HasPrefix(route, denied+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+// isSafeMethod reports whether the method only reads.
+func isSafeMethod(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return true
+	}
+	return false
+}
+
+// authenticateImpersonation sets the principal of a request made with an
+// impersonation token: the impersonated user, in the organization the
+// session was started for, with the staff member as ImpersonatorID. The
+// organization's status and security policy still apply, and the session
+// counts as signed in with a second factor since staff need one to start
+// it. Write requests are refused unless the session allows them, and
+// destructive ones always. It aborts the request and returns false
+// otherwise.
+func (m AuthMiddleware) authenticateImpersonation(c *gin.Context, token string, allowClosing bool) (Impersonation, bool) {
+	impersonation, err := m.impersonations.VerifyImpersonation(c.Request.Context(), token)
+	if errors.Is(err, ErrInvalidImpersonation) {
+		abortUnauthorized(c, "Invalid token", "The impersonation session is invalid, has ended or has expired")
+		return Impersonation{}, false
+	}
+	if err != nil {
+		m.logger.Error(fmt.Sprintf("Impersonation verification failed: %v", err))
+		c.AbortWithStatusJSON(http.StatusServiceUnavailable, authError{
+			Error: "Failed to verify impersonation",
+		})
+		return Impersonation{}, false
+	}
+	c.Header(ImpersonationHeader, impersonation.ID)
+
+	principal := Principal{
+		UserID:         impersonation.UserID,
+		AAL:            "aal2",
+		SessionID:      impersonation.ID,
+		IssuedAt:       impersonation.StartedAt,
+		ImpersonatorID: impersonation.StaffUserID,
+	}
+	if !m.checkRevocation(c, principal) || !m.resolveMembership(c, &principal) {
+		return Impersonation{}, false
+	}
+	// The user left the organization since the session started
+	if principal.OrganizationID != impersonation.OrganizationID {
+		abortUnauthorized(c, "Invalid token", "The user no longer belongs to the organization of the impersonation session")
+		return Impersonation{}, false
+	}
+	if !m.checkOrganizationStatus(c, principal.OrganizationID, allowClosing) {
+		return Impersonation{}, false
+	}
+	if !m.checkSecurityPolicy(c, principal) {
+		return Impersonation{}, false
+	}
+	if impersonationDenied(c.Request.Method, requestRoute(c)) {
+		c.AbortWithStatusJSON(http.StatusForbidden, authError{
+			Error:   "Not allowed while impersonating",
+			Message: "Impersonation sessions cannot close the organization, manage API keys, revoke sessions or change billing",
+			Code:    CodeImpersonationDenied,
+		})
+		return Impersonation{}, false
+	}
+	if !impersonation.AllowWrites && !isSafeMethod(c.Request.Method) {
+		c.AbortWithStatusJSON(http.StatusForbidden, authError{
+			Error:   "Impersonation is read-only",
+			Message: "This impersonation session does not allow changes",
+			Code:    CodeImpersonationReadOnly,
+		})
+		return Impersonation{}, false
+	}
+
+	SetPrincipal(c, principal)
+	return impersonation, true
+}
+
+// recordImpersonatedRequest hands completed write requests of an
+// impersonation session to the audit log. Reads are not recorded; the
+// session itself is.
+func (m AuthMiddleware) recordImpersonatedRequest(c *gin.Context, impersonation Impersonation) {
+	if isSafeMethod(c.Request.Method) {
+		return
+	}
+	m.impersonations.RecordImpersonatedRequest(c.Request.Context(), impersonation, c.Request.Method, requestRoute(c), c.Writer.Status())
+}
+
+// requestRoute returns the matched route, e.g. "/users/:id/role", or the
+// path when no route matched.
+func requestRoute(c *gin.Context) string {
+	if route := c.FullPath(); route != "" {
+		return route
+	}
+	return c.Request.URL.Path
+}
@@ -0,0 +1,118 @@
+package middlewares
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+type recordedRequest struct {
+	method string
+	path   string
+	status int
+}
+
+type fakeImpersonations struct {
+	sessions map[string]Impersonation
+	recorded []recordedRequest
+}
+
+func (f *fakeImpersonations) VerifyImpersonation(ctx context.Context, token string) (Impersonation, error) {
+	impersonation, ok := f.sessions[token]
+	if !ok {
+		return Impersonation{}, ErrInvalidImpersonation
+	}
+	return impersonation, nil
+}
+
+func (f *fakeImpersonations) RecordImpersonatedRequest(ctx context.Context, impersonation Impersonation, method string, path string, status int) {
+	f.recorded = append(f.recorded, recordedRequest{method: method, path: path, status: status})
+}
+
+func TestAuthMiddleware_Impersonation(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	const user = "3f1c2a5e-0000-4000-8000-000000000001"
+	expires := time.Now().Add(time.Minute)
+	memberships := NewMemberships(nil)
+	memberships.entries[user] = memberEntry{member: Member{OrganizationID: 1, Role: "OWNER", Status: "ACTIVE"}, expires: expires}
+	status := NewOrganizationStatus(nil)
+	status.entries[1] = statusEntry{status: OrganizationActive, expires: expires}
+
+	started := time.Now().Add(-time.Minute)
+	session := Impersonation{ID: "session-1", StaffUserID: "staff-1", UserID: user, OrganizationID: 1, StartedAt: started, ExpiresAt: expires}
+	writable := session
+	writable.ID, writable.AllowWrites = "session-2", true
+	moved := session
+	moved.ID, moved.OrganizationID = "session-3", 2
+	impersonations := &fakeImpersonations{sessions: map[string]Impersonation{
+		"hfimp_read":  session,
+		"hfimp_write": writable,
+		"hfimp_moved": moved,
+	}}
+	revocations := loadedRevocations()
+	revocations.sessions["session-4"] = time.Now()
+	revoked := session
+	revoked.ID = "session-4"
+	impersonations.sessions["hfimp_revoked"] = revoked
+
+	m := AuthMiddleware{
+		memberships:        memberships,
+		revocations:        revocations,
+		organizationStatus: status,
+		securityPolicies:   loadedPolicies(map[int64]SecurityPolicy{1: {RequireMFA: true}}),
+		impersonations:     impersonations,
+		logger:             &recordingLogger{},
+	}
+
+	r := gin.New()
+	respond := func(c *gin.Context) {
+		principal := GetPrincipal(c)
+		c.JSON(http.StatusOK, gin.H{"user": principal.UserID, "by": principal.ImpersonatorID})
+	}
+	r.GET("/profile", m.Handler(), respond)
+	r.PUT("/profile", m.Handler(), respond)
+	r.GET("/organization/api-keys", m.Handler(), respond)
+	r.POST("/organization/api-keys", m.Handler(), respond)
+	r.POST("/organization/closure", m.Handler(), respond)
+	r.DELETE("/sessions", m.Handler(), respond)
+	r.PUT("/organization/billing", m.Handler(), respond)
+
+	cases := []struct {
+		method, path, token string
+		status              int
+		body                string
+	}{
+		{http.MethodGet, "/profile", "hfimp_read", http.StatusOK, `"by":"staff-1"`},
+		{http.MethodPut, "/profile", "hfimp_read", http.StatusForbidden, CodeImpersonationReadOnly},
+		{http.MethodPut, "/profile", "hfimp_write", http.StatusOK, `"user":"` + user + `"`},
+		{http.MethodGet, "/organization/api-keys", "hfimp_write", http.StatusOK, `"by":"staff-1"`},
+		{http.MethodPost, "/organization/api-keys", "hfimp_write", http.StatusForbidden, CodeImpersonationDenied},
+		{http.MethodPost, "/organization/closure", "hfimp_write", http.StatusForbidden, CodeImpersonationDenied},
+		{http.MethodDelete, "/sessions", "hfimp_write", http.StatusForbidden, CodeImpersonationDenied},
+		{http.MethodPut, "/organization/billing", "hfimp_write", http.StatusForbidden, CodeImpersonationDenied},
+		{http.MethodGet, "/profile", "hfimp_moved", http.StatusUnauthorized, "no longer belongs"},
+		{http.MethodGet, "/profile", "hfimp_revoked", http.StatusUnauthorized, "Session revoked"},
+		{http.MethodGet, "/profile", "hfimp_unknown", http.StatusUnauthorized, "Invalid token"},
+	}
+	for _, tc := range cases {
+		req := httptest.NewRequest(tc.method, tc.path, nil)
+		req.Header.Set("Authorization", "Bearer "+tc.token)
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, tc.status, w.Code, "%s %s with %s", tc.method, tc.path, tc.token)
+		assert.Contains(t, w.Body.String(), tc.body)
+		if tc.token != "hfimp_unknown" {
+			assert.NotEmpty(t, w.Header().Get(ImpersonationHeader), tc.token)
+		}
+	}
+
+	// Only the completed write is recorded
+	assert.Equal(t, []recordedRequest{{method: http.MethodPut, path: "/profile", status: http.StatusOK}}, impersonations.recorded)
+}
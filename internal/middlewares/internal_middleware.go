@@ -0,0 +1,106 @@
+package middlewares
+
+import (
+	"crypto/subtle"
+	"net/http"
+	"os"
+	"slices"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ServiceCaller is the service behind a request authenticated with a
+// service token.
+type ServiceCaller struct {
+	ClientID int32
+	Name     string
+	Scopes   []string
+}
+
+// HasScopes reports whether the caller was granted every scope.
+func (c ServiceCaller) HasScopes(scopes ...string) bool {
+	for _, scope := range scopes {
+		if !slices.Contains(c.Scopes, scope) {
+			return false
+		}
+	}
+	return true
+}
+
+// ServiceTokenVerifier checks the signed tokens services obtain from the
+// token endpoint of this service.
+type ServiceTokenVerifier interface {
+	VerifyServiceToken(tokenString string) (ServiceCaller, error)
+}
+
+// serviceCallerKey is the context key of the ServiceCaller.
+type serviceCallerKey struct{}
+
+// GetServiceCaller returns the service behind the request, or found=false
+// on routes opened with the shared token.
+func GetServiceCaller(c *gin.Context) (ServiceCaller, bool) {
+	value, ok := c.Get(serviceCallerKey{})
+	if !ok {
+		return ServiceCaller{}, false
+	}
+	caller, ok := value.(ServiceCaller)
+	return caller, ok
+}
+
+// InternalMiddleware protects service-to-service endpoints under /internal.
+// Routes with scopes admit only service tokens carrying them, so every
+// service is limited to what it was granted. The shared bearer token from
+// INTERNAL_API_TOKEN only opens the routes without scopes, which manage the
+// service clients.
+type InternalMiddleware struct {
+	token    string
+	verifier ServiceTokenVerifier
+}
+
+func NewInternalMiddleware(verifier ServiceTokenVerifier) InternalMiddleware {
+	return InternalMiddleware{
+		token:    os.Getenv("INTERNAL_API_TOKEN"),
+		verifier: verifier,
+	}
+}
+
+// Handler admits service tokens granted all of the scopes. Without scopes,
+// only the shared token is admitted.
+func (m InternalMiddleware) Handler(scopes ...string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		provided, _ := bearerToken(c.GetHeader("Authorization"))
+
+		if len(scopes) == 0 {
+			// Without a configured token the client routes stay closed
+			if m.token == "" {
+				c.AbortWithStatusJSON(http.StatusServiceUnavailable, authError{
+					Error: "Internal API is not configured",
+				})
+				return
+			}
+			if subtle.ConstantTimeCompare([]byte(provided), []byte(m.token)) != 1 {
+				abortUnauthorized(c, "Invalid service token", "Send the internal API token as a bearer token")
+				return
+			}
+			c.Next()
+			return
+		}
+
+		if provided == "" {
+			abortUnauthorized(c, "Invalid service token", "Send a service token as a bearer token")
+			return
+		}
+		caller, err := m.verifier.VerifyServiceToken(provided)
+		if err != nil {
+			abortUnauthorized(c, "Invalid service token", "Send a service token as a bearer token")
+			return
+		}
+		if !caller.HasScopes(scopes...) {
+			abortForbidden(c, "Insufficient scope", "The service token lacks a scope this route requires")
+			return
+		}
+
+		c.Set(serviceCallerKey{}, caller)
+		c.Next()
+	}
+}
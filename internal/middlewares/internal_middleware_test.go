@@ -0,0 +1,87 @@
+package middlewares
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeServiceTokens map[string]ServiceCaller
+
+func (f fakeServiceTokens) VerifyServiceToken(tokenString string) (ServiceCaller, error) {
+	caller, ok := f[tokenString]
+	if !ok {
+		return ServiceCaller{}, errors.New("invalid token")
+	}
+	return caller, nil
+}
+
+func TestInternalMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	m := InternalMiddleware{
+		token: "shared-secret",
+		verifier: fakeServiceTokens{
+			"booking": {ClientID: 1, Name: "booking-service", Scopes: []string{"billing:read"}},
+		},
+	}
+
+	r := gin.New()
+	r.GET("/billing", m.Handler("billing:read"), func(c *gin.Context) {
+		caller, _ := GetServiceCaller(c)
+		c.String(http.StatusOK, caller.Name)
+	})
+	r.PUT("/plan", m.Handler("plans:write"), func(c *gin.Context) { c.Status(http.StatusOK) })
+	r.POST("/clients", m.Handler(), func(c *gin.Context) { c.Status(http.StatusOK) })
+
+	cases := []struct {
+		method, path, token string
+		status              int
+	}{
+		{http.MethodGet, "/billing", "shared-secret", http.StatusUnauthorized},
+		{http.MethodPut, "/plan", "shared-secret", http.StatusUnauthorized},
+		{http.MethodGet, "/billing", "booking", http.StatusOK},
+		{http.MethodGet, "/billing", "forged", http.StatusUnauthorized},
+		{http.MethodGet, "/billing", "", http.StatusUnauthorized},
+		{http.MethodPut, "/plan", "booking", http.StatusForbidden},
+		{http.MethodPost, "/clients", "shared-secret", http.StatusOK},
+		{http.MethodPost, "/clients", "booking", http.StatusUnauthorized},
+	}
+	for _, tc := range cases {
+		req := httptest.NewRequest(tc.method, tc.path, nil)
+		if tc.token != "" {
+			req.Header.Set("Authorization", "Bearer "+tc.token)
+		}
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		assert.Equal(t, tc.status, w.Code, "%s %s with %q", tc.method, tc.path, tc.token)
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/billing", nil)
+	req.Header.Set("Authorization", "Bearer booking")
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	assert.Equal(t, "booking-service", w.Body.String())
+}
+
+func TestInternalMiddleware_NotConfigured(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	m := InternalMiddleware{verifier: fakeServiceTokens{}}
+	r := gin.New()
+	r.POST("/clients", m.Handler(), func(c *gin.Context) { c.Status(http.StatusOK) })
+	r.GET("/billing", m.Handler("billing:read"), func(c *gin.Context) { c.Status(http.StatusOK) })
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/clients", nil))
+	assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+
+	// Service tokens work without the shared token
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/billing", nil))
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+}
@@ -0,0 +1,109 @@
+package middlewares
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// ProfileInactive is the status of deactivated members, who are turned away
+// even while their tokens are still valid.
+const ProfileInactive = "INACTIVE"
+
+// membershipTTL bounds how long a membership is served from memory. Changes
+// made by this instance invalidate the entry right away; other instances
+// pick them up once it expires.
+const membershipTTL = 15 * time.Second
+
+// Member is the organization, role and status of a user's profile.
+type Member struct {
+	OrganizationID int64
+	Role           string
+	Status         string
+}
+
+type memberEntry struct {
+	member  Member
+	expires time.Time
+}
+
+// Memberships resolves the organization and role of users from their
+// profiles for the auth middleware, with a short-lived in-memory cache.
+// Token metadata is not trusted for this, since users can edit parts of it.
+type Memberships struct {
+	db      *pgxpool.Pool
+	mu      sync.Mutex
+	entries map[string]memberEntry
+}
+
+func NewMemberships(db *pgxpool.Pool) *Memberships {
+	return &Memberships{
+		db:      db,
+		entries: map[string]memberEntry{},
+	}
+}
+
+// Lookup returns the profile of the user, or found=false when the user has
+// none or it was deleted. Users without a profile are not cached, so joining an organization
+// takes effect on the next request.
+func (m *Memberships) Lookup(ctx context.Context, userID string) (Member, bool, error) {
+	id, err := uuid.Parse(userID)
+	if err != nil {
+		return Member{}, false, nil
+	}
+	key := id.String()
+
+	m.mu.Lock()
+	entry, ok := m.entries[key]
+	m.mu.Unlock()
+	if ok && time.Now().Before(entry.expires) {
+		return entry.member, true, nil
+	}
+
+	var member Member
+	err = m.db.QueryRow(ctx,
+		`SELECT organization_id, role, status FROM "profiles" WHERE id = $1 AND deleted_at IS NULL`,
+		id,
+	).Scan(&member.OrganizationID, &member.Role, &member.Status)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return Member{}, false, nil
+	}
+	if err != nil {
+		return Member{}, false, err
+	}
+
+	m.mu.Lock()
+	m.entries[key] = memberEntry{member: member, expires: time.Now().Add(membershipTTL)}
+	m.mu.Unlock()
+	return member, true, nil
+}
+
+// Invalidate drops the cached membership after the user's role or status
+// changed.
+func (m *Memberships) Invalidate(userID string) {
+	id, err := uuid.Parse(userID)
+	if err != nil {
+		return
+	}
+
+	m.mu.Lock()
+	delete(m.entries, id.String())
+	m.mu.Unlock()
+}
+
+// InvalidateOrganization drops the cached memberships of every member of
+// the organization, e.g. after its profiles were removed.
+func (m *Memberships) InvalidateOrganization(orgID int64) {
+	m.mu.Lock()
+	for key, entry := range m.entries {
+		if entry.member.OrganizationID == orgID {
+			delete(m.entries, key)
+		}
+	}
+	m.mu.Unlock()
+}
@@ -72,5 +72,13 @@ var Module = fx.Options(
 	fx.Provide(GetCorsMiddleware),
 	fx.Provide(GetErrorsMiddleware),
 	fx.Provide(GetMiddlewares),
+	fx.Provide(NewOrganizationStatus),
+	fx.Provide(NewSecurityPolicies),
+	fx.Provide(NewPlatformStaff),
+	fx.Provide(NewTokenVerifier),
+	fx.Provide(NewMemberships),
+	fx.Provide(NewRevocations),
 	fx.Provide(NewAuthMiddleware),
+	fx.Provide(NewInternalMiddleware),
+	fx.Invoke(RegisterRevocationListener),
 )
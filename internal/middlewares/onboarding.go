@@ -0,0 +1,29 @@
+package middlewares
+
+import (
+	"context"
+)
+
+// Identity is what the token says about a user who has no organization
+// claim yet.
+type Identity struct {
+	UserID        string
+	Email         string
+	EmailVerified bool
+	Name          string
+}
+
+// Membership is the organization a user was placed in. Pending is set when
+// the user asked to join and waits for approval.
+type Membership struct {
+	OrganizationID int64
+	Role           string
+	Pending        bool
+}
+
+// Onboarding places users whose token carries no organization, e.g. by the
+// join rules of their email domain. It returns nil when the user belongs
+// nowhere.
+type Onboarding interface {
+	Onboard(ctx context.Context, identity Identity) (*Membership, error)
+}
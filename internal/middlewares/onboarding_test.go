@@ -0,0 +1,83 @@
+package middlewares
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeOnboarding struct {
+	membership *Membership
+	identity   Identity
+}
+
+func (f *fakeOnboarding) Onboard(ctx context.Context, identity Identity) (*Membership, error) {
+	f.identity = identity
+	return f.membership, nil
+}
+
+func newTestContext() (*gin.Context, *httptest.ResponseRecorder) {
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest("GET", "/", nil)
+	return c, w
+}
+
+func TestSetOrganization_Onboarded(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	status := NewOrganizationStatus(nil)
+	status.entries[7] = statusEntry{status: OrganizationActive, expires: time.Now().Add(time.Minute)}
+	onboarding := &fakeOnboarding{membership: &Membership{OrganizationID: 7, Role: "MEMBER"}}
+	m := AuthMiddleware{organizationStatus: status, onboarding: onboarding}
+
+	principal, err := principalFromClaims(jwt.MapClaims{
+		"sub":            "3f1c2a5e-0000-4000-8000-000000000001",
+		"email":          "maja@villabled.si",
+		"email_verified": true,
+		"user_metadata":  map[string]interface{}{"full_name": "Maja Novak"},
+	})
+	assert.NoError(t, err)
+	c, _ := newTestContext()
+
+	assert.True(t, m.setOrganization(c, &principal, true, false))
+	assert.Equal(t, int64(7), principal.OrganizationID)
+	assert.Equal(t, "MEMBER", principal.Role)
+	assert.Equal(t, Identity{
+		UserID:        "3f1c2a5e-0000-4000-8000-000000000001",
+		Email:         "maja@villabled.si",
+		EmailVerified: true,
+		Name:          "Maja Novak",
+	}, onboarding.identity)
+}
+
+func TestSetOrganization_Pending(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	onboarding := &fakeOnboarding{membership: &Membership{OrganizationID: 7, Pending: true}}
+	m := AuthMiddleware{organizationStatus: NewOrganizationStatus(nil), onboarding: onboarding}
+	c, w := newTestContext()
+
+	assert.False(t, m.setOrganization(c, &Principal{UserID: "user"}, true, false))
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	assert.Contains(t, w.Body.String(), "Join request pending")
+}
+
+func TestSetOrganization_IdentityOnly(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	onboarding := &fakeOnboarding{}
+	m := AuthMiddleware{organizationStatus: NewOrganizationStatus(nil), onboarding: onboarding}
+	c, _ := newTestContext()
+	principal := Principal{UserID: "user"}
+
+	assert.True(t, m.setOrganization(c, &principal, false, false))
+	assert.Equal(t, Identity{}, onboarding.identity)
+	assert.False(t, principal.HasOrganization())
+}
@@ -0,0 +1,88 @@
+package middlewares
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// Organization statuses checked on every authenticated request. Suspended
+// is not stored as a status: platform staff suspend an organization beside
+// its closure state, which it keeps once unsuspended.
+const (
+	OrganizationActive    = "ACTIVE"
+	OrganizationClosing   = "CLOSING"
+	OrganizationClosed    = "CLOSED"
+	OrganizationSuspended = "SUSPENDED"
+)
+
+// CodeOrganizationSuspended is the code of requests to a suspended
+// organization.
+const CodeOrganizationSuspended = "organization_suspended"
+
+// organizationStatusTTL bounds how long a status is served from memory.
+// Changes made by this instance invalidate the entry right away.
+const organizationStatusTTL = 5 * time.Second
+
+var errOrganizationNotFound = errors.New("organization not found")
+
+type statusEntry struct {
+	status  string
+	expires time.Time
+}
+
+// OrganizationStatus looks up the status of organizations for the auth
+// middleware with a short-lived in-memory cache.
+type OrganizationStatus struct {
+	db      *pgxpool.Pool
+	mu      sync.Mutex
+	entries map[int64]statusEntry
+}
+
+func NewOrganizationStatus(db *pgxpool.Pool) *OrganizationStatus {
+	return &OrganizationStatus{
+		db:      db,
+		entries: map[int64]statusEntry{},
+	}
+}
+
+// Get returns the status of the organization; OrganizationSuspended for
+// suspended organizations that are not closed.
+func (s *OrganizationStatus) Get(ctx context.Context, orgID int64) (string, error) {
+	s.mu.Lock()
+	entry, ok := s.entries[orgID]
+	s.mu.Unlock()
+	if ok && time.Now().Before(entry.expires) {
+		return entry.status, nil
+	}
+
+	var status string
+	err := s.db.QueryRow(ctx, `
+        SELECT CASE WHEN suspended_at IS NOT NULL AND status <> 'CLOSED' THEN 'SUSPENDED' ELSE status END
+        FROM organization
+        WHERE id = $1`,
+		orgID,
+	).Scan(&status)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return "", errOrganizationNotFound
+		}
+		return "", err
+	}
+
+	s.mu.Lock()
+	s.entries[orgID] = statusEntry{status: status, expires: time.Now().Add(organizationStatusTTL)}
+	s.mu.Unlock()
+	return status, nil
+}
+
+// Invalidate drops the cached status after the organization changed it.
+func (s *OrganizationStatus) Invalidate(orgID int64) {
+	s.mu.Lock()
+	delete(s.entries, orgID)
+	s.mu.Unlock()
+}
@@ -0,0 +1,53 @@
+package middlewares
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckOrganizationStatus(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	status := NewOrganizationStatus(nil)
+	expires := time.Now().Add(time.Minute)
+	status.entries[1] = statusEntry{status: OrganizationActive, expires: expires}
+	status.entries[2] = statusEntry{status: OrganizationClosing, expires: expires}
+	status.entries[3] = statusEntry{status: OrganizationClosed, expires: expires}
+	status.entries[4] = statusEntry{status: OrganizationSuspended, expires: expires}
+	m := AuthMiddleware{organizationStatus: status}
+
+	cases := []struct {
+		orgID        int64
+		allowClosing bool
+		allowed      bool
+	}{
+		{1, false, true},
+		{2, false, false},
+		{2, true, true},
+		{3, false, false},
+		{3, true, false},
+		{4, false, false},
+		{4, true, false},
+	}
+
+	for _, tc := range cases {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request, _ = http.NewRequest("GET", "/", nil)
+
+		allowed := m.checkOrganizationStatus(c, tc.orgID, tc.allowClosing)
+
+		assert.Equal(t, tc.allowed, allowed, "org %d", tc.orgID)
+		if !tc.allowed {
+			assert.Equal(t, http.StatusForbidden, w.Code)
+		}
+		if tc.orgID == 4 {
+			assert.Contains(t, w.Body.String(), CodeOrganizationSuspended)
+		}
+	}
+}
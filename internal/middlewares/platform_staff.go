@@ -0,0 +1,113 @@
+package middlewares
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// CodeStaffOnly is the code of requests to staff routes by other users.
+const CodeStaffOnly = "platform_staff_only"
+
+// staffTTL bounds how long whether a user is staff is served from memory.
+const staffTTL = 30 * time.Second
+
+type staffEntry struct {
+	staff   bool
+	expires time.Time
+}
+
+// PlatformStaff tells whether users are platform staff, from the
+// platform_staff table, with a short-lived in-memory cache.
+type PlatformStaff struct {
+	db      *pgxpool.Pool
+	mu      sync.Mutex
+	entries map[string]staffEntry
+}
+
+func NewPlatformStaff(db *pgxpool.Pool) *PlatformStaff {
+	return &PlatformStaff{
+		db:      db,
+		entries: map[string]staffEntry{},
+	}
+}
+
+// IsStaff reports whether the user is platform staff.
+func (s *PlatformStaff) IsStaff(ctx context.Context, userID string) (bool, error) {
+	id, err := uuid.Parse(userID)
+	if err != nil {
+		return false, nil
+	}
+	key := id.String()
+
+	s.mu.Lock()
+	entry, ok := s.entries[key]
+	s.mu.Unlock()
+	if ok && time.Now().Before(entry.expires) {
+		return entry.staff, nil
+	}
+
+	var staff bool
+	err = s.db.QueryRow(ctx, `SELECT EXISTS (SELECT 1 FROM platform_staff WHERE user_id = $1)`, id).Scan(&staff)
+	if err != nil {
+		return false, err
+	}
+
+	s.mu.Lock()
+	s.entries[key] = staffEntry{staff: staff, expires: time.Now().Add(staffTTL)}
+	s.mu.Unlock()
+	return staff, nil
+}
+
+// StaffHandler admits platform staff signed in with a second factor. Only
+// user tokens are accepted: no API keys and no impersonation tokens. Staff
+// need not belong to an organization; the principal's organization is left
+// empty so staff routes never act on it by accident.
+func (m AuthMiddleware) StaffHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		tokenString, ok := bearerToken(c.GetHeader("Authorization"))
+		if !ok {
+			abortUnauthorized(c, "Missing token", "Send a bearer token in the Authorization header")
+			return
+		}
+		principal, ok := m.authenticateToken(c, tokenString)
+		if !ok || !m.checkRevocation(c, principal) {
+			return
+		}
+
+		staff, err := m.platformStaff.IsStaff(c.Request.Context(), principal.UserID)
+		if err != nil {
+			m.logger.Error(fmt.Sprintf("Failed to look up platform staff: %v", err))
+			c.AbortWithStatusJSON(http.StatusServiceUnavailable, authError{
+				Error: "Failed to verify platform staff",
+			})
+			return
+		}
+		if !staff {
+			c.AbortWithStatusJSON(http.StatusForbidden, authError{
+				Error:   "Platform staff only",
+				Message: "This route is only available to platform staff",
+				Code:    CodeStaffOnly,
+			})
+			return
+		}
+		if principal.AAL != "aal2" {
+			c.AbortWithStatusJSON(http.StatusForbidden, authError{
+				Error:   "MFA required",
+				Message: "Platform staff have to sign in with a second factor",
+				Code:    CodeMFARequired,
+			})
+			return
+		}
+
+		principal.PlatformStaff = true
+		SetPrincipal(c, principal)
+		c.Next()
+	}
+}
@@ -0,0 +1,59 @@
+package middlewares
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStaffHandler(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	const staff = "3f1c2a5e-0000-4000-8000-00000000000a"
+	const user = "3f1c2a5e-0000-4000-8000-00000000000b"
+	verifier, err := NewTokenVerifierWithConfig(hmacConfig())
+	require.NoError(t, err)
+	platformStaff := NewPlatformStaff(nil)
+	expires := time.Now().Add(time.Minute)
+	platformStaff.entries[staff] = staffEntry{staff: true, expires: expires}
+	platformStaff.entries[user] = staffEntry{staff: false, expires: expires}
+	m := AuthMiddleware{verifier: verifier, revocations: loadedRevocations(), platformStaff: platformStaff, logger: &recordingLogger{}}
+
+	r := gin.New()
+	r.GET("/admin", m.StaffHandler(), func(c *gin.Context) {
+		principal := GetPrincipal(c)
+		assert.True(t, principal.PlatformStaff)
+		c.String(http.StatusOK, principal.UserID)
+	})
+
+	token := func(sub string, aal string) string {
+		claims := testClaims()
+		claims["sub"], claims["aal"] = sub, aal
+		return "Bearer " + signHMAC(t, claims)
+	}
+	cases := []struct {
+		header string
+		status int
+		body   string
+	}{
+		{token(staff, "aal2"), http.StatusOK, staff},
+		{token(staff, "aal1"), http.StatusForbidden, CodeMFARequired},
+		{token(user, "aal2"), http.StatusForbidden, CodeStaffOnly},
+		{"Bearer hfimp_0123_secret", http.StatusUnauthorized, "Invalid token"},
+		{"", http.StatusUnauthorized, "Missing token"},
+	}
+	for _, tc := range cases {
+		req := httptest.NewRequest(http.MethodGet, "/admin", nil)
+		req.Header.Set("Authorization", tc.header)
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, tc.status, w.Code, tc.body)
+		assert.Contains(t, w.Body.String(), tc.body)
+	}
+}
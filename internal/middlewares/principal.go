@@ -0,0 +1,134 @@
+package middlewares
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// errNoSubject is returned for tokens that do not say who the user is.
+var errNoSubject = errors.New("token has no subject")
+
+// Principal is the authenticated user behind a request.
+type Principal struct {
+	UserID        string
+	Email         string
+	EmailVerified bool
+	Name          string
+	// OrganizationID and Role come from the user's profile. They are zero
+	// when the user belongs to no organization, which only identity routes
+	// such as sign-up admit.
+	OrganizationID int64
+	Role           string
+	// AAL is the authenticator assurance level of the session: aal1 for a
+	// password or magic link, aal2 after a second factor.
+	AAL       string
+	SessionID string
+	// IssuedAt is when the token was issued; tokens issued before a
+	// revocation of the user or session are turned away.
+	IssuedAt time.Time
+	// APIKeyID is set when the request was made with an organization API
+	// key instead of a user token. UserID is empty then.
+	APIKeyID int64
+	// ImpersonatorID is the platform staff member acting as the user in an
+	// impersonation session; SessionID is the session's ID then.
+	ImpersonatorID string
+	// PlatformStaff is set on staff routes, for staff signed in as
+	// themselves.
+	PlatformStaff bool
+}
+
+// HasOrganization reports whether the user acts within an organization.
+func (p Principal) HasOrganization() bool {
+	return p.OrganizationID > 0
+}
+
+// Impersonated reports whether platform staff make the request as the user.
+func (p Principal) Impersonated() bool {
+	return p.ImpersonatorID != ""
+}
+
+// Identity returns what onboarding needs to know about the user.
+func (p Principal) Identity() Identity {
+	return Identity{
+		UserID:        p.UserID,
+		Email:         p.Email,
+		EmailVerified: p.EmailVerified,
+		Name:          p.Name,
+	}
+}
+
+// principalKey is the context key of the Principal. Being unexported, only
+// this package can set it.
+type principalKey struct{}
+
+// SetPrincipal stores the principal on the gin context and on the request
+// context, so code holding only a context.Context can reach it too.
+func SetPrincipal(c *gin.Context, principal Principal) {
+	c.Set(principalKey{}, principal)
+	if c.Request != nil {
+		c.Request = c.Request.WithContext(context.WithValue(c.Request.Context(), principalKey{}, principal))
+	}
+}
+
+// GetPrincipal returns the principal of the request, or the zero Principal
+// on routes without authentication.
+func GetPrincipal(c *gin.Context) Principal {
+	if value, ok := c.Get(principalKey{}); ok {
+		if principal, ok := value.(Principal); ok {
+			return principal
+		}
+	}
+	return Principal{}
+}
+
+// PrincipalFromContext returns the principal stored by SetPrincipal.
+func PrincipalFromContext(ctx context.Context) (Principal, bool) {
+	principal, ok := ctx.Value(principalKey{}).(Principal)
+	return principal, ok
+}
+
+// principalFromClaims reads the principal from verified token claims. Every
+// claim is type checked, since a well signed token can still carry
+// unexpected shapes; only a missing subject is an error. The organization
+// and role are left to the membership lookup: metadata in the token is not
+// trusted for them, nor for whether the e-mail address is verified.
+func principalFromClaims(claims jwt.MapClaims) (Principal, error) {
+	principal := Principal{
+		UserID:    stringClaim(claims, "sub"),
+		Email:     stringClaim(claims, "email"),
+		AAL:       stringClaim(claims, "aal"),
+		SessionID: stringClaim(claims, "session_id"),
+	}
+	if principal.UserID == "" {
+		return Principal{}, errNoSubject
+	}
+	if issuedAt, err := claims.GetIssuedAt(); err == nil && issuedAt != nil {
+		principal.IssuedAt = issuedAt.Time
+	}
+
+	// Only the top-level email_verified claim, set by the identity provider,
+	// is trusted. Users can edit their own user_metadata, so its
+	// email_verified would let them claim an address at a verified domain
+	// they never confirmed.
+	principal.EmailVerified, _ = claims["email_verified"].(bool)
+	if meta, ok := claims["user_metadata"].(map[string]interface{}); ok {
+		for _, key := range []string{"full_name", "name"} {
+			if name, ok := meta[key].(string); ok && name != "" {
+				principal.Name = name
+				break
+			}
+		}
+	}
+
+	return principal, nil
+}
+
+// stringClaim returns the claim when it is a string.
+func stringClaim(claims jwt.MapClaims, key string) string {
+	value, _ := claims[key].(string)
+	return value
+}
@@ -0,0 +1,178 @@
+package middlewares
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type recordingLogger struct {
+	messages []string
+}
+
+func (l *recordingLogger) Info(args ...interface{})  { l.record(args) }
+func (l *recordingLogger) Error(args ...interface{}) { l.record(args) }
+func (l *recordingLogger) Fatal(args ...interface{}) { l.record(args) }
+
+func (l *recordingLogger) record(args []interface{}) {
+	for _, arg := range args {
+		if message, ok := arg.(string); ok {
+			l.messages = append(l.messages, message)
+		}
+	}
+}
+
+func TestPrincipalFromClaims(t *testing.T) {
+	principal, err := principalFromClaims(jwt.MapClaims{
+		"sub":          "user-1",
+		"email":        "maja@villabled.si",
+		"aal":          "aal2",
+		"session_id":   "session-1",
+		"iat":          float64(1760000000),
+		"app_metadata": map[string]interface{}{"organization_id": float64(7), "role": "OWNER"},
+	})
+
+	// The organization in the token is ignored, it comes from the profile
+	assert.NoError(t, err)
+	assert.Equal(t, Principal{
+		UserID:    "user-1",
+		Email:     "maja@villabled.si",
+		AAL:       "aal2",
+		SessionID: "session-1",
+		IssuedAt:  time.Unix(1760000000, 0),
+	}, principal)
+}
+
+func TestPrincipalFromClaims_EmailVerified(t *testing.T) {
+	principal, err := principalFromClaims(jwt.MapClaims{
+		"sub":            "user-1",
+		"email":          "maja@villabled.si",
+		"email_verified": true,
+	})
+	assert.NoError(t, err)
+	assert.True(t, principal.EmailVerified)
+
+	// Users can edit their own user_metadata, so it never verifies an address
+	principal, err = principalFromClaims(jwt.MapClaims{
+		"sub":            "user-1",
+		"email":          "maja@villabled.si",
+		"email_verified": false,
+		"user_metadata":  map[string]interface{}{"email_verified": true},
+	})
+	assert.NoError(t, err)
+	assert.False(t, principal.EmailVerified)
+
+	principal, err = principalFromClaims(jwt.MapClaims{
+		"sub":           "user-1",
+		"email":         "maja@villabled.si",
+		"user_metadata": map[string]interface{}{"email_verified": true},
+	})
+	assert.NoError(t, err)
+	assert.False(t, principal.EmailVerified)
+}
+
+func TestPrincipalFromClaims_UnexpectedShapes(t *testing.T) {
+	cases := []jwt.MapClaims{
+		{"sub": "user-1", "user_metadata": "OWNER"},
+		{"sub": "user-1", "user_metadata": map[string]interface{}{"email_verified": "yes", "full_name": 7}},
+		{"sub": "user-1", "email": 42, "aal": []string{"aal2"}, "session_id": nil},
+	}
+	for _, claims := range cases {
+		principal, err := principalFromClaims(claims)
+		assert.NoError(t, err)
+		assert.Equal(t, Principal{UserID: "user-1"}, principal, "%v", claims)
+	}
+
+	_, err := principalFromClaims(jwt.MapClaims{"sub": 12})
+	assert.ErrorIs(t, err, errNoSubject)
+}
+
+func TestPrincipalAccessors(t *testing.T) {
+	c, _ := newTestContext()
+	assert.Equal(t, Principal{}, GetPrincipal(c))
+
+	SetPrincipal(c, Principal{UserID: "user-1", OrganizationID: 3})
+	assert.Equal(t, int64(3), GetPrincipal(c).OrganizationID)
+
+	fromRequest, ok := PrincipalFromContext(c.Request.Context())
+	assert.True(t, ok)
+	assert.Equal(t, "user-1", fromRequest.UserID)
+}
+
+func TestAuthHandler_RejectsWithoutLeakingToken(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	verifier, err := NewTokenVerifierWithConfig(hmacConfig())
+	require.NoError(t, err)
+	logger := &recordingLogger{}
+	m := AuthMiddleware{verifier: verifier, memberships: NewMemberships(nil), revocations: loadedRevocations(), logger: logger}
+
+	r := gin.New()
+	r.GET("/", m.IdentityHandler(), func(c *gin.Context) {
+		c.String(http.StatusOK, GetPrincipal(c).UserID)
+	})
+
+	expired := testClaims()
+	expired["exp"] = time.Now().Add(-time.Hour).Unix()
+	expiredToken := signHMAC(t, expired)
+
+	for header, title := range map[string]string{
+		"":                       "Missing token",
+		"Basic dXNlcjpwYXNz":     "Missing token",
+		"Bearer " + expiredToken: "Invalid token",
+	} {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/", nil)
+		req.Header.Set("Authorization", header)
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+		assert.Contains(t, w.Body.String(), `"error":"`+title+`"`)
+		assert.NotContains(t, w.Body.String(), expiredToken)
+		assert.NotEmpty(t, w.Header().Get("WWW-Authenticate"))
+	}
+	for _, message := range logger.messages {
+		assert.NotContains(t, message, expiredToken)
+	}
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/", nil)
+	req.Header.Set("Authorization", "Bearer "+signHMAC(t, testClaims()))
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "user-1", w.Body.String())
+}
+
+func TestResolveMembership(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	memberships := NewMemberships(nil)
+	expires := time.Now().Add(time.Minute)
+	memberships.entries["3f1c2a5e-0000-4000-8000-000000000001"] = memberEntry{member: Member{OrganizationID: 7, Role: "MEMBER", Status: "ACTIVE"}, expires: expires}
+	memberships.entries["3f1c2a5e-0000-4000-8000-000000000002"] = memberEntry{member: Member{OrganizationID: 7, Role: "OWNER", Status: ProfileInactive}, expires: expires}
+	m := AuthMiddleware{memberships: memberships}
+
+	// The profile wins over whatever the token claimed
+	c, _ := newTestContext()
+	principal := Principal{UserID: "3f1c2a5e-0000-4000-8000-000000000001", OrganizationID: 9, Role: "OWNER"}
+	assert.True(t, m.resolveMembership(c, &principal))
+	assert.Equal(t, int64(7), principal.OrganizationID)
+	assert.Equal(t, "MEMBER", principal.Role)
+
+	c, w := newTestContext()
+	principal = Principal{UserID: "3f1c2a5e-0000-4000-8000-000000000002"}
+	assert.False(t, m.resolveMembership(c, &principal))
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	assert.Contains(t, w.Body.String(), "Account deactivated")
+
+	memberships.Invalidate("3f1c2a5e-0000-4000-8000-000000000002")
+	memberships.InvalidateOrganization(7)
+	assert.Empty(t, memberships.entries)
+}
@@ -0,0 +1,293 @@
+package middlewares
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"hostflow/profile-service/pkg/lib"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"go.uber.org/fx"
+)
+
+// Kinds of revocations.
+const (
+	RevokeUser    = "USER"
+	RevokeSession = "SESSION"
+)
+
+const (
+	// revocationChannel is the Postgres channel revocations are announced
+	// on, so every instance applies them right away.
+	revocationChannel = "session_revocations"
+
+	// DefaultRevocationRetention is how long revocations are kept, unless
+	// SESSION_REVOCATION_RETENTION says otherwise. It must cover the
+	// longest token lifetime; Supabase allows up to a week.
+	DefaultRevocationRetention = 7 * 24 * time.Hour
+
+	// revocationResyncInterval is how often revocations are reloaded in
+	// full, in case a notification was missed.
+	revocationResyncInterval = time.Minute
+
+	// listenRetryDelay is the pause before reconnecting the listener.
+	listenRetryDelay = 5 * time.Second
+)
+
+// errRevocationsNotLoaded is returned until revocations were loaded once,
+// so revoked tokens are not let through while the service starts.
+var errRevocationsNotLoaded = errors.New("session revocations are not loaded")
+
+// revocation is a notification payload: tokens of the user or session
+// issued before Before are no longer accepted.
+type revocation struct {
+	Kind   string    `json:"kind"`
+	ID     string    `json:"id"`
+	Before time.Time `json:"before"`
+}
+
+// Revocations rejects tokens issued before their user or session was
+// revoked, e.g. after a deactivation or a "log out everywhere". Revocations
+// are held in memory and kept in sync across instances with Postgres
+// LISTEN/NOTIFY.
+type Revocations struct {
+	db        *pgxpool.Pool
+	listenURL string
+	retention time.Duration
+
+	mu       sync.RWMutex
+	loaded   bool
+	users    map[string]time.Time
+	sessions map[string]time.Time
+}
+
+// NewRevocations sets up the revocations. LISTEN needs a session of its
+// own, which a transaction-mode pooler such as PgBouncer does not keep, so
+// the listener connects to DATABASE_DIRECT_URL when it is set.
+func NewRevocations(db *pgxpool.Pool) (*Revocations, error) {
+	retention, err := lib.DurationFromEnv("SESSION_REVOCATION_RETENTION", DefaultRevocationRetention)
+	if err != nil {
+		return nil, err
+	}
+
+	listenURL := os.Getenv("DATABASE_DIRECT_URL")
+	if listenURL == "" {
+		listenURL = os.Getenv("DATABASE_URL")
+	}
+
+	return &Revocations{
+		db:        db,
+		listenURL: listenURL,
+		retention: retention,
+		users:     map[string]time.Time{},
+		sessions:  map[string]time.Time{},
+	}, nil
+}
+
+// Revoked reports whether a token of the user and session issued at
+// issuedAt has been revoked. Tokens without iat count as issued at the
+// epoch.
+func (r *Revocations) Revoked(userID string, sessionID string, issuedAt time.Time) (bool, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if !r.loaded {
+		return false, errRevocationsNotLoaded
+	}
+	if before, ok := r.users[userID]; ok && issuedAt.Before(before) {
+		return true, nil
+	}
+	if sessionID == "" {
+		return false, nil
+	}
+	before, ok := r.sessions[sessionID]
+	return ok && issuedAt.Before(before), nil
+}
+
+// Revoke rejects the tokens of a user (kind RevokeUser) or a session (kind
+// RevokeSession) issued until now. The revocation is stored and announced
+// to the other instances in one transaction.
+func (r *Revocations) Revoke(ctx context.Context, kind string, id string, reason string) error {
+	if kind != RevokeUser && kind != RevokeSession {
+		return fmt.Errorf("unknown revocation kind %q", kind)
+	}
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	entry := revocation{Kind: kind, ID: id}
+	err = tx.QueryRow(ctx, `
+        INSERT INTO session_revocations (kind, subject_id, revoked_before, reason)
+        VALUES ($1, $2, $3, $4)
+        ON CONFLICT (kind, subject_id) DO UPDATE SET
+            revoked_before = GREATEST(session_revocations.revoked_before, EXCLUDED.revoked_before),
+            reason         = EXCLUDED.reason
+        RETURNING revoked_before`,
+		kind, id, time.Now(), reason,
+	).Scan(&entry.Before)
+	if err != nil {
+		return err
+	}
+
+	payload, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	if _, err := tx.Exec(ctx, `SELECT pg_notify($1, $2)`, revocationChannel, string(payload)); err != nil {
+		return err
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return err
+	}
+
+	r.apply(entry)
+	return nil
+}
+
+// Reload reads the stored revocations and drops those past the retention.
+func (r *Revocations) Reload(ctx context.Context) error {
+	since := time.Now().Add(-r.retention)
+	if _, err := r.db.Exec(ctx, `DELETE FROM session_revocations WHERE revoked_before < $1`, since); err != nil {
+		return err
+	}
+
+	rows, err := r.db.Query(ctx, `SELECT kind, subject_id, revoked_before FROM session_revocations`)
+	if err != nil {
+		return err
+	}
+	users, sessions := map[string]time.Time{}, map[string]time.Time{}
+	var entry revocation
+	_, err = pgx.ForEachRow(rows, []any{&entry.Kind, &entry.ID, &entry.Before}, func() error {
+		if entry.Kind == RevokeUser {
+			users[entry.ID] = entry.Before
+		} else {
+			sessions[entry.ID] = entry.Before
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	// Revocations applied while loading are newer than what was read
+	r.mu.Lock()
+	merge(users, r.users, since)
+	merge(sessions, r.sessions, since)
+	r.users, r.sessions, r.loaded = users, sessions, true
+	r.mu.Unlock()
+	return nil
+}
+
+// merge adds the entries of from that are later than those of into and
+// not past the retention.
+func merge(into map[string]time.Time, from map[string]time.Time, since time.Time) {
+	for id, before := range from {
+		if before.After(since) && before.After(into[id]) {
+			into[id] = before
+		}
+	}
+}
+
+// apply records a revocation unless a later one is known.
+func (r *Revocations) apply(entry revocation) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	entries := r.users
+	if entry.Kind == RevokeSession {
+		entries = r.sessions
+	}
+	if entry.Before.After(entries[entry.ID]) {
+		entries[entry.ID] = entry.Before
+	}
+}
+
+// listen applies announced revocations until ctx is cancelled, reconnecting
+// when the connection drops. Revocations are reloaded on every connect, so
+// none announced while disconnected are missed.
+func (r *Revocations) listen(ctx context.Context, logger lib.Logger) {
+	for {
+		err := r.listenOnce(ctx)
+		if ctx.Err() != nil {
+			return
+		}
+		logger.Error(fmt.Sprintf("Session revocation listener stopped, reconnecting: %v", err))
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(listenRetryDelay):
+		}
+	}
+}
+
+func (r *Revocations) listenOnce(ctx context.Context) error {
+	conn, err := pgx.Connect(ctx, r.listenURL)
+	if err != nil {
+		return err
+	}
+	defer conn.Close(context.Background())
+
+	if _, err := conn.Exec(ctx, "LISTEN "+revocationChannel); err != nil {
+		return err
+	}
+	if err := r.Reload(ctx); err != nil {
+		return err
+	}
+
+	for {
+		notification, err := conn.WaitForNotification(ctx)
+		if err != nil {
+			return err
+		}
+		var entry revocation
+		if err := json.Unmarshal([]byte(notification.Payload), &entry); err != nil {
+			continue
+		}
+		r.apply(entry)
+	}
+}
+
+// RegisterRevocationListener loads the revocations when the app starts and
+// keeps them in sync for its lifetime.
+func RegisterRevocationListener(lifecycle fx.Lifecycle, revocations *Revocations, logger lib.Logger) {
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+
+	lifecycle.Append(fx.Hook{
+		OnStart: func(startCtx context.Context) error {
+			// Until a load succeeds, authenticated requests get a 503
+			if err := revocations.Reload(startCtx); err != nil {
+				logger.Error(fmt.Sprintf("Failed to load session revocations: %v", err))
+			}
+			go func() {
+				defer close(done)
+				revocations.listen(ctx, logger)
+			}()
+			return nil
+		},
+		OnStop: func(stopCtx context.Context) error {
+			cancel()
+			select {
+			case <-done:
+			case <-stopCtx.Done():
+			}
+			return nil
+		},
+	})
+
+	lib.RunPeriodically(lifecycle, revocationResyncInterval, func(ctx context.Context) {
+		if err := revocations.Reload(ctx); err != nil {
+			logger.Error(fmt.Sprintf("Failed to reload session revocations: %v", err))
+		}
+	})
+}
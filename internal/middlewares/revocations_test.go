@@ -0,0 +1,86 @@
+package middlewares
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// loadedRevocations returns revocations as if loaded from an empty table.
+func loadedRevocations() *Revocations {
+	return &Revocations{
+		retention: DefaultRevocationRetention,
+		loaded:    true,
+		users:     map[string]time.Time{},
+		sessions:  map[string]time.Time{},
+	}
+}
+
+func TestRevocations_Revoked(t *testing.T) {
+	now := time.Now()
+
+	_, err := (&Revocations{}).Revoked("user-1", "", now)
+	assert.ErrorIs(t, err, errRevocationsNotLoaded)
+
+	r := loadedRevocations()
+	r.apply(revocation{Kind: RevokeUser, ID: "user-1", Before: now})
+	r.apply(revocation{Kind: RevokeSession, ID: "session-1", Before: now})
+	// An older revocation arriving late does not undo a newer one
+	r.apply(revocation{Kind: RevokeUser, ID: "user-1", Before: now.Add(-time.Hour)})
+
+	cases := []struct {
+		user, session string
+		issuedAt      time.Time
+		revoked       bool
+	}{
+		{"user-1", "", now.Add(-time.Minute), true},
+		{"user-1", "", now.Add(time.Second), false},
+		{"user-2", "session-1", now.Add(-time.Minute), true},
+		{"user-2", "session-2", now.Add(-time.Minute), false},
+		{"user-2", "", time.Time{}, false},
+	}
+	for _, tc := range cases {
+		revoked, err := r.Revoked(tc.user, tc.session, tc.issuedAt)
+		require.NoError(t, err)
+		assert.Equal(t, tc.revoked, revoked, "%s/%s", tc.user, tc.session)
+	}
+}
+
+func TestMerge(t *testing.T) {
+	now := time.Now()
+	since := now.Add(-time.Hour)
+
+	into := map[string]time.Time{"a": now.Add(-time.Minute)}
+	merge(into, map[string]time.Time{
+		"a": now,                     // newer than the stored one
+		"b": now.Add(-time.Minute),   // applied while loading
+		"c": now.Add(-2 * time.Hour), // past the retention
+	}, since)
+
+	assert.Equal(t, map[string]time.Time{"a": now, "b": now.Add(-time.Minute)}, into)
+}
+
+func TestCheckRevocation(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	r := loadedRevocations()
+	r.apply(revocation{Kind: RevokeUser, ID: "user-1", Before: time.Now()})
+	m := AuthMiddleware{revocations: r}
+
+	c, w := newTestContext()
+	assert.False(t, m.checkRevocation(c, Principal{UserID: "user-1", IssuedAt: time.Now().Add(-time.Minute)}))
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+	assert.Contains(t, w.Body.String(), "Session revoked")
+
+	c, _ = newTestContext()
+	assert.True(t, m.checkRevocation(c, Principal{UserID: "user-1", IssuedAt: time.Now().Add(time.Minute)}))
+
+	m = AuthMiddleware{revocations: &Revocations{}}
+	c, w = newTestContext()
+	assert.False(t, m.checkRevocation(c, Principal{UserID: "user-1"}))
+	assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+}
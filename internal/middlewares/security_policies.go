@@ -0,0 +1,192 @@
+package middlewares
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/netip"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// Codes of requests turned away by a security policy, in the code field of
+// the error body.
+const (
+	CodeMFARequired   = "mfa_required"
+	CodeIPNotAllowed  = "ip_not_allowed"
+	CodeSessionTooOld = "session_too_old"
+)
+
+// securityPolicyTTL bounds how long a policy is served from memory. Changes
+// made by this instance invalidate the entry right away.
+const securityPolicyTTL = 30 * time.Second
+
+// SecurityPolicy restricts the requests of an organization's members. The
+// zero value restricts nothing.
+type SecurityPolicy struct {
+	// RequireMFA admits only sessions with a second factor (aal2).
+	RequireMFA bool
+	// AllowedNetworks are the ranges requests, including those made with
+	// API keys, must come from. Empty allows any address.
+	AllowedNetworks []netip.Prefix
+	// MaxSessionAge turns away tokens issued longer ago. Zero is no limit.
+	MaxSessionAge time.Duration
+}
+
+// policyViolation is why a policy turned a request away.
+type policyViolation struct {
+	status  int
+	code    string
+	title   string
+	message string
+}
+
+// check returns the first rule the request breaks, or nil. MFA and session
+// age only apply to user tokens.
+func (p SecurityPolicy) check(principal Principal, clientIP string, now time.Time) *policyViolation {
+	if len(p.AllowedNetworks) > 0 && !p.Allows(clientIP) {
+		return &policyViolation{
+			status:  http.StatusForbidden,
+			code:    CodeIPNotAllowed,
+			title:   "IP address not allowed",
+			message: "The organization only admits requests from its allowed networks",
+		}
+	}
+	if principal.APIKeyID != 0 {
+		return nil
+	}
+	if p.RequireMFA && principal.AAL != "aal2" {
+		return &policyViolation{
+			status:  http.StatusForbidden,
+			code:    CodeMFARequired,
+			title:   "MFA required",
+			message: "The organization requires signing in with a second factor",
+		}
+	}
+	if p.MaxSessionAge > 0 && now.Sub(principal.IssuedAt) > p.MaxSessionAge {
+		return &policyViolation{
+			status:  http.StatusUnauthorized,
+			code:    CodeSessionTooOld,
+			title:   "Session too old",
+			message: "The organization limits the session age; sign in again",
+		}
+	}
+	return nil
+}
+
+// Allows reports whether the address is in an allowed network.
+func (p SecurityPolicy) Allows(clientIP string) bool {
+	addr, err := netip.ParseAddr(clientIP)
+	if err != nil {
+		return false
+	}
+	addr = addr.Unmap()
+	for _, network := range p.AllowedNetworks {
+		if network.Contains(addr) {
+			return true
+		}
+	}
+	return false
+}
+
+type policyEntry struct {
+	policy  SecurityPolicy
+	expires time.Time
+}
+
+// SecurityPolicies looks up the security policies of organizations for the
+// auth middleware with a short-lived in-memory cache.
+type SecurityPolicies struct {
+	db      *pgxpool.Pool
+	mu      sync.Mutex
+	entries map[int64]policyEntry
+}
+
+func NewSecurityPolicies(db *pgxpool.Pool) *SecurityPolicies {
+	return &SecurityPolicies{
+		db:      db,
+		entries: map[int64]policyEntry{},
+	}
+}
+
+// Get returns the policy of the organization.
+func (s *SecurityPolicies) Get(ctx context.Context, orgID int64) (SecurityPolicy, error) {
+	s.mu.Lock()
+	entry, ok := s.entries[orgID]
+	s.mu.Unlock()
+	if ok && time.Now().Before(entry.expires) {
+		return entry.policy, nil
+	}
+
+	var policy SecurityPolicy
+	var networks []string
+	var maxAgeMinutes *int
+	err := s.db.QueryRow(ctx, `
+        SELECT require_mfa, allowed_networks, max_session_age_minutes
+        FROM organization_security_policies
+        WHERE organization_id = $1`,
+		orgID,
+	).Scan(&policy.RequireMFA, &networks, &maxAgeMinutes)
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		return SecurityPolicy{}, err
+	}
+	for _, network := range networks {
+		prefix, err := netip.ParsePrefix(network)
+		if err != nil {
+			return SecurityPolicy{}, fmt.Errorf("organization %d: allowed network %q: %w", orgID, network, err)
+		}
+		policy.AllowedNetworks = append(policy.AllowedNetworks, prefix)
+	}
+	if maxAgeMinutes != nil {
+		policy.MaxSessionAge = time.Duration(*maxAgeMinutes) * time.Minute
+	}
+
+	s.mu.Lock()
+	s.entries[orgID] = policyEntry{policy: policy, expires: time.Now().Add(securityPolicyTTL)}
+	s.mu.Unlock()
+	return policy, nil
+}
+
+// Invalidate drops the cached policy after the organization changed it.
+func (s *SecurityPolicies) Invalidate(orgID int64) {
+	s.mu.Lock()
+	delete(s.entries, orgID)
+	s.mu.Unlock()
+}
+
+// checkSecurityPolicy aborts the request and returns false when it breaks
+// the policy of the principal's organization. Requests outside an
+// organization are not restricted.
+func (m AuthMiddleware) checkSecurityPolicy(c *gin.Context, principal Principal) bool {
+	if !principal.HasOrganization() {
+		return true
+	}
+
+	policy, err := m.securityPolicies.Get(c.Request.Context(), principal.OrganizationID)
+	if err != nil {
+		m.logger.Error(fmt.Sprintf("Failed to load security policy: %v", err))
+		c.AbortWithStatusJSON(http.StatusServiceUnavailable, authError{
+			Error: "Failed to verify security policy",
+		})
+		return false
+	}
+
+	violation := policy.check(principal, c.ClientIP(), time.Now())
+	if violation == nil {
+		return true
+	}
+	if violation.status == http.StatusUnauthorized {
+		c.Header("WWW-Authenticate", `Bearer realm="profile-service"`)
+	}
+	c.AbortWithStatusJSON(violation.status, authError{
+		Error:   violation.title,
+		Message: violation.message,
+		Code:    violation.code,
+	})
+	return false
+}
@@ -0,0 +1,107 @@
+package middlewares
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+// loadedPolicies returns policies served from memory for the organizations.
+func loadedPolicies(policies map[int64]SecurityPolicy) *SecurityPolicies {
+	s := NewSecurityPolicies(nil)
+	expires := time.Now().Add(time.Minute)
+	for orgID, policy := range policies {
+		s.entries[orgID] = policyEntry{policy: policy, expires: expires}
+	}
+	return s
+}
+
+func TestSecurityPolicy_Check(t *testing.T) {
+	now := time.Now()
+	office := []netip.Prefix{netip.MustParsePrefix("203.0.113.0/24"), netip.MustParsePrefix("2001:db8::/32")}
+	fresh := Principal{UserID: "user-1", OrganizationID: 1, AAL: "aal2", IssuedAt: now.Add(-time.Minute)}
+
+	cases := []struct {
+		name      string
+		policy    SecurityPolicy
+		principal Principal
+		ip        string
+		code      string
+	}{
+		{"no policy", SecurityPolicy{}, Principal{UserID: "user-1"}, "198.51.100.7", ""},
+		{"allowed network", SecurityPolicy{AllowedNetworks: office}, fresh, "203.0.113.9", ""},
+		{"mapped IPv4", SecurityPolicy{AllowedNetworks: office}, fresh, "::ffff:203.0.113.9", ""},
+		{"allowed IPv6", SecurityPolicy{AllowedNetworks: office}, fresh, "2001:db8::1", ""},
+		{"other network", SecurityPolicy{AllowedNetworks: office}, fresh, "198.51.100.7", CodeIPNotAllowed},
+		{"API key outside", SecurityPolicy{AllowedNetworks: office}, Principal{APIKeyID: 5}, "198.51.100.7", CodeIPNotAllowed},
+		{"aal1", SecurityPolicy{RequireMFA: true}, Principal{UserID: "user-1", AAL: "aal1"}, "198.51.100.7", CodeMFARequired},
+		{"aal2", SecurityPolicy{RequireMFA: true}, fresh, "198.51.100.7", ""},
+		{"API key without MFA", SecurityPolicy{RequireMFA: true, MaxSessionAge: time.Hour}, Principal{APIKeyID: 5}, "198.51.100.7", ""},
+		{"old token", SecurityPolicy{MaxSessionAge: time.Hour}, Principal{UserID: "user-1", IssuedAt: now.Add(-2 * time.Hour)}, "198.51.100.7", CodeSessionTooOld},
+		{"no iat", SecurityPolicy{MaxSessionAge: time.Hour}, Principal{UserID: "user-1"}, "198.51.100.7", CodeSessionTooOld},
+	}
+	for _, tc := range cases {
+		violation := tc.policy.check(tc.principal, tc.ip, now)
+		if tc.code == "" {
+			assert.Nil(t, violation, tc.name)
+			continue
+		}
+		if assert.NotNil(t, violation, tc.name) {
+			assert.Equal(t, tc.code, violation.code, tc.name)
+		}
+	}
+}
+
+func TestCheckSecurityPolicy(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	m := AuthMiddleware{securityPolicies: loadedPolicies(map[int64]SecurityPolicy{
+		1: {RequireMFA: true},
+	})}
+
+	c, w := newTestContext()
+	assert.False(t, m.checkSecurityPolicy(c, Principal{UserID: "user-1", OrganizationID: 1, AAL: "aal1"}))
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	assert.Contains(t, w.Body.String(), `"code":"mfa_required"`)
+
+	// Identity routes of users without an organization are not restricted
+	c, _ = newTestContext()
+	assert.True(t, m.checkSecurityPolicy(c, Principal{UserID: "user-2"}))
+
+	m.securityPolicies.Invalidate(1)
+	assert.Empty(t, m.securityPolicies.entries)
+}
+
+func TestCheckSecurityPolicy_ClientIP(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	m := AuthMiddleware{securityPolicies: loadedPolicies(map[int64]SecurityPolicy{
+		1: {AllowedNetworks: []netip.Prefix{netip.MustParsePrefix("203.0.113.0/24")}},
+	})}
+
+	r := gin.New()
+	r.GET("/", func(c *gin.Context) {
+		if m.checkSecurityPolicy(c, Principal{UserID: "user-1", OrganizationID: 1}) {
+			c.Status(http.StatusOK)
+		}
+	})
+
+	// Without trusted proxies, X-Forwarded-For is ignored
+	r.SetTrustedProxies(nil)
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.RemoteAddr = "198.51.100.7:4242"
+	req.Header.Set("X-Forwarded-For", "203.0.113.9")
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	assert.Contains(t, w.Body.String(), `"code":"ip_not_allowed"`)
+
+	// Behind a trusted proxy, the forwarded address counts
+	r.SetTrustedProxies([]string{"198.51.100.0/24"})
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+}
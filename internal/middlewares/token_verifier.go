@@ -0,0 +1,179 @@
+package middlewares
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"hostflow/profile-service/pkg/lib"
+
+	"github.com/MicahParks/keyfunc/v3"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const (
+	// DefaultTokenAudience is the audience Supabase puts in user tokens.
+	DefaultTokenAudience = "authenticated"
+
+	// DefaultTokenLeeway is the clock skew tolerated on exp, nbf and iat,
+	// unless JWT_LEEWAY says otherwise.
+	DefaultTokenLeeway = 5 * time.Minute
+
+	// minSecretLength is the shortest HS256 secret accepted (256 bits).
+	minSecretLength = 32
+)
+
+// supportedAlgorithms are the signing algorithms a verifier can be set up
+// to accept.
+var supportedAlgorithms = map[string]bool{"ES256": true, "RS256": true, "HS256": true}
+
+// TokenConfig describes which user tokens are accepted.
+type TokenConfig struct {
+	// JWKSURL serves the public keys of asymmetric algorithms (ES256, RS256).
+	JWKSURL string
+	// Secret is the shared key of HS256, used by legacy Supabase projects.
+	Secret []byte
+	// Issuer and Audience must match the iss and aud claims.
+	Issuer   string
+	Audience string
+	// Algorithms lists the accepted signing algorithms.
+	Algorithms []string
+	// Leeway is the clock skew tolerated on time based claims.
+	Leeway time.Duration
+}
+
+// LoadTokenConfig reads the token configuration from the environment.
+// The JWKS URL and issuer default to the Supabase project at SUPABASE_URL,
+// and the algorithms to ES256 and RS256 when keys are published, plus HS256
+// when JWT_SECRET is set.
+func LoadTokenConfig() (TokenConfig, error) {
+	supabaseURL := strings.TrimRight(os.Getenv("SUPABASE_URL"), "/")
+
+	config := TokenConfig{
+		JWKSURL:  os.Getenv("JWT_JWKS_URL"),
+		Secret:   []byte(os.Getenv("JWT_SECRET")),
+		Issuer:   os.Getenv("JWT_ISSUER"),
+		Audience: os.Getenv("JWT_AUDIENCE"),
+	}
+	if config.JWKSURL == "" && supabaseURL != "" {
+		config.JWKSURL = supabaseURL + "/auth/v1/.well-known/jwks.json"
+	}
+	if config.Issuer == "" && supabaseURL != "" {
+		config.Issuer = supabaseURL + "/auth/v1"
+	}
+	if config.Audience == "" {
+		config.Audience = DefaultTokenAudience
+	}
+
+	if value := os.Getenv("JWT_ALGORITHMS"); value != "" {
+		for _, algorithm := range strings.Split(value, ",") {
+			if algorithm = strings.ToUpper(strings.TrimSpace(algorithm)); algorithm != "" {
+				config.Algorithms = append(config.Algorithms, algorithm)
+			}
+		}
+	} else {
+		if config.JWKSURL != "" {
+			config.Algorithms = append(config.Algorithms, "ES256", "RS256")
+		}
+		if len(config.Secret) > 0 {
+			config.Algorithms = append(config.Algorithms, "HS256")
+		}
+	}
+
+	leeway, err := lib.DurationFromEnv("JWT_LEEWAY", DefaultTokenLeeway)
+	if err != nil {
+		return TokenConfig{}, err
+	}
+	config.Leeway = leeway
+
+	return config, nil
+}
+
+// TokenVerifier checks the signature and registered claims of user tokens.
+type TokenVerifier struct {
+	parser  *jwt.Parser
+	keyfunc jwt.Keyfunc
+}
+
+// NewTokenVerifier builds the verifier from the environment. It fails when
+// the configuration is incomplete, so a misconfigured service does not
+// start.
+func NewTokenVerifier() (*TokenVerifier, error) {
+	config, err := LoadTokenConfig()
+	if err != nil {
+		return nil, err
+	}
+	return NewTokenVerifierWithConfig(config)
+}
+
+// NewTokenVerifierWithConfig builds a verifier for the given configuration.
+func NewTokenVerifierWithConfig(config TokenConfig) (*TokenVerifier, error) {
+	if len(config.Algorithms) == 0 {
+		return nil, errors.New("jwt: no signing algorithms configured; set SUPABASE_URL, JWT_JWKS_URL or JWT_SECRET")
+	}
+	if config.Issuer == "" {
+		return nil, errors.New("jwt: no issuer configured; set JWT_ISSUER or SUPABASE_URL")
+	}
+	if config.Audience == "" {
+		return nil, errors.New("jwt: no audience configured")
+	}
+
+	var symmetric, asymmetric bool
+	for _, algorithm := range config.Algorithms {
+		if !supportedAlgorithms[algorithm] {
+			return nil, fmt.Errorf("jwt: unsupported signing algorithm %q", algorithm)
+		}
+		if algorithm == "HS256" {
+			symmetric = true
+		} else {
+			asymmetric = true
+		}
+	}
+	if symmetric && len(config.Secret) < minSecretLength {
+		return nil, fmt.Errorf("jwt: HS256 needs JWT_SECRET of at least %d bytes", minSecretLength)
+	}
+
+	var keys keyfunc.Keyfunc
+	if asymmetric {
+		if config.JWKSURL == "" {
+			return nil, errors.New("jwt: ES256 and RS256 need JWT_JWKS_URL or SUPABASE_URL")
+		}
+		var err error
+		keys, err = keyfunc.NewDefault([]string{config.JWKSURL})
+		if err != nil {
+			return nil, fmt.Errorf("jwt: failed to set up JWKS from %s: %w", config.JWKSURL, err)
+		}
+	}
+
+	secret := config.Secret
+	return &TokenVerifier{
+		parser: jwt.NewParser(
+			jwt.WithValidMethods(config.Algorithms),
+			jwt.WithIssuer(config.Issuer),
+			jwt.WithAudience(config.Audience),
+			jwt.WithLeeway(config.Leeway),
+			jwt.WithExpirationRequired(),
+			jwt.WithIssuedAt(),
+		),
+		// The parser has already checked the algorithm is one of the
+		// configured ones, so each key type is only reached when enabled
+		keyfunc: func(token *jwt.Token) (interface{}, error) {
+			if _, ok := token.Method.(*jwt.SigningMethodHMAC); ok {
+				return secret, nil
+			}
+			return keys.Keyfunc(token)
+		},
+	}, nil
+}
+
+// Verify parses the token and returns its claims when the signature, issuer,
+// audience and validity period check out.
+func (v *TokenVerifier) Verify(tokenString string) (jwt.MapClaims, error) {
+	claims := jwt.MapClaims{}
+	if _, err := v.parser.ParseWithClaims(tokenString, claims, v.keyfunc); err != nil {
+		return nil, err
+	}
+	return claims, nil
+}
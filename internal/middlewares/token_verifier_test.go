@@ -0,0 +1,155 @@
+package middlewares
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testSecret = "0123456789abcdef0123456789abcdef"
+
+func testClaims() jwt.MapClaims {
+	now := time.Now()
+	return jwt.MapClaims{
+		"sub": "user-1",
+		"iss": "https://project.supabase.co/auth/v1",
+		"aud": "authenticated",
+		"iat": now.Unix(),
+		"exp": now.Add(time.Hour).Unix(),
+	}
+}
+
+func hmacConfig() TokenConfig {
+	return TokenConfig{
+		Secret:     []byte(testSecret),
+		Issuer:     "https://project.supabase.co/auth/v1",
+		Audience:   "authenticated",
+		Algorithms: []string{"HS256"},
+		Leeway:     time.Minute,
+	}
+}
+
+func signHMAC(t *testing.T, claims jwt.MapClaims) string {
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte(testSecret))
+	require.NoError(t, err)
+	return token
+}
+
+func TestTokenVerifier_HS256(t *testing.T) {
+	verifier, err := NewTokenVerifierWithConfig(hmacConfig())
+	require.NoError(t, err)
+
+	claims, err := verifier.Verify(signHMAC(t, testClaims()))
+	assert.NoError(t, err)
+	assert.Equal(t, "user-1", claims["sub"])
+
+	cases := map[string]func(jwt.MapClaims){
+		"issuer":    func(c jwt.MapClaims) { c["iss"] = "https://other.supabase.co/auth/v1" },
+		"audience":  func(c jwt.MapClaims) { c["aud"] = "anon" },
+		"expired":   func(c jwt.MapClaims) { c["exp"] = time.Now().Add(-2 * time.Minute).Unix() },
+		"no expiry": func(c jwt.MapClaims) { delete(c, "exp") },
+		"not yet":   func(c jwt.MapClaims) { c["nbf"] = time.Now().Add(2 * time.Minute).Unix() },
+	}
+	for name, mutate := range cases {
+		claims := testClaims()
+		mutate(claims)
+		_, err := verifier.Verify(signHMAC(t, claims))
+		assert.Error(t, err, name)
+	}
+
+	// Within the leeway
+	claims = testClaims()
+	claims["exp"] = time.Now().Add(-30 * time.Second).Unix()
+	_, err = verifier.Verify(signHMAC(t, claims))
+	assert.NoError(t, err)
+}
+
+func TestTokenVerifier_ES256FromJWKS(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	encode := func(b []byte) string { return base64.RawURLEncoding.EncodeToString(b) }
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"keys": []map[string]string{{
+				"kty": "EC",
+				"crv": "P-256",
+				"kid": "key-1",
+				"alg": "ES256",
+				"use": "sig",
+				"x":   encode(key.PublicKey.X.FillBytes(make([]byte, 32))),
+				"y":   encode(key.PublicKey.Y.FillBytes(make([]byte, 32))),
+			}},
+		})
+	}))
+	defer server.Close()
+
+	config := hmacConfig()
+	config.JWKSURL = server.URL
+	config.Algorithms = []string{"ES256"}
+	verifier, err := NewTokenVerifierWithConfig(config)
+	require.NoError(t, err)
+
+	token := jwt.NewWithClaims(jwt.SigningMethodES256, testClaims())
+	token.Header["kid"] = "key-1"
+	signed, err := token.SignedString(key)
+	require.NoError(t, err)
+
+	_, err = verifier.Verify(signed)
+	assert.NoError(t, err)
+
+	// HS256 is not enabled, even though the secret is known
+	_, err = verifier.Verify(signHMAC(t, testClaims()))
+	assert.Error(t, err)
+}
+
+func TestNewTokenVerifierWithConfig_Invalid(t *testing.T) {
+	cases := map[string]func(*TokenConfig){
+		"no algorithms":  func(c *TokenConfig) { c.Algorithms = nil },
+		"unknown":        func(c *TokenConfig) { c.Algorithms = []string{"none"} },
+		"no issuer":      func(c *TokenConfig) { c.Issuer = "" },
+		"short secret":   func(c *TokenConfig) { c.Secret = []byte("secret") },
+		"no JWKS for ES": func(c *TokenConfig) { c.Algorithms = []string{"ES256"} },
+		"no JWKS for RS": func(c *TokenConfig) { c.Algorithms = []string{"RS256", "HS256"} },
+		"invalid JWKS":   func(c *TokenConfig) { c.Algorithms = []string{"ES256"}; c.JWKSURL = "::" },
+	}
+	for name, mutate := range cases {
+		config := hmacConfig()
+		mutate(&config)
+		_, err := NewTokenVerifierWithConfig(config)
+		assert.Error(t, err, name)
+	}
+}
+
+func TestLoadTokenConfig_Defaults(t *testing.T) {
+	t.Setenv("SUPABASE_URL", "https://project.supabase.co/")
+	t.Setenv("JWT_JWKS_URL", "")
+	t.Setenv("JWT_ISSUER", "")
+	t.Setenv("JWT_AUDIENCE", "")
+	t.Setenv("JWT_ALGORITHMS", "")
+	t.Setenv("JWT_SECRET", testSecret)
+	t.Setenv("JWT_LEEWAY", "")
+
+	config, err := LoadTokenConfig()
+	require.NoError(t, err)
+	assert.Equal(t, "https://project.supabase.co/auth/v1/.well-known/jwks.json", config.JWKSURL)
+	assert.Equal(t, "https://project.supabase.co/auth/v1", config.Issuer)
+	assert.Equal(t, DefaultTokenAudience, config.Audience)
+	assert.Equal(t, []string{"ES256", "RS256", "HS256"}, config.Algorithms)
+	assert.Equal(t, 5*time.Minute, config.Leeway)
+
+	t.Setenv("JWT_ALGORITHMS", "rs256, ")
+	config, err = LoadTokenConfig()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"RS256"}, config.Algorithms)
+}
@@ -0,0 +1,16 @@
+package organization
+
+import "go.uber.org/fx"
+
+// ======== EXPORTS ========
+
+// Module exports services present
+var Context = fx.Options(
+	fx.Provide(GetOrganizationController),
+	fx.Provide(fx.Annotate(
+		GetOrganizationService,
+		fx.As(new(Service)),
+	)),
+	fx.Provide(GetOrganizationRepository),
+	fx.Provide(SetOrganizationRoutes),
+)
@@ -0,0 +1,116 @@
+package organization
+
+import (
+	"errors"
+	"net/http"
+
+	"hostflow/profile-service/internal/middlewares"
+	"hostflow/profile-service/pkg/common"
+
+	"github.com/gin-gonic/gin"
+)
+
+type OrganizationController struct {
+	service Service
+}
+
+func GetOrganizationController(service Service) *OrganizationController {
+	return &OrganizationController{
+		service: service,
+	}
+}
+
+// GetOrganizationHandler godoc
+// @Summary Get organization
+// @Description Returns the organization associated with the current user's token.
+// @Tags organization
+// @Produce json
+// @Security ApiKeyAuth
+// @Success 200 {object} Organization
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /organization [get]
+func (c *OrganizationController) GetOrganizationHandler(ctx *gin.Context) {
+	principal := middlewares.GetPrincipal(ctx)
+
+	org, err := c.service.Get(ctx.Request.Context(), principal.OrganizationID)
+	if err != nil {
+		respondError(ctx, "Failed to fetch organization", err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, org)
+}
+
+// UpdateOrganizationHandler godoc
+// @Summary Update organization
+// @Description Partially updates the requester's organization. Omitted fields are left unchanged. Requires OWNER role.
+// @Tags organization
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param body body UpdateOrganizationRequest true "Fields to update"
+// @Success 200 {object} Organization
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Router /organization [patch]
+func (c *OrganizationController) UpdateOrganizationHandler(ctx *gin.Context) {
+	principal := middlewares.GetPrincipal(ctx)
+
+	var body UpdateOrganizationRequest
+	if errors := common.Validation.ValidateBody(ctx, &body); errors != nil {
+		ctx.JSON(http.StatusBadRequest, errors)
+		return
+	}
+
+	org, err := c.service.Update(ctx.Request.Context(), principal.OrganizationID, principal.Role, body)
+	if err != nil {
+		respondError(ctx, "Failed to update organization", err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, org)
+}
+
+// GetOrgNameHandler godoc
+// @Summary Get organization name
+// @Description Returns the name of the organization associated with the current user's token
+// @Tags organization
+// @Produce json
+// @Security ApiKeyAuth
+// @Success 200 {object} map[string]string "Example: {"name": "My Org"}"
+// @Failure 404 {object} ErrorResponse
+// @Router /organization/name [get]
+func (c *OrganizationController) GetOrgNameHandler(ctx *gin.Context) {
+	principal := middlewares.GetPrincipal(ctx)
+
+	org, err := c.service.Get(ctx.Request.Context(), principal.OrganizationID)
+	if err != nil {
+		respondError(ctx, "Organization not found", err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"name": org.Name})
+}
+
+// respondError maps service errors to HTTP status codes.
+func respondError(ctx *gin.Context, title string, err error) {
+	status := http.StatusInternalServerError
+	switch {
+	case errors.Is(err, ErrInvalidInput):
+		status = http.StatusBadRequest
+	case errors.Is(err, ErrForbidden):
+		status = http.StatusForbidden
+	case errors.Is(err, ErrOrganizationNotFound):
+		status = http.StatusNotFound
+	case errors.Is(err, ErrSlugTaken):
+		status = http.StatusConflict
+	}
+
+	ctx.JSON(status, ErrorResponse{
+		Error:   title,
+		Message: err.Error(),
+	})
+}
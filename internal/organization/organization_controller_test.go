@@ -0,0 +1,94 @@
+package organization
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"hostflow/profile-service/internal/middlewares"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockOrganizationService struct {
+	mock.Mock
+}
+
+func (m *MockOrganizationService) Get(ctx context.Context, orgID int64) (*Organization, error) {
+	args := m.Called(ctx, orgID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*Organization), args.Error(1)
+}
+
+func (m *MockOrganizationService) Update(ctx context.Context, orgID int64, role string, req UpdateOrganizationRequest) (*Organization, error) {
+	args := m.Called(ctx, orgID, role, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*Organization), args.Error(1)
+}
+
+func TestGetOrgNameHandler_Success(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	mockSvc := new(MockOrganizationService)
+	controller := GetOrganizationController(mockSvc)
+
+	r := gin.New()
+	r.GET("/organization/name", func(c *gin.Context) {
+		middlewares.SetPrincipal(c, middlewares.Principal{OrganizationID: 1})
+		controller.GetOrgNameHandler(c)
+	})
+
+	mockSvc.On("Get", mock.Anything, int64(1)).Return(&Organization{ID: 1, Name: "Vila Bled"}, nil)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/organization/name", nil)
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{"name":"Vila Bled"}`, w.Body.String())
+}
+
+func TestUpdateOrganizationHandler_Forbidden(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	mockSvc := new(MockOrganizationService)
+	controller := GetOrganizationController(mockSvc)
+
+	r := gin.New()
+	r.PATCH("/organization", func(c *gin.Context) {
+		middlewares.SetPrincipal(c, middlewares.Principal{OrganizationID: 1, Role: "MANAGER"})
+		controller.UpdateOrganizationHandler(c)
+	})
+
+	name := "New name"
+	mockSvc.On("Update", mock.Anything, int64(1), "MANAGER", UpdateOrganizationRequest{Name: &name}).Return(nil, ErrForbidden)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("PATCH", "/organization", strings.NewReader(`{"name":"New name"}`))
+	req.Header.Set("Content-Type", "application/json")
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusForbidden, w.Code)
+}
+
+func TestUpdateOrganizationHandler_InvalidEmail(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	controller := GetOrganizationController(new(MockOrganizationService))
+
+	r := gin.New()
+	r.PATCH("/organization", controller.UpdateOrganizationHandler)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("PATCH", "/organization", strings.NewReader(`{"contact_email":"not-an-email"}`))
+	req.Header.Set("Content-Type", "application/json")
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), "contact_email")
+}
@@ -0,0 +1,44 @@
+package organization
+
+// Organization is a tenant of the platform.
+type Organization struct {
+	ID            int64    `json:"id" db:"id"`
+	Name          string   `json:"name" db:"name"`
+	Slug          *string  `json:"slug" db:"slug" example:"vila-bled"`
+	LegalName     *string  `json:"legal_name" db:"legal_name" example:"Vila Bled d.o.o."`
+	Address       *Address `json:"address" db:"address"`
+	ContactEmail  *string  `json:"contact_email" db:"contact_email" example:"info@vilabled.si"`
+	Timezone      string   `json:"timezone" db:"timezone" example:"Europe/Ljubljana"`
+	DefaultLocale string   `json:"default_locale" db:"default_locale" example:"sl-SI"`
+	Currency      string   `json:"currency" db:"currency" example:"EUR"`
+}
+
+// Address is the postal address of an organization. Country is an
+// ISO 3166-1 alpha-2 code.
+type Address struct {
+	Line1      string `json:"line1" binding:"max=200" example:"Cesta svobode 26"`
+	Line2      string `json:"line2,omitempty" binding:"max=200"`
+	PostalCode string `json:"postal_code" binding:"max=20" example:"4260"`
+	City       string `json:"city" binding:"max=100" example:"Bled"`
+	Country    string `json:"country" binding:"max=2" example:"SI"`
+}
+
+// UpdateOrganizationRequest is a partial update: omitted fields are left
+// unchanged, and an empty string clears the optional ones (slug, legal
+// name, contact email). An address with every field empty clears it.
+type UpdateOrganizationRequest struct {
+	Name          *string  `json:"name" binding:"omitempty,max=200"`
+	Slug          *string  `json:"slug" binding:"omitempty,max=63"`
+	LegalName     *string  `json:"legal_name" binding:"omitempty,max=200"`
+	Address       *Address `json:"address"`
+	ContactEmail  *string  `json:"contact_email" binding:"omitempty,email"`
+	Timezone      *string  `json:"timezone"`
+	DefaultLocale *string  `json:"default_locale"`
+	Currency      *string  `json:"currency"`
+}
+
+// ErrorResponse represents an error response
+type ErrorResponse struct {
+	Error   string `json:"error" example:"Invalid request"`
+	Message string `json:"message,omitempty" example:"The provided data is invalid"`
+}
@@ -0,0 +1,117 @@
+package organization
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+const organizationColumns = `id, name, slug, legal_name, address, contact_email, timezone, default_locale, currency`
+
+type OrganizationRepository struct {
+	db *pgxpool.Pool
+}
+
+func GetOrganizationRepository(db *pgxpool.Pool) *OrganizationRepository {
+	return &OrganizationRepository{
+		db: db,
+	}
+}
+
+// GetByID returns an organization, or nil if it does not exist.
+func (r *OrganizationRepository) GetByID(ctx context.Context, id int64) (*Organization, error) {
+	rows, err := r.db.Query(ctx, `SELECT `+organizationColumns+` FROM organization WHERE id = $1`, id)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	org, err := pgx.CollectOneRow(rows, pgx.RowToStructByName[Organization])
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &org, nil
+}
+
+// Update applies the fields present in a normalized request. Empty
+// optional values are stored as NULL. Returns nil if the organization
+// does not exist.
+func (r *OrganizationRepository) Update(ctx context.Context, id int64, req UpdateOrganizationRequest) (*Organization, error) {
+	sets := []string{}
+	args := []any{id}
+
+	set := func(column string, value any) {
+		args = append(args, value)
+		sets = append(sets, fmt.Sprintf("%s = $%d", column, len(args)))
+	}
+
+	if req.Name != nil {
+		set("name", *req.Name)
+	}
+	if req.Slug != nil {
+		set("slug", nullIfEmpty(*req.Slug))
+	}
+	if req.LegalName != nil {
+		set("legal_name", nullIfEmpty(*req.LegalName))
+	}
+	if req.Address != nil {
+		if req.Address.IsEmpty() {
+			set("address", nil)
+		} else {
+			set("address", req.Address)
+		}
+	}
+	if req.ContactEmail != nil {
+		set("contact_email", nullIfEmpty(*req.ContactEmail))
+	}
+	if req.Timezone != nil {
+		set("timezone", *req.Timezone)
+	}
+	if req.DefaultLocale != nil {
+		set("default_locale", *req.DefaultLocale)
+	}
+	if req.Currency != nil {
+		set("currency", *req.Currency)
+	}
+
+	if len(sets) == 0 {
+		return r.GetByID(ctx, id)
+	}
+
+	query := `UPDATE organization SET ` + strings.Join(sets, ", ") + ` WHERE id = $1 RETURNING ` + organizationColumns
+
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	org, err := pgx.CollectOneRow(rows, pgx.RowToStructByName[Organization])
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+			return nil, ErrSlugTaken
+		}
+		return nil, err
+	}
+	return &org, nil
+}
+
+// nullIfEmpty maps an empty string to SQL NULL.
+func nullIfEmpty(value string) *string {
+	if value == "" {
+		return nil
+	}
+	return &value
+}
@@ -0,0 +1,41 @@
+package organization
+
+import (
+	"hostflow/profile-service/internal/middlewares"
+	"hostflow/profile-service/pkg/lib"
+)
+
+type OrganizationRoutes struct {
+	logger                 lib.Logger
+	router                 *lib.Router
+	organizationController *OrganizationController
+	authMiddleware         middlewares.AuthMiddleware
+}
+
+func SetOrganizationRoutes(
+	logger lib.Logger,
+	router *lib.Router,
+	organizationController *OrganizationController,
+	authMiddleware middlewares.AuthMiddleware,
+) OrganizationRoutes {
+	return OrganizationRoutes{
+		logger:                 logger,
+		router:                 router,
+		organizationController: organizationController,
+		authMiddleware:         authMiddleware,
+	}
+}
+
+func (route OrganizationRoutes) Setup() {
+	route.logger.Info("Setting up [ORGANIZATION] routes.")
+
+	organizations := route.router.Group("/organization")
+	organizations.Use(route.authMiddleware.Handler())
+	{
+		organizations.GET("", route.organizationController.GetOrganizationHandler)
+		organizations.PATCH("", route.organizationController.UpdateOrganizationHandler)
+		organizations.GET("/name", route.organizationController.GetOrgNameHandler)
+	}
+
+	route.logger.Info("[ORGANIZATION] routes setup complete.")
+}
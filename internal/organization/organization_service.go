@@ -0,0 +1,60 @@
+package organization
+
+import (
+	"context"
+	"errors"
+)
+
+var (
+	ErrOrganizationNotFound = errors.New("organization not found")
+	ErrSlugTaken            = errors.New("this slug is already taken")
+	ErrForbidden            = errors.New("you are not allowed to perform this action")
+	ErrInvalidInput         = errors.New("invalid input")
+)
+
+type OrganizationService struct {
+	repo *OrganizationRepository
+}
+
+type Service interface {
+	Get(ctx context.Context, orgID int64) (*Organization, error)
+	Update(ctx context.Context, orgID int64, role string, req UpdateOrganizationRequest) (*Organization, error)
+}
+
+func GetOrganizationService(repo *OrganizationRepository) *OrganizationService {
+	return &OrganizationService{
+		repo: repo,
+	}
+}
+
+// Get returns the requester's organization.
+func (s *OrganizationService) Get(ctx context.Context, orgID int64) (*Organization, error) {
+	org, err := s.repo.GetByID(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+	if org == nil {
+		return nil, ErrOrganizationNotFound
+	}
+	return org, nil
+}
+
+// Update applies a partial update to the requester's organization.
+// Requires OWNER role.
+func (s *OrganizationService) Update(ctx context.Context, orgID int64, role string, req UpdateOrganizationRequest) (*Organization, error) {
+	if role != "OWNER" {
+		return nil, ErrForbidden
+	}
+	if err := req.Normalize(); err != nil {
+		return nil, err
+	}
+
+	org, err := s.repo.Update(ctx, orgID, req)
+	if err != nil {
+		return nil, err
+	}
+	if org == nil {
+		return nil, ErrOrganizationNotFound
+	}
+	return org, nil
+}
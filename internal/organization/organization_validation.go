@@ -0,0 +1,124 @@
+package organization
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"golang.org/x/text/currency"
+	"golang.org/x/text/language"
+)
+
+const (
+	// MinSlugLength and MaxSlugLength bound the length of a slug.
+	MinSlugLength = 3
+	MaxSlugLength = 63
+)
+
+// slugPattern allows lowercase letters and digits in groups separated by
+// single hyphens, e.g. "vila-bled".
+var slugPattern = regexp.MustCompile(`^[a-z0-9]+(-[a-z0-9]+)*$`)
+
+// IsEmpty reports whether every field of the address is blank.
+func (a Address) IsEmpty() bool {
+	return a.Line1 == "" && a.Line2 == "" && a.PostalCode == "" && a.City == "" && a.Country == ""
+}
+
+// Normalize trims and canonicalizes the fields present in the request and
+// validates them. Locales are returned as canonical BCP 47 tags and
+// currencies as upper-case ISO 4217 codes.
+func (req *UpdateOrganizationRequest) Normalize() error {
+	if req.Name != nil {
+		*req.Name = strings.TrimSpace(*req.Name)
+		if *req.Name == "" {
+			return fmt.Errorf("%w: name must not be blank", ErrInvalidInput)
+		}
+	}
+
+	if req.Slug != nil {
+		*req.Slug = strings.ToLower(strings.TrimSpace(*req.Slug))
+		if err := validateSlug(*req.Slug); err != nil {
+			return err
+		}
+	}
+
+	if req.LegalName != nil {
+		*req.LegalName = strings.TrimSpace(*req.LegalName)
+	}
+
+	if req.ContactEmail != nil {
+		*req.ContactEmail = strings.ToLower(strings.TrimSpace(*req.ContactEmail))
+	}
+
+	if req.Address != nil {
+		if err := req.Address.Normalize(); err != nil {
+			return err
+		}
+	}
+
+	if req.Timezone != nil {
+		*req.Timezone = strings.TrimSpace(*req.Timezone)
+		if *req.Timezone == "" || *req.Timezone == "Local" {
+			return fmt.Errorf("%w: timezone must be an IANA timezone name", ErrInvalidInput)
+		}
+		if _, err := time.LoadLocation(*req.Timezone); err != nil {
+			return fmt.Errorf("%w: unknown timezone %q", ErrInvalidInput, *req.Timezone)
+		}
+	}
+
+	if req.DefaultLocale != nil {
+		tag, err := language.Parse(strings.TrimSpace(*req.DefaultLocale))
+		if err != nil || tag == language.Und {
+			return fmt.Errorf("%w: %q is not a valid BCP 47 locale", ErrInvalidInput, *req.DefaultLocale)
+		}
+		*req.DefaultLocale = tag.String()
+	}
+
+	if req.Currency != nil {
+		unit, err := currency.ParseISO(strings.TrimSpace(*req.Currency))
+		if err != nil {
+			return fmt.Errorf("%w: %q is not an ISO 4217 currency code", ErrInvalidInput, *req.Currency)
+		}
+		*req.Currency = unit.String()
+	}
+
+	return nil
+}
+
+// validateSlug accepts an empty slug (which clears it) or a well-formed one.
+func validateSlug(slug string) error {
+	if slug == "" {
+		return nil
+	}
+	if len(slug) < MinSlugLength || len(slug) > MaxSlugLength {
+		return fmt.Errorf("%w: slug must be between %d and %d characters", ErrInvalidInput, MinSlugLength, MaxSlugLength)
+	}
+	if !slugPattern.MatchString(slug) {
+		return fmt.Errorf("%w: slug may only contain lowercase letters, digits and single hyphens", ErrInvalidInput)
+	}
+	return nil
+}
+
+// Normalize trims the address and checks that a non-empty address has a
+// street, city and a valid country.
+func (a *Address) Normalize() error {
+	a.Line1 = strings.TrimSpace(a.Line1)
+	a.Line2 = strings.TrimSpace(a.Line2)
+	a.PostalCode = strings.TrimSpace(a.PostalCode)
+	a.City = strings.TrimSpace(a.City)
+	a.Country = strings.ToUpper(strings.TrimSpace(a.Country))
+
+	if a.IsEmpty() {
+		return nil
+	}
+	if a.Line1 == "" || a.City == "" || a.Country == "" {
+		return fmt.Errorf("%w: address requires line1, city and country", ErrInvalidInput)
+	}
+
+	region, err := language.ParseRegion(a.Country)
+	if err != nil || len(a.Country) != 2 || !region.IsCountry() {
+		return fmt.Errorf("%w: %q is not an ISO 3166-1 alpha-2 country code", ErrInvalidInput, a.Country)
+	}
+	return nil
+}
@@ -0,0 +1,64 @@
+package organization
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func ptr(value string) *string {
+	return &value
+}
+
+func TestNormalize_Canonicalizes(t *testing.T) {
+	req := UpdateOrganizationRequest{
+		Name:          ptr("  Vila Bled "),
+		Slug:          ptr(" Vila-Bled "),
+		ContactEmail:  ptr("Info@VilaBled.si"),
+		Address:       &Address{Line1: " Cesta svobode 26 ", City: "Bled", PostalCode: "4260", Country: "si"},
+		Timezone:      ptr("Europe/Ljubljana"),
+		DefaultLocale: ptr("sl-si"),
+		Currency:      ptr("eur"),
+	}
+
+	assert.NoError(t, req.Normalize())
+	assert.Equal(t, "Vila Bled", *req.Name)
+	assert.Equal(t, "vila-bled", *req.Slug)
+	assert.Equal(t, "info@vilabled.si", *req.ContactEmail)
+	assert.Equal(t, "Cesta svobode 26", req.Address.Line1)
+	assert.Equal(t, "SI", req.Address.Country)
+	assert.Equal(t, "sl-SI", *req.DefaultLocale)
+	assert.Equal(t, "EUR", *req.Currency)
+}
+
+func TestNormalize_ClearsOptionalFields(t *testing.T) {
+	req := UpdateOrganizationRequest{
+		Slug:    ptr(""),
+		Address: &Address{},
+	}
+
+	assert.NoError(t, req.Normalize())
+	assert.True(t, req.Address.IsEmpty())
+}
+
+func TestNormalize_Invalid(t *testing.T) {
+	cases := map[string]UpdateOrganizationRequest{
+		"blank name":       {Name: ptr("  ")},
+		"short slug":       {Slug: ptr("ab")},
+		"slug characters":  {Slug: ptr("vila_bled")},
+		"double hyphen":    {Slug: ptr("vila--bled")},
+		"unknown timezone": {Timezone: ptr("Europe/Atlantis")},
+		"local timezone":   {Timezone: ptr("Local")},
+		"locale":           {DefaultLocale: ptr("not a locale")},
+		"currency":         {Currency: ptr("EURO")},
+		"unknown currency": {Currency: ptr("XYZ")},
+		"partial address":  {Address: &Address{Line1: "Cesta svobode 26"}},
+		"country":          {Address: &Address{Line1: "Cesta svobode 26", City: "Bled", Country: "XX"}},
+	}
+
+	for name, req := range cases {
+		t.Run(name, func(t *testing.T) {
+			assert.ErrorIs(t, req.Normalize(), ErrInvalidInput)
+		})
+	}
+}
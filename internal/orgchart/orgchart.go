@@ -0,0 +1,16 @@
+package orgchart
+
+import "go.uber.org/fx"
+
+// ======== EXPORTS ========
+
+// Module exports services present
+var Context = fx.Options(
+	fx.Provide(GetOrgChartController),
+	fx.Provide(fx.Annotate(
+		GetOrgChartService,
+		fx.As(new(Service)),
+	)),
+	fx.Provide(GetOrgChartRepository),
+	fx.Provide(SetOrgChartRoutes),
+)
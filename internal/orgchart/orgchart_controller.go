@@ -0,0 +1,170 @@
+package orgchart
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"hostflow/profile-service/internal/middlewares"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type OrgChartController struct {
+	service Service
+}
+
+func GetOrgChartController(service Service) *OrgChartController {
+	return &OrgChartController{
+		service: service,
+	}
+}
+
+// SetManagerHandler godoc
+// @Summary Set a user's manager
+// @Description Sets or clears (manager_id null) the manager of a user. Rejects changes that would create a reporting cycle. Requires OWNER role.
+// @Tags org-chart
+// @Accept json
+// @Security ApiKeyAuth
+// @Param id path string true "User ID (UUID)"
+// @Param body body SetManagerRequest true "Manager"
+// @Success 204 "No Content"
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Router /users/{id}/manager [put]
+func (c *OrgChartController) SetManagerHandler(ctx *gin.Context) {
+	principal := middlewares.GetPrincipal(ctx)
+
+	profileID, ok := parseProfileID(ctx)
+	if !ok {
+		return
+	}
+
+	var body SetManagerRequest
+	if err := ctx.ShouldBindJSON(&body); err != nil {
+		ctx.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "Invalid request",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	err := c.service.SetManager(ctx.Request.Context(), profileID, body.ManagerID, principal.OrganizationID, principal.Role)
+	if err != nil {
+		respondError(ctx, "Failed to set manager", err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+// GetReportsHandler godoc
+// @Summary List a user's reports
+// @Description Returns the direct reports of a user, or everyone below them with transitive=true.
+// @Tags org-chart
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "User ID (UUID)"
+// @Param transitive query bool false "Include indirect reports"
+// @Success 200 {array} Report
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /users/{id}/reports [get]
+func (c *OrgChartController) GetReportsHandler(ctx *gin.Context) {
+	principal := middlewares.GetPrincipal(ctx)
+
+	profileID, ok := parseProfileID(ctx)
+	if !ok {
+		return
+	}
+
+	transitive, err := strconv.ParseBool(ctx.DefaultQuery("transitive", "false"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "Invalid query",
+			Message: "'transitive' must be true or false",
+		})
+		return
+	}
+
+	reports, err := c.service.GetReports(ctx.Request.Context(), profileID, principal.OrganizationID, transitive)
+	if err != nil {
+		respondError(ctx, "Failed to fetch reports", err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, reports)
+}
+
+// GetChartHandler godoc
+// @Summary Export the org chart
+// @Description Returns the organization's reporting lines as a JSON tree (default) or as a Graphviz DOT digraph with format=dot.
+// @Tags org-chart
+// @Produce json
+// @Produce text/vnd.graphviz
+// @Security ApiKeyAuth
+// @Param format query string false "json (default) or dot"
+// @Success 200 {array} Node
+// @Failure 400 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /organization/chart [get]
+func (c *OrgChartController) GetChartHandler(ctx *gin.Context) {
+	principal := middlewares.GetPrincipal(ctx)
+
+	format := ctx.DefaultQuery("format", "json")
+	if format != "json" && format != "dot" {
+		ctx.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "Invalid format",
+			Message: "'format' must be json or dot",
+		})
+		return
+	}
+
+	members, err := c.service.GetChart(ctx.Request.Context(), principal.OrganizationID)
+	if err != nil {
+		respondError(ctx, "Failed to build org chart", err)
+		return
+	}
+
+	if format == "dot" {
+		ctx.Data(http.StatusOK, "text/vnd.graphviz; charset=utf-8", []byte(RenderDOT(members)))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, BuildTree(members))
+}
+
+// parseProfileID reads the :id path parameter and aborts with 400 when it
+// is not a UUID.
+func parseProfileID(ctx *gin.Context) (uuid.UUID, bool) {
+	id, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "Invalid ID format",
+			Message: "ID must be a valid UUID",
+		})
+		return uuid.Nil, false
+	}
+	return id, true
+}
+
+// respondError maps service errors to HTTP status codes.
+func respondError(ctx *gin.Context, title string, err error) {
+	status := http.StatusInternalServerError
+	switch {
+	case errors.Is(err, ErrForbidden):
+		status = http.StatusForbidden
+	case errors.Is(err, ErrProfileNotFound), errors.Is(err, ErrManagerNotFound):
+		status = http.StatusNotFound
+	case errors.Is(err, ErrCycle):
+		status = http.StatusConflict
+	}
+
+	ctx.JSON(status, ErrorResponse{
+		Error:   title,
+		Message: err.Error(),
+	})
+}
@@ -0,0 +1,107 @@
+package orgchart
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"hostflow/profile-service/internal/middlewares"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockOrgChartService struct {
+	mock.Mock
+}
+
+func (m *MockOrgChartService) SetManager(ctx context.Context, profileID uuid.UUID, managerID *uuid.UUID, orgID int64, role string) error {
+	return m.Called(ctx, profileID, managerID, orgID, role).Error(0)
+}
+
+func (m *MockOrgChartService) GetReports(ctx context.Context, managerID uuid.UUID, orgID int64, transitive bool) ([]Report, error) {
+	args := m.Called(ctx, managerID, orgID, transitive)
+	return args.Get(0).([]Report), args.Error(1)
+}
+
+func (m *MockOrgChartService) GetChart(ctx context.Context, orgID int64) ([]Member, error) {
+	args := m.Called(ctx, orgID)
+	return args.Get(0).([]Member), args.Error(1)
+}
+
+func (m *MockOrgChartService) IsManagerOf(ctx context.Context, managerID uuid.UUID, profileID uuid.UUID, orgID int64) (bool, error) {
+	args := m.Called(ctx, managerID, profileID, orgID)
+	return args.Bool(0), args.Error(1)
+}
+
+func TestSetManagerHandler_Cycle(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	mockSvc := new(MockOrgChartService)
+	controller := GetOrgChartController(mockSvc)
+	profileID := uuid.New()
+	managerID := uuid.New()
+
+	r := gin.New()
+	r.PUT("/users/:id/manager", func(c *gin.Context) {
+		middlewares.SetPrincipal(c, middlewares.Principal{OrganizationID: 1, Role: "OWNER"})
+		controller.SetManagerHandler(c)
+	})
+
+	mockSvc.On("SetManager", mock.Anything, profileID, &managerID, int64(1), "OWNER").Return(ErrCycle)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("PUT", "/users/"+profileID.String()+"/manager", strings.NewReader(`{"manager_id":"`+managerID.String()+`"}`))
+	req.Header.Set("Content-Type", "application/json")
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusConflict, w.Code)
+}
+
+func TestGetReportsHandler_Transitive(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	mockSvc := new(MockOrgChartService)
+	controller := GetOrgChartController(mockSvc)
+	managerID := uuid.New()
+
+	r := gin.New()
+	r.GET("/users/:id/reports", func(c *gin.Context) {
+		middlewares.SetPrincipal(c, middlewares.Principal{OrganizationID: 1})
+		controller.GetReportsHandler(c)
+	})
+
+	mockSvc.On("GetReports", mock.Anything, managerID, int64(1), true).
+		Return([]Report{{Member: Member{Name: "Maja"}, Depth: 2}}, nil)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/users/"+managerID.String()+"/reports?transitive=true", nil)
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"depth":2`)
+}
+
+func TestGetChartHandler_Dot(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	mockSvc := new(MockOrgChartService)
+	controller := GetOrgChartController(mockSvc)
+
+	r := gin.New()
+	r.GET("/organization/chart", func(c *gin.Context) {
+		middlewares.SetPrincipal(c, middlewares.Principal{OrganizationID: 1})
+		controller.GetChartHandler(c)
+	})
+
+	mockSvc.On("GetChart", mock.Anything, int64(1)).Return([]Member{{ID: uuid.New(), Name: "Ana", Role: "OWNER"}}, nil)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/organization/chart?format=dot", nil)
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Header().Get("Content-Type"), "text/vnd.graphviz")
+	assert.Contains(t, w.Body.String(), "digraph orgchart")
+}
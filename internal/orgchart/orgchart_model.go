@@ -0,0 +1,38 @@
+package orgchart
+
+import (
+	"github.com/google/uuid"
+)
+
+// Member is a profile together with its position in the reporting lines.
+type Member struct {
+	ID        uuid.UUID  `json:"id" db:"id"`
+	Name      string     `json:"name" db:"full_name"`
+	Role      string     `json:"role" db:"role"`
+	Email     string     `json:"email" db:"email"`
+	Status    string     `json:"status" db:"status"`
+	ManagerID *uuid.UUID `json:"manager_id" db:"manager_id"`
+}
+
+// Report is a member below a manager. Depth is 1 for direct reports.
+type Report struct {
+	Member
+	Depth int `json:"depth" db:"depth"`
+}
+
+// Node is a member in the org chart tree.
+type Node struct {
+	Member
+	Reports []*Node `json:"reports"`
+}
+
+// SetManagerRequest assigns or clears (null) the manager of a profile.
+type SetManagerRequest struct {
+	ManagerID *uuid.UUID `json:"manager_id" swaggertype:"string" format:"uuid"`
+}
+
+// ErrorResponse represents an error response
+type ErrorResponse struct {
+	Error   string `json:"error" example:"Invalid request"`
+	Message string `json:"message,omitempty" example:"The provided data is invalid"`
+}
@@ -0,0 +1,144 @@
+package orgchart
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type OrgChartRepository struct {
+	db *pgxpool.Pool
+}
+
+func GetOrgChartRepository(db *pgxpool.Pool) *OrgChartRepository {
+	return &OrgChartRepository{
+		db: db,
+	}
+}
+
+// ProfileInOrganization reports whether the profile belongs to the organization.
+func (r *OrgChartRepository) ProfileInOrganization(ctx context.Context, profileID uuid.UUID, orgID int64) (bool, error) {
+	var exists bool
+	query := `SELECT EXISTS (SELECT 1 FROM "profiles" WHERE id = $1 AND organization_id = $2)`
+
+	if err := r.db.QueryRow(ctx, query, profileID, orgID).Scan(&exists); err != nil {
+		return false, err
+	}
+	return exists, nil
+}
+
+// SetManager sets or clears the manager of a profile. Writes within an
+// organization are serialized by locking its row so two concurrent changes
+// cannot together close a loop; ErrCycle is returned when the new manager
+// already reports (transitively) to the profile.
+func (r *OrgChartRepository) SetManager(ctx context.Context, profileID uuid.UUID, managerID *uuid.UUID, orgID int64) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	if _, err := tx.Exec(ctx, `SELECT 1 FROM organization WHERE id = $1 FOR NO KEY UPDATE`, orgID); err != nil {
+		return err
+	}
+
+	if managerID != nil {
+		var cycle bool
+		err := tx.QueryRow(ctx, `
+            WITH RECURSIVE chain AS (
+                SELECT id, manager_id FROM "profiles" WHERE id = $1
+                UNION
+                SELECT p.id, p.manager_id
+                FROM "profiles" p
+                JOIN chain c ON p.id = c.manager_id
+            )
+            SELECT EXISTS (SELECT 1 FROM chain WHERE id = $2)
+        `, *managerID, profileID).Scan(&cycle)
+		if err != nil {
+			return err
+		}
+		if cycle {
+			return ErrCycle
+		}
+	}
+
+	_, err = tx.Exec(ctx,
+		`UPDATE "profiles" SET manager_id = $1, updated_at = now() WHERE id = $2 AND organization_id = $3`,
+		managerID, profileID, orgID,
+	)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+// ListReports returns the profiles below a manager up to maxDepth levels,
+// ordered by depth and name.
+func (r *OrgChartRepository) ListReports(ctx context.Context, managerID uuid.UUID, orgID int64, maxDepth int) ([]Report, error) {
+	query := `
+        WITH RECURSIVE reports AS (
+            SELECT id, full_name, role, email, status, manager_id, 1 AS depth
+            FROM "profiles"
+            WHERE manager_id = $1 AND organization_id = $2
+            UNION ALL
+            SELECT p.id, p.full_name, p.role, p.email, p.status, p.manager_id, r.depth + 1
+            FROM "profiles" p
+            JOIN reports r ON p.manager_id = r.id
+            WHERE p.organization_id = $2 AND r.depth < $3
+        )
+        SELECT id, full_name, role, email, status, manager_id, depth
+        FROM reports
+        ORDER BY depth, full_name
+    `
+
+	rows, err := r.db.Query(ctx, query, managerID, orgID, maxDepth)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return pgx.CollectRows(rows, pgx.RowToStructByName[Report])
+}
+
+// ListMembers returns every profile of an organization with its manager.
+func (r *OrgChartRepository) ListMembers(ctx context.Context, orgID int64) ([]Member, error) {
+	query := `
+        SELECT id, full_name, role, email, status, manager_id
+        FROM "profiles"
+        WHERE organization_id = $1
+        ORDER BY full_name
+    `
+
+	rows, err := r.db.Query(ctx, query, orgID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return pgx.CollectRows(rows, pgx.RowToStructByName[Member])
+}
+
+// IsManagerOf reports whether managerID is above profileID in the
+// reporting lines, directly or transitively.
+func (r *OrgChartRepository) IsManagerOf(ctx context.Context, managerID uuid.UUID, profileID uuid.UUID, orgID int64) (bool, error) {
+	var exists bool
+	query := `
+        WITH RECURSIVE chain AS (
+            SELECT manager_id FROM "profiles" WHERE id = $2 AND organization_id = $3
+            UNION
+            SELECT p.manager_id
+            FROM "profiles" p
+            JOIN chain c ON p.id = c.manager_id
+            WHERE p.organization_id = $3
+        )
+        SELECT EXISTS (SELECT 1 FROM chain WHERE manager_id = $1)
+    `
+
+	if err := r.db.QueryRow(ctx, query, managerID, profileID, orgID).Scan(&exists); err != nil {
+		return false, err
+	}
+	return exists, nil
+}
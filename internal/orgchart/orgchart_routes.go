@@ -0,0 +1,46 @@
+package orgchart
+
+import (
+	"hostflow/profile-service/internal/middlewares"
+	"hostflow/profile-service/pkg/lib"
+)
+
+type OrgChartRoutes struct {
+	logger             lib.Logger
+	router             *lib.Router
+	orgChartController *OrgChartController
+	authMiddleware     middlewares.AuthMiddleware
+}
+
+func SetOrgChartRoutes(
+	logger lib.Logger,
+	router *lib.Router,
+	orgChartController *OrgChartController,
+	authMiddleware middlewares.AuthMiddleware,
+) OrgChartRoutes {
+	return OrgChartRoutes{
+		logger:             logger,
+		router:             router,
+		orgChartController: orgChartController,
+		authMiddleware:     authMiddleware,
+	}
+}
+
+func (route OrgChartRoutes) Setup() {
+	route.logger.Info("Setting up [ORGCHART] routes.")
+
+	users := route.router.Group("/users/:id")
+	users.Use(route.authMiddleware.Handler())
+	{
+		users.PUT("/manager", route.orgChartController.SetManagerHandler)
+		users.GET("/reports", route.orgChartController.GetReportsHandler)
+	}
+
+	organizations := route.router.Group("/organization")
+	organizations.Use(route.authMiddleware.Handler())
+	{
+		organizations.GET("/chart", route.orgChartController.GetChartHandler)
+	}
+
+	route.logger.Info("[ORGCHART] routes setup complete.")
+}
@@ -0,0 +1,111 @@
+package orgchart
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+)
+
+// MaxDepth bounds how far transitive report lookups descend.
+const MaxDepth = 32
+
+var (
+	ErrProfileNotFound = errors.New("profile not found in your organization")
+	ErrManagerNotFound = errors.New("manager not found in your organization")
+	ErrCycle           = errors.New("this change would create a reporting cycle")
+	ErrForbidden       = errors.New("you are not allowed to perform this action")
+)
+
+type OrgChartService struct {
+	repo *OrgChartRepository
+}
+
+type Service interface {
+	SetManager(ctx context.Context, profileID uuid.UUID, managerID *uuid.UUID, orgID int64, role string) error
+	GetReports(ctx context.Context, managerID uuid.UUID, orgID int64, transitive bool) ([]Report, error)
+	GetChart(ctx context.Context, orgID int64) ([]Member, error)
+	IsManagerOf(ctx context.Context, managerID uuid.UUID, profileID uuid.UUID, orgID int64) (bool, error)
+}
+
+func GetOrgChartService(repo *OrgChartRepository) *OrgChartService {
+	return &OrgChartService{
+		repo: repo,
+	}
+}
+
+// SetManager sets or clears (nil) the manager of a profile. Requires OWNER role.
+func (s *OrgChartService) SetManager(ctx context.Context, profileID uuid.UUID, managerID *uuid.UUID, orgID int64, role string) error {
+	if role != "OWNER" {
+		return ErrForbidden
+	}
+	if managerID != nil && *managerID == profileID {
+		return ErrCycle
+	}
+
+	if err := s.ensureMember(ctx, profileID, orgID, ErrProfileNotFound); err != nil {
+		return err
+	}
+	if managerID != nil {
+		if err := s.ensureMember(ctx, *managerID, orgID, ErrManagerNotFound); err != nil {
+			return err
+		}
+	}
+
+	return s.repo.SetManager(ctx, profileID, managerID, orgID)
+}
+
+// GetReports returns the direct reports of a manager, or everyone below
+// them when transitive is set.
+func (s *OrgChartService) GetReports(ctx context.Context, managerID uuid.UUID, orgID int64, transitive bool) ([]Report, error) {
+	if err := s.ensureMember(ctx, managerID, orgID, ErrProfileNotFound); err != nil {
+		return nil, err
+	}
+
+	depth := 1
+	if transitive {
+		depth = MaxDepth
+	}
+
+	reports, err := s.repo.ListReports(ctx, managerID, orgID, depth)
+	if err != nil {
+		return nil, err
+	}
+	if reports == nil {
+		return []Report{}, nil
+	}
+	return reports, nil
+}
+
+// GetChart returns every member of the organization with their manager.
+func (s *OrgChartService) GetChart(ctx context.Context, orgID int64) ([]Member, error) {
+	members, err := s.repo.ListMembers(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+	if members == nil {
+		return []Member{}, nil
+	}
+	return members, nil
+}
+
+// IsManagerOf reports whether managerID is above profileID in the
+// organization's reporting lines.
+func (s *OrgChartService) IsManagerOf(ctx context.Context, managerID uuid.UUID, profileID uuid.UUID, orgID int64) (bool, error) {
+	if managerID == profileID {
+		return false, nil
+	}
+	return s.repo.IsManagerOf(ctx, managerID, profileID, orgID)
+}
+
+// ensureMember checks that the profile belongs to the requester's organization.
+func (s *OrgChartService) ensureMember(ctx context.Context, profileID uuid.UUID, orgID int64, notFound error) error {
+	ok, err := s.repo.ProfileInOrganization(ctx, profileID, orgID)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return notFound
+	}
+	return nil
+}
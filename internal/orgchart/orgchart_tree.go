@@ -0,0 +1,105 @@
+package orgchart
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// BuildTree arranges members into a forest. Members without a manager, or
+// whose manager is not part of the list, become roots. Siblings are sorted
+// by name. A member is never emitted twice, so even corrupted data with a
+// cycle cannot produce an infinitely deep tree.
+func BuildTree(members []Member) []*Node {
+	sorted := sortedByName(members)
+
+	known := make(map[uuid.UUID]bool, len(sorted))
+	for _, member := range sorted {
+		known[member.ID] = true
+	}
+
+	children := make(map[uuid.UUID][]Member)
+	roots := []Member{}
+	for _, member := range sorted {
+		if member.ManagerID == nil || !known[*member.ManagerID] {
+			roots = append(roots, member)
+			continue
+		}
+		children[*member.ManagerID] = append(children[*member.ManagerID], member)
+	}
+
+	visited := make(map[uuid.UUID]bool, len(sorted))
+	var build func(member Member) *Node
+	build = func(member Member) *Node {
+		visited[member.ID] = true
+		node := &Node{Member: member, Reports: []*Node{}}
+		for _, child := range children[member.ID] {
+			if !visited[child.ID] {
+				node.Reports = append(node.Reports, build(child))
+			}
+		}
+		return node
+	}
+
+	forest := []*Node{}
+	for _, root := range roots {
+		forest = append(forest, build(root))
+	}
+	// Members stuck in a cycle are unreachable from any root
+	for _, member := range sorted {
+		if !visited[member.ID] {
+			forest = append(forest, build(member))
+		}
+	}
+
+	return forest
+}
+
+// RenderDOT renders the reporting lines as a Graphviz digraph with an edge
+// from every manager to each of their direct reports.
+func RenderDOT(members []Member) string {
+	sorted := sortedByName(members)
+
+	known := make(map[uuid.UUID]bool, len(sorted))
+	for _, member := range sorted {
+		known[member.ID] = true
+	}
+
+	var b strings.Builder
+	b.WriteString("digraph orgchart {\n")
+	b.WriteString("  rankdir=TB;\n")
+	b.WriteString("  node [shape=box];\n")
+
+	for _, member := range sorted {
+		fmt.Fprintf(&b, "  %s [label=%s];\n", quoteDOT(member.ID.String()), quoteDOT(member.Name+"\n"+member.Role))
+	}
+	for _, member := range sorted {
+		if member.ManagerID != nil && known[*member.ManagerID] {
+			fmt.Fprintf(&b, "  %s -> %s;\n", quoteDOT(member.ManagerID.String()), quoteDOT(member.ID.String()))
+		}
+	}
+
+	b.WriteString("}\n")
+	return b.String()
+}
+
+// quoteDOT returns a double-quoted DOT identifier.
+func quoteDOT(value string) string {
+	replacer := strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+	return `"` + replacer.Replace(value) + `"`
+}
+
+// sortedByName returns a copy of the members ordered by name, then ID.
+func sortedByName(members []Member) []Member {
+	sorted := make([]Member, len(members))
+	copy(sorted, members)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if sorted[i].Name != sorted[j].Name {
+			return sorted[i].Name < sorted[j].Name
+		}
+		return sorted[i].ID.String() < sorted[j].ID.String()
+	})
+	return sorted
+}
@@ -0,0 +1,64 @@
+package orgchart
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func member(name string, manager *Member) Member {
+	m := Member{ID: uuid.New(), Name: name, Role: "MEMBER"}
+	if manager != nil {
+		m.ManagerID = &manager.ID
+	}
+	return m
+}
+
+func TestBuildTree(t *testing.T) {
+	owner := member("Ana", nil)
+	lead := member("Bojan", &owner)
+	cleaner := member("Cene", &lead)
+	other := member("Alja", &lead)
+	orphan := member("Zala", &Member{ID: uuid.New()})
+
+	forest := BuildTree([]Member{cleaner, orphan, lead, owner, other})
+
+	assert.Len(t, forest, 2)
+	assert.Equal(t, "Ana", forest[0].Name)
+	assert.Equal(t, "Zala", forest[1].Name)
+	assert.Len(t, forest[0].Reports, 1)
+
+	bojan := forest[0].Reports[0]
+	assert.Equal(t, "Bojan", bojan.Name)
+	assert.Equal(t, "Alja", bojan.Reports[0].Name)
+	assert.Equal(t, "Cene", bojan.Reports[1].Name)
+	assert.Empty(t, bojan.Reports[0].Reports)
+}
+
+func TestBuildTree_CycleIsBroken(t *testing.T) {
+	a := member("A", nil)
+	b := member("B", &a)
+	a.ManagerID = &b.ID
+
+	forest := BuildTree([]Member{a, b})
+
+	assert.Len(t, forest, 1)
+	assert.Equal(t, "A", forest[0].Name)
+	assert.Equal(t, "B", forest[0].Reports[0].Name)
+	assert.Empty(t, forest[0].Reports[0].Reports)
+}
+
+func TestRenderDOT(t *testing.T) {
+	owner := member(`Ana "Boss"`, nil)
+	owner.Role = "OWNER"
+	report := member("Bojan", &owner)
+
+	dot := RenderDOT([]Member{report, owner})
+
+	assert.True(t, strings.HasPrefix(dot, "digraph orgchart {\n"))
+	assert.Contains(t, dot, `"`+owner.ID.String()+`" [label="Ana \"Boss\"\nOWNER"];`)
+	assert.Contains(t, dot, `"`+owner.ID.String()+`" -> "`+report.ID.String()+`";`)
+	assert.True(t, strings.HasSuffix(dot, "}\n"))
+}
@@ -0,0 +1,16 @@
+package plans
+
+import "go.uber.org/fx"
+
+// ======== EXPORTS ========
+
+// Module exports services present
+var Context = fx.Options(
+	fx.Provide(GetPlansController),
+	fx.Provide(fx.Annotate(
+		GetPlansService,
+		fx.As(new(Service)),
+	)),
+	fx.Provide(GetPlansRepository),
+	fx.Provide(SetPlansRoutes),
+)
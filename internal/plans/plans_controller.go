@@ -0,0 +1,132 @@
+package plans
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"hostflow/profile-service/internal/middlewares"
+	"hostflow/profile-service/pkg/common"
+
+	"github.com/gin-gonic/gin"
+)
+
+type PlansController struct {
+	service Service
+}
+
+func GetPlansController(service Service) *PlansController {
+	return &PlansController{
+		service: service,
+	}
+}
+
+// GetUsageHandler godoc
+// @Summary Get plan usage
+// @Description Returns the organization's plan with seats used versus allowed (null means unlimited).
+// @Tags plans
+// @Produce json
+// @Security ApiKeyAuth
+// @Success 200 {object} Usage
+// @Failure 404 {object} ErrorResponse
+// @Router /organization/usage [get]
+func (c *PlansController) GetUsageHandler(ctx *gin.Context) {
+	principal := middlewares.GetPrincipal(ctx)
+
+	usage, err := c.service.GetUsage(ctx.Request.Context(), principal.OrganizationID)
+	if err != nil {
+		respondError(ctx, "Failed to fetch usage", err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, usage)
+}
+
+// GetInternalEntitlementsHandler godoc
+// @Summary Get plan entitlements (internal)
+// @Description Returns the plan of an organization. For other services only; requires a service token with the plans:read scope.
+// @Tags internal
+// @Produce json
+// @Param id path int true "Organization ID"
+// @Success 200 {object} Entitlements
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /internal/organizations/{id}/plan [get]
+func (c *PlansController) GetInternalEntitlementsHandler(ctx *gin.Context) {
+	orgID, ok := parseOrganizationID(ctx)
+	if !ok {
+		return
+	}
+
+	entitlements, err := c.service.GetEntitlements(ctx.Request.Context(), orgID)
+	if err != nil {
+		respondError(ctx, "Failed to fetch plan", err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, entitlements)
+}
+
+// UpdateInternalPlanHandler godoc
+// @Summary Set plan (internal)
+// @Description Replaces the plan and entitlements of an organization. Called by the billing service; requires a service token with the plans:write scope.
+// @Tags internal
+// @Accept json
+// @Produce json
+// @Param id path int true "Organization ID"
+// @Param body body UpdatePlanRequest true "Plan"
+// @Success 200 {object} Entitlements
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /internal/organizations/{id}/plan [put]
+func (c *PlansController) UpdateInternalPlanHandler(ctx *gin.Context) {
+	orgID, ok := parseOrganizationID(ctx)
+	if !ok {
+		return
+	}
+
+	var body UpdatePlanRequest
+	if errors := common.Validation.ValidateBody(ctx, &body); errors != nil {
+		ctx.JSON(http.StatusBadRequest, errors)
+		return
+	}
+
+	entitlements, err := c.service.UpdatePlan(ctx.Request.Context(), orgID, body)
+	if err != nil {
+		respondError(ctx, "Failed to update plan", err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, entitlements)
+}
+
+// parseOrganizationID reads the numeric :id path parameter.
+func parseOrganizationID(ctx *gin.Context) (int64, bool) {
+	id, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "Invalid ID format",
+			Message: "Organization ID must be numeric",
+		})
+		return 0, false
+	}
+	return id, true
+}
+
+// respondError maps service errors to HTTP status codes.
+func respondError(ctx *gin.Context, title string, err error) {
+	status := http.StatusInternalServerError
+	switch {
+	case errors.Is(err, ErrInvalidInput):
+		status = http.StatusBadRequest
+	case errors.Is(err, ErrOrganizationNotFound):
+		status = http.StatusNotFound
+	}
+
+	ctx.JSON(status, ErrorResponse{
+		Error:   title,
+		Message: err.Error(),
+	})
+}
@@ -0,0 +1,84 @@
+package plans
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"hostflow/profile-service/internal/middlewares"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockPlansService struct {
+	mock.Mock
+}
+
+func (m *MockPlansService) GetUsage(ctx context.Context, orgID int64) (*Usage, error) {
+	args := m.Called(ctx, orgID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*Usage), args.Error(1)
+}
+
+func (m *MockPlansService) GetEntitlements(ctx context.Context, orgID int64) (*Entitlements, error) {
+	args := m.Called(ctx, orgID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*Entitlements), args.Error(1)
+}
+
+func (m *MockPlansService) UpdatePlan(ctx context.Context, orgID int64, req UpdatePlanRequest) (*Entitlements, error) {
+	args := m.Called(ctx, orgID, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*Entitlements), args.Error(1)
+}
+
+func TestGetUsageHandler(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	mockSvc := new(MockPlansService)
+	controller := GetPlansController(mockSvc)
+
+	r := gin.New()
+	r.GET("/organization/usage", func(c *gin.Context) {
+		middlewares.SetPrincipal(c, middlewares.Principal{OrganizationID: 1})
+		controller.GetUsageHandler(c)
+	})
+
+	allowed := 5
+	mockSvc.On("GetUsage", mock.Anything, int64(1)).
+		Return(&Usage{Plan: "starter", Seats: Seats{Used: 3, Allowed: &allowed}, Features: []string{}}, nil)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/organization/usage", nil)
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"seats":{"used":3,"allowed":5}`)
+}
+
+func TestUpdateInternalPlanHandler_NotFound(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	mockSvc := new(MockPlansService)
+	controller := GetPlansController(mockSvc)
+
+	r := gin.New()
+	r.PUT("/internal/organizations/:id/plan", controller.UpdateInternalPlanHandler)
+
+	mockSvc.On("UpdatePlan", mock.Anything, int64(9), UpdatePlanRequest{Plan: "pro"}).Return(nil, ErrOrganizationNotFound)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("PUT", "/internal/organizations/9/plan", bytes.NewBufferString(`{"plan":"pro"}`))
+	req.Header.Set("Content-Type", "application/json")
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
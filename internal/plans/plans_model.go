@@ -0,0 +1,56 @@
+package plans
+
+import (
+	"time"
+)
+
+// Entitlements is what the organization's subscription plan allows. Nil
+// limits mean unlimited.
+type Entitlements struct {
+	OrganizationID int64      `json:"organization_id" db:"id"`
+	Plan           string     `json:"plan" db:"plan" example:"pro"`
+	MaxMembers     *int       `json:"max_members" db:"max_members" example:"25"`
+	MaxProperties  *int       `json:"max_properties" db:"max_properties" example:"50"`
+	Features       []string   `json:"features" db:"features"`
+	UpdatedAt      *time.Time `json:"updated_at" db:"plan_updated_at"`
+}
+
+// Seats compares used seats with the plan's limit.
+type Seats struct {
+	Used    int  `json:"used"`
+	Allowed *int `json:"allowed"`
+}
+
+// Usage is the organization's consumption of its plan.
+type Usage struct {
+	Plan          string   `json:"plan"`
+	Seats         Seats    `json:"seats"`
+	MaxProperties *int     `json:"max_properties"`
+	Features      []string `json:"features"`
+}
+
+// UpdatePlanRequest replaces the plan of an organization. Omitted limits
+// mean unlimited.
+type UpdatePlanRequest struct {
+	Plan          string   `json:"plan" binding:"required" example:"pro"`
+	MaxMembers    *int     `json:"max_members" example:"25"`
+	MaxProperties *int     `json:"max_properties" example:"50"`
+	Features      []string `json:"features" example:"channel_manager,api_access"`
+}
+
+// ErrorResponse represents an error response
+type ErrorResponse struct {
+	Error   string `json:"error" example:"Invalid request"`
+	Message string `json:"message,omitempty" example:"The provided data is invalid"`
+}
+
+// SeatLimitResponse is returned with 402 Payment Required when the plan has
+// no free seat left.
+type SeatLimitResponse struct {
+	Error   string `json:"error" example:"Failed to activate member"`
+	Message string `json:"message" example:"the organization's plan has no free seats"`
+	Code    string `json:"code" example:"seat_limit_reached"`
+	Plan    string `json:"plan" example:"starter"`
+	Limit   int    `json:"limit" example:"5"`
+	Used    int    `json:"used" example:"5"`
+}
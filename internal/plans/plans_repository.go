@@ -0,0 +1,70 @@
+package plans
+
+import (
+	"context"
+	"errors"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+const entitlementColumns = `id, plan, max_members, max_properties, features, plan_updated_at`
+
+type PlansRepository struct {
+	db *pgxpool.Pool
+}
+
+func GetPlansRepository(db *pgxpool.Pool) *PlansRepository {
+	return &PlansRepository{
+		db: db,
+	}
+}
+
+// Get returns the entitlements of an organization.
+func (r *PlansRepository) Get(ctx context.Context, orgID int64) (*Entitlements, error) {
+	rows, err := r.db.Query(ctx, `SELECT `+entitlementColumns+` FROM organization WHERE id = $1`, orgID)
+	if err != nil {
+		return nil, err
+	}
+
+	entitlements, err := pgx.CollectOneRow(rows, pgx.RowToStructByName[Entitlements])
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrOrganizationNotFound
+		}
+		return nil, err
+	}
+	return &entitlements, nil
+}
+
+// Update replaces the plan of an organization.
+func (r *PlansRepository) Update(ctx context.Context, orgID int64, req UpdatePlanRequest) (*Entitlements, error) {
+	rows, err := r.db.Query(ctx, `
+        UPDATE organization
+        SET plan = $1, max_members = $2, max_properties = $3, features = $4, plan_updated_at = now()
+        WHERE id = $5
+        RETURNING `+entitlementColumns,
+		req.Plan, req.MaxMembers, req.MaxProperties, req.Features, orgID,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	entitlements, err := pgx.CollectOneRow(rows, pgx.RowToStructByName[Entitlements])
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrOrganizationNotFound
+		}
+		return nil, err
+	}
+	return &entitlements, nil
+}
+
+// CountSeats returns how many members occupy a seat.
+func (r *PlansRepository) CountSeats(ctx context.Context, orgID int64) (int, error) {
+	var used int
+	if err := r.db.QueryRow(ctx, seatsQuery, orgID).Scan(&used); err != nil {
+		return 0, err
+	}
+	return used, nil
+}
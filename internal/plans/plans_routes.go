@@ -0,0 +1,49 @@
+package plans
+
+import (
+	"hostflow/profile-service/internal/auth"
+	"hostflow/profile-service/internal/middlewares"
+	"hostflow/profile-service/pkg/lib"
+)
+
+type PlansRoutes struct {
+	logger             lib.Logger
+	router             *lib.Router
+	plansController    *PlansController
+	authMiddleware     middlewares.AuthMiddleware
+	internalMiddleware middlewares.InternalMiddleware
+}
+
+func SetPlansRoutes(
+	logger lib.Logger,
+	router *lib.Router,
+	plansController *PlansController,
+	authMiddleware middlewares.AuthMiddleware,
+	internalMiddleware middlewares.InternalMiddleware,
+) PlansRoutes {
+	return PlansRoutes{
+		logger:             logger,
+		router:             router,
+		plansController:    plansController,
+		authMiddleware:     authMiddleware,
+		internalMiddleware: internalMiddleware,
+	}
+}
+
+func (route PlansRoutes) Setup() {
+	route.logger.Info("Setting up [PLANS] routes.")
+
+	organizations := route.router.Group("/organization")
+	organizations.Use(route.authMiddleware.Handler())
+	{
+		organizations.GET("/usage", route.plansController.GetUsageHandler)
+	}
+
+	internal := route.router.Group("/internal")
+	{
+		internal.GET("/organizations/:id/plan", route.internalMiddleware.Handler(auth.ScopePlansRead), route.plansController.GetInternalEntitlementsHandler)
+		internal.PUT("/organizations/:id/plan", route.internalMiddleware.Handler(auth.ScopePlansWrite), route.plansController.UpdateInternalPlanHandler)
+	}
+
+	route.logger.Info("[PLANS] routes setup complete.")
+}
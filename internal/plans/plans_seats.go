@@ -0,0 +1,68 @@
+package plans
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// ErrSeatLimitReached is matched by every SeatLimitError.
+var ErrSeatLimitReached = errors.New("the organization's plan has no free seats")
+
+// SeatLimitError reports a seat limit with the numbers behind it.
+type SeatLimitError struct {
+	Plan  string
+	Limit int
+	Used  int
+}
+
+func (e *SeatLimitError) Error() string {
+	return fmt.Sprintf("%s (%d of %d used on plan %s)", ErrSeatLimitReached, e.Used, e.Limit, e.Plan)
+}
+
+func (e *SeatLimitError) Is(target error) bool {
+	return target == ErrSeatLimitReached
+}
+
+// NewSeatLimitResponse builds the 402 body for a seat limit error.
+func NewSeatLimitResponse(title string, err *SeatLimitError) SeatLimitResponse {
+	return SeatLimitResponse{
+		Error:   title,
+		Message: ErrSeatLimitReached.Error(),
+		Code:    "seat_limit_reached",
+		Plan:    err.Plan,
+		Limit:   err.Limit,
+		Used:    err.Used,
+	}
+}
+
+// seatsQuery counts members that occupy a seat.
+const seatsQuery = `SELECT count(*) FROM "profiles" WHERE organization_id = $1 AND status <> 'INACTIVE'`
+
+// ReserveSeat checks, inside the caller's transaction, that the organization
+// has a free seat for one more active member. The organization row is
+// locked until the transaction ends so concurrent additions cannot both
+// take the last seat; callers add or reactivate the member in the same
+// transaction.
+func ReserveSeat(ctx context.Context, tx pgx.Tx, orgID int64) error {
+	var plan string
+	var maxMembers *int
+	err := tx.QueryRow(ctx, `SELECT plan, max_members FROM organization WHERE id = $1 FOR UPDATE`, orgID).Scan(&plan, &maxMembers)
+	if err != nil {
+		return err
+	}
+	if maxMembers == nil {
+		return nil
+	}
+
+	var used int
+	if err := tx.QueryRow(ctx, seatsQuery, orgID).Scan(&used); err != nil {
+		return err
+	}
+	if used >= *maxMembers {
+		return &SeatLimitError{Plan: plan, Limit: *maxMembers, Used: used}
+	}
+	return nil
+}
@@ -0,0 +1,131 @@
+package plans
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"hostflow/profile-service/internal/audit"
+)
+
+var (
+	planPattern    = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]{0,31}$`)
+	featurePattern = regexp.MustCompile(`^[a-z][a-z0-9_]{0,63}$`)
+)
+
+var (
+	ErrOrganizationNotFound = errors.New("organization not found")
+	ErrInvalidInput         = errors.New("invalid input")
+)
+
+type PlansService struct {
+	repo  *PlansRepository
+	audit audit.Service
+}
+
+type Service interface {
+	GetUsage(ctx context.Context, orgID int64) (*Usage, error)
+	GetEntitlements(ctx context.Context, orgID int64) (*Entitlements, error)
+	UpdatePlan(ctx context.Context, orgID int64, req UpdatePlanRequest) (*Entitlements, error)
+}
+
+func GetPlansService(repo *PlansRepository, audit audit.Service) *PlansService {
+	return &PlansService{
+		repo:  repo,
+		audit: audit,
+	}
+}
+
+// GetUsage returns the seats used by the organization against its plan.
+func (s *PlansService) GetUsage(ctx context.Context, orgID int64) (*Usage, error) {
+	entitlements, err := s.repo.Get(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+
+	used, err := s.repo.CountSeats(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Usage{
+		Plan:          entitlements.Plan,
+		Seats:         Seats{Used: used, Allowed: entitlements.MaxMembers},
+		MaxProperties: entitlements.MaxProperties,
+		Features:      entitlements.Features,
+	}, nil
+}
+
+// GetEntitlements returns the plan of an organization. Used by other services.
+func (s *PlansService) GetEntitlements(ctx context.Context, orgID int64) (*Entitlements, error) {
+	return s.repo.Get(ctx, orgID)
+}
+
+// UpdatePlan replaces the plan of an organization. Lowering a limit below
+// current usage is allowed; existing members keep their seats but no new
+// ones can be added until usage drops.
+func (s *PlansService) UpdatePlan(ctx context.Context, orgID int64, req UpdatePlanRequest) (*Entitlements, error) {
+	if err := normalizePlan(&req); err != nil {
+		return nil, err
+	}
+
+	entitlements, err := s.repo.Update(ctx, orgID, req)
+	if err != nil {
+		return nil, err
+	}
+
+	details := map[string]interface{}{
+		"plan":           entitlements.Plan,
+		"max_members":    entitlements.MaxMembers,
+		"max_properties": entitlements.MaxProperties,
+		"features":       entitlements.Features,
+	}
+	targetID := strconv.FormatInt(orgID, 10)
+	err = s.audit.Record(ctx, audit.Entry{
+		OrganizationID: orgID,
+		Action:         "plan.updated",
+		TargetType:     "organization",
+		TargetID:       &targetID,
+		Details:        details,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return entitlements, nil
+}
+
+// normalizePlan validates the request and sorts and deduplicates features.
+func normalizePlan(req *UpdatePlanRequest) error {
+	req.Plan = strings.ToLower(strings.TrimSpace(req.Plan))
+	if !planPattern.MatchString(req.Plan) {
+		return fmt.Errorf("%w: plan must be a short lowercase identifier", ErrInvalidInput)
+	}
+	if req.MaxMembers != nil && *req.MaxMembers < 0 {
+		return fmt.Errorf("%w: max_members must not be negative", ErrInvalidInput)
+	}
+	if req.MaxProperties != nil && *req.MaxProperties < 0 {
+		return fmt.Errorf("%w: max_properties must not be negative", ErrInvalidInput)
+	}
+
+	seen := map[string]bool{}
+	features := []string{}
+	for _, feature := range req.Features {
+		feature = strings.ToLower(strings.TrimSpace(feature))
+		if !featurePattern.MatchString(feature) {
+			return fmt.Errorf("%w: invalid feature %q", ErrInvalidInput, feature)
+		}
+		if !seen[feature] {
+			seen[feature] = true
+			features = append(features, feature)
+		}
+	}
+	sort.Strings(features)
+	req.Features = features
+
+	return nil
+}
@@ -0,0 +1,52 @@
+package plans
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormalizePlan(t *testing.T) {
+	req := UpdatePlanRequest{
+		Plan:     " Pro ",
+		Features: []string{"api_access", " Channel_Manager", "api_access"},
+	}
+
+	assert.NoError(t, normalizePlan(&req))
+	assert.Equal(t, "pro", req.Plan)
+	assert.Equal(t, []string{"api_access", "channel_manager"}, req.Features)
+}
+
+func TestNormalizePlan_Invalid(t *testing.T) {
+	negative := -1
+	cases := []UpdatePlanRequest{
+		{Plan: ""},
+		{Plan: "pro plan"},
+		{Plan: "pro", MaxMembers: &negative},
+		{Plan: "pro", MaxProperties: &negative},
+		{Plan: "pro", Features: []string{"api-access"}},
+	}
+
+	for _, req := range cases {
+		assert.True(t, errors.Is(normalizePlan(&req), ErrInvalidInput), req.Plan)
+	}
+}
+
+func TestSeatLimitError(t *testing.T) {
+	var err error = fmt.Errorf("activate: %w", &SeatLimitError{Plan: "starter", Limit: 5, Used: 5})
+
+	assert.True(t, errors.Is(err, ErrSeatLimitReached))
+
+	var seatErr *SeatLimitError
+	assert.True(t, errors.As(err, &seatErr))
+	assert.Equal(t, SeatLimitResponse{
+		Error:   "Failed",
+		Message: ErrSeatLimitReached.Error(),
+		Code:    "seat_limit_reached",
+		Plan:    "starter",
+		Limit:   5,
+		Used:    5,
+	}, NewSeatLimitResponse("Failed", seatErr))
+}
@@ -1,8 +1,14 @@
 package profile
 
 import (
+	"errors"
 	"fmt"
 	"net/http"
+	"strconv"
+	"strings"
+
+	"hostflow/profile-service/internal/middlewares"
+	"hostflow/profile-service/pkg/common"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
@@ -54,47 +60,31 @@ func (route ProfileRoutes) ReadinessHandler(c *gin.Context) {
 	})
 }
 
-/*// GetUsersHandler godoc
-// @Summary Get all users
-// @Description Returns a list of all users
-// @Tags users
-// @Accept json
-// @Produce json
-// @Success 200 {array} profile.User
-// @Failure 500 {object} profile.ErrorResponse
-// @Router /users [get]
-func (c *ProfileController) GetUsersHandler(ctx *gin.Context) {
-	users, err := c.service.GetUsers()
-	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, ErrorResponse{
-			Error:   "Failed to fetch users",
-			Message: err.Error(),
-		})
-		return
-	}
-
-	// Return directly
-	ctx.JSON(http.StatusOK, users)
-}*/
-
 // GetUsersHandler godoc
 // @Summary Get organization users
-// @Description Returns a list of users belonging to the requester's organization. Requires OWNER role.
+// @Description Returns a list of users belonging to the requester's organization. Requires OWNER role, or an API key with the users:read scope.
+// @Description Skills, languages and tags accept comma-separated values; the matching *_match parameter selects whether a user needs any or all of them.
 // @Tags users
 // @Accept json
 // @Produce json
 // @Security ApiKeyAuth
+// @Param skills query string false "Skill IDs, e.g. 1,4"
+// @Param skills_match query string false "any (default) or all"
+// @Param languages query string false "ISO 639-1 codes, e.g. sl,en,de"
+// @Param languages_match query string false "any (default) or all"
+// @Param tags query string false "Tags, e.g. check-in,senior"
+// @Param tags_match query string false "any (default) or all"
 // @Success 200 {array} User
+// @Failure 400 {object} ErrorResponse
 // @Failure 401 {object} ErrorResponse
 // @Failure 403 {object} ErrorResponse
 // @Failure 500 {object} ErrorResponse
 // @Router /users [get]
 func (c *ProfileController) GetUsersHandler(ctx *gin.Context) {
-	// 1. Extract claims set by your Auth Middleware
-	orgID, orgExists := ctx.Get("organization_id")
-	role, roleExists := ctx.Get("role")
+	// 1. Extract the principal set by the auth middleware
+	principal := middlewares.GetPrincipal(ctx)
 
-	if !orgExists || !roleExists {
+	if !principal.HasOrganization() {
 		ctx.JSON(http.StatusUnauthorized, ErrorResponse{
 			Error:   "Unauthorized",
 			Message: "Missing authentication claims",
@@ -102,8 +92,9 @@ func (c *ProfileController) GetUsersHandler(ctx *gin.Context) {
 		return
 	}
 
-	// 2. Access Management: Only allow OWNERS to fetch the full list
-	if role.(string) != "OWNER" {
+	// 2. Access Management: Only allow OWNERS to fetch the full list. API
+	// keys cannot be OWNERs; the middleware admits them with users:read only
+	if principal.Role != "OWNER" && principal.APIKeyID == 0 {
 		ctx.JSON(http.StatusForbidden, ErrorResponse{
 			Error:   "Forbidden",
 			Message: "Only organization owners can view the user list",
@@ -111,9 +102,18 @@ func (c *ProfileController) GetUsersHandler(ctx *gin.Context) {
 		return
 	}
 
-	// 3. Call the service with the specific Organization ID
-	// Note: ensure your service method signature accepts (ctx, orgID)
-	users, err := c.service.GetUsersProtected(ctx.Request.Context(), orgID.(int64))
+	// 3. Parse the optional skill, language and tag filters
+	filter, err := parseUserFilter(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "Invalid filter",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	// 4. Call the service with the specific Organization ID
+	users, err := c.service.GetUsersProtected(ctx.Request.Context(), principal.OrganizationID, filter)
 	if err != nil {
 		ctx.JSON(http.StatusInternalServerError, ErrorResponse{
 			Error:   "Failed to fetch users",
@@ -139,12 +139,10 @@ func (c *ProfileController) DeactivateHandler(ctx *gin.Context) {
 	fmt.Println("Deactivate handler called")
 	targetID := ctx.Param("id")
 
-	// These values are set by your Auth Middleware from the Supabase JWT
-	adminID := ctx.GetString("user_id")
-	orgID := ctx.GetInt64("organization_id")
-	role := ctx.GetString("role")
+	// Set by the auth middleware from the verified token
+	principal := middlewares.GetPrincipal(ctx)
 
-	err := c.service.DeactivateUser(ctx, targetID, adminID, orgID, role)
+	err := c.service.DeactivateUser(ctx, targetID, principal.UserID, principal.OrganizationID, principal.Role)
 	if err != nil {
 		ctx.JSON(400, gin.H{"error": err.Error()})
 		return
@@ -153,41 +151,106 @@ func (c *ProfileController) DeactivateHandler(ctx *gin.Context) {
 	ctx.Status(204)
 }
 
-// GetOrgNameHandler godoc
-// @Summary Get organization name
-// @Description Returns the name of the organization associated with the current user's token
-// @Tags organization
+// ChangeRoleHandler godoc
+// @Summary Change a member's role
+// @Description Sets the role of a member of the organization. The last active OWNER cannot be demoted. Requires OWNER role.
+// @Tags users
+// @Accept json
 // @Produce json
 // @Security ApiKeyAuth
-// @Success 200 {object} map[string]string "Example: {"name": "My Org"}"
-// @Failure 401 {object} map[string]string
-// @Failure 404 {object} map[string]string
-// @Router /org/name [get]
-func (c *ProfileController) GetOrgNameHandler(ctx *gin.Context) {
-	// 1. Get the organization_id that the Middleware extracted from the JWT
-	// Use ctx.Get() because it was stored there by c.Set("organization_id", value)
-	val, exists := ctx.Get("organization_id")
-
-	if !exists {
-		ctx.JSON(401, gin.H{"error": "Organization ID not found in session"})
+// @Param id path string true "User ID (UUID)"
+// @Param request body ChangeRoleRequest true "New role"
+// @Success 200 {object} User
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Router /users/{id}/role [put]
+func (c *ProfileController) ChangeRoleHandler(ctx *gin.Context) {
+	id, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "Invalid ID format",
+			Message: "ID must be a valid UUID",
+		})
 		return
 	}
 
-	// 2. Type-assert the value to int64
-	orgID, ok := val.(int64)
-	if !ok {
-		ctx.JSON(500, gin.H{"error": "Internal server error: ID format mismatch"})
+	var body ChangeRoleRequest
+	if errors := common.Validation.ValidateBody(ctx, &body); errors != nil {
+		ctx.JSON(http.StatusBadRequest, errors)
 		return
 	}
 
-	// 3. Call your service using the ID from the token
-	name, err := c.service.GetOrganizationName(ctx, orgID)
+	principal := middlewares.GetPrincipal(ctx)
+	user, err := c.service.ChangeRole(ctx.Request.Context(), id, principal.UserID, principal.OrganizationID, principal.Role, body.Role)
 	if err != nil {
-		ctx.JSON(404, gin.H{"error": "Organization not found"})
+		status := http.StatusInternalServerError
+		switch {
+		case errors.Is(err, ErrForbidden):
+			status = http.StatusForbidden
+		case errors.Is(err, ErrUserNotFound):
+			status = http.StatusNotFound
+		case errors.Is(err, ErrLastOwner):
+			status = http.StatusConflict
+		}
+		ctx.JSON(status, ErrorResponse{
+			Error:   "Failed to change role",
+			Message: err.Error(),
+		})
 		return
 	}
 
-	ctx.JSON(200, gin.H{"name": name})
+	ctx.JSON(http.StatusOK, user)
+}
+
+// LogoutEverywhereHandler godoc
+// @Summary Log out everywhere
+// @Description Revokes every token of the current user issued until now, on all devices. Clients have to sign in again.
+// @Tags sessions
+// @Security ApiKeyAuth
+// @Success 204 "No Content"
+// @Failure 401 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /sessions [delete]
+func (c *ProfileController) LogoutEverywhereHandler(ctx *gin.Context) {
+	principal := middlewares.GetPrincipal(ctx)
+	if err := c.service.LogoutEverywhere(ctx.Request.Context(), principal.UserID); err != nil {
+		ctx.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "Failed to log out",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+// LogoutSessionHandler godoc
+// @Summary Log out the current session
+// @Description Revokes the tokens of the session the request was made with. Other sessions of the user keep working.
+// @Tags sessions
+// @Security ApiKeyAuth
+// @Success 204 "No Content"
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /sessions/current [delete]
+func (c *ProfileController) LogoutSessionHandler(ctx *gin.Context) {
+	principal := middlewares.GetPrincipal(ctx)
+	if err := c.service.LogoutSession(ctx.Request.Context(), principal.SessionID); err != nil {
+		status := http.StatusInternalServerError
+		if errors.Is(err, ErrNoSession) {
+			status = http.StatusBadRequest
+		}
+		ctx.JSON(status, ErrorResponse{
+			Error:   "Failed to log out",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
 }
 
 // GetUserByIDHandler godoc
@@ -235,3 +298,76 @@ func (c *ProfileController) GetUserByIDHandler(ctx *gin.Context) {
 	// Return directly
 	ctx.JSON(http.StatusOK, user)
 }
+
+// parseUserFilter reads the skills, languages and tags query parameters.
+// Values may be comma-separated or repeated and are deduplicated so that
+// "all" matching can compare counts.
+func parseUserFilter(ctx *gin.Context) (UserFilter, error) {
+	var filter UserFilter
+	var err error
+
+	if filter.SkillMatch, err = parseMatch(ctx, "skills_match"); err != nil {
+		return filter, err
+	}
+	if filter.LanguageMatch, err = parseMatch(ctx, "languages_match"); err != nil {
+		return filter, err
+	}
+	if filter.TagMatch, err = parseMatch(ctx, "tags_match"); err != nil {
+		return filter, err
+	}
+
+	for _, value := range queryList(ctx, "skills") {
+		id, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return filter, fmt.Errorf("skill ID %q must be numeric", value)
+		}
+		filter.SkillIDs = appendUnique(filter.SkillIDs, id)
+	}
+
+	for _, value := range queryList(ctx, "languages") {
+		code := common.Languages.Normalize(value)
+		if !common.Languages.IsValid(code) {
+			return filter, fmt.Errorf("%q is not an ISO 639-1 language code", value)
+		}
+		filter.Languages = appendUnique(filter.Languages, code)
+	}
+
+	for _, value := range queryList(ctx, "tags") {
+		filter.Tags = appendUnique(filter.Tags, strings.ToLower(value))
+	}
+
+	return filter, nil
+}
+
+// parseMatch reads an any/all match mode, defaulting to any.
+func parseMatch(ctx *gin.Context, key string) (string, error) {
+	switch match := strings.ToLower(ctx.DefaultQuery(key, MatchAny)); match {
+	case MatchAny, MatchAll:
+		return match, nil
+	default:
+		return "", fmt.Errorf("%s must be %q or %q", key, MatchAny, MatchAll)
+	}
+}
+
+// queryList collects the trimmed, non-empty values of a repeated and/or
+// comma-separated query parameter.
+func queryList(ctx *gin.Context, key string) []string {
+	values := []string{}
+	for _, raw := range ctx.QueryArray(key) {
+		for _, value := range strings.Split(raw, ",") {
+			if value = strings.TrimSpace(value); value != "" {
+				values = append(values, value)
+			}
+		}
+	}
+	return values
+}
+
+func appendUnique[T comparable](values []T, value T) []T {
+	for _, existing := range values {
+		if existing == value {
+			return values
+		}
+	}
+	return append(values, value)
+}
@@ -4,8 +4,11 @@ import (
 	"context"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 
+	"hostflow/profile-service/internal/middlewares"
+
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
@@ -16,16 +19,11 @@ type MockProfileService struct {
 	mock.Mock
 }
 
-func (m *MockProfileService) GetUsersProtected(ctx context.Context, orgID int64) ([]User, error) {
-	args := m.Called(ctx, orgID)
+func (m *MockProfileService) GetUsersProtected(ctx context.Context, orgID int64, filter UserFilter) ([]User, error) {
+	args := m.Called(ctx, orgID, filter)
 	return args.Get(0).([]User), args.Error(1)
 }
 
-func (m *MockProfileService) GetOrganizationName(ctx context.Context, orgID int64) (string, error) {
-	args := m.Called(ctx, orgID)
-	return args.String(0), args.Error(1)
-}
-
 func (m *MockProfileService) GetUserByID(id uuid.UUID) (*User, error) {
 	args := m.Called(id)
 	if args.Get(0) == nil {
@@ -38,6 +36,22 @@ func (m *MockProfileService) DeactivateUser(ctx context.Context, tID, aID string
 	return m.Called(ctx, tID, aID, oID, r).Error(0)
 }
 
+func (m *MockProfileService) ChangeRole(ctx context.Context, targetID uuid.UUID, actorID string, orgID int64, actorRole string, role string) (*User, error) {
+	args := m.Called(ctx, targetID, actorID, orgID, actorRole, role)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*User), args.Error(1)
+}
+
+func (m *MockProfileService) LogoutEverywhere(ctx context.Context, userID string) error {
+	return m.Called(ctx, userID).Error(0)
+}
+
+func (m *MockProfileService) LogoutSession(ctx context.Context, sessionID string) error {
+	return m.Called(ctx, sessionID).Error(0)
+}
+
 // --- TESTI ---
 
 func TestGetUsersHandler_OwnerSuccess(t *testing.T) {
@@ -47,12 +61,11 @@ func TestGetUsersHandler_OwnerSuccess(t *testing.T) {
 
 	r := gin.Default()
 	r.GET("/users", func(c *gin.Context) {
-		c.Set("organization_id", int64(1))
-		c.Set("role", "OWNER") // Simuliramo OWNER dostop
+		middlewares.SetPrincipal(c, middlewares.Principal{OrganizationID: 1, Role: "OWNER"}) // Simuliramo OWNER dostop
 		controller.GetUsersHandler(c)
 	})
 
-	mockSvc.On("GetUsersProtected", mock.Anything, int64(1)).Return([]User{{Name: "Leon"}}, nil)
+	mockSvc.On("GetUsersProtected", mock.Anything, int64(1), UserFilter{SkillMatch: MatchAny, LanguageMatch: MatchAny, TagMatch: MatchAny}).Return([]User{{Name: "Leon"}}, nil)
 
 	w := httptest.NewRecorder()
 	req, _ := http.NewRequest("GET", "/users", nil)
@@ -62,6 +75,54 @@ func TestGetUsersHandler_OwnerSuccess(t *testing.T) {
 	assert.Contains(t, w.Body.String(), "Leon")
 }
 
+func TestGetUsersHandler_Filters(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	mockSvc := new(MockProfileService)
+	controller := GetProfileController(mockSvc)
+
+	r := gin.Default()
+	r.GET("/users", func(c *gin.Context) {
+		middlewares.SetPrincipal(c, middlewares.Principal{OrganizationID: 1, Role: "OWNER"})
+		controller.GetUsersHandler(c)
+	})
+
+	expected := UserFilter{
+		SkillIDs:      []int64{1, 4},
+		SkillMatch:    MatchAll,
+		Languages:     []string{"sl", "en", "de"},
+		LanguageMatch: MatchAny,
+		Tags:          []string{"check-in"},
+		TagMatch:      MatchAny,
+	}
+	mockSvc.On("GetUsersProtected", mock.Anything, int64(1), expected).Return([]User{{Name: "Maja"}}, nil)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/users?skills=1,4,1&skills_match=all&languages=SL,en&languages=de&tags=Check-In", nil)
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), "Maja")
+}
+
+func TestGetUsersHandler_InvalidFilter(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	controller := GetProfileController(new(MockProfileService))
+
+	r := gin.Default()
+	r.GET("/users", func(c *gin.Context) {
+		middlewares.SetPrincipal(c, middlewares.Principal{OrganizationID: 1, Role: "OWNER"})
+		controller.GetUsersHandler(c)
+	})
+
+	for _, query := range []string{"languages=xx", "skills=cleaning", "tags_match=some"} {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/users?"+query, nil)
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code, query)
+	}
+}
+
 func TestGetUsersHandler_ForbiddenForMember(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 	mockSvc := new(MockProfileService)
@@ -69,8 +130,7 @@ func TestGetUsersHandler_ForbiddenForMember(t *testing.T) {
 
 	r := gin.Default()
 	r.GET("/users", func(c *gin.Context) {
-		c.Set("organization_id", int64(1))
-		c.Set("role", "MEMBER") // Simuliramo navadnega člana
+		middlewares.SetPrincipal(c, middlewares.Principal{OrganizationID: 1, Role: "MEMBER"}) // Simuliramo navadnega člana
 		controller.GetUsersHandler(c)
 	})
 
@@ -82,6 +142,28 @@ func TestGetUsersHandler_ForbiddenForMember(t *testing.T) {
 	assert.Contains(t, w.Body.String(), "Only organization owners can view")
 }
 
+func TestGetUsersHandler_APIKey(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	mockSvc := new(MockProfileService)
+	controller := GetProfileController(mockSvc)
+
+	r := gin.Default()
+	r.GET("/users", func(c *gin.Context) {
+		// API keys are at most MANAGERs; the middleware checked users:read
+		middlewares.SetPrincipal(c, middlewares.Principal{OrganizationID: 1, Role: "MANAGER", APIKeyID: 3})
+		controller.GetUsersHandler(c)
+	})
+
+	mockSvc.On("GetUsersProtected", mock.Anything, int64(1), UserFilter{SkillMatch: MatchAny, LanguageMatch: MatchAny, TagMatch: MatchAny}).Return([]User{{Name: "Leon"}}, nil)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/users", nil)
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), "Leon")
+}
+
 func TestGetUserByIDHandler_InvalidUUID(t *testing.T) {
 	controller := GetProfileController(new(MockProfileService))
 	r := gin.Default()
@@ -94,3 +176,81 @@ func TestGetUserByIDHandler_InvalidUUID(t *testing.T) {
 	assert.Equal(t, http.StatusBadRequest, w.Code)
 	assert.Contains(t, w.Body.String(), "ID must be a valid UUID")
 }
+
+func TestChangeRoleHandler_LastOwner(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	mockSvc := new(MockProfileService)
+	controller := GetProfileController(mockSvc)
+	targetID := uuid.New()
+
+	r := gin.New()
+	r.PUT("/users/:id/role", func(c *gin.Context) {
+		middlewares.SetPrincipal(c, middlewares.Principal{UserID: "owner", OrganizationID: 1, Role: "OWNER"})
+		controller.ChangeRoleHandler(c)
+	})
+
+	mockSvc.On("ChangeRole", mock.Anything, targetID, "owner", int64(1), "OWNER", "MEMBER").Return(nil, ErrLastOwner)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("PUT", "/users/"+targetID.String()+"/role", strings.NewReader(`{"role":"MEMBER"}`))
+	req.Header.Set("Content-Type", "application/json")
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusConflict, w.Code)
+}
+
+func TestChangeRoleHandler_InvalidRole(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	controller := GetProfileController(new(MockProfileService))
+
+	r := gin.New()
+	r.PUT("/users/:id/role", controller.ChangeRoleHandler)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("PUT", "/users/"+uuid.New().String()+"/role", strings.NewReader(`{"role":"ADMIN"}`))
+	req.Header.Set("Content-Type", "application/json")
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestLogoutEverywhereHandler(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	mockSvc := new(MockProfileService)
+	controller := GetProfileController(mockSvc)
+
+	r := gin.New()
+	r.DELETE("/sessions", func(c *gin.Context) {
+		middlewares.SetPrincipal(c, middlewares.Principal{UserID: "user-1"})
+		controller.LogoutEverywhereHandler(c)
+	})
+
+	mockSvc.On("LogoutEverywhere", mock.Anything, "user-1").Return(nil)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("DELETE", "/sessions", nil)
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusNoContent, w.Code)
+	mockSvc.AssertExpectations(t)
+}
+
+func TestLogoutSessionHandler_NoSession(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	mockSvc := new(MockProfileService)
+	controller := GetProfileController(mockSvc)
+
+	r := gin.New()
+	r.DELETE("/sessions/current", func(c *gin.Context) {
+		middlewares.SetPrincipal(c, middlewares.Principal{UserID: "user-1"})
+		controller.LogoutSessionHandler(c)
+	})
+
+	mockSvc.On("LogoutSession", mock.Anything, "").Return(ErrNoSession)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("DELETE", "/sessions/current", nil)
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
@@ -17,8 +17,31 @@ type User struct {
 	UpdatedAt      time.Time `json:"updated_at" db:"updated_at"`
 }
 
+// ChangeRoleRequest is the body of PUT /users/{id}/role.
+type ChangeRoleRequest struct {
+	Role string `json:"role" binding:"required,oneof=OWNER MANAGER MEMBER" example:"MANAGER"`
+}
+
 // ErrorResponse represents an error response
 type ErrorResponse struct {
 	Error   string `json:"error" example:"Invalid request"`
 	Message string `json:"message,omitempty" example:"The provided data is invalid"`
 }
+
+// Match modes for list filters: "any" keeps users carrying at least one of
+// the values, "all" keeps users carrying every value.
+const (
+	MatchAny = "any"
+	MatchAll = "all"
+)
+
+// UserFilter narrows GET /users by skills, spoken languages and tags.
+// Empty slices mean no filtering on that attribute.
+type UserFilter struct {
+	SkillIDs      []int64
+	SkillMatch    string
+	Languages     []string
+	LanguageMatch string
+	Tags          []string
+	TagMatch      string
+}
@@ -3,6 +3,7 @@ package profile
 import (
 	"context"
 	"errors"
+	"fmt"
 	_ "time"
 
 	"github.com/google/uuid"
@@ -20,43 +21,51 @@ func GetProfileRepository(db *pgxpool.Pool) *ProfileRepository {
 	}
 }
 
-// GetUsersByOrganizationID returns all users belonging to a specific organization.
-func (r *ProfileRepository) GetUsersByOrganizationID(ctx context.Context, organizationId int64) ([]User, error) {
+// GetUsersByOrganizationID returns all users belonging to a specific organization,
+// narrowed by the skill, language and tag filter.
+func (r *ProfileRepository) GetUsersByOrganizationID(ctx context.Context, organizationId int64, filter UserFilter) ([]User, error) {
 	query := `
         SELECT id, organization_id, full_name, role, email, status, created_at, updated_at
-        FROM "profiles"
-        WHERE organization_id = $1
-        ORDER BY created_at DESC
+        FROM "profiles" p
+        WHERE organization_id = $1 AND deleted_at IS NULL
     `
-
-	rows, err := r.db.Query(ctx, query, organizationId)
-	if err != nil {
-		return nil, err
-	}
-	defer rows.Close()
-
-	// pgx.CollectRows handles scanning the entire result set into a slice of structs
-	users, err := pgx.CollectRows(rows, pgx.RowToStructByName[User])
-	if err != nil {
-		return nil, err
+	args := []any{organizationId}
+
+	// Each attribute adds one condition. "any" only needs a single matching
+	// row, "all" needs as many distinct matches as there are (deduplicated)
+	// values in the filter.
+	addCondition := func(table, column string, values any, count int, match string) {
+		if count == 0 {
+			return
+		}
+		args = append(args, values)
+		placeholder := fmt.Sprintf("$%d", len(args))
+		if match == MatchAll {
+			query += fmt.Sprintf(
+				"AND (SELECT count(DISTINCT %s) FROM %s WHERE profile_id = p.id AND %s = ANY(%s)) = %d\n",
+				column, table, column, placeholder, count,
+			)
+			return
+		}
+		query += fmt.Sprintf(
+			"AND EXISTS (SELECT 1 FROM %s WHERE profile_id = p.id AND %s = ANY(%s))\n",
+			table, column, placeholder,
+		)
 	}
 
-	return users, nil
-}
+	addCondition("profile_skills", "skill_id", filter.SkillIDs, len(filter.SkillIDs), filter.SkillMatch)
+	addCondition("profile_languages", "language_code", filter.Languages, len(filter.Languages), filter.LanguageMatch)
+	addCondition("profile_tags", "tag", filter.Tags, len(filter.Tags), filter.TagMatch)
 
-func (r *ProfileRepository) GetUsers() ([]User, error) {
-	query := `
-        SELECT *
-        FROM "user"
-        ORDER BY created_at DESC
-    `
+	query += "ORDER BY created_at DESC"
 
-	rows, err := r.db.Query(context.Background(), query)
+	rows, err := r.db.Query(ctx, query, args...)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
 
+	// pgx.CollectRows handles scanning the entire result set into a slice of structs
 	users, err := pgx.CollectRows(rows, pgx.RowToStructByName[User])
 	if err != nil {
 		return nil, err
@@ -79,15 +88,61 @@ func (r *ProfileRepository) UpdateStatus(ctx context.Context, userID string, org
 	return nil
 }
 
-func (r *ProfileRepository) GetNameByID(ctx context.Context, orgID int64) (string, error) {
-	var name string
-	query := `SELECT name FROM organization WHERE id = $1`
+// ChangeRole sets the role of a profile in the organization and returns the
+// profile with its previous role. Role changes within an organization are
+// serialized by locking its row, so two owners demoting each other cannot
+// leave it without an active OWNER (ErrLastOwner).
+func (r *ProfileRepository) ChangeRole(ctx context.Context, userID uuid.UUID, orgID int64, role string) (*User, string, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return nil, "", err
+	}
+	defer tx.Rollback(ctx)
+
+	if _, err := tx.Exec(ctx, `SELECT 1 FROM organization WHERE id = $1 FOR NO KEY UPDATE`, orgID); err != nil {
+		return nil, "", err
+	}
+
+	var previous string
+	err = tx.QueryRow(ctx,
+		`SELECT role FROM "profiles" WHERE id = $1 AND organization_id = $2 FOR UPDATE`,
+		userID, orgID,
+	).Scan(&previous)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, "", ErrUserNotFound
+		}
+		return nil, "", err
+	}
+
+	if previous == "OWNER" && role != "OWNER" {
+		var owners int
+		err := tx.QueryRow(ctx, `
+            SELECT count(*) FROM "profiles"
+            WHERE organization_id = $1 AND role = 'OWNER' AND status = 'ACTIVE' AND id <> $2
+        `, orgID, userID).Scan(&owners)
+		if err != nil {
+			return nil, "", err
+		}
+		if owners == 0 {
+			return nil, "", ErrLastOwner
+		}
+	}
 
-	err := r.db.QueryRow(ctx, query, orgID).Scan(&name)
+	rows, err := tx.Query(ctx, `
+        UPDATE "profiles" SET role = $1, updated_at = now()
+        WHERE id = $2 AND organization_id = $3
+        RETURNING id, organization_id, full_name, role, email, status, created_at, updated_at
+    `, role, userID, orgID)
+	if err != nil {
+		return nil, "", err
+	}
+
+	user, err := pgx.CollectOneRow(rows, pgx.RowToStructByName[User])
 	if err != nil {
-		return "", err
+		return nil, "", err
 	}
-	return name, nil
+	return &user, previous, tx.Commit(ctx)
 }
 
 func (r *ProfileRepository) GetUserByID(id uuid.UUID) (*User, error) {
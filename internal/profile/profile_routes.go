@@ -35,17 +35,20 @@ func (route ProfileRoutes) Setup() {
 	route.logger.Info("Setting up [PROFILE] routes.")
 
 	users := route.router.Group("/users")
-	users.Use(route.authMiddleware.Handler())
+	users.Use(route.authMiddleware.HandlerWithAPIKeys("users"))
 	{
 		users.GET("", route.profileController.GetUsersHandler)
 		users.GET("/:id", route.profileController.GetUserByIDHandler)
 		users.PUT("/:id/status", route.profileController.DeactivateHandler)
+		users.PUT("/:id/role", route.profileController.ChangeRoleHandler)
 	}
 
-	organizations := route.router.Group("/organization")
-	organizations.Use(route.authMiddleware.Handler())
+	// Members of no organization can log out as well
+	sessions := route.router.Group("/sessions")
+	sessions.Use(route.authMiddleware.IdentityHandler())
 	{
-		organizations.GET("/name", route.profileController.GetOrgNameHandler)
+		sessions.DELETE("", route.profileController.LogoutEverywhereHandler)
+		sessions.DELETE("/current", route.profileController.LogoutSessionHandler)
 	}
 
 	metrics := route.router.Group("/metrics")
@@ -3,41 +3,65 @@ package profile
 import (
 	"context"
 	"errors"
+	"fmt"
+
+	"hostflow/profile-service/internal/audit"
+	"hostflow/profile-service/internal/iamsync"
+	"hostflow/profile-service/internal/middlewares"
+	"hostflow/profile-service/pkg/lib"
 
 	"github.com/google/uuid"
 )
 
+var (
+	ErrUserNotFound = errors.New("user not found in the organization")
+	ErrLastOwner    = errors.New("the organization needs at least one active owner")
+	ErrForbidden    = errors.New("you are not allowed to perform this action")
+	ErrNoSession    = errors.New("the token does not belong to a session")
+)
+
 type ProfileService struct {
-	repo *ProfileRepository
+	repo        *ProfileRepository
+	memberships *middlewares.Memberships
+	revocations *middlewares.Revocations
+	identity    iamsync.Service
+	audit       audit.Service
+	logger      lib.Logger
 }
 
 type Service interface {
-	GetUsersProtected(ctx context.Context, orgID int64) ([]User, error)
+	GetUsersProtected(ctx context.Context, orgID int64, filter UserFilter) ([]User, error)
 	DeactivateUser(ctx context.Context, targetID, adminID string, orgID int64, role string) error
-	GetOrganizationName(ctx context.Context, orgID int64) (string, error)
+	ChangeRole(ctx context.Context, targetID uuid.UUID, actorID string, orgID int64, actorRole string, role string) (*User, error)
 	GetUserByID(id uuid.UUID) (*User, error)
+	LogoutEverywhere(ctx context.Context, userID string) error
+	LogoutSession(ctx context.Context, sessionID string) error
 }
 
-func GetProfileService(repo *ProfileRepository) *ProfileService {
+func GetProfileService(
+	repo *ProfileRepository,
+	memberships *middlewares.Memberships,
+	revocations *middlewares.Revocations,
+	identity iamsync.Service,
+	audit audit.Service,
+	logger lib.Logger,
+) *ProfileService {
 	return &ProfileService{
-		repo: repo,
+		repo:        repo,
+		memberships: memberships,
+		revocations: revocations,
+		identity:    identity,
+		audit:       audit,
+		logger:      logger,
 	}
 }
 
-// GetUsers returns all users
-func (s *ProfileService) GetUsers() ([]User, error) {
-	users, err := s.repo.GetUsers()
-	if err != nil {
-		return nil, err
-	}
-	return users, nil
-}
-
 // GetUsers returns all users belonging to the requester's organization.
-// It uses the organizationID extracted from the OIDC/JWT token.
-func (s *ProfileService) GetUsersProtected(ctx context.Context, organizationID int64) ([]User, error) {
+// It uses the organizationID extracted from the OIDC/JWT token and narrows
+// the list by the given skill, language and tag filter.
+func (s *ProfileService) GetUsersProtected(ctx context.Context, organizationID int64, filter UserFilter) ([]User, error) {
 	// We call the specific repository method that filters by Org
-	users, err := s.repo.GetUsersByOrganizationID(ctx, organizationID)
+	users, err := s.repo.GetUsersByOrganizationID(ctx, organizationID, filter)
 	if err != nil {
 		// You can add custom logging or error wrapping here
 		return nil, err
@@ -108,9 +132,77 @@ func (s *ProfileService) DeactivateUser(ctx context.Context, targetID string, ad
 	}
 
 	// 3. Execute update
-	return s.repo.UpdateStatus(ctx, targetID, orgID, "INACTIVE")
+	if err := s.repo.UpdateStatus(ctx, targetID, orgID, "INACTIVE"); err != nil {
+		return err
+	}
+
+	// 4. Turn the user away right away instead of when the token expires
+	s.memberships.Invalidate(targetID)
+	if err := s.revocations.Revoke(ctx, middlewares.RevokeUser, targetID, "deactivated"); err != nil {
+		return err
+	}
+
+	// 5. Ban the identity so no new session can be started
+	if err := s.identity.Sync(ctx, targetID); err != nil {
+		s.logger.Error(fmt.Sprintf("Failed to queue identity sync of %s: %v", targetID, err))
+	}
+	return nil
 }
 
-func (s *ProfileService) GetOrganizationName(ctx context.Context, orgID int64) (string, error) {
-	return s.repo.GetNameByID(ctx, orgID)
+// ChangeRole sets the role of a member. The organization always keeps an
+// active OWNER. Requires OWNER role.
+func (s *ProfileService) ChangeRole(ctx context.Context, targetID uuid.UUID, actorID string, orgID int64, actorRole string, role string) (*User, error) {
+	if actorRole != "OWNER" {
+		return nil, ErrForbidden
+	}
+
+	user, previous, err := s.repo.ChangeRole(ctx, targetID, orgID, role)
+	if err != nil {
+		return nil, err
+	}
+	if previous == role {
+		return user, nil
+	}
+	s.memberships.Invalidate(targetID.String())
+
+	// Tokens carry the role for clients only; authorization reads the profile
+	if err := s.identity.Sync(ctx, targetID.String()); err != nil {
+		s.logger.Error(fmt.Sprintf("Failed to queue identity sync of %s: %v", targetID, err))
+	}
+
+	// Clients refresh their token to pick up the new role
+	if err := s.revocations.Revoke(ctx, middlewares.RevokeUser, targetID.String(), "role_changed"); err != nil {
+		s.logger.Error(fmt.Sprintf("Failed to revoke sessions of %s: %v", targetID, err))
+	}
+
+	targetIDString := targetID.String()
+	entry := audit.Entry{
+		OrganizationID:      orgID,
+		ActorOrganizationID: &orgID,
+		Action:              "member.role_changed",
+		TargetType:          "profile",
+		TargetID:            &targetIDString,
+		Details:             map[string]interface{}{"from": previous, "to": role},
+	}
+	if id, err := uuid.Parse(actorID); err == nil {
+		entry.ActorID = &id
+	}
+	if err := s.audit.Record(ctx, entry); err != nil {
+		return nil, err
+	}
+	return user, nil
+}
+
+// LogoutEverywhere revokes all tokens the user was issued until now, on
+// every device.
+func (s *ProfileService) LogoutEverywhere(ctx context.Context, userID string) error {
+	return s.revocations.Revoke(ctx, middlewares.RevokeUser, userID, "logout_everywhere")
+}
+
+// LogoutSession revokes the tokens of a single session.
+func (s *ProfileService) LogoutSession(ctx context.Context, sessionID string) error {
+	if sessionID == "" {
+		return ErrNoSession
+	}
+	return s.revocations.Revoke(ctx, middlewares.RevokeSession, sessionID, "logout")
 }
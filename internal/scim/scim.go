@@ -0,0 +1,16 @@
+package scim
+
+import "go.uber.org/fx"
+
+// ======== EXPORTS ========
+
+// Module exports services present
+var Context = fx.Options(
+	fx.Provide(GetSCIMController),
+	fx.Provide(fx.Annotate(
+		GetSCIMService,
+		fx.As(new(Service)),
+	)),
+	fx.Provide(GetSCIMRepository),
+	fx.Provide(SetSCIMRoutes),
+)
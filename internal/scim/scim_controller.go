@@ -0,0 +1,425 @@
+package scim
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"hostflow/profile-service/internal/middlewares"
+	"hostflow/profile-service/internal/plans"
+
+	"github.com/gin-gonic/gin"
+)
+
+// maxBodyBytes bounds the size of a request.
+const maxBodyBytes = 1 << 20
+
+type SCIMController struct {
+	service Service
+}
+
+func GetSCIMController(service Service) *SCIMController {
+	return &SCIMController{
+		service: service,
+	}
+}
+
+// ListUsersHandler godoc
+// @Summary List SCIM users
+// @Description Returns a page of the organization's members as SCIM users. filter supports eq comparisons of userName, emails.value, externalId, id, displayName and active, joined with "and". startIndex is 1-based; count defaults to 100 and is capped at 200. excludedAttributes=groups leaves out team memberships. Requires an organization API key with the scim:read scope, sent as a bearer token.
+// @Tags scim
+// @Produce json
+// @Security ApiKeyAuth
+// @Param filter query string false "Filter, e.g. userName eq \"ana@villa-bled.si\""
+// @Param startIndex query int false "1-based index of the first result"
+// @Param count query int false "Page size"
+// @Param excludedAttributes query string false "groups"
+// @Success 200 {object} ListResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Router /scim/v2/Users [get]
+func (c *SCIMController) ListUsersHandler(ctx *gin.Context) {
+	query, ok := listQuery(ctx)
+	if !ok {
+		return
+	}
+
+	users, err := c.service.ListUsers(ctx.Request.Context(), middlewares.GetPrincipal(ctx).OrganizationID, query)
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+
+	respond(ctx, http.StatusOK, users)
+}
+
+// GetUserHandler godoc
+// @Summary Get a SCIM user
+// @Description Returns a member of the organization as a SCIM user with their teams. Requires the scim:read scope.
+// @Tags scim
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "User ID"
+// @Success 200 {object} User
+// @Failure 404 {object} ErrorResponse
+// @Router /scim/v2/Users/{id} [get]
+func (c *SCIMController) GetUserHandler(ctx *gin.Context) {
+	user, err := c.service.GetUser(ctx.Request.Context(), middlewares.GetPrincipal(ctx).OrganizationID, ctx.Param("id"))
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+
+	respond(ctx, http.StatusOK, user)
+}
+
+// CreateUserHandler godoc
+// @Summary Provision a SCIM user
+// @Description Adds a member to the organization. userName is the member's email and must be on one of the organization's verified domains, otherwise the request is refused with 400 invalidValue; a Supabase Auth user is created for it unless one exists. roles may be MANAGER or MEMBER (the default). Active members take a seat of the plan. A user who already belongs to an organization is refused with 409. Requires the scim:write scope.
+// @Tags scim
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param body body User true "User"
+// @Success 201 {object} User
+// @Failure 400 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Router /scim/v2/Users [post]
+func (c *SCIMController) CreateUserHandler(ctx *gin.Context) {
+	var body User
+	if !bindBody(ctx, &body) {
+		return
+	}
+
+	principal := middlewares.GetPrincipal(ctx)
+	user, err := c.service.CreateUser(ctx.Request.Context(), principal.OrganizationID, principal.APIKeyID, body)
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+
+	ctx.Header("Location", user.Meta.Location)
+	respond(ctx, http.StatusCreated, user)
+}
+
+// ReplaceUserHandler godoc
+// @Summary Replace a SCIM user
+// @Description Sets the name, role, status and externalId of a member. userName cannot change. Deactivating a member or changing their role signs them out; the owner's role and status cannot be changed. Requires the scim:write scope.
+// @Tags scim
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "User ID"
+// @Param body body User true "User"
+// @Success 200 {object} User
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /scim/v2/Users/{id} [put]
+func (c *SCIMController) ReplaceUserHandler(ctx *gin.Context) {
+	var body User
+	if !bindBody(ctx, &body) {
+		return
+	}
+
+	principal := middlewares.GetPrincipal(ctx)
+	user, err := c.service.ReplaceUser(ctx.Request.Context(), principal.OrganizationID, principal.APIKeyID, ctx.Param("id"), body)
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+
+	respond(ctx, http.StatusOK, user)
+}
+
+// PatchUserHandler godoc
+// @Summary Patch a SCIM user
+// @Description Applies add, replace and remove operations to a member, e.g. replace active with false to deactivate them. Requires the scim:write scope.
+// @Tags scim
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "User ID"
+// @Param body body PatchRequest true "Operations"
+// @Success 200 {object} User
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /scim/v2/Users/{id} [patch]
+func (c *SCIMController) PatchUserHandler(ctx *gin.Context) {
+	var body PatchRequest
+	if !bindBody(ctx, &body) {
+		return
+	}
+
+	principal := middlewares.GetPrincipal(ctx)
+	user, err := c.service.PatchUser(ctx.Request.Context(), principal.OrganizationID, principal.APIKeyID, ctx.Param("id"), body)
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+
+	respond(ctx, http.StatusOK, user)
+}
+
+// DeleteUserHandler godoc
+// @Summary Deprovision a SCIM user
+// @Description Removes a member from the organization: the profile is deactivated and marked deleted, taken out of its teams, and the member is signed out. The owner cannot be deleted. Requires the scim:write scope.
+// @Tags scim
+// @Security ApiKeyAuth
+// @Param id path string true "User ID"
+// @Success 204
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /scim/v2/Users/{id} [delete]
+func (c *SCIMController) DeleteUserHandler(ctx *gin.Context) {
+	principal := middlewares.GetPrincipal(ctx)
+	if err := c.service.DeleteUser(ctx.Request.Context(), principal.OrganizationID, principal.APIKeyID, ctx.Param("id")); err != nil {
+		respondError(ctx, err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+// ListGroupsHandler godoc
+// @Summary List SCIM groups
+// @Description Returns a page of the organization's teams as SCIM groups. filter supports eq comparisons of displayName, externalId and id. excludedAttributes=members leaves out the members. Requires the scim:read scope.
+// @Tags scim
+// @Produce json
+// @Security ApiKeyAuth
+// @Param filter query string false "Filter, e.g. displayName eq \"Housekeeping\""
+// @Param startIndex query int false "1-based index of the first result"
+// @Param count query int false "Page size"
+// @Param excludedAttributes query string false "members"
+// @Success 200 {object} ListResponse
+// @Failure 400 {object} ErrorResponse
+// @Router /scim/v2/Groups [get]
+func (c *SCIMController) ListGroupsHandler(ctx *gin.Context) {
+	query, ok := listQuery(ctx)
+	if !ok {
+		return
+	}
+
+	groups, err := c.service.ListGroups(ctx.Request.Context(), middlewares.GetPrincipal(ctx).OrganizationID, query)
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+
+	respond(ctx, http.StatusOK, groups)
+}
+
+// GetGroupHandler godoc
+// @Summary Get a SCIM group
+// @Description Returns a team of the organization with its members. Requires the scim:read scope.
+// @Tags scim
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "Group ID"
+// @Success 200 {object} Group
+// @Failure 404 {object} ErrorResponse
+// @Router /scim/v2/Groups/{id} [get]
+func (c *SCIMController) GetGroupHandler(ctx *gin.Context) {
+	group, err := c.service.GetGroup(ctx.Request.Context(), middlewares.GetPrincipal(ctx).OrganizationID, ctx.Param("id"))
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+
+	respond(ctx, http.StatusOK, group)
+}
+
+// CreateGroupHandler godoc
+// @Summary Create a SCIM group
+// @Description Adds a team to the organization. Members must be users of the organization. Requires the scim:write scope.
+// @Tags scim
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param body body Group true "Group"
+// @Success 201 {object} Group
+// @Failure 400 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Router /scim/v2/Groups [post]
+func (c *SCIMController) CreateGroupHandler(ctx *gin.Context) {
+	var body Group
+	if !bindBody(ctx, &body) {
+		return
+	}
+
+	principal := middlewares.GetPrincipal(ctx)
+	group, err := c.service.CreateGroup(ctx.Request.Context(), principal.OrganizationID, principal.APIKeyID, body)
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+
+	ctx.Header("Location", group.Meta.Location)
+	respond(ctx, http.StatusCreated, group)
+}
+
+// ReplaceGroupHandler godoc
+// @Summary Replace a SCIM group
+// @Description Sets the name, externalId and members of a team. Requires the scim:write scope.
+// @Tags scim
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "Group ID"
+// @Param body body Group true "Group"
+// @Success 200 {object} Group
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Router /scim/v2/Groups/{id} [put]
+func (c *SCIMController) ReplaceGroupHandler(ctx *gin.Context) {
+	var body Group
+	if !bindBody(ctx, &body) {
+		return
+	}
+
+	principal := middlewares.GetPrincipal(ctx)
+	group, err := c.service.ReplaceGroup(ctx.Request.Context(), principal.OrganizationID, principal.APIKeyID, ctx.Param("id"), body)
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+
+	respond(ctx, http.StatusOK, group)
+}
+
+// PatchGroupHandler godoc
+// @Summary Patch a SCIM group
+// @Description Applies add, replace and remove operations to a team, e.g. add members or remove members[value eq "<id>"]. Requires the scim:write scope.
+// @Tags scim
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "Group ID"
+// @Param body body PatchRequest true "Operations"
+// @Success 200 {object} Group
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /scim/v2/Groups/{id} [patch]
+func (c *SCIMController) PatchGroupHandler(ctx *gin.Context) {
+	var body PatchRequest
+	if !bindBody(ctx, &body) {
+		return
+	}
+
+	principal := middlewares.GetPrincipal(ctx)
+	group, err := c.service.PatchGroup(ctx.Request.Context(), principal.OrganizationID, principal.APIKeyID, ctx.Param("id"), body)
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+
+	respond(ctx, http.StatusOK, group)
+}
+
+// DeleteGroupHandler godoc
+// @Summary Delete a SCIM group
+// @Description Removes a team. Its members stay in the organization. Requires the scim:write scope.
+// @Tags scim
+// @Security ApiKeyAuth
+// @Param id path string true "Group ID"
+// @Success 204
+// @Failure 404 {object} ErrorResponse
+// @Router /scim/v2/Groups/{id} [delete]
+func (c *SCIMController) DeleteGroupHandler(ctx *gin.Context) {
+	principal := middlewares.GetPrincipal(ctx)
+	if err := c.service.DeleteGroup(ctx.Request.Context(), principal.OrganizationID, principal.APIKeyID, ctx.Param("id")); err != nil {
+		respondError(ctx, err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+// listQuery reads filter, startIndex, count and excludedAttributes.
+func listQuery(ctx *gin.Context) (ListQuery, bool) {
+	query := ListQuery{Filter: ctx.Query("filter"), StartIndex: 1, Count: defaultCount}
+	for name, target := range map[string]*int{"startIndex": &query.StartIndex, "count": &query.Count} {
+		value := ctx.Query(name)
+		if value == "" {
+			continue
+		}
+		number, err := strconv.Atoi(value)
+		if err != nil {
+			respondError(ctx, fmt.Errorf("%w: %s must be a number", ErrInvalidValue, name))
+			return query, false
+		}
+		*target = number
+	}
+	for _, attribute := range strings.Split(ctx.Query("excludedAttributes"), ",") {
+		switch strings.ToLower(strings.TrimSpace(attribute)) {
+		case "members":
+			query.ExcludeMembers = true
+		case "groups":
+			query.ExcludeGroups = true
+		}
+	}
+	return query, true
+}
+
+// bindBody decodes a JSON body, which SCIM clients send as
+// application/scim+json.
+func bindBody(ctx *gin.Context, body interface{}) bool {
+	decoder := json.NewDecoder(http.MaxBytesReader(ctx.Writer, ctx.Request.Body, maxBodyBytes))
+	if err := decoder.Decode(body); err != nil {
+		respondError(ctx, fmt.Errorf("%w: %v", ErrInvalidSyntax, err))
+		return false
+	}
+	return true
+}
+
+// respond writes a SCIM resource.
+func respond(ctx *gin.Context, status int, body interface{}) {
+	payload, err := json.Marshal(body)
+	if err != nil {
+		respondError(ctx, err)
+		return
+	}
+	ctx.Data(status, contentType, payload)
+}
+
+// respondError maps service errors to SCIM errors.
+func respondError(ctx *gin.Context, err error) {
+	status, scimType := http.StatusInternalServerError, ""
+	switch {
+	case errors.Is(err, ErrNotFound):
+		status = http.StatusNotFound
+	case errors.Is(err, ErrForbidden):
+		status = http.StatusForbidden
+	case errors.Is(err, ErrUniqueness):
+		status, scimType = http.StatusConflict, "uniqueness"
+	case errors.Is(err, plans.ErrSeatLimitReached):
+		status = http.StatusConflict
+	case errors.Is(err, ErrInvalidFilter):
+		status, scimType = http.StatusBadRequest, "invalidFilter"
+	case errors.Is(err, ErrInvalidSyntax):
+		status, scimType = http.StatusBadRequest, "invalidSyntax"
+	case errors.Is(err, ErrInvalidPath):
+		status, scimType = http.StatusBadRequest, "invalidPath"
+	case errors.Is(err, ErrInvalidValue):
+		status, scimType = http.StatusBadRequest, "invalidValue"
+	case errors.Is(err, ErrMutability):
+		status, scimType = http.StatusBadRequest, "mutability"
+	case errors.Is(err, ErrNoTarget):
+		status, scimType = http.StatusBadRequest, "noTarget"
+	case errors.Is(err, ErrTooMany):
+		status, scimType = http.StatusBadRequest, "tooMany"
+	}
+
+	payload, _ := json.Marshal(ErrorResponse{
+		Schemas:  []string{SchemaError},
+		Status:   strconv.Itoa(status),
+		ScimType: scimType,
+		Detail:   err.Error(),
+	})
+	ctx.Data(status, contentType, payload)
+}
@@ -0,0 +1,221 @@
+package scim
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"hostflow/profile-service/internal/middlewares"
+	"hostflow/profile-service/internal/plans"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockSCIMService struct {
+	mock.Mock
+}
+
+func (m *MockSCIMService) ListUsers(ctx context.Context, orgID int64, query ListQuery) (*ListResponse, error) {
+	args := m.Called(ctx, orgID, query)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*ListResponse), args.Error(1)
+}
+
+func (m *MockSCIMService) GetUser(ctx context.Context, orgID int64, id string) (*User, error) {
+	args := m.Called(ctx, orgID, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*User), args.Error(1)
+}
+
+func (m *MockSCIMService) CreateUser(ctx context.Context, orgID int64, apiKeyID int64, user User) (*User, error) {
+	args := m.Called(ctx, orgID, apiKeyID, user)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*User), args.Error(1)
+}
+
+func (m *MockSCIMService) ReplaceUser(ctx context.Context, orgID int64, apiKeyID int64, id string, user User) (*User, error) {
+	args := m.Called(ctx, orgID, apiKeyID, id, user)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*User), args.Error(1)
+}
+
+func (m *MockSCIMService) PatchUser(ctx context.Context, orgID int64, apiKeyID int64, id string, patch PatchRequest) (*User, error) {
+	args := m.Called(ctx, orgID, apiKeyID, id, patch)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*User), args.Error(1)
+}
+
+func (m *MockSCIMService) DeleteUser(ctx context.Context, orgID int64, apiKeyID int64, id string) error {
+	args := m.Called(ctx, orgID, apiKeyID, id)
+	return args.Error(0)
+}
+
+func (m *MockSCIMService) ListGroups(ctx context.Context, orgID int64, query ListQuery) (*ListResponse, error) {
+	args := m.Called(ctx, orgID, query)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*ListResponse), args.Error(1)
+}
+
+func (m *MockSCIMService) GetGroup(ctx context.Context, orgID int64, id string) (*Group, error) {
+	args := m.Called(ctx, orgID, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*Group), args.Error(1)
+}
+
+func (m *MockSCIMService) CreateGroup(ctx context.Context, orgID int64, apiKeyID int64, group Group) (*Group, error) {
+	args := m.Called(ctx, orgID, apiKeyID, group)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*Group), args.Error(1)
+}
+
+func (m *MockSCIMService) ReplaceGroup(ctx context.Context, orgID int64, apiKeyID int64, id string, group Group) (*Group, error) {
+	args := m.Called(ctx, orgID, apiKeyID, id, group)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*Group), args.Error(1)
+}
+
+func (m *MockSCIMService) PatchGroup(ctx context.Context, orgID int64, apiKeyID int64, id string, patch PatchRequest) (*Group, error) {
+	args := m.Called(ctx, orgID, apiKeyID, id, patch)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*Group), args.Error(1)
+}
+
+func (m *MockSCIMService) DeleteGroup(ctx context.Context, orgID int64, apiKeyID int64, id string) error {
+	args := m.Called(ctx, orgID, apiKeyID, id)
+	return args.Error(0)
+}
+
+func newSCIMRouter(service Service) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	controller := GetSCIMController(service)
+	r := gin.New()
+	withKey := func(handler gin.HandlerFunc) gin.HandlerFunc {
+		return func(c *gin.Context) {
+			middlewares.SetPrincipal(c, middlewares.Principal{OrganizationID: 1, Role: "OWNER", APIKeyID: 5})
+			handler(c)
+		}
+	}
+	r.GET("/scim/v2/Users", withKey(controller.ListUsersHandler))
+	r.POST("/scim/v2/Users", withKey(controller.CreateUserHandler))
+	r.PATCH("/scim/v2/Users/:id", withKey(controller.PatchUserHandler))
+	r.DELETE("/scim/v2/Users/:id", withKey(controller.DeleteUserHandler))
+	return r
+}
+
+func TestListUsersHandler_Query(t *testing.T) {
+	mockSvc := new(MockSCIMService)
+	query := ListQuery{Filter: `userName eq "ana@villa-bled.si"`, StartIndex: 3, Count: 10, ExcludeGroups: true}
+	mockSvc.On("ListUsers", mock.Anything, int64(1), query).
+		Return(listResponse([]User{}, 0, 2), nil)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", `/scim/v2/Users?filter=userName+eq+%22ana%40villa-bled.si%22&startIndex=3&count=10&excludedAttributes=groups`, nil)
+	newSCIMRouter(mockSvc).ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, contentType, w.Header().Get("Content-Type"))
+	assert.JSONEq(t, `{"schemas":["`+SchemaListResponse+`"],"totalResults":0,"startIndex":3,"itemsPerPage":0,"Resources":[]}`, w.Body.String())
+	mockSvc.AssertExpectations(t)
+}
+
+func TestListUsersHandler_InvalidCount(t *testing.T) {
+	mockSvc := new(MockSCIMService)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/scim/v2/Users?count=ten", nil)
+	newSCIMRouter(mockSvc).ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), `"scimType":"invalidValue"`)
+	mockSvc.AssertNotCalled(t, "ListUsers")
+}
+
+func TestCreateUserHandler_Created(t *testing.T) {
+	mockSvc := new(MockSCIMService)
+	body := User{Schemas: []string{SchemaUser}, UserName: "ana@villa-bled.si"}
+	created := &User{Schemas: []string{SchemaUser}, ID: "c1a0d9e4-1f7b-4d0e-9a53-0b8a4f3d2e11", UserName: "ana@villa-bled.si",
+		Meta: &Meta{ResourceType: "User", Location: "/scim/v2/Users/c1a0d9e4-1f7b-4d0e-9a53-0b8a4f3d2e11"}}
+	mockSvc.On("CreateUser", mock.Anything, int64(1), int64(5), body).Return(created, nil)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("POST", "/scim/v2/Users", strings.NewReader(`{"schemas":["`+SchemaUser+`"],"userName":"ana@villa-bled.si"}`))
+	req.Header.Set("Content-Type", contentType)
+	newSCIMRouter(mockSvc).ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusCreated, w.Code)
+	assert.Equal(t, created.Meta.Location, w.Header().Get("Location"))
+	mockSvc.AssertExpectations(t)
+}
+
+func TestCreateUserHandler_Errors(t *testing.T) {
+	cases := map[string]struct {
+		err      error
+		status   int
+		scimType string
+	}{
+		"taken":      {ErrUniqueness, http.StatusConflict, `"scimType":"uniqueness"`},
+		"seat limit": {&plans.SeatLimitError{Plan: "FREE", Limit: 5, Used: 5}, http.StatusConflict, `"status":"409"`},
+		"invalid":    {ErrInvalidValue, http.StatusBadRequest, `"scimType":"invalidValue"`},
+		"unverified": {fmt.Errorf("%w: userName must be an address on one of the organization's verified domains", ErrInvalidValue), http.StatusBadRequest, `verified domains`},
+	}
+	for name, c := range cases {
+		mockSvc := new(MockSCIMService)
+		mockSvc.On("CreateUser", mock.Anything, int64(1), int64(5), mock.Anything).Return(nil, c.err)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", "/scim/v2/Users", strings.NewReader(`{"userName":"ana@villa-bled.si"}`))
+		newSCIMRouter(mockSvc).ServeHTTP(w, req)
+
+		assert.Equal(t, c.status, w.Code, name)
+		assert.Contains(t, w.Body.String(), `"schemas":["`+SchemaError+`"]`, name)
+		assert.Contains(t, w.Body.String(), c.scimType, name)
+	}
+}
+
+func TestPatchUserHandler_InvalidBody(t *testing.T) {
+	mockSvc := new(MockSCIMService)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("PATCH", "/scim/v2/Users/abc", strings.NewReader(`{"Operations":`))
+	newSCIMRouter(mockSvc).ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), `"scimType":"invalidSyntax"`)
+}
+
+func TestDeleteUserHandler_Owner(t *testing.T) {
+	mockSvc := new(MockSCIMService)
+	mockSvc.On("DeleteUser", mock.Anything, int64(1), int64(5), "owner-id").Return(ErrForbidden)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("DELETE", "/scim/v2/Users/owner-id", nil)
+	newSCIMRouter(mockSvc).ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	mockSvc.AssertExpectations(t)
+}
@@ -0,0 +1,153 @@
+package scim
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// serviceProviderConfig tells clients which SCIM features are supported.
+var serviceProviderConfig = map[string]interface{}{
+	"schemas":        []string{SchemaServiceProvider},
+	"patch":          map[string]bool{"supported": true},
+	"bulk":           map[string]interface{}{"supported": false, "maxOperations": 0, "maxPayloadSize": 0},
+	"filter":         map[string]interface{}{"supported": true, "maxResults": maxCount},
+	"changePassword": map[string]bool{"supported": false},
+	"sort":           map[string]bool{"supported": false},
+	"etag":           map[string]bool{"supported": false},
+	"authenticationSchemes": []map[string]interface{}{{
+		"type":        "oauthbearertoken",
+		"name":        "Organization API key",
+		"description": "An organization API key with the scim:read and scim:write scopes, sent as a bearer token",
+		"primary":     true,
+	}},
+	"meta": map[string]string{"resourceType": "ServiceProviderConfig", "location": basePath + "/ServiceProviderConfig"},
+}
+
+// resourceTypes are the resources served.
+var resourceTypes = []map[string]interface{}{
+	{
+		"schemas":     []string{SchemaResourceType},
+		"id":          "User",
+		"name":        "User",
+		"endpoint":    "/Users",
+		"description": "Member of the organization",
+		"schema":      SchemaUser,
+		"meta":        map[string]string{"resourceType": "ResourceType", "location": basePath + "/ResourceTypes/User"},
+	},
+	{
+		"schemas":     []string{SchemaResourceType},
+		"id":          "Group",
+		"name":        "Group",
+		"endpoint":    "/Groups",
+		"description": "Team of the organization",
+		"schema":      SchemaGroup,
+		"meta":        map[string]string{"resourceType": "ResourceType", "location": basePath + "/ResourceTypes/Group"},
+	},
+}
+
+// schemas describe the supported attributes of users and groups.
+var schemas = []map[string]interface{}{
+	{
+		"schemas":     []string{SchemaSchema},
+		"id":          SchemaUser,
+		"name":        "User",
+		"description": "Member of the organization",
+		"attributes": []map[string]interface{}{
+			attribute("userName", "string", "readWrite", "server", true),
+			attribute("externalId", "string", "readWrite", "server", false),
+			subAttributes("name", "readWrite",
+				attribute("formatted", "string", "readWrite", "none", false),
+				attribute("givenName", "string", "readWrite", "none", false),
+				attribute("familyName", "string", "readWrite", "none", false),
+			),
+			attribute("displayName", "string", "readWrite", "none", false),
+			multiValued(subAttributes("emails", "readWrite",
+				attribute("value", "string", "readWrite", "none", false),
+				attribute("primary", "boolean", "readWrite", "none", false),
+			)),
+			attribute("active", "boolean", "readWrite", "none", false),
+			multiValued(subAttributes("roles", "readWrite",
+				attribute("value", "string", "readWrite", "none", false),
+				attribute("primary", "boolean", "readWrite", "none", false),
+			)),
+			multiValued(subAttributes("groups", "readOnly",
+				attribute("value", "string", "readOnly", "none", false),
+				attribute("display", "string", "readOnly", "none", false),
+			)),
+		},
+		"meta": map[string]string{"resourceType": "Schema", "location": basePath + "/Schemas/" + SchemaUser},
+	},
+	{
+		"schemas":     []string{SchemaSchema},
+		"id":          SchemaGroup,
+		"name":        "Group",
+		"description": "Team of the organization",
+		"attributes": []map[string]interface{}{
+			attribute("displayName", "string", "readWrite", "server", true),
+			attribute("externalId", "string", "readWrite", "server", false),
+			multiValued(subAttributes("members", "readWrite",
+				attribute("value", "string", "immutable", "none", false),
+				attribute("display", "string", "readOnly", "none", false),
+			)),
+		},
+		"meta": map[string]string{"resourceType": "Schema", "location": basePath + "/Schemas/" + SchemaGroup},
+	},
+}
+
+func attribute(name string, kind string, mutability string, uniqueness string, required bool) map[string]interface{} {
+	return map[string]interface{}{
+		"name":        name,
+		"type":        kind,
+		"multiValued": false,
+		"required":    required,
+		"caseExact":   false,
+		"mutability":  mutability,
+		"returned":    "default",
+		"uniqueness":  uniqueness,
+	}
+}
+
+func subAttributes(name string, mutability string, attributes ...map[string]interface{}) map[string]interface{} {
+	object := attribute(name, "complex", mutability, "none", false)
+	object["subAttributes"] = attributes
+	return object
+}
+
+func multiValued(attribute map[string]interface{}) map[string]interface{} {
+	attribute["multiValued"] = true
+	return attribute
+}
+
+// ServiceProviderConfigHandler godoc
+// @Summary SCIM service provider configuration
+// @Description Describes the supported SCIM features: PATCH and eq filters, without bulk, sorting or ETags.
+// @Tags scim
+// @Produce json
+// @Success 200 {object} map[string]interface{}
+// @Router /scim/v2/ServiceProviderConfig [get]
+func (c *SCIMController) ServiceProviderConfigHandler(ctx *gin.Context) {
+	respond(ctx, http.StatusOK, serviceProviderConfig)
+}
+
+// ResourceTypesHandler godoc
+// @Summary SCIM resource types
+// @Description Lists the User and Group resource types.
+// @Tags scim
+// @Produce json
+// @Success 200 {object} ListResponse
+// @Router /scim/v2/ResourceTypes [get]
+func (c *SCIMController) ResourceTypesHandler(ctx *gin.Context) {
+	respond(ctx, http.StatusOK, listResponse(resourceTypes, len(resourceTypes), 0))
+}
+
+// SchemasHandler godoc
+// @Summary SCIM schemas
+// @Description Describes the supported attributes of users and groups.
+// @Tags scim
+// @Produce json
+// @Success 200 {object} ListResponse
+// @Router /scim/v2/Schemas [get]
+func (c *SCIMController) SchemasHandler(ctx *gin.Context) {
+	respond(ctx, http.StatusOK, listResponse(schemas, len(schemas), 0))
+}
@@ -0,0 +1,108 @@
+package scim
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// condition is "<attribute> eq <value>" of a filter. Attribute is lower
+// case; value is a string, a bool or nil.
+type condition struct {
+	Attribute string
+	Value     interface{}
+}
+
+// parseFilter parses the subset of SCIM filters identity providers send:
+// equality comparisons joined with "and", e.g.
+// userName eq "ana@villa-bled.si" and active eq true.
+func parseFilter(filter string) ([]condition, error) {
+	tokens, err := tokenize(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	var conditions []condition
+	for len(tokens) > 0 {
+		if len(conditions) > 0 {
+			if !strings.EqualFold(tokens[0].text, "and") || tokens[0].quoted {
+				return nil, fmt.Errorf("%w: only \"and\" can join comparisons", ErrInvalidFilter)
+			}
+			tokens = tokens[1:]
+		}
+		if len(tokens) < 3 || tokens[0].quoted || tokens[1].quoted {
+			return nil, fmt.Errorf("%w: expected <attribute> eq <value>", ErrInvalidFilter)
+		}
+		if !strings.EqualFold(tokens[1].text, "eq") {
+			return nil, fmt.Errorf("%w: operator %q is not supported, only eq", ErrInvalidFilter, tokens[1].text)
+		}
+
+		value, err := tokens[2].value()
+		if err != nil {
+			return nil, err
+		}
+		attribute := strings.ToLower(tokens[0].text)
+		attribute = strings.TrimPrefix(attribute, strings.ToLower(SchemaUser)+":")
+		attribute = strings.TrimPrefix(attribute, strings.ToLower(SchemaGroup)+":")
+		conditions = append(conditions, condition{Attribute: attribute, Value: value})
+		tokens = tokens[3:]
+	}
+	return conditions, nil
+}
+
+type token struct {
+	text   string
+	quoted bool
+}
+
+// value converts a comparison value: a JSON string, true, false or null.
+func (t token) value() (interface{}, error) {
+	if t.quoted {
+		return t.text, nil
+	}
+	switch strings.ToLower(t.text) {
+	case "true":
+		return true, nil
+	case "false":
+		return false, nil
+	case "null":
+		return nil, nil
+	}
+	return nil, fmt.Errorf("%w: value %s must be quoted", ErrInvalidFilter, t.text)
+}
+
+// tokenize splits a filter at spaces, keeping quoted strings together.
+func tokenize(filter string) ([]token, error) {
+	var tokens []token
+	for i := 0; i < len(filter); {
+		switch {
+		case filter[i] == ' ':
+			i++
+		case filter[i] == '"':
+			end := i + 1
+			for end < len(filter) && filter[end] != '"' {
+				if filter[end] == '\\' {
+					end++
+				}
+				end++
+			}
+			if end >= len(filter) {
+				return nil, fmt.Errorf("%w: unterminated string", ErrInvalidFilter)
+			}
+			text, err := strconv.Unquote(filter[i : end+1])
+			if err != nil {
+				return nil, fmt.Errorf("%w: malformed string %s", ErrInvalidFilter, filter[i:end+1])
+			}
+			tokens = append(tokens, token{text: text, quoted: true})
+			i = end + 1
+		default:
+			end := i
+			for end < len(filter) && filter[end] != ' ' {
+				end++
+			}
+			tokens = append(tokens, token{text: filter[i:end]})
+			i = end
+		}
+	}
+	return tokens, nil
+}
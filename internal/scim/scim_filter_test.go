@@ -0,0 +1,71 @@
+package scim
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseFilter(t *testing.T) {
+	conditions, err := parseFilter(`userName eq "ana@villa-bled.si" and active eq true`)
+	require.NoError(t, err)
+	assert.Equal(t, []condition{
+		{Attribute: "username", Value: "ana@villa-bled.si"},
+		{Attribute: "active", Value: true},
+	}, conditions)
+
+	conditions, err = parseFilter(`urn:ietf:params:scim:schemas:core:2.0:User:externalId EQ "a \"b\""`)
+	require.NoError(t, err)
+	assert.Equal(t, []condition{{Attribute: "externalid", Value: `a "b"`}}, conditions)
+}
+
+func TestParseFilter_Rejects(t *testing.T) {
+	for _, filter := range []string{
+		`userName co "ana"`,
+		`userName eq ana`,
+		`userName eq "ana" or active eq true`,
+		`userName eq "ana`,
+		`userName eq`,
+	} {
+		_, err := parseFilter(filter)
+		assert.True(t, errors.Is(err, ErrInvalidFilter), filter)
+	}
+}
+
+func TestUserConditions(t *testing.T) {
+	conditions, err := userConditions(`emails.value eq "Ana@Villa-Bled.si" and active eq false`)
+	require.NoError(t, err)
+	assert.Equal(t, []sqlCondition{
+		{Expression: "lower(email)", Value: "ana@villa-bled.si"},
+		{Expression: "(status <> 'INACTIVE')", Value: false},
+	}, conditions)
+
+	_, err = userConditions(`active eq "yes"`)
+	assert.True(t, errors.Is(err, ErrInvalidFilter))
+	_, err = userConditions(`title eq "Chef"`)
+	assert.True(t, errors.Is(err, ErrInvalidFilter))
+}
+
+func TestWhereClause(t *testing.T) {
+	where, args := whereClause("organization_id = $1", []any{int64(7)}, []sqlCondition{
+		{Expression: "external_id", Value: "00u1"},
+		{Expression: "name", Value: "Housekeeping"},
+	})
+	assert.Equal(t, "organization_id = $1 AND external_id = $2 AND name = $3", where)
+	assert.Equal(t, []any{int64(7), "00u1", "Housekeeping"}, args)
+}
+
+func TestPage(t *testing.T) {
+	offset, limit := page(ListQuery{StartIndex: 0, Count: defaultCount})
+	assert.Equal(t, 0, offset)
+	assert.Equal(t, defaultCount, limit)
+
+	offset, limit = page(ListQuery{StartIndex: 11, Count: 1000})
+	assert.Equal(t, 10, offset)
+	assert.Equal(t, maxCount, limit)
+
+	_, limit = page(ListQuery{StartIndex: 1, Count: -5})
+	assert.Equal(t, 0, limit)
+}
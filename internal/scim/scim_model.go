@@ -0,0 +1,259 @@
+package scim
+
+import (
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Schema URNs of RFC 7643 and RFC 7644.
+const (
+	SchemaUser             = "urn:ietf:params:scim:schemas:core:2.0:User"
+	SchemaGroup            = "urn:ietf:params:scim:schemas:core:2.0:Group"
+	SchemaListResponse     = "urn:ietf:params:scim:api:messages:2.0:ListResponse"
+	SchemaPatchOp          = "urn:ietf:params:scim:api:messages:2.0:PatchOp"
+	SchemaError            = "urn:ietf:params:scim:api:messages:2.0:Error"
+	SchemaServiceProvider  = "urn:ietf:params:scim:schemas:core:2.0:ServiceProviderConfig"
+	SchemaResourceType     = "urn:ietf:params:scim:schemas:core:2.0:ResourceType"
+	SchemaSchema           = "urn:ietf:params:scim:schemas:core:2.0:Schema"
+	contentType            = "application/scim+json"
+	basePath               = "/scim/v2"
+	defaultCount           = 100
+	maxCount               = 200
+	maxOperationsPerPatch  = 100
+	maxMembersPerOperation = 1000
+)
+
+// User is a SCIM user, mapped onto a profile of the organization. userName
+// is the member's email.
+type User struct {
+	Schemas     []string   `json:"schemas"`
+	ID          string     `json:"id,omitempty"`
+	ExternalID  string     `json:"externalId,omitempty"`
+	UserName    string     `json:"userName"`
+	Name        *Name      `json:"name,omitempty"`
+	DisplayName string     `json:"displayName,omitempty"`
+	Emails      []Email    `json:"emails,omitempty"`
+	Active      *bool      `json:"active,omitempty"`
+	Roles       []Role     `json:"roles,omitempty"`
+	Groups      []GroupRef `json:"groups,omitempty"`
+	Meta        *Meta      `json:"meta,omitempty"`
+}
+
+type Name struct {
+	Formatted  string `json:"formatted,omitempty"`
+	GivenName  string `json:"givenName,omitempty"`
+	FamilyName string `json:"familyName,omitempty"`
+}
+
+type Email struct {
+	Value   string `json:"value"`
+	Type    string `json:"type,omitempty"`
+	Primary bool   `json:"primary,omitempty"`
+}
+
+// Role is the member's role in the organization: MANAGER or MEMBER. OWNER
+// cannot be provisioned.
+type Role struct {
+	Value   string `json:"value"`
+	Primary bool   `json:"primary,omitempty"`
+}
+
+// GroupRef is a team the user belongs to. It is read-only on users.
+type GroupRef struct {
+	Value   string `json:"value"`
+	Display string `json:"display,omitempty"`
+	Ref     string `json:"$ref,omitempty"`
+}
+
+// Group is a SCIM group, mapped onto a team of the organization.
+type Group struct {
+	Schemas     []string    `json:"schemas"`
+	ID          string      `json:"id,omitempty"`
+	ExternalID  string      `json:"externalId,omitempty"`
+	DisplayName string      `json:"displayName"`
+	Members     []MemberRef `json:"members,omitempty"`
+	Meta        *Meta       `json:"meta,omitempty"`
+}
+
+// MemberRef is a user in a group.
+type MemberRef struct {
+	Value   string `json:"value"`
+	Display string `json:"display,omitempty"`
+	Ref     string `json:"$ref,omitempty"`
+}
+
+type Meta struct {
+	ResourceType string    `json:"resourceType"`
+	Created      time.Time `json:"created"`
+	LastModified time.Time `json:"lastModified"`
+	Location     string    `json:"location"`
+}
+
+// ListResponse is a page of resources. startIndex is 1-based.
+type ListResponse struct {
+	Schemas      []string    `json:"schemas"`
+	TotalResults int         `json:"totalResults"`
+	StartIndex   int         `json:"startIndex"`
+	ItemsPerPage int         `json:"itemsPerPage"`
+	Resources    interface{} `json:"Resources"`
+}
+
+// PatchRequest is the body of a PATCH request.
+type PatchRequest struct {
+	Schemas    []string         `json:"schemas"`
+	Operations []PatchOperation `json:"Operations"`
+}
+
+// PatchOperation changes the attribute at Path, or the attributes in Value
+// when there is no path. Op is add, replace or remove, in any case.
+type PatchOperation struct {
+	Op    string      `json:"op"`
+	Path  string      `json:"path,omitempty"`
+	Value interface{} `json:"value,omitempty"`
+}
+
+// ErrorResponse is a SCIM error. scimType narrows down 400 and 409 errors.
+type ErrorResponse struct {
+	Schemas  []string `json:"schemas"`
+	Status   string   `json:"status"`
+	ScimType string   `json:"scimType,omitempty"`
+	Detail   string   `json:"detail,omitempty"`
+}
+
+// ListQuery selects a page of resources.
+type ListQuery struct {
+	Filter         string
+	StartIndex     int
+	Count          int
+	ExcludeMembers bool
+	ExcludeGroups  bool
+}
+
+// profileRecord is a profile as SCIM sees it.
+type profileRecord struct {
+	ID         uuid.UUID `db:"id"`
+	FullName   string    `db:"full_name"`
+	Email      string    `db:"email"`
+	Role       string    `db:"role"`
+	Status     string    `db:"status"`
+	ExternalID *string   `db:"external_id"`
+	CreatedAt  time.Time `db:"created_at"`
+	UpdatedAt  time.Time `db:"updated_at"`
+}
+
+// teamRecord is a team as SCIM sees it.
+type teamRecord struct {
+	ID         int64     `db:"id"`
+	Name       string    `db:"name"`
+	ExternalID *string   `db:"external_id"`
+	CreatedAt  time.Time `db:"created_at"`
+	UpdatedAt  time.Time `db:"updated_at"`
+}
+
+// teamMember is a profile in a team.
+type teamMember struct {
+	TeamID    int64     `db:"team_id"`
+	TeamName  string    `db:"team_name"`
+	ProfileID uuid.UUID `db:"profile_id"`
+	FullName  string    `db:"full_name"`
+}
+
+// profileFields are the profile attributes a SCIM user sets.
+type profileFields struct {
+	Email      string
+	FullName   string
+	Role       string
+	Active     bool
+	ExternalID *string
+}
+
+// groupFields are the team attributes a SCIM group sets.
+type groupFields struct {
+	Name       string
+	ExternalID *string
+	Members    []uuid.UUID
+}
+
+// toUser renders a profile with its teams.
+func toUser(record profileRecord, teams []teamMember) User {
+	active := record.Status != "INACTIVE"
+	user := User{
+		Schemas:     []string{SchemaUser},
+		ID:          record.ID.String(),
+		UserName:    record.Email,
+		Name:        splitName(record.FullName),
+		DisplayName: record.FullName,
+		Emails:      []Email{{Value: record.Email, Type: "work", Primary: true}},
+		Active:      &active,
+		Roles:       []Role{{Value: record.Role, Primary: true}},
+		Meta: &Meta{
+			ResourceType: "User",
+			Created:      record.CreatedAt,
+			LastModified: record.UpdatedAt,
+			Location:     basePath + "/Users/" + record.ID.String(),
+		},
+	}
+	if record.ExternalID != nil {
+		user.ExternalID = *record.ExternalID
+	}
+	for _, team := range teams {
+		id := formatID(team.TeamID)
+		user.Groups = append(user.Groups, GroupRef{Value: id, Display: team.TeamName, Ref: basePath + "/Groups/" + id})
+	}
+	return user
+}
+
+// toGroup renders a team with its members.
+func toGroup(record teamRecord, members []teamMember) Group {
+	id := formatID(record.ID)
+	group := Group{
+		Schemas:     []string{SchemaGroup},
+		ID:          id,
+		DisplayName: record.Name,
+		Meta: &Meta{
+			ResourceType: "Group",
+			Created:      record.CreatedAt,
+			LastModified: record.UpdatedAt,
+			Location:     basePath + "/Groups/" + id,
+		},
+	}
+	if record.ExternalID != nil {
+		group.ExternalID = *record.ExternalID
+	}
+	for _, member := range members {
+		value := member.ProfileID.String()
+		group.Members = append(group.Members, MemberRef{Value: value, Display: member.FullName, Ref: basePath + "/Users/" + value})
+	}
+	return group
+}
+
+// splitName splits a full name at the first space, since profiles keep a
+// single name.
+func splitName(fullName string) *Name {
+	given, family, _ := strings.Cut(fullName, " ")
+	return &Name{Formatted: fullName, GivenName: given, FamilyName: family}
+}
+
+// fullName is the name to store for the user: the formatted name, the
+// given and family names, the display name or the email's local part, in
+// that order.
+func (u User) fullName() string {
+	if u.Name != nil {
+		if formatted := strings.TrimSpace(u.Name.Formatted); formatted != "" {
+			return formatted
+		}
+		if joined := strings.TrimSpace(u.Name.GivenName + " " + u.Name.FamilyName); joined != "" {
+			return joined
+		}
+	}
+	if display := strings.TrimSpace(u.DisplayName); display != "" {
+		return display
+	}
+	email := strings.TrimSpace(u.UserName)
+	if at := strings.LastIndex(email, "@"); at > 0 {
+		return email[:at]
+	}
+	return email
+}
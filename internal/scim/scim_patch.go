@@ -0,0 +1,317 @@
+package scim
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Patch operations.
+const (
+	opAdd     = "add"
+	opReplace = "replace"
+	opRemove  = "remove"
+)
+
+// applyUserPatch applies the operations to the user. The result is then
+// saved like a replacement, so it is validated the same way.
+func applyUserPatch(user *User, operations []PatchOperation) error {
+	return applyPatch(operations, func(op string, path string, value interface{}) error {
+		return patchUserAttribute(user, op, path, value)
+	})
+}
+
+// applyGroupPatch applies the operations to the group.
+func applyGroupPatch(group *Group, operations []PatchOperation) error {
+	return applyPatch(operations, func(op string, path string, value interface{}) error {
+		return patchGroupAttribute(group, op, path, value)
+	})
+}
+
+// applyPatch runs each operation, splitting operations without a path into
+// one per attribute of their value.
+func applyPatch(operations []PatchOperation, apply func(op string, path string, value interface{}) error) error {
+	if len(operations) == 0 {
+		return fmt.Errorf("%w: Operations must not be empty", ErrInvalidSyntax)
+	}
+	if len(operations) > maxOperationsPerPatch {
+		return fmt.Errorf("%w: at most %d operations are allowed", ErrTooMany, maxOperationsPerPatch)
+	}
+
+	for _, operation := range operations {
+		op := strings.ToLower(operation.Op)
+		if op != opAdd && op != opReplace && op != opRemove {
+			return fmt.Errorf("%w: unknown op %q", ErrInvalidSyntax, operation.Op)
+		}
+
+		if operation.Path != "" {
+			if err := apply(op, operation.Path, operation.Value); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if op == opRemove {
+			return fmt.Errorf("%w: remove needs a path", ErrNoTarget)
+		}
+		attributes, ok := operation.Value.(map[string]interface{})
+		if !ok {
+			return fmt.Errorf("%w: an operation without a path needs an object value", ErrInvalidValue)
+		}
+		for path, value := range attributes {
+			if err := apply(op, path, value); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func patchUserAttribute(user *User, op string, path string, value interface{}) error {
+	path = strings.TrimPrefix(strings.ToLower(path), strings.ToLower(SchemaUser)+":")
+	remove := op == opRemove
+
+	switch {
+	case path == "active":
+		if remove {
+			return fmt.Errorf("%w: active cannot be removed", ErrMutability)
+		}
+		active, err := boolValue(path, value)
+		if err != nil {
+			return err
+		}
+		user.Active = &active
+	case path == "username":
+		if remove {
+			return fmt.Errorf("%w: userName cannot be removed", ErrMutability)
+		}
+		return setString(&user.UserName, path, value)
+	case path == "displayname":
+		if remove {
+			user.DisplayName = ""
+			return nil
+		}
+		return setString(&user.DisplayName, path, value)
+	case path == "externalid":
+		if remove {
+			user.ExternalID = ""
+			return nil
+		}
+		return setString(&user.ExternalID, path, value)
+	case path == "name":
+		if remove {
+			user.Name = nil
+			return nil
+		}
+		attributes, ok := value.(map[string]interface{})
+		if !ok {
+			return fmt.Errorf("%w: name must be an object", ErrInvalidValue)
+		}
+		for key, part := range attributes {
+			if err := patchUserAttribute(user, op, "name."+key, part); err != nil {
+				return err
+			}
+		}
+	case strings.HasPrefix(path, "name."):
+		if user.Name == nil {
+			user.Name = &Name{}
+		}
+		var target *string
+		switch strings.TrimPrefix(path, "name.") {
+		case "formatted":
+			target = &user.Name.Formatted
+		case "givenname":
+			target = &user.Name.GivenName
+		case "familyname":
+			target = &user.Name.FamilyName
+		default:
+			return fmt.Errorf("%w: %s is not supported", ErrInvalidPath, path)
+		}
+		if remove {
+			*target = ""
+			return nil
+		}
+		return setString(target, path, value)
+	case path == "roles":
+		if remove {
+			user.Roles = nil
+			return nil
+		}
+		roles, err := rolesValue(value)
+		if err != nil {
+			return err
+		}
+		if op == opAdd {
+			roles = append(user.Roles, roles...)
+		}
+		user.Roles = roles
+	case strings.HasPrefix(path, "emails"):
+		// Emails mirror userName; they may be restated but not changed
+		if remove {
+			return nil
+		}
+		for _, email := range stringValues(value) {
+			if !strings.EqualFold(email, user.UserName) {
+				return fmt.Errorf("%w: emails follow userName and cannot be changed", ErrMutability)
+			}
+		}
+	case path == "id" || path == "schemas" || path == "meta":
+		// Sent back unchanged by some clients along with the attributes
+	default:
+		return fmt.Errorf("%w: %s is not supported", ErrInvalidPath, path)
+	}
+	return nil
+}
+
+func patchGroupAttribute(group *Group, op string, path string, value interface{}) error {
+	lower := strings.TrimPrefix(strings.ToLower(path), strings.ToLower(SchemaGroup)+":")
+	remove := op == opRemove
+
+	switch {
+	case lower == "displayname":
+		if remove {
+			return fmt.Errorf("%w: displayName cannot be removed", ErrMutability)
+		}
+		return setString(&group.DisplayName, lower, value)
+	case lower == "externalid":
+		if remove {
+			group.ExternalID = ""
+			return nil
+		}
+		return setString(&group.ExternalID, lower, value)
+	case lower == "members":
+		if remove && value == nil {
+			group.Members = nil
+			return nil
+		}
+		members, err := membersValue(value)
+		if err != nil {
+			return err
+		}
+		switch op {
+		case opAdd:
+			group.Members = append(group.Members, members...)
+		case opReplace:
+			group.Members = members
+		case opRemove:
+			group.Members = withoutMembers(group.Members, members)
+		}
+	case strings.HasPrefix(lower, "members["):
+		if !remove {
+			return fmt.Errorf("%w: only remove can target members with a filter", ErrInvalidPath)
+		}
+		filter, ok := strings.CutSuffix(path[len("members["):], "]")
+		if !ok {
+			return fmt.Errorf("%w: malformed path %s", ErrInvalidPath, path)
+		}
+		conditions, err := parseFilter(filter)
+		if err != nil {
+			return err
+		}
+		var members []MemberRef
+		for _, c := range conditions {
+			id, ok := c.Value.(string)
+			if c.Attribute != "value" || !ok {
+				return fmt.Errorf("%w: members can only be filtered by value", ErrInvalidFilter)
+			}
+			members = append(members, MemberRef{Value: id})
+		}
+		group.Members = withoutMembers(group.Members, members)
+	case lower == "id" || lower == "schemas" || lower == "meta":
+		// Sent back unchanged by some clients along with the attributes
+	default:
+		return fmt.Errorf("%w: %s is not supported", ErrInvalidPath, path)
+	}
+	return nil
+}
+
+// withoutMembers drops the given members.
+func withoutMembers(members []MemberRef, drop []MemberRef) []MemberRef {
+	var kept []MemberRef
+	for _, member := range members {
+		found := false
+		for _, d := range drop {
+			if strings.EqualFold(member.Value, d.Value) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			kept = append(kept, member)
+		}
+	}
+	return kept
+}
+
+func setString(target *string, path string, value interface{}) error {
+	text, ok := value.(string)
+	if !ok {
+		return fmt.Errorf("%w: %s must be a string", ErrInvalidValue, path)
+	}
+	*target = text
+	return nil
+}
+
+// boolValue accepts booleans and, as some identity providers send them,
+// "true" and "false" in any case.
+func boolValue(path string, value interface{}) (bool, error) {
+	switch v := value.(type) {
+	case bool:
+		return v, nil
+	case string:
+		switch strings.ToLower(v) {
+		case "true":
+			return true, nil
+		case "false":
+			return false, nil
+		}
+	}
+	return false, fmt.Errorf("%w: %s must be a boolean", ErrInvalidValue, path)
+}
+
+// stringValues collects the strings of a value: a string, a {"value": ...}
+// object or a list of either.
+func stringValues(value interface{}) []string {
+	switch v := value.(type) {
+	case string:
+		return []string{v}
+	case map[string]interface{}:
+		if text, ok := v["value"].(string); ok {
+			return []string{text}
+		}
+	case []interface{}:
+		var values []string
+		for _, item := range v {
+			values = append(values, stringValues(item)...)
+		}
+		return values
+	}
+	return nil
+}
+
+func rolesValue(value interface{}) ([]Role, error) {
+	if list, ok := value.([]interface{}); ok && len(list) == 0 {
+		return nil, nil
+	}
+	var roles []Role
+	for _, role := range stringValues(value) {
+		roles = append(roles, Role{Value: role})
+	}
+	if roles == nil {
+		return nil, fmt.Errorf("%w: roles must be a list of {\"value\": ...}", ErrInvalidValue)
+	}
+	return roles, nil
+}
+
+func membersValue(value interface{}) ([]MemberRef, error) {
+	if list, ok := value.([]interface{}); ok && len(list) == 0 {
+		return nil, nil
+	}
+	var members []MemberRef
+	for _, id := range stringValues(value) {
+		members = append(members, MemberRef{Value: id})
+	}
+	if members == nil {
+		return nil, fmt.Errorf("%w: members must be a list of {\"value\": ...}", ErrInvalidValue)
+	}
+	return members, nil
+}
@@ -0,0 +1,98 @@
+package scim
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testUser() User {
+	active := true
+	return User{
+		UserName: "ana@villa-bled.si",
+		Name:     &Name{Formatted: "Ana Novak", GivenName: "Ana", FamilyName: "Novak"},
+		Active:   &active,
+		Roles:    []Role{{Value: "MEMBER", Primary: true}},
+	}
+}
+
+func TestApplyUserPatch_Deactivate(t *testing.T) {
+	// Azure AD sends booleans as strings and capitalizes ops
+	user := testUser()
+	err := applyUserPatch(&user, []PatchOperation{{Op: "Replace", Path: "active", Value: "False"}})
+	require.NoError(t, err)
+	assert.False(t, *user.Active)
+}
+
+func TestApplyUserPatch_WithoutPath(t *testing.T) {
+	user := testUser()
+	err := applyUserPatch(&user, []PatchOperation{{
+		Op: "replace",
+		Value: map[string]interface{}{
+			"name.givenName": "Anja",
+			"externalId":     "00u1",
+			"roles":          []interface{}{map[string]interface{}{"value": "MANAGER"}},
+		},
+	}})
+	require.NoError(t, err)
+	assert.Equal(t, "Anja", user.Name.GivenName)
+	assert.Equal(t, "00u1", user.ExternalID)
+	assert.Equal(t, []Role{{Value: "MANAGER"}}, user.Roles)
+}
+
+func TestApplyUserPatch_Rejects(t *testing.T) {
+	cases := map[string]struct {
+		operation PatchOperation
+		err       error
+	}{
+		"unknown op":       {PatchOperation{Op: "move", Path: "active", Value: true}, ErrInvalidSyntax},
+		"remove userName":  {PatchOperation{Op: "remove", Path: "userName"}, ErrMutability},
+		"change email":     {PatchOperation{Op: "replace", Path: "emails[type eq \"work\"].value", Value: "ana@drugje.si"}, ErrMutability},
+		"unknown path":     {PatchOperation{Op: "replace", Path: "title", Value: "Chef"}, ErrInvalidPath},
+		"bad active":       {PatchOperation{Op: "replace", Path: "active", Value: "maybe"}, ErrInvalidValue},
+		"remove with none": {PatchOperation{Op: "remove"}, ErrNoTarget},
+	}
+	for name, c := range cases {
+		user := testUser()
+		err := applyUserPatch(&user, []PatchOperation{c.operation})
+		assert.True(t, errors.Is(err, c.err), name)
+	}
+}
+
+func TestApplyGroupPatch_Members(t *testing.T) {
+	group := Group{DisplayName: "Housekeeping", Members: []MemberRef{{Value: "a"}, {Value: "b"}}}
+
+	err := applyGroupPatch(&group, []PatchOperation{
+		{Op: "add", Path: "members", Value: []interface{}{map[string]interface{}{"value": "c"}}},
+		{Op: "remove", Path: `members[value eq "a"]`},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []MemberRef{{Value: "b"}, {Value: "c"}}, group.Members)
+
+	err = applyGroupPatch(&group, []PatchOperation{{Op: "replace", Path: "members", Value: []interface{}{}}})
+	require.NoError(t, err)
+	assert.Empty(t, group.Members)
+}
+
+func TestUserFields(t *testing.T) {
+	fields, err := userFields(testUser(), nil)
+	require.NoError(t, err)
+	assert.Equal(t, profileFields{Email: "ana@villa-bled.si", FullName: "Ana Novak", Role: "MEMBER", Active: true}, fields)
+
+	user := testUser()
+	user.Roles = []Role{{Value: "OWNER"}}
+	_, err = userFields(user, nil)
+	assert.True(t, errors.Is(err, ErrInvalidValue))
+
+	// The owner may restate their role
+	fields, err = userFields(user, &profileRecord{Role: "OWNER", Status: "ACTIVE"})
+	require.NoError(t, err)
+	assert.Equal(t, "OWNER", fields.Role)
+
+	user = testUser()
+	user.UserName = "ana"
+	_, err = userFields(user, nil)
+	assert.True(t, errors.Is(err, ErrInvalidValue))
+}
@@ -0,0 +1,418 @@
+package scim
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"hostflow/profile-service/internal/plans"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+const (
+	profileColumns = `id, full_name, email, role, status, external_id, created_at, updated_at`
+	teamColumns    = `id, name, external_id, created_at, updated_at`
+)
+
+// sqlCondition narrows a listing to rows where Expression equals Value.
+// Expressions come from the service's attribute mappings, never from input.
+type sqlCondition struct {
+	Expression string
+	Value      interface{}
+}
+
+type SCIMRepository struct {
+	db *pgxpool.Pool
+}
+
+func GetSCIMRepository(db *pgxpool.Pool) *SCIMRepository {
+	return &SCIMRepository{
+		db: db,
+	}
+}
+
+// ListProfiles returns a page of the organization's profiles that match
+// the conditions, and how many match in total.
+func (r *SCIMRepository) ListProfiles(ctx context.Context, orgID int64, conditions []sqlCondition, offset int, limit int) ([]profileRecord, int, error) {
+	where, args := whereClause(`organization_id = $1 AND deleted_at IS NULL`, []any{orgID}, conditions)
+	return list[profileRecord](ctx, r.db, `"profiles"`, profileColumns, where, args, offset, limit)
+}
+
+// FindProfile returns a profile of the organization that is not deleted.
+func (r *SCIMRepository) FindProfile(ctx context.Context, orgID int64, id uuid.UUID) (*profileRecord, error) {
+	rows, err := r.db.Query(ctx,
+		`SELECT `+profileColumns+` FROM "profiles" WHERE id = $1 AND organization_id = $2 AND deleted_at IS NULL`,
+		id, orgID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return collectOne[profileRecord](rows)
+}
+
+// HasVerifiedDomain reports whether the domain is one of the organization's
+// verified domains.
+func (r *SCIMRepository) HasVerifiedDomain(ctx context.Context, orgID int64, domain string) (bool, error) {
+	var verified bool
+	err := r.db.QueryRow(ctx, `
+        SELECT EXISTS (
+            SELECT 1 FROM organization_domains
+            WHERE organization_id = $1 AND domain = $2 AND verified_at IS NOT NULL
+        )`,
+		orgID, domain,
+	).Scan(&verified)
+	return verified, err
+}
+
+// FindIdentity returns the ID of the Supabase Auth user with the email.
+func (r *SCIMRepository) FindIdentity(ctx context.Context, email string) (*uuid.UUID, error) {
+	var id uuid.UUID
+	err := r.db.QueryRow(ctx, `SELECT id FROM auth.users WHERE lower(email) = lower($1)`, email).Scan(&id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &id, nil
+}
+
+// CreateProfile adds the user to the organization. A profile the
+// organization deleted before is restored. Active members take a seat.
+// Additions for the same user are serialized with the advisory lock
+// sign-up and joining take, so a user cannot end up in two organizations.
+func (r *SCIMRepository) CreateProfile(ctx context.Context, orgID int64, userID uuid.UUID, fields profileFields) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	if _, err := tx.Exec(ctx, `SELECT pg_advisory_xact_lock(hashtext('signup:' || $1::text))`, userID); err != nil {
+		return err
+	}
+
+	var existingOrg int64
+	var deleted bool
+	err = tx.QueryRow(ctx,
+		`SELECT organization_id, deleted_at IS NOT NULL FROM "profiles" WHERE id = $1`,
+		userID,
+	).Scan(&existingOrg, &deleted)
+	exists := err == nil
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		return err
+	}
+	if exists && (existingOrg != orgID || !deleted) {
+		return fmt.Errorf("%w: the user already is a member of an organization", ErrUniqueness)
+	}
+
+	var taken bool
+	err = tx.QueryRow(ctx, `
+        SELECT EXISTS (
+            SELECT 1 FROM "profiles"
+            WHERE organization_id = $1 AND lower(email) = lower($2) AND deleted_at IS NULL
+        )`,
+		orgID, fields.Email,
+	).Scan(&taken)
+	if err != nil {
+		return err
+	}
+	if taken {
+		return fmt.Errorf("%w: userName is taken", ErrUniqueness)
+	}
+
+	if fields.Active {
+		if err := plans.ReserveSeat(ctx, tx, orgID); err != nil {
+			return err
+		}
+	}
+
+	if exists {
+		_, err = tx.Exec(ctx, `
+            UPDATE "profiles" SET
+                full_name   = $2,
+                email       = $3,
+                role        = $4,
+                status      = $5,
+                external_id = $6,
+                deleted_at  = NULL,
+                updated_at  = now()
+            WHERE id = $1`,
+			userID, fields.FullName, fields.Email, fields.Role, status(fields.Active), fields.ExternalID,
+		)
+	} else {
+		_, err = tx.Exec(ctx, `
+            INSERT INTO "profiles" (id, organization_id, full_name, role, email, status, external_id, created_at, updated_at)
+            VALUES ($1, $2, $3, $4, $5, $6, $7, now(), now())`,
+			userID, orgID, fields.FullName, fields.Role, fields.Email, status(fields.Active), fields.ExternalID,
+		)
+	}
+	if err != nil {
+		return uniqueness(err, "externalId is taken")
+	}
+
+	return tx.Commit(ctx)
+}
+
+// UpdateProfile stores the fields of a profile. Reactivating a member
+// takes a seat, checked in the same transaction.
+func (r *SCIMRepository) UpdateProfile(ctx context.Context, orgID int64, id uuid.UUID, fields profileFields, reactivate bool) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	if reactivate {
+		if err := plans.ReserveSeat(ctx, tx, orgID); err != nil {
+			return err
+		}
+	}
+
+	tag, err := tx.Exec(ctx, `
+        UPDATE "profiles" SET
+            full_name   = $3,
+            role        = $4,
+            status      = $5,
+            external_id = $6,
+            updated_at  = now()
+        WHERE id = $1 AND organization_id = $2 AND deleted_at IS NULL`,
+		id, orgID, fields.FullName, fields.Role, status(fields.Active), fields.ExternalID,
+	)
+	if err != nil {
+		return uniqueness(err, "externalId is taken")
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrNotFound
+	}
+
+	return tx.Commit(ctx)
+}
+
+// DeleteProfile deactivates the profile, marks it deleted and removes it
+// from its teams.
+func (r *SCIMRepository) DeleteProfile(ctx context.Context, orgID int64, id uuid.UUID) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	tag, err := tx.Exec(ctx, `
+        UPDATE "profiles" SET status = 'INACTIVE', deleted_at = now(), updated_at = now()
+        WHERE id = $1 AND organization_id = $2 AND deleted_at IS NULL`,
+		id, orgID,
+	)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrNotFound
+	}
+	if _, err := tx.Exec(ctx, `DELETE FROM team_members WHERE profile_id = $1`, id); err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+// ListTeams returns a page of the organization's teams that match the
+// conditions, and how many match in total.
+func (r *SCIMRepository) ListTeams(ctx context.Context, orgID int64, conditions []sqlCondition, offset int, limit int) ([]teamRecord, int, error) {
+	where, args := whereClause(`organization_id = $1`, []any{orgID}, conditions)
+	return list[teamRecord](ctx, r.db, `teams`, teamColumns, where, args, offset, limit)
+}
+
+// FindTeam returns a team of the organization.
+func (r *SCIMRepository) FindTeam(ctx context.Context, orgID int64, id int64) (*teamRecord, error) {
+	rows, err := r.db.Query(ctx,
+		`SELECT `+teamColumns+` FROM teams WHERE id = $1 AND organization_id = $2`,
+		id, orgID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return collectOne[teamRecord](rows)
+}
+
+// CreateTeam adds a team with its members and returns its ID.
+func (r *SCIMRepository) CreateTeam(ctx context.Context, orgID int64, fields groupFields) (int64, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback(ctx)
+
+	var id int64
+	err = tx.QueryRow(ctx, `
+        INSERT INTO teams (organization_id, name, external_id)
+        VALUES ($1, $2, $3)
+        RETURNING id`,
+		orgID, fields.Name, fields.ExternalID,
+	).Scan(&id)
+	if err != nil {
+		return 0, uniqueness(err, "displayName or externalId is taken")
+	}
+	if err := setTeamMembers(ctx, tx, orgID, id, fields.Members); err != nil {
+		return 0, err
+	}
+
+	return id, tx.Commit(ctx)
+}
+
+// UpdateTeam stores the fields of a team and replaces its members.
+func (r *SCIMRepository) UpdateTeam(ctx context.Context, orgID int64, id int64, fields groupFields) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	tag, err := tx.Exec(ctx, `
+        UPDATE teams SET name = $3, external_id = $4, updated_at = now()
+        WHERE id = $1 AND organization_id = $2`,
+		id, orgID, fields.Name, fields.ExternalID,
+	)
+	if err != nil {
+		return uniqueness(err, "displayName or externalId is taken")
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrNotFound
+	}
+	if err := setTeamMembers(ctx, tx, orgID, id, fields.Members); err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+// DeleteTeam removes a team and its memberships.
+func (r *SCIMRepository) DeleteTeam(ctx context.Context, orgID int64, id int64) error {
+	tag, err := r.db.Exec(ctx, `DELETE FROM teams WHERE id = $1 AND organization_id = $2`, id, orgID)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+// TeamsOfProfiles returns the team memberships of the profiles.
+func (r *SCIMRepository) TeamsOfProfiles(ctx context.Context, orgID int64, profileIDs []uuid.UUID) ([]teamMember, error) {
+	return r.teamMembers(ctx, `t.organization_id = $1 AND tm.profile_id = ANY($2)`, orgID, profileIDs)
+}
+
+// MembersOfTeams returns the members of the teams.
+func (r *SCIMRepository) MembersOfTeams(ctx context.Context, orgID int64, teamIDs []int64) ([]teamMember, error) {
+	return r.teamMembers(ctx, `t.organization_id = $1 AND tm.team_id = ANY($2)`, orgID, teamIDs)
+}
+
+func (r *SCIMRepository) teamMembers(ctx context.Context, where string, args ...any) ([]teamMember, error) {
+	rows, err := r.db.Query(ctx, `
+        SELECT tm.team_id, t.name AS team_name, tm.profile_id, p.full_name
+        FROM team_members tm
+        JOIN teams t ON t.id = tm.team_id
+        JOIN "profiles" p ON p.id = tm.profile_id
+        WHERE `+where+`
+        ORDER BY t.name, p.full_name`,
+		args...,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return pgx.CollectRows(rows, pgx.RowToStructByName[teamMember])
+}
+
+// setTeamMembers replaces the members of a team inside tx. Every member has
+// to be a profile of the organization.
+func setTeamMembers(ctx context.Context, tx pgx.Tx, orgID int64, teamID int64, members []uuid.UUID) error {
+	var found int
+	err := tx.QueryRow(ctx, `
+        SELECT count(*) FROM "profiles"
+        WHERE id = ANY($1) AND organization_id = $2 AND deleted_at IS NULL`,
+		members, orgID,
+	).Scan(&found)
+	if err != nil {
+		return err
+	}
+	if found != len(members) {
+		return fmt.Errorf("%w: every member has to be a user of the organization", ErrInvalidValue)
+	}
+
+	if _, err := tx.Exec(ctx, `DELETE FROM team_members WHERE team_id = $1`, teamID); err != nil {
+		return err
+	}
+	_, err = tx.Exec(ctx, `
+        INSERT INTO team_members (team_id, profile_id)
+        SELECT $1, unnest($2::uuid[])`,
+		teamID, members,
+	)
+	return err
+}
+
+// whereClause appends the conditions to base, numbering their parameters
+// after args.
+func whereClause(base string, args []any, conditions []sqlCondition) (string, []any) {
+	where := base
+	for _, c := range conditions {
+		args = append(args, c.Value)
+		where += fmt.Sprintf(" AND %s = $%d", c.Expression, len(args))
+	}
+	return where, args
+}
+
+// list returns a page of the rows of table matching where, oldest first,
+// and the number of matching rows.
+func list[T any](ctx context.Context, db *pgxpool.Pool, table string, columns string, where string, args []any, offset int, limit int) ([]T, int, error) {
+	var total int
+	if err := db.QueryRow(ctx, `SELECT count(*) FROM `+table+` WHERE `+where, args...).Scan(&total); err != nil {
+		return nil, 0, err
+	}
+	if limit == 0 || offset >= total {
+		return []T{}, total, nil
+	}
+
+	args = append(args, offset, limit)
+	rows, err := db.Query(ctx,
+		fmt.Sprintf(`SELECT %s FROM %s WHERE %s ORDER BY created_at, id OFFSET $%d LIMIT $%d`, columns, table, where, len(args)-1, len(args)),
+		args...,
+	)
+	if err != nil {
+		return nil, 0, err
+	}
+	records, err := pgx.CollectRows(rows, pgx.RowToStructByName[T])
+	return records, total, err
+}
+
+// collectOne returns the single row, or ErrNotFound when there is none.
+func collectOne[T any](rows pgx.Rows) (*T, error) {
+	record, err := pgx.CollectOneRow(rows, pgx.RowToStructByName[T])
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+	return &record, nil
+}
+
+// uniqueness reports unique violations as ErrUniqueness.
+func uniqueness(err error, detail string) error {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+		return fmt.Errorf("%w: %s", ErrUniqueness, detail)
+	}
+	return err
+}
+
+func status(active bool) string {
+	if active {
+		return "ACTIVE"
+	}
+	return "INACTIVE"
+}
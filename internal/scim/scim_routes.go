@@ -0,0 +1,64 @@
+package scim
+
+import (
+	"hostflow/profile-service/internal/middlewares"
+	"hostflow/profile-service/pkg/lib"
+)
+
+type SCIMRoutes struct {
+	logger         lib.Logger
+	router         *lib.Router
+	scimController *SCIMController
+	authMiddleware middlewares.AuthMiddleware
+}
+
+func SetSCIMRoutes(
+	logger lib.Logger,
+	router *lib.Router,
+	scimController *SCIMController,
+	authMiddleware middlewares.AuthMiddleware,
+) SCIMRoutes {
+	return SCIMRoutes{
+		logger:         logger,
+		router:         router,
+		scimController: scimController,
+		authMiddleware: authMiddleware,
+	}
+}
+
+func (route SCIMRoutes) Setup() {
+	route.logger.Info("Setting up [SCIM] routes.")
+
+	scim := route.router.Group(basePath)
+	{
+		// Public: discovery documents hold nothing of an organization
+		scim.GET("/ServiceProviderConfig", route.scimController.ServiceProviderConfigHandler)
+		scim.GET("/ResourceTypes", route.scimController.ResourceTypesHandler)
+		scim.GET("/Schemas", route.scimController.SchemasHandler)
+	}
+
+	// Identity providers authenticate with an organization API key
+	users := scim.Group("/Users")
+	users.Use(route.authMiddleware.APIKeyBearerHandler("scim"))
+	{
+		users.GET("", route.scimController.ListUsersHandler)
+		users.POST("", route.scimController.CreateUserHandler)
+		users.GET("/:id", route.scimController.GetUserHandler)
+		users.PUT("/:id", route.scimController.ReplaceUserHandler)
+		users.PATCH("/:id", route.scimController.PatchUserHandler)
+		users.DELETE("/:id", route.scimController.DeleteUserHandler)
+	}
+
+	groups := scim.Group("/Groups")
+	groups.Use(route.authMiddleware.APIKeyBearerHandler("scim"))
+	{
+		groups.GET("", route.scimController.ListGroupsHandler)
+		groups.POST("", route.scimController.CreateGroupHandler)
+		groups.GET("/:id", route.scimController.GetGroupHandler)
+		groups.PUT("/:id", route.scimController.ReplaceGroupHandler)
+		groups.PATCH("/:id", route.scimController.PatchGroupHandler)
+		groups.DELETE("/:id", route.scimController.DeleteGroupHandler)
+	}
+
+	route.logger.Info("[SCIM] routes setup complete.")
+}
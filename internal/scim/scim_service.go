@@ -0,0 +1,639 @@
+package scim
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"hostflow/profile-service/internal/audit"
+	"hostflow/profile-service/internal/domains"
+	"hostflow/profile-service/internal/iamsync"
+	"hostflow/profile-service/internal/middlewares"
+	"hostflow/profile-service/pkg/iam"
+	"hostflow/profile-service/pkg/lib"
+
+	"github.com/google/uuid"
+)
+
+// Errors carry the SCIM error they are reported as; see respondError.
+var (
+	ErrNotFound      = errors.New("resource not found")
+	ErrUniqueness    = errors.New("resource is not unique")
+	ErrForbidden     = errors.New("the organization's owner cannot be changed by provisioning")
+	ErrInvalidFilter = errors.New("invalid filter")
+	ErrInvalidSyntax = errors.New("invalid request")
+	ErrInvalidPath   = errors.New("invalid path")
+	ErrInvalidValue  = errors.New("invalid value")
+	ErrMutability    = errors.New("attribute cannot be changed")
+	ErrNoTarget      = errors.New("no target")
+	ErrTooMany       = errors.New("too many items")
+)
+
+// Roles a provisioned user can have.
+var provisionedRoles = []string{"MANAGER", "MEMBER"}
+
+type SCIMService struct {
+	repo        *SCIMRepository
+	provider    iam.IdentityProvider
+	identity    iamsync.Service
+	memberships *middlewares.Memberships
+	revocations *middlewares.Revocations
+	audit       audit.Service
+	logger      lib.Logger
+}
+
+type Service interface {
+	ListUsers(ctx context.Context, orgID int64, query ListQuery) (*ListResponse, error)
+	GetUser(ctx context.Context, orgID int64, id string) (*User, error)
+	CreateUser(ctx context.Context, orgID int64, apiKeyID int64, user User) (*User, error)
+	ReplaceUser(ctx context.Context, orgID int64, apiKeyID int64, id string, user User) (*User, error)
+	PatchUser(ctx context.Context, orgID int64, apiKeyID int64, id string, patch PatchRequest) (*User, error)
+	DeleteUser(ctx context.Context, orgID int64, apiKeyID int64, id string) error
+	ListGroups(ctx context.Context, orgID int64, query ListQuery) (*ListResponse, error)
+	GetGroup(ctx context.Context, orgID int64, id string) (*Group, error)
+	CreateGroup(ctx context.Context, orgID int64, apiKeyID int64, group Group) (*Group, error)
+	ReplaceGroup(ctx context.Context, orgID int64, apiKeyID int64, id string, group Group) (*Group, error)
+	PatchGroup(ctx context.Context, orgID int64, apiKeyID int64, id string, patch PatchRequest) (*Group, error)
+	DeleteGroup(ctx context.Context, orgID int64, apiKeyID int64, id string) error
+}
+
+func GetSCIMService(
+	repo *SCIMRepository,
+	provider iam.IdentityProvider,
+	identity iamsync.Service,
+	memberships *middlewares.Memberships,
+	revocations *middlewares.Revocations,
+	audit audit.Service,
+	logger lib.Logger,
+) *SCIMService {
+	return &SCIMService{
+		repo:        repo,
+		provider:    provider,
+		identity:    identity,
+		memberships: memberships,
+		revocations: revocations,
+		audit:       audit,
+		logger:      logger,
+	}
+}
+
+// ListUsers returns a page of the organization's users matching the filter.
+func (s *SCIMService) ListUsers(ctx context.Context, orgID int64, query ListQuery) (*ListResponse, error) {
+	conditions, err := userConditions(query.Filter)
+	if err != nil {
+		return nil, err
+	}
+	offset, limit := page(query)
+	records, total, err := s.repo.ListProfiles(ctx, orgID, conditions, offset, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	var teams []teamMember
+	if !query.ExcludeGroups && len(records) > 0 {
+		ids := make([]uuid.UUID, len(records))
+		for i, record := range records {
+			ids[i] = record.ID
+		}
+		if teams, err = s.repo.TeamsOfProfiles(ctx, orgID, ids); err != nil {
+			return nil, err
+		}
+	}
+
+	users := make([]User, len(records))
+	for i, record := range records {
+		users[i] = toUser(record, teamsOf(teams, func(t teamMember) bool { return t.ProfileID == record.ID }))
+	}
+	return listResponse(users, total, offset), nil
+}
+
+// GetUser returns a user of the organization with their teams.
+func (s *SCIMService) GetUser(ctx context.Context, orgID int64, id string) (*User, error) {
+	profileID, err := uuid.Parse(id)
+	if err != nil {
+		return nil, ErrNotFound
+	}
+	record, err := s.repo.FindProfile(ctx, orgID, profileID)
+	if err != nil {
+		return nil, err
+	}
+	teams, err := s.repo.TeamsOfProfiles(ctx, orgID, []uuid.UUID{profileID})
+	if err != nil {
+		return nil, err
+	}
+	user := toUser(*record, teams)
+	return &user, nil
+}
+
+// CreateUser adds a user to the organization. Only addresses on the
+// organization's verified domains are provisioned: the organization
+// controls their mailboxes, so it may reuse the Supabase Auth user with the
+// email or create it with a confirmed email, and the member can sign in
+// with a magic link or the organization's SSO right away. Other addresses
+// are rejected, so a key cannot claim users of other organizations or mint
+// confirmed identities for addresses it does not own.
+func (s *SCIMService) CreateUser(ctx context.Context, orgID int64, apiKeyID int64, user User) (*User, error) {
+	fields, err := userFields(user, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	domain := domains.EmailDomain(fields.Email)
+	verified := false
+	if domain != "" {
+		verified, err = s.repo.HasVerifiedDomain(ctx, orgID, domain)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if !verified {
+		return nil, fmt.Errorf("%w: userName must be an address on one of the organization's verified domains", ErrInvalidValue)
+	}
+
+	userID, err := s.findOrCreateIdentity(ctx, fields.Email, fields.FullName)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.repo.CreateProfile(ctx, orgID, userID, fields); err != nil {
+		return nil, err
+	}
+	s.memberships.Invalidate(userID.String())
+	s.syncIdentity(ctx, userID.String())
+
+	if err := s.record(ctx, orgID, apiKeyID, "scim.user_created", "profile", userID.String(), map[string]interface{}{
+		"role":   fields.Role,
+		"active": fields.Active,
+	}); err != nil {
+		return nil, err
+	}
+	return s.GetUser(ctx, orgID, userID.String())
+}
+
+// ReplaceUser sets the attributes of a user. userName cannot change, and
+// the owner's role and status are left to the organization.
+func (s *SCIMService) ReplaceUser(ctx context.Context, orgID int64, apiKeyID int64, id string, user User) (*User, error) {
+	profileID, err := uuid.Parse(id)
+	if err != nil {
+		return nil, ErrNotFound
+	}
+	current, err := s.repo.FindProfile(ctx, orgID, profileID)
+	if err != nil {
+		return nil, err
+	}
+	return s.replaceUser(ctx, orgID, apiKeyID, *current, user)
+}
+
+// PatchUser applies the operations to a user and saves the result like a
+// replacement.
+func (s *SCIMService) PatchUser(ctx context.Context, orgID int64, apiKeyID int64, id string, patch PatchRequest) (*User, error) {
+	profileID, err := uuid.Parse(id)
+	if err != nil {
+		return nil, ErrNotFound
+	}
+	current, err := s.repo.FindProfile(ctx, orgID, profileID)
+	if err != nil {
+		return nil, err
+	}
+
+	user := toUser(*current, nil)
+	if err := applyUserPatch(&user, patch.Operations); err != nil {
+		return nil, err
+	}
+	return s.replaceUser(ctx, orgID, apiKeyID, *current, user)
+}
+
+func (s *SCIMService) replaceUser(ctx context.Context, orgID int64, apiKeyID int64, current profileRecord, user User) (*User, error) {
+	fields, err := userFields(user, &current)
+	if err != nil {
+		return nil, err
+	}
+	if !strings.EqualFold(fields.Email, current.Email) {
+		return nil, fmt.Errorf("%w: userName cannot be changed", ErrMutability)
+	}
+
+	wasActive := current.Status != "INACTIVE"
+	roleChanged := fields.Role != current.Role
+	statusChanged := fields.Active != wasActive
+	if current.Role == "OWNER" && (roleChanged || statusChanged) {
+		return nil, ErrForbidden
+	}
+
+	if err := s.repo.UpdateProfile(ctx, orgID, current.ID, fields, statusChanged && fields.Active); err != nil {
+		return nil, err
+	}
+	userID := current.ID.String()
+	s.memberships.Invalidate(userID)
+
+	if statusChanged && !fields.Active {
+		if err := s.revocations.Revoke(ctx, middlewares.RevokeUser, userID, "deactivated"); err != nil {
+			return nil, err
+		}
+	} else if roleChanged {
+		if err := s.revocations.Revoke(ctx, middlewares.RevokeUser, userID, "role_changed"); err != nil {
+			s.logger.Error(fmt.Sprintf("Failed to revoke sessions of %s: %v", userID, err))
+		}
+	}
+	if roleChanged || statusChanged {
+		s.syncIdentity(ctx, userID)
+	}
+
+	if err := s.record(ctx, orgID, apiKeyID, "scim.user_updated", "profile", userID, map[string]interface{}{
+		"role":   fields.Role,
+		"active": fields.Active,
+	}); err != nil {
+		return nil, err
+	}
+	return s.GetUser(ctx, orgID, userID)
+}
+
+// DeleteUser deprovisions a user: their profile is deactivated, marked
+// deleted and taken out of its teams, and their sessions are revoked. The
+// Supabase Auth user is kept, as it may sign up again.
+func (s *SCIMService) DeleteUser(ctx context.Context, orgID int64, apiKeyID int64, id string) error {
+	profileID, err := uuid.Parse(id)
+	if err != nil {
+		return ErrNotFound
+	}
+	current, err := s.repo.FindProfile(ctx, orgID, profileID)
+	if err != nil {
+		return err
+	}
+	if current.Role == "OWNER" {
+		return ErrForbidden
+	}
+
+	if err := s.repo.DeleteProfile(ctx, orgID, profileID); err != nil {
+		return err
+	}
+	s.memberships.Invalidate(id)
+	if err := s.revocations.Revoke(ctx, middlewares.RevokeUser, id, "deprovisioned"); err != nil {
+		return err
+	}
+	s.syncIdentity(ctx, id)
+
+	return s.record(ctx, orgID, apiKeyID, "scim.user_deleted", "profile", id, map[string]interface{}{})
+}
+
+// ListGroups returns a page of the organization's teams matching the
+// filter.
+func (s *SCIMService) ListGroups(ctx context.Context, orgID int64, query ListQuery) (*ListResponse, error) {
+	conditions, err := groupConditions(query.Filter)
+	if err != nil {
+		return nil, err
+	}
+	offset, limit := page(query)
+	records, total, err := s.repo.ListTeams(ctx, orgID, conditions, offset, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	var members []teamMember
+	if !query.ExcludeMembers && len(records) > 0 {
+		ids := make([]int64, len(records))
+		for i, record := range records {
+			ids[i] = record.ID
+		}
+		if members, err = s.repo.MembersOfTeams(ctx, orgID, ids); err != nil {
+			return nil, err
+		}
+	}
+
+	groups := make([]Group, len(records))
+	for i, record := range records {
+		groups[i] = toGroup(record, teamsOf(members, func(m teamMember) bool { return m.TeamID == record.ID }))
+	}
+	return listResponse(groups, total, offset), nil
+}
+
+// GetGroup returns a team of the organization with its members.
+func (s *SCIMService) GetGroup(ctx context.Context, orgID int64, id string) (*Group, error) {
+	teamID, err := parseID(id)
+	if err != nil {
+		return nil, err
+	}
+	record, err := s.repo.FindTeam(ctx, orgID, teamID)
+	if err != nil {
+		return nil, err
+	}
+	members, err := s.repo.MembersOfTeams(ctx, orgID, []int64{teamID})
+	if err != nil {
+		return nil, err
+	}
+	group := toGroup(*record, members)
+	return &group, nil
+}
+
+// CreateGroup adds a team with its members.
+func (s *SCIMService) CreateGroup(ctx context.Context, orgID int64, apiKeyID int64, group Group) (*Group, error) {
+	fields, err := teamFields(group)
+	if err != nil {
+		return nil, err
+	}
+	teamID, err := s.repo.CreateTeam(ctx, orgID, fields)
+	if err != nil {
+		return nil, err
+	}
+
+	id := formatID(teamID)
+	if err := s.record(ctx, orgID, apiKeyID, "scim.group_created", "team", id, map[string]interface{}{
+		"name":    fields.Name,
+		"members": len(fields.Members),
+	}); err != nil {
+		return nil, err
+	}
+	return s.GetGroup(ctx, orgID, id)
+}
+
+// ReplaceGroup sets the name and members of a team.
+func (s *SCIMService) ReplaceGroup(ctx context.Context, orgID int64, apiKeyID int64, id string, group Group) (*Group, error) {
+	teamID, err := parseID(id)
+	if err != nil {
+		return nil, err
+	}
+	return s.replaceGroup(ctx, orgID, apiKeyID, teamID, group)
+}
+
+// PatchGroup applies the operations to a team and saves the result like a
+// replacement.
+func (s *SCIMService) PatchGroup(ctx context.Context, orgID int64, apiKeyID int64, id string, patch PatchRequest) (*Group, error) {
+	current, err := s.GetGroup(ctx, orgID, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := applyGroupPatch(current, patch.Operations); err != nil {
+		return nil, err
+	}
+	teamID, _ := parseID(id)
+	return s.replaceGroup(ctx, orgID, apiKeyID, teamID, *current)
+}
+
+func (s *SCIMService) replaceGroup(ctx context.Context, orgID int64, apiKeyID int64, teamID int64, group Group) (*Group, error) {
+	fields, err := teamFields(group)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.repo.UpdateTeam(ctx, orgID, teamID, fields); err != nil {
+		return nil, err
+	}
+
+	id := formatID(teamID)
+	if err := s.record(ctx, orgID, apiKeyID, "scim.group_updated", "team", id, map[string]interface{}{
+		"name":    fields.Name,
+		"members": len(fields.Members),
+	}); err != nil {
+		return nil, err
+	}
+	return s.GetGroup(ctx, orgID, id)
+}
+
+// DeleteGroup removes a team. Its members stay in the organization.
+func (s *SCIMService) DeleteGroup(ctx context.Context, orgID int64, apiKeyID int64, id string) error {
+	teamID, err := parseID(id)
+	if err != nil {
+		return err
+	}
+	if err := s.repo.DeleteTeam(ctx, orgID, teamID); err != nil {
+		return err
+	}
+	return s.record(ctx, orgID, apiKeyID, "scim.group_deleted", "team", id, map[string]interface{}{})
+}
+
+// findOrCreateIdentity returns the Supabase Auth user with the email,
+// creating it when there is none.
+func (s *SCIMService) findOrCreateIdentity(ctx context.Context, email string, name string) (uuid.UUID, error) {
+	existing, err := s.repo.FindIdentity(ctx, email)
+	if err != nil {
+		return uuid.Nil, err
+	}
+	if existing != nil {
+		return *existing, nil
+	}
+
+	created, err := s.provider.CreateUser(ctx, email, name)
+	if errors.Is(err, iam.ErrUserExists) {
+		// Created concurrently, e.g. by a sign-up
+		existing, err = s.repo.FindIdentity(ctx, email)
+		if err != nil {
+			return uuid.Nil, err
+		}
+		if existing == nil {
+			return uuid.Nil, fmt.Errorf("%w: userName is taken", ErrUniqueness)
+		}
+		return *existing, nil
+	}
+	if err != nil {
+		return uuid.Nil, err
+	}
+	return uuid.Parse(created)
+}
+
+// syncIdentity queues pushing the user's role and status to Supabase Auth.
+// A failure leaves the identity stale, but the profile is authoritative.
+func (s *SCIMService) syncIdentity(ctx context.Context, userID string) {
+	if err := s.identity.Sync(ctx, userID); err != nil {
+		s.logger.Error(fmt.Sprintf("Failed to queue identity sync of %s: %v", userID, err))
+	}
+}
+
+// record writes an audit entry on behalf of the API key.
+func (s *SCIMService) record(ctx context.Context, orgID int64, apiKeyID int64, action string, targetType string, targetID string, details map[string]interface{}) error {
+	details["api_key_id"] = apiKeyID
+	return s.audit.Record(ctx, audit.Entry{
+		OrganizationID:      orgID,
+		ActorOrganizationID: &orgID,
+		Action:              action,
+		TargetType:          targetType,
+		TargetID:            &targetID,
+		Details:             details,
+	})
+}
+
+// userFields validates a user. On replacements, current provides the role
+// and status the request leaves out.
+func userFields(user User, current *profileRecord) (profileFields, error) {
+	fields := profileFields{
+		Email:    strings.ToLower(strings.TrimSpace(user.UserName)),
+		FullName: user.fullName(),
+		Role:     "MEMBER",
+		Active:   true,
+	}
+	if current != nil {
+		fields.Role = current.Role
+		fields.Active = current.Status != "INACTIVE"
+	}
+
+	if fields.Email == "" {
+		return fields, fmt.Errorf("%w: userName is required", ErrInvalidValue)
+	}
+	if at := strings.LastIndex(fields.Email, "@"); at <= 0 || at == len(fields.Email)-1 {
+		return fields, fmt.Errorf("%w: userName must be an email address", ErrInvalidValue)
+	}
+	for _, email := range user.Emails {
+		if !strings.EqualFold(strings.TrimSpace(email.Value), fields.Email) {
+			return fields, fmt.Errorf("%w: emails must match userName", ErrInvalidValue)
+		}
+	}
+
+	if role := primaryRole(user.Roles); role != "" {
+		role = strings.ToUpper(role)
+		isCurrent := current != nil && role == current.Role
+		if !isCurrent && !slices.Contains(provisionedRoles, role) {
+			return fields, fmt.Errorf("%w: roles must be MANAGER or MEMBER", ErrInvalidValue)
+		}
+		fields.Role = role
+	}
+	if user.Active != nil {
+		fields.Active = *user.Active
+	}
+	if externalID := strings.TrimSpace(user.ExternalID); externalID != "" {
+		fields.ExternalID = &externalID
+	}
+	return fields, nil
+}
+
+// teamFields validates a group.
+func teamFields(group Group) (groupFields, error) {
+	fields := groupFields{Name: strings.TrimSpace(group.DisplayName)}
+	if fields.Name == "" {
+		return fields, fmt.Errorf("%w: displayName is required", ErrInvalidValue)
+	}
+	if externalID := strings.TrimSpace(group.ExternalID); externalID != "" {
+		fields.ExternalID = &externalID
+	}
+	if len(group.Members) > maxMembersPerOperation {
+		return fields, fmt.Errorf("%w: at most %d members are allowed", ErrTooMany, maxMembersPerOperation)
+	}
+
+	seen := map[uuid.UUID]bool{}
+	fields.Members = []uuid.UUID{}
+	for _, member := range group.Members {
+		id, err := uuid.Parse(member.Value)
+		if err != nil {
+			return fields, fmt.Errorf("%w: member %q is not a user ID", ErrInvalidValue, member.Value)
+		}
+		if !seen[id] {
+			seen[id] = true
+			fields.Members = append(fields.Members, id)
+		}
+	}
+	return fields, nil
+}
+
+// primaryRole returns the primary role, or the first one.
+func primaryRole(roles []Role) string {
+	for _, role := range roles {
+		if role.Primary {
+			return strings.TrimSpace(role.Value)
+		}
+	}
+	if len(roles) > 0 {
+		return strings.TrimSpace(roles[0].Value)
+	}
+	return ""
+}
+
+// userConditions maps a user filter onto profile columns.
+func userConditions(filter string) ([]sqlCondition, error) {
+	return conditions(filter, func(c condition) (sqlCondition, bool) {
+		switch c.Attribute {
+		case "username", "emails", "emails.value":
+			if value, ok := c.Value.(string); ok {
+				return sqlCondition{Expression: "lower(email)", Value: strings.ToLower(value)}, true
+			}
+		case "externalid":
+			return stringCondition("external_id", c.Value)
+		case "id":
+			return stringCondition("id::text", c.Value)
+		case "displayname":
+			return stringCondition("full_name", c.Value)
+		case "active":
+			if value, ok := c.Value.(bool); ok {
+				return sqlCondition{Expression: "(status <> 'INACTIVE')", Value: value}, true
+			}
+		}
+		return sqlCondition{}, false
+	})
+}
+
+// groupConditions maps a group filter onto team columns.
+func groupConditions(filter string) ([]sqlCondition, error) {
+	return conditions(filter, func(c condition) (sqlCondition, bool) {
+		switch c.Attribute {
+		case "displayname":
+			return stringCondition("name", c.Value)
+		case "externalid":
+			return stringCondition("external_id", c.Value)
+		case "id":
+			return stringCondition("id::text", c.Value)
+		}
+		return sqlCondition{}, false
+	})
+}
+
+func conditions(filter string, mapping func(condition) (sqlCondition, bool)) ([]sqlCondition, error) {
+	if strings.TrimSpace(filter) == "" {
+		return nil, nil
+	}
+	parsed, err := parseFilter(filter)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]sqlCondition, 0, len(parsed))
+	for _, c := range parsed {
+		mapped, ok := mapping(c)
+		if !ok {
+			return nil, fmt.Errorf("%w: cannot filter by %s with %v", ErrInvalidFilter, c.Attribute, c.Value)
+		}
+		result = append(result, mapped)
+	}
+	return result, nil
+}
+
+func stringCondition(expression string, value interface{}) (sqlCondition, bool) {
+	text, ok := value.(string)
+	return sqlCondition{Expression: expression, Value: text}, ok
+}
+
+// page converts the 1-based startIndex and count of a query to an offset
+// and a limit, bounding the count.
+func page(query ListQuery) (int, int) {
+	offset := max(query.StartIndex, 1) - 1
+	return offset, min(max(query.Count, 0), maxCount)
+}
+
+func listResponse[T any](resources []T, total int, offset int) *ListResponse {
+	return &ListResponse{
+		Schemas:      []string{SchemaListResponse},
+		TotalResults: total,
+		StartIndex:   offset + 1,
+		ItemsPerPage: len(resources),
+		Resources:    resources,
+	}
+}
+
+// teamsOf returns the memberships that match.
+func teamsOf(members []teamMember, match func(teamMember) bool) []teamMember {
+	var matched []teamMember
+	for _, member := range members {
+		if match(member) {
+			matched = append(matched, member)
+		}
+	}
+	return matched
+}
+
+// parseID parses a group ID; malformed IDs name no group.
+func parseID(id string) (int64, error) {
+	teamID, err := strconv.ParseInt(id, 10, 64)
+	if err != nil || teamID <= 0 {
+		return 0, ErrNotFound
+	}
+	return teamID, nil
+}
+
+func formatID(id int64) string {
+	return strconv.FormatInt(id, 10)
+}
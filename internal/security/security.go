@@ -0,0 +1,16 @@
+package security
+
+import "go.uber.org/fx"
+
+// ======== EXPORTS ========
+
+// Module exports services present
+var Context = fx.Options(
+	fx.Provide(GetSecurityController),
+	fx.Provide(fx.Annotate(
+		GetSecurityService,
+		fx.As(new(Service)),
+	)),
+	fx.Provide(GetSecurityRepository),
+	fx.Provide(SetSecurityRoutes),
+)
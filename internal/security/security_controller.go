@@ -0,0 +1,91 @@
+package security
+
+import (
+	"errors"
+	"net/http"
+
+	"hostflow/profile-service/internal/middlewares"
+	"hostflow/profile-service/pkg/common"
+
+	"github.com/gin-gonic/gin"
+)
+
+type SecurityController struct {
+	service Service
+}
+
+func GetSecurityController(service Service) *SecurityController {
+	return &SecurityController{
+		service: service,
+	}
+}
+
+// GetPolicyHandler godoc
+// @Summary Get the security policy
+// @Description Returns the security policy of the requester's organization: whether members must sign in with a second factor, the networks requests must come from, and the maximum session age. Requires OWNER role.
+// @Tags security
+// @Produce json
+// @Security ApiKeyAuth
+// @Success 200 {object} Policy
+// @Failure 403 {object} ErrorResponse
+// @Router /organization/security-policy [get]
+func (c *SecurityController) GetPolicyHandler(ctx *gin.Context) {
+	principal := middlewares.GetPrincipal(ctx)
+
+	policy, err := c.service.Get(ctx.Request.Context(), principal.OrganizationID, principal.Role)
+	if err != nil {
+		respondError(ctx, "Failed to fetch security policy", err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, policy)
+}
+
+// UpdatePolicyHandler godoc
+// @Summary Update the security policy
+// @Description Replaces the security policy of the requester's organization. Requests breaking it are turned away with a code: mfa_required (403) for sessions without a second factor, ip_not_allowed (403) for addresses outside the allowed networks (API keys included), and session_too_old (401) for tokens issued longer ago than the maximum session age. A policy the request itself would break is refused with 409. Requires OWNER role.
+// @Tags security
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param body body UpdatePolicyRequest true "Security policy"
+// @Success 200 {object} Policy
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Router /organization/security-policy [put]
+func (c *SecurityController) UpdatePolicyHandler(ctx *gin.Context) {
+	principal := middlewares.GetPrincipal(ctx)
+
+	var body UpdatePolicyRequest
+	if errors := common.Validation.ValidateBody(ctx, &body); errors != nil {
+		ctx.JSON(http.StatusBadRequest, errors)
+		return
+	}
+
+	policy, err := c.service.Update(ctx.Request.Context(), principal, ctx.ClientIP(), body)
+	if err != nil {
+		respondError(ctx, "Failed to update security policy", err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, policy)
+}
+
+// respondError maps service errors to HTTP status codes.
+func respondError(ctx *gin.Context, title string, err error) {
+	status := http.StatusInternalServerError
+	switch {
+	case errors.Is(err, ErrInvalidInput):
+		status = http.StatusBadRequest
+	case errors.Is(err, ErrForbidden):
+		status = http.StatusForbidden
+	case errors.Is(err, ErrLockout):
+		status = http.StatusConflict
+	}
+
+	ctx.JSON(status, ErrorResponse{
+		Error:   title,
+		Message: err.Error(),
+	})
+}
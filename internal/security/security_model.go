@@ -0,0 +1,41 @@
+package security
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// maxNetworks bounds the allowlist of an organization.
+const maxNetworks = 100
+
+// Policy is the security policy of an organization, enforced on every
+// request of its members. Organizations that never set one get the zero
+// policy, which restricts nothing.
+type Policy struct {
+	OrganizationID int64 `json:"organization_id" db:"organization_id"`
+	// RequireMFA admits only sessions signed in with a second factor.
+	RequireMFA bool `json:"require_mfa" db:"require_mfa"`
+	// AllowedNetworks are the CIDR ranges requests, including those made
+	// with API keys, must come from. Empty allows any address.
+	AllowedNetworks []string `json:"allowed_networks" db:"allowed_networks" example:"203.0.113.0/24"`
+	// MaxSessionAgeMinutes turns away tokens issued longer ago; null for no
+	// limit.
+	MaxSessionAgeMinutes *int       `json:"max_session_age_minutes" db:"max_session_age_minutes" example:"720"`
+	UpdatedBy            *uuid.UUID `json:"updated_by" db:"updated_by"`
+	UpdatedAt            *time.Time `json:"updated_at" db:"updated_at"`
+}
+
+// UpdatePolicyRequest replaces the security policy of an organization.
+// Networks are CIDR ranges or single addresses.
+type UpdatePolicyRequest struct {
+	RequireMFA           bool     `json:"require_mfa" example:"true"`
+	AllowedNetworks      []string `json:"allowed_networks" example:"203.0.113.0/24,2001:db8::/32"`
+	MaxSessionAgeMinutes *int     `json:"max_session_age_minutes" binding:"omitempty,min=5,max=43200" example:"720"`
+}
+
+// ErrorResponse represents an error response
+type ErrorResponse struct {
+	Error   string `json:"error" example:"Invalid request"`
+	Message string `json:"message,omitempty" example:"The provided data is invalid"`
+}
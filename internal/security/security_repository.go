@@ -0,0 +1,67 @@
+package security
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+const policyColumns = `organization_id, require_mfa, allowed_networks, max_session_age_minutes, updated_by, updated_at`
+
+type SecurityRepository struct {
+	db *pgxpool.Pool
+}
+
+func GetSecurityRepository(db *pgxpool.Pool) *SecurityRepository {
+	return &SecurityRepository{
+		db: db,
+	}
+}
+
+// Find returns the organization's policy, or the zero policy when it has
+// none.
+func (r *SecurityRepository) Find(ctx context.Context, orgID int64) (*Policy, error) {
+	rows, err := r.db.Query(ctx,
+		`SELECT `+policyColumns+` FROM organization_security_policies WHERE organization_id = $1`,
+		orgID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	policy, err := pgx.CollectOneRow(rows, pgx.RowToStructByName[Policy])
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return &Policy{OrganizationID: orgID, AllowedNetworks: []string{}}, nil
+		}
+		return nil, err
+	}
+	return &policy, nil
+}
+
+// Save stores the organization's policy.
+func (r *SecurityRepository) Save(ctx context.Context, orgID int64, userID *uuid.UUID, req UpdatePolicyRequest) (*Policy, error) {
+	rows, err := r.db.Query(ctx, `
+        INSERT INTO organization_security_policies
+            (organization_id, require_mfa, allowed_networks, max_session_age_minutes, updated_by, updated_at)
+        VALUES ($1, $2, $3, $4, $5, now())
+        ON CONFLICT (organization_id) DO UPDATE SET
+            require_mfa             = EXCLUDED.require_mfa,
+            allowed_networks        = EXCLUDED.allowed_networks,
+            max_session_age_minutes = EXCLUDED.max_session_age_minutes,
+            updated_by              = EXCLUDED.updated_by,
+            updated_at              = EXCLUDED.updated_at
+        RETURNING `+policyColumns,
+		orgID, req.RequireMFA, req.AllowedNetworks, req.MaxSessionAgeMinutes, userID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	policy, err := pgx.CollectOneRow(rows, pgx.RowToStructByName[Policy])
+	if err != nil {
+		return nil, err
+	}
+	return &policy, nil
+}
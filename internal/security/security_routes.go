@@ -0,0 +1,41 @@
+package security
+
+import (
+	"hostflow/profile-service/internal/middlewares"
+	"hostflow/profile-service/pkg/lib"
+)
+
+type SecurityRoutes struct {
+	logger             lib.Logger
+	router             *lib.Router
+	securityController *SecurityController
+	authMiddleware     middlewares.AuthMiddleware
+}
+
+func SetSecurityRoutes(
+	logger lib.Logger,
+	router *lib.Router,
+	securityController *SecurityController,
+	authMiddleware middlewares.AuthMiddleware,
+) SecurityRoutes {
+	return SecurityRoutes{
+		logger:             logger,
+		router:             router,
+		securityController: securityController,
+		authMiddleware:     authMiddleware,
+	}
+}
+
+func (route SecurityRoutes) Setup() {
+	route.logger.Info("Setting up [SECURITY] routes.")
+
+	// Policies are managed with user tokens only
+	organizations := route.router.Group("/organization")
+	organizations.Use(route.authMiddleware.Handler())
+	{
+		organizations.GET("/security-policy", route.securityController.GetPolicyHandler)
+		organizations.PUT("/security-policy", route.securityController.UpdatePolicyHandler)
+	}
+
+	route.logger.Info("[SECURITY] routes setup complete.")
+}
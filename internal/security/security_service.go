@@ -0,0 +1,138 @@
+package security
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/netip"
+	"slices"
+	"strings"
+
+	"hostflow/profile-service/internal/audit"
+	"hostflow/profile-service/internal/middlewares"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrForbidden    = errors.New("you are not allowed to perform this action")
+	ErrInvalidInput = errors.New("invalid input")
+	ErrLockout      = errors.New("the policy would lock you out")
+)
+
+type SecurityService struct {
+	repo     *SecurityRepository
+	policies *middlewares.SecurityPolicies
+	audit    audit.Service
+}
+
+type Service interface {
+	Get(ctx context.Context, orgID int64, role string) (*Policy, error)
+	Update(ctx context.Context, principal middlewares.Principal, clientIP string, req UpdatePolicyRequest) (*Policy, error)
+}
+
+func GetSecurityService(repo *SecurityRepository, policies *middlewares.SecurityPolicies, audit audit.Service) *SecurityService {
+	return &SecurityService{
+		repo:     repo,
+		policies: policies,
+		audit:    audit,
+	}
+}
+
+// Get returns the organization's security policy. Requires OWNER role.
+func (s *SecurityService) Get(ctx context.Context, orgID int64, role string) (*Policy, error) {
+	if role != "OWNER" {
+		return nil, ErrForbidden
+	}
+	return s.repo.Find(ctx, orgID)
+}
+
+// Update replaces the organization's security policy. A policy the owner's
+// own request would break is refused, so they cannot lock themselves out:
+// MFA can only be required from a session with a second factor, and the
+// allowlist has to include the address the request comes from. Requires
+// OWNER role.
+func (s *SecurityService) Update(ctx context.Context, principal middlewares.Principal, clientIP string, req UpdatePolicyRequest) (*Policy, error) {
+	if principal.Role != "OWNER" {
+		return nil, ErrForbidden
+	}
+
+	networks, err := normalizeNetworks(req.AllowedNetworks)
+	if err != nil {
+		return nil, err
+	}
+	req.AllowedNetworks = make([]string, len(networks))
+	for i, network := range networks {
+		req.AllowedNetworks[i] = network.String()
+	}
+
+	if req.RequireMFA && principal.AAL != "aal2" {
+		return nil, fmt.Errorf("%w: sign in with a second factor before requiring one", ErrLockout)
+	}
+	if len(networks) > 0 && !(middlewares.SecurityPolicy{AllowedNetworks: networks}).Allows(clientIP) {
+		return nil, fmt.Errorf("%w: the allowed networks do not include your address %s", ErrLockout, clientIP)
+	}
+
+	var actorID *uuid.UUID
+	if id, err := uuid.Parse(principal.UserID); err == nil {
+		actorID = &id
+	}
+	policy, err := s.repo.Save(ctx, principal.OrganizationID, actorID, req)
+	if err != nil {
+		return nil, err
+	}
+	s.policies.Invalidate(principal.OrganizationID)
+
+	targetID := fmt.Sprintf("%d", principal.OrganizationID)
+	err = s.audit.Record(ctx, audit.Entry{
+		OrganizationID:      principal.OrganizationID,
+		ActorID:             actorID,
+		ActorOrganizationID: &principal.OrganizationID,
+		Action:              "security_policy.updated",
+		TargetType:          "organization",
+		TargetID:            &targetID,
+		Details: map[string]interface{}{
+			"require_mfa":             policy.RequireMFA,
+			"allowed_networks":        policy.AllowedNetworks,
+			"max_session_age_minutes": policy.MaxSessionAgeMinutes,
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	return policy, nil
+}
+
+// normalizeNetworks parses CIDR ranges and single addresses into masked,
+// sorted and distinct prefixes.
+func normalizeNetworks(values []string) ([]netip.Prefix, error) {
+	if len(values) > maxNetworks {
+		return nil, fmt.Errorf("%w: at most %d allowed networks", ErrInvalidInput, maxNetworks)
+	}
+
+	networks := make([]netip.Prefix, 0, len(values))
+	for _, value := range values {
+		value = strings.TrimSpace(value)
+		prefix, err := netip.ParsePrefix(value)
+		if err != nil {
+			addr, addrErr := netip.ParseAddr(value)
+			if addrErr != nil {
+				return nil, fmt.Errorf("%w: %q is not a CIDR range or IP address", ErrInvalidInput, value)
+			}
+			addr = addr.Unmap()
+			prefix = netip.PrefixFrom(addr, addr.BitLen())
+		}
+		if prefix.Bits() == 0 {
+			return nil, fmt.Errorf("%w: %q allows every address; leave the list empty instead", ErrInvalidInput, value)
+		}
+		networks = append(networks, prefix.Masked())
+	}
+
+	slices.SortFunc(networks, func(a, b netip.Prefix) int {
+		if c := a.Addr().Compare(b.Addr()); c != 0 {
+			return c
+		}
+		return a.Bits() - b.Bits()
+	})
+	return slices.Compact(networks), nil
+}
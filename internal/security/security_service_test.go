@@ -0,0 +1,45 @@
+package security
+
+import (
+	"context"
+	"errors"
+	"net/netip"
+	"testing"
+
+	"hostflow/profile-service/internal/middlewares"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNormalizeNetworks(t *testing.T) {
+	networks, err := normalizeNetworks([]string{" 203.0.113.77/24", "198.51.100.7", "2001:db8::1/32", "203.0.113.0/24", "::ffff:192.0.2.1"})
+	require.NoError(t, err)
+	assert.Equal(t, []netip.Prefix{
+		netip.MustParsePrefix("192.0.2.1/32"),
+		netip.MustParsePrefix("198.51.100.7/32"),
+		netip.MustParsePrefix("203.0.113.0/24"),
+		netip.MustParsePrefix("2001:db8::/32"),
+	}, networks)
+
+	for _, value := range []string{"office", "0.0.0.0/0", "203.0.113.0/33"} {
+		_, err := normalizeNetworks([]string{value})
+		assert.True(t, errors.Is(err, ErrInvalidInput), value)
+	}
+}
+
+func TestUpdate_RefusesLockout(t *testing.T) {
+	service := GetSecurityService(nil, nil, nil)
+	owner := middlewares.Principal{UserID: "user-1", OrganizationID: 1, Role: "OWNER", AAL: "aal1"}
+
+	_, err := service.Update(context.Background(), owner, "203.0.113.9", UpdatePolicyRequest{RequireMFA: true})
+	assert.True(t, errors.Is(err, ErrLockout))
+
+	_, err = service.Update(context.Background(), owner, "198.51.100.7", UpdatePolicyRequest{AllowedNetworks: []string{"203.0.113.0/24"}})
+	assert.True(t, errors.Is(err, ErrLockout))
+
+	manager := owner
+	manager.Role = "MANAGER"
+	_, err = service.Update(context.Background(), manager, "203.0.113.9", UpdatePolicyRequest{})
+	assert.True(t, errors.Is(err, ErrForbidden))
+}
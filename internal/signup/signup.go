@@ -0,0 +1,16 @@
+package signup
+
+import "go.uber.org/fx"
+
+// ======== EXPORTS ========
+
+// Module exports services present
+var Context = fx.Options(
+	fx.Provide(GetSignupController),
+	fx.Provide(fx.Annotate(
+		GetSignupService,
+		fx.As(new(Service)),
+	)),
+	fx.Provide(GetSignupRepository),
+	fx.Provide(SetSignupRoutes),
+)
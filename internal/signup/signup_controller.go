@@ -0,0 +1,79 @@
+package signup
+
+import (
+	"errors"
+	"net/http"
+
+	"hostflow/profile-service/internal/middlewares"
+	"hostflow/profile-service/internal/organization"
+	"hostflow/profile-service/pkg/common"
+
+	"github.com/gin-gonic/gin"
+)
+
+type SignupController struct {
+	service Service
+}
+
+func GetSignupController(service Service) *SignupController {
+	return &SignupController{
+		service: service,
+	}
+}
+
+// SignupOrganizationHandler godoc
+// @Summary Sign up an organization
+// @Description Creates an organization and the caller's OWNER profile in one transaction and stores the membership in the caller's app metadata. Safe to retry: repeated calls return the same organization with 200.
+// @Tags signup
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param body body SignupOrganizationRequest true "Organization"
+// @Success 201 {object} SignupResult
+// @Success 200 {object} SignupResult
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 502 {object} ErrorResponse
+// @Router /signup/organization [post]
+func (c *SignupController) SignupOrganizationHandler(ctx *gin.Context) {
+	principal := middlewares.GetPrincipal(ctx)
+
+	var body SignupOrganizationRequest
+	if errors := common.Validation.ValidateBody(ctx, &body); errors != nil {
+		ctx.JSON(http.StatusBadRequest, errors)
+		return
+	}
+
+	result, err := c.service.SignupOrganization(ctx.Request.Context(), principal.UserID, principal.Email, body)
+	if err != nil {
+		respondError(ctx, "Failed to sign up organization", err)
+		return
+	}
+
+	status := http.StatusOK
+	if result.Created {
+		status = http.StatusCreated
+	}
+	ctx.JSON(status, result)
+}
+
+// respondError maps service errors to HTTP status codes.
+func respondError(ctx *gin.Context, title string, err error) {
+	status := http.StatusInternalServerError
+	switch {
+	case errors.Is(err, ErrInvalidInput), errors.Is(err, organization.ErrInvalidInput):
+		status = http.StatusBadRequest
+	case errors.Is(err, ErrUnauthorized):
+		status = http.StatusUnauthorized
+	case errors.Is(err, ErrAlreadyMember), errors.Is(err, organization.ErrSlugTaken):
+		status = http.StatusConflict
+	case errors.Is(err, ErrIdentitySync):
+		status = http.StatusBadGateway
+	}
+
+	ctx.JSON(status, ErrorResponse{
+		Error:   title,
+		Message: err.Error(),
+	})
+}
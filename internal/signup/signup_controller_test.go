@@ -0,0 +1,89 @@
+package signup
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"hostflow/profile-service/internal/middlewares"
+	"hostflow/profile-service/internal/organization"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockSignupService struct {
+	mock.Mock
+}
+
+func (m *MockSignupService) SignupOrganization(ctx context.Context, userID string, email string, req SignupOrganizationRequest) (*SignupResult, error) {
+	args := m.Called(ctx, userID, email, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*SignupResult), args.Error(1)
+}
+
+func newSignupRouter(controller *SignupController) *gin.Engine {
+	r := gin.New()
+	r.POST("/signup/organization", func(c *gin.Context) {
+		middlewares.SetPrincipal(c, middlewares.Principal{UserID: "5b0c1f2e-6a1d-4d0e-9a57-4f4a1c2b3d4e", Email: "ana@vilabled.si"})
+		controller.SignupOrganizationHandler(c)
+	})
+	return r
+}
+
+func TestSignupOrganizationHandler_CreatedThenIdempotent(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	mockSvc := new(MockSignupService)
+	r := newSignupRouter(GetSignupController(mockSvc))
+
+	body := SignupOrganizationRequest{OrganizationName: "Vila Bled", FullName: "Ana Novak"}
+	created := &SignupResult{Organization: organization.Organization{ID: 9, Name: "Vila Bled"}, Created: true}
+	replayed := &SignupResult{Organization: organization.Organization{ID: 9, Name: "Vila Bled"}, Created: false}
+	mockSvc.On("SignupOrganization", mock.Anything, "5b0c1f2e-6a1d-4d0e-9a57-4f4a1c2b3d4e", "ana@vilabled.si", body).
+		Return(created, nil).Once()
+	mockSvc.On("SignupOrganization", mock.Anything, "5b0c1f2e-6a1d-4d0e-9a57-4f4a1c2b3d4e", "ana@vilabled.si", body).
+		Return(replayed, nil).Once()
+
+	for _, expected := range []int{http.StatusCreated, http.StatusOK} {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", "/signup/organization", strings.NewReader(`{"organization_name":"Vila Bled","full_name":"Ana Novak"}`))
+		req.Header.Set("Content-Type", "application/json")
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, expected, w.Code)
+		assert.Contains(t, w.Body.String(), `"id":9`)
+	}
+}
+
+func TestSignupOrganizationHandler_IdentitySyncFailure(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	mockSvc := new(MockSignupService)
+	r := newSignupRouter(GetSignupController(mockSvc))
+
+	mockSvc.On("SignupOrganization", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil, ErrIdentitySync)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("POST", "/signup/organization", strings.NewReader(`{"organization_name":"Vila Bled","full_name":"Ana Novak"}`))
+	req.Header.Set("Content-Type", "application/json")
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadGateway, w.Code)
+}
+
+func TestSignupOrganizationHandler_MissingName(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	r := newSignupRouter(GetSignupController(new(MockSignupService)))
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("POST", "/signup/organization", strings.NewReader(`{"full_name":"Ana Novak"}`))
+	req.Header.Set("Content-Type", "application/json")
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), "organization_name")
+}
@@ -0,0 +1,28 @@
+package signup
+
+import (
+	"hostflow/profile-service/internal/organization"
+	"hostflow/profile-service/internal/profile"
+)
+
+// SignupOrganizationRequest creates an organization owned by the caller.
+type SignupOrganizationRequest struct {
+	OrganizationName string `json:"organization_name" binding:"required,max=200" example:"Vila Bled"`
+	FullName         string `json:"full_name" binding:"required,max=200" example:"Ana Novak"`
+	Slug             string `json:"slug" binding:"max=63" example:"vila-bled"`
+	Timezone         string `json:"timezone" example:"Europe/Ljubljana"`
+}
+
+// SignupResult is the organization and OWNER profile created by sign-up.
+// Created is false when an earlier attempt already did the work.
+type SignupResult struct {
+	Organization organization.Organization `json:"organization"`
+	Owner        profile.User              `json:"owner"`
+	Created      bool                      `json:"created"`
+}
+
+// ErrorResponse represents an error response
+type ErrorResponse struct {
+	Error   string `json:"error" example:"Invalid request"`
+	Message string `json:"message,omitempty" example:"The provided data is invalid"`
+}
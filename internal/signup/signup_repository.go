@@ -0,0 +1,128 @@
+package signup
+
+import (
+	"context"
+	"errors"
+
+	"hostflow/profile-service/internal/organization"
+	"hostflow/profile-service/internal/profile"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+const (
+	organizationColumns = `id, name, slug, legal_name, address, contact_email, timezone, default_locale, currency`
+	profileColumns      = `id, organization_id, full_name, role, email, status, created_at, updated_at`
+)
+
+type SignupRepository struct {
+	db *pgxpool.Pool
+}
+
+func GetSignupRepository(db *pgxpool.Pool) *SignupRepository {
+	return &SignupRepository{
+		db: db,
+	}
+}
+
+// CreateOrganization creates the organization and its OWNER profile in one
+// transaction. Attempts by the same user are serialized with an advisory
+// lock; if an earlier attempt already created the organization it is
+// returned with Created set to false. A user who already belongs to an
+// organization they did not create gets ErrAlreadyMember.
+func (r *SignupRepository) CreateOrganization(ctx context.Context, userID uuid.UUID, email string, name string, slug *string, timezone string, fullName string) (*SignupResult, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	if _, err := tx.Exec(ctx, `SELECT pg_advisory_xact_lock(hashtext('signup:' || $1::text))`, userID); err != nil {
+		return nil, err
+	}
+
+	var existingOrg int64
+	var createdBy *uuid.UUID
+	err = tx.QueryRow(ctx, `
+        SELECT p.organization_id, o.created_by
+        FROM "profiles" p
+        JOIN organization o ON o.id = p.organization_id
+        WHERE p.id = $1
+    `, userID).Scan(&existingOrg, &createdBy)
+	switch {
+	case err == nil:
+		if createdBy == nil || *createdBy != userID {
+			return nil, ErrAlreadyMember
+		}
+		result, err := r.load(ctx, tx, userID, existingOrg)
+		if err != nil {
+			return nil, err
+		}
+		return result, tx.Commit(ctx)
+	case !errors.Is(err, pgx.ErrNoRows):
+		return nil, err
+	}
+
+	rows, err := tx.Query(ctx, `
+        INSERT INTO organization (name, slug, timezone, created_by)
+        VALUES ($1, $2, $3, $4)
+        RETURNING `+organizationColumns,
+		name, slug, timezone, userID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	org, err := pgx.CollectOneRow(rows, pgx.RowToStructByName[organization.Organization])
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+			return nil, organization.ErrSlugTaken
+		}
+		return nil, err
+	}
+
+	rows, err = tx.Query(ctx, `
+        INSERT INTO "profiles" (id, organization_id, full_name, role, email, status, created_at, updated_at)
+        VALUES ($1, $2, $3, 'OWNER', $4, 'ACTIVE', now(), now())
+        RETURNING `+profileColumns,
+		userID, org.ID, fullName, email,
+	)
+	if err != nil {
+		return nil, err
+	}
+	owner, err := pgx.CollectOneRow(rows, pgx.RowToStructByName[profile.User])
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+	return &SignupResult{Organization: org, Owner: owner, Created: true}, nil
+}
+
+// load reads back an organization and its owner created by an earlier attempt.
+func (r *SignupRepository) load(ctx context.Context, tx pgx.Tx, userID uuid.UUID, orgID int64) (*SignupResult, error) {
+	rows, err := tx.Query(ctx, `SELECT `+organizationColumns+` FROM organization WHERE id = $1`, orgID)
+	if err != nil {
+		return nil, err
+	}
+	org, err := pgx.CollectOneRow(rows, pgx.RowToStructByName[organization.Organization])
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err = tx.Query(ctx, `SELECT `+profileColumns+` FROM "profiles" WHERE id = $1`, userID)
+	if err != nil {
+		return nil, err
+	}
+	owner, err := pgx.CollectOneRow(rows, pgx.RowToStructByName[profile.User])
+	if err != nil {
+		return nil, err
+	}
+
+	return &SignupResult{Organization: org, Owner: owner, Created: false}, nil
+}
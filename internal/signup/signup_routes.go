@@ -0,0 +1,40 @@
+package signup
+
+import (
+	"hostflow/profile-service/internal/middlewares"
+	"hostflow/profile-service/pkg/lib"
+)
+
+type SignupRoutes struct {
+	logger           lib.Logger
+	router           *lib.Router
+	signupController *SignupController
+	authMiddleware   middlewares.AuthMiddleware
+}
+
+func SetSignupRoutes(
+	logger lib.Logger,
+	router *lib.Router,
+	signupController *SignupController,
+	authMiddleware middlewares.AuthMiddleware,
+) SignupRoutes {
+	return SignupRoutes{
+		logger:           logger,
+		router:           router,
+		signupController: signupController,
+		authMiddleware:   authMiddleware,
+	}
+}
+
+func (route SignupRoutes) Setup() {
+	route.logger.Info("Setting up [SIGNUP] routes.")
+
+	// Callers do not belong to an organization yet
+	signup := route.router.Group("/signup")
+	signup.Use(route.authMiddleware.IdentityHandler())
+	{
+		signup.POST("/organization", route.signupController.SignupOrganizationHandler)
+	}
+
+	route.logger.Info("[SIGNUP] routes setup complete.")
+}
@@ -0,0 +1,94 @@
+package signup
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"hostflow/profile-service/internal/iamsync"
+	"hostflow/profile-service/internal/organization"
+
+	"github.com/google/uuid"
+)
+
+// DefaultTimezone is used when sign-up does not specify one.
+const DefaultTimezone = "Europe/Ljubljana"
+
+var (
+	ErrAlreadyMember = errors.New("you already belong to an organization")
+	ErrUnauthorized  = errors.New("the token does not identify a user")
+	ErrInvalidInput  = errors.New("invalid input")
+	ErrIdentitySync  = errors.New("organization was created but the user account could not be updated; retry the request")
+)
+
+type SignupService struct {
+	repo     *SignupRepository
+	identity iamsync.Service
+}
+
+type Service interface {
+	SignupOrganization(ctx context.Context, userID string, email string, req SignupOrganizationRequest) (*SignupResult, error)
+}
+
+func GetSignupService(repo *SignupRepository, identity iamsync.Service) *SignupService {
+	return &SignupService{
+		repo:     repo,
+		identity: identity,
+	}
+}
+
+// SignupOrganization creates an organization with the caller as OWNER and
+// queues the membership for the caller's app metadata. Retrying after any
+// failure is safe: the database part is idempotent per user and the sync
+// is queued again every time.
+func (s *SignupService) SignupOrganization(ctx context.Context, userID string, email string, req SignupOrganizationRequest) (*SignupResult, error) {
+	id, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, ErrUnauthorized
+	}
+
+	fields, err := normalize(req)
+	if err != nil {
+		return nil, err
+	}
+
+	var slug *string
+	if *fields.Slug != "" {
+		slug = fields.Slug
+	}
+
+	result, err := s.repo.CreateOrganization(ctx, id, strings.ToLower(email), *fields.Name, slug, *fields.Timezone, strings.TrimSpace(req.FullName))
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.identity.Sync(ctx, userID); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrIdentitySync, err)
+	}
+
+	return result, nil
+}
+
+// normalize validates the organization fields with the same rules as
+// PATCH /organization.
+func normalize(req SignupOrganizationRequest) (*organization.UpdateOrganizationRequest, error) {
+	if strings.TrimSpace(req.FullName) == "" {
+		return nil, fmt.Errorf("%w: full_name must not be blank", ErrInvalidInput)
+	}
+
+	timezone := req.Timezone
+	if strings.TrimSpace(timezone) == "" {
+		timezone = DefaultTimezone
+	}
+
+	fields := &organization.UpdateOrganizationRequest{
+		Name:     &req.OrganizationName,
+		Slug:     &req.Slug,
+		Timezone: &timezone,
+	}
+	if err := fields.Normalize(); err != nil {
+		return nil, err
+	}
+	return fields, nil
+}
@@ -0,0 +1,16 @@
+package skills
+
+import "go.uber.org/fx"
+
+// ======== EXPORTS ========
+
+// Module exports services present
+var Context = fx.Options(
+	fx.Provide(GetSkillsController),
+	fx.Provide(fx.Annotate(
+		GetSkillsService,
+		fx.As(new(Service)),
+	)),
+	fx.Provide(GetSkillsRepository),
+	fx.Provide(SetSkillsRoutes),
+)
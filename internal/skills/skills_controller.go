@@ -0,0 +1,373 @@
+package skills
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"hostflow/profile-service/internal/middlewares"
+	"hostflow/profile-service/pkg/common"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type SkillsController struct {
+	service Service
+}
+
+func GetSkillsController(service Service) *SkillsController {
+	return &SkillsController{
+		service: service,
+	}
+}
+
+// ListSkillsHandler godoc
+// @Summary List skills
+// @Description Returns the skill vocabulary of the requester's organization.
+// @Tags skills
+// @Produce json
+// @Security ApiKeyAuth
+// @Success 200 {array} Skill
+// @Failure 500 {object} ErrorResponse
+// @Router /skills [get]
+func (c *SkillsController) ListSkillsHandler(ctx *gin.Context) {
+	principal := middlewares.GetPrincipal(ctx)
+
+	skills, err := c.service.ListSkills(ctx.Request.Context(), principal.OrganizationID)
+	if err != nil {
+		respondError(ctx, "Failed to fetch skills", err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, skills)
+}
+
+// CreateSkillHandler godoc
+// @Summary Create a skill
+// @Description Adds a skill to the organization's vocabulary. Requires OWNER role.
+// @Tags skills
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param body body SkillRequest true "Skill"
+// @Success 201 {object} Skill
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Router /skills [post]
+func (c *SkillsController) CreateSkillHandler(ctx *gin.Context) {
+	principal := middlewares.GetPrincipal(ctx)
+
+	var body SkillRequest
+	if errors := common.Validation.ValidateBody(ctx, &body); errors != nil {
+		ctx.JSON(http.StatusBadRequest, errors)
+		return
+	}
+
+	skill, err := c.service.CreateSkill(ctx.Request.Context(), principal.OrganizationID, principal.Role, body.Name)
+	if err != nil {
+		respondError(ctx, "Failed to create skill", err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, skill)
+}
+
+// RenameSkillHandler godoc
+// @Summary Rename a skill
+// @Description Renames a skill in the organization's vocabulary. Requires OWNER role.
+// @Tags skills
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path int true "Skill ID"
+// @Param body body SkillRequest true "Skill"
+// @Success 200 {object} Skill
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Router /skills/{id} [patch]
+func (c *SkillsController) RenameSkillHandler(ctx *gin.Context) {
+	principal := middlewares.GetPrincipal(ctx)
+
+	id, ok := parseSkillID(ctx)
+	if !ok {
+		return
+	}
+
+	var body SkillRequest
+	if errors := common.Validation.ValidateBody(ctx, &body); errors != nil {
+		ctx.JSON(http.StatusBadRequest, errors)
+		return
+	}
+
+	skill, err := c.service.RenameSkill(ctx.Request.Context(), id, principal.OrganizationID, principal.Role, body.Name)
+	if err != nil {
+		respondError(ctx, "Failed to rename skill", err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, skill)
+}
+
+// DeleteSkillHandler godoc
+// @Summary Delete a skill
+// @Description Removes a skill from the vocabulary and from every profile. Requires OWNER role.
+// @Tags skills
+// @Security ApiKeyAuth
+// @Param id path int true "Skill ID"
+// @Success 204 "No Content"
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /skills/{id} [delete]
+func (c *SkillsController) DeleteSkillHandler(ctx *gin.Context) {
+	principal := middlewares.GetPrincipal(ctx)
+
+	id, ok := parseSkillID(ctx)
+	if !ok {
+		return
+	}
+
+	if err := c.service.DeleteSkill(ctx.Request.Context(), id, principal.OrganizationID, principal.Role); err != nil {
+		respondError(ctx, "Failed to delete skill", err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+// ListLanguagesHandler godoc
+// @Summary List languages
+// @Description Returns the ISO 639-1 languages that can be attached to profiles.
+// @Tags skills
+// @Produce json
+// @Security ApiKeyAuth
+// @Success 200 {array} common.Language
+// @Router /languages [get]
+func (c *SkillsController) ListLanguagesHandler(ctx *gin.Context) {
+	ctx.JSON(http.StatusOK, common.Languages.All())
+}
+
+// ListTagsHandler godoc
+// @Summary List tags
+// @Description Returns the tags used in the requester's organization with the number of profiles carrying each.
+// @Tags skills
+// @Produce json
+// @Security ApiKeyAuth
+// @Success 200 {array} TagUsage
+// @Failure 500 {object} ErrorResponse
+// @Router /tags [get]
+func (c *SkillsController) ListTagsHandler(ctx *gin.Context) {
+	principal := middlewares.GetPrincipal(ctx)
+
+	tags, err := c.service.ListTags(ctx.Request.Context(), principal.OrganizationID)
+	if err != nil {
+		respondError(ctx, "Failed to fetch tags", err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, tags)
+}
+
+// GetAttributesHandler godoc
+// @Summary Get a user's skills, languages and tags
+// @Tags skills
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "User ID (UUID)"
+// @Success 200 {object} Attributes
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /users/{id}/attributes [get]
+func (c *SkillsController) GetAttributesHandler(ctx *gin.Context) {
+	principal := middlewares.GetPrincipal(ctx)
+
+	profileID, ok := parseProfileID(ctx)
+	if !ok {
+		return
+	}
+
+	attributes, err := c.service.GetAttributes(ctx.Request.Context(), profileID, principal.OrganizationID)
+	if err != nil {
+		respondError(ctx, "Failed to fetch attributes", err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, attributes)
+}
+
+// SetSkillsHandler godoc
+// @Summary Set a user's skills
+// @Description Replaces the skills of a user. Requires OWNER or MANAGER role.
+// @Tags skills
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "User ID (UUID)"
+// @Param body body SetSkillsRequest true "Skill IDs"
+// @Success 200 {object} Attributes
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /users/{id}/skills [put]
+func (c *SkillsController) SetSkillsHandler(ctx *gin.Context) {
+	principal := middlewares.GetPrincipal(ctx)
+
+	profileID, ok := parseProfileID(ctx)
+	if !ok {
+		return
+	}
+
+	var body SetSkillsRequest
+	if !bindJSON(ctx, &body) {
+		return
+	}
+
+	attributes, err := c.service.SetSkills(ctx.Request.Context(), profileID, principal.OrganizationID, principal.Role, body.SkillIDs)
+	if err != nil {
+		respondError(ctx, "Failed to set skills", err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, attributes)
+}
+
+// SetLanguagesHandler godoc
+// @Summary Set a user's languages
+// @Description Replaces the spoken languages (ISO 639-1) of a user. Members can set their own, OWNERs and MANAGERs anyone's.
+// @Tags skills
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "User ID (UUID)"
+// @Param body body SetLanguagesRequest true "Language codes"
+// @Success 200 {object} Attributes
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /users/{id}/languages [put]
+func (c *SkillsController) SetLanguagesHandler(ctx *gin.Context) {
+	principal := middlewares.GetPrincipal(ctx)
+
+	profileID, ok := parseProfileID(ctx)
+	if !ok {
+		return
+	}
+
+	var body SetLanguagesRequest
+	if !bindJSON(ctx, &body) {
+		return
+	}
+
+	attributes, err := c.service.SetLanguages(
+		ctx.Request.Context(),
+		profileID,
+		principal.UserID,
+		principal.OrganizationID,
+		principal.Role,
+		body.Languages,
+	)
+	if err != nil {
+		respondError(ctx, "Failed to set languages", err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, attributes)
+}
+
+// SetTagsHandler godoc
+// @Summary Set a user's tags
+// @Description Replaces the free-form tags of a user. Requires OWNER or MANAGER role.
+// @Tags skills
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "User ID (UUID)"
+// @Param body body SetTagsRequest true "Tags"
+// @Success 200 {object} Attributes
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /users/{id}/tags [put]
+func (c *SkillsController) SetTagsHandler(ctx *gin.Context) {
+	principal := middlewares.GetPrincipal(ctx)
+
+	profileID, ok := parseProfileID(ctx)
+	if !ok {
+		return
+	}
+
+	var body SetTagsRequest
+	if !bindJSON(ctx, &body) {
+		return
+	}
+
+	attributes, err := c.service.SetTags(ctx.Request.Context(), profileID, principal.OrganizationID, principal.Role, body.Tags)
+	if err != nil {
+		respondError(ctx, "Failed to set tags", err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, attributes)
+}
+
+// bindJSON binds a JSON body and aborts with 400 when it is malformed.
+func bindJSON(ctx *gin.Context, body interface{}) bool {
+	if err := ctx.ShouldBindJSON(body); err != nil {
+		ctx.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "Invalid request",
+			Message: err.Error(),
+		})
+		return false
+	}
+	return true
+}
+
+// parseProfileID reads the :id path parameter and aborts with 400 when it
+// is not a UUID.
+func parseProfileID(ctx *gin.Context) (uuid.UUID, bool) {
+	id, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "Invalid ID format",
+			Message: "ID must be a valid UUID",
+		})
+		return uuid.Nil, false
+	}
+	return id, true
+}
+
+// parseSkillID reads the numeric :id path parameter of a skill.
+func parseSkillID(ctx *gin.Context) (int64, bool) {
+	id, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "Invalid ID format",
+			Message: "Skill ID must be numeric",
+		})
+		return 0, false
+	}
+	return id, true
+}
+
+// respondError maps service errors to HTTP status codes.
+func respondError(ctx *gin.Context, title string, err error) {
+	status := http.StatusInternalServerError
+	switch {
+	case errors.Is(err, ErrInvalidInput), errors.Is(err, ErrUnknownSkill):
+		status = http.StatusBadRequest
+	case errors.Is(err, ErrForbidden):
+		status = http.StatusForbidden
+	case errors.Is(err, ErrProfileNotFound), errors.Is(err, ErrSkillNotFound):
+		status = http.StatusNotFound
+	case errors.Is(err, ErrDuplicateSkill):
+		status = http.StatusConflict
+	}
+
+	ctx.JSON(status, ErrorResponse{
+		Error:   title,
+		Message: err.Error(),
+	})
+}
@@ -0,0 +1,172 @@
+package skills
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"hostflow/profile-service/internal/middlewares"
+	"hostflow/profile-service/pkg/common"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockSkillsService struct {
+	mock.Mock
+}
+
+func (m *MockSkillsService) ListSkills(ctx context.Context, orgID int64) ([]Skill, error) {
+	args := m.Called(ctx, orgID)
+	return args.Get(0).([]Skill), args.Error(1)
+}
+
+func (m *MockSkillsService) CreateSkill(ctx context.Context, orgID int64, role string, name string) (*Skill, error) {
+	args := m.Called(ctx, orgID, role, name)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*Skill), args.Error(1)
+}
+
+func (m *MockSkillsService) RenameSkill(ctx context.Context, id int64, orgID int64, role string, name string) (*Skill, error) {
+	args := m.Called(ctx, id, orgID, role, name)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*Skill), args.Error(1)
+}
+
+func (m *MockSkillsService) DeleteSkill(ctx context.Context, id int64, orgID int64, role string) error {
+	return m.Called(ctx, id, orgID, role).Error(0)
+}
+
+func (m *MockSkillsService) ListTags(ctx context.Context, orgID int64) ([]TagUsage, error) {
+	args := m.Called(ctx, orgID)
+	return args.Get(0).([]TagUsage), args.Error(1)
+}
+
+func (m *MockSkillsService) GetAttributes(ctx context.Context, profileID uuid.UUID, orgID int64) (*Attributes, error) {
+	args := m.Called(ctx, profileID, orgID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*Attributes), args.Error(1)
+}
+
+func (m *MockSkillsService) SetSkills(ctx context.Context, profileID uuid.UUID, orgID int64, role string, skillIDs []int64) (*Attributes, error) {
+	args := m.Called(ctx, profileID, orgID, role, skillIDs)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*Attributes), args.Error(1)
+}
+
+func (m *MockSkillsService) SetLanguages(ctx context.Context, profileID uuid.UUID, requesterID string, orgID int64, role string, codes []string) (*Attributes, error) {
+	args := m.Called(ctx, profileID, requesterID, orgID, role, codes)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*Attributes), args.Error(1)
+}
+
+func (m *MockSkillsService) SetTags(ctx context.Context, profileID uuid.UUID, orgID int64, role string, tags []string) (*Attributes, error) {
+	args := m.Called(ctx, profileID, orgID, role, tags)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*Attributes), args.Error(1)
+}
+
+func TestCreateSkillHandler_Duplicate(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	mockSvc := new(MockSkillsService)
+	controller := GetSkillsController(mockSvc)
+
+	r := gin.New()
+	r.POST("/skills", func(c *gin.Context) {
+		middlewares.SetPrincipal(c, middlewares.Principal{OrganizationID: 1, Role: "OWNER"})
+		controller.CreateSkillHandler(c)
+	})
+
+	mockSvc.On("CreateSkill", mock.Anything, int64(1), "OWNER", "Laundry").Return(nil, ErrDuplicateSkill)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("POST", "/skills", strings.NewReader(`{"name":"Laundry"}`))
+	req.Header.Set("Content-Type", "application/json")
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusConflict, w.Code)
+}
+
+func TestSetLanguagesHandler_Success(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	mockSvc := new(MockSkillsService)
+	controller := GetSkillsController(mockSvc)
+	profileID := uuid.New()
+
+	r := gin.New()
+	r.PUT("/users/:id/languages", func(c *gin.Context) {
+		middlewares.SetPrincipal(c, middlewares.Principal{UserID: profileID.String(), OrganizationID: 1, Role: "MEMBER"})
+		controller.SetLanguagesHandler(c)
+	})
+
+	mockSvc.On("SetLanguages", mock.Anything, profileID, profileID.String(), int64(1), "MEMBER", []string{"sl", "de"}).
+		Return(&Attributes{
+			Skills:    []Skill{},
+			Languages: []common.Language{{Code: "de", Name: "German"}, {Code: "sl", Name: "Slovenian"}},
+			Tags:      []string{},
+		}, nil)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("PUT", "/users/"+profileID.String()+"/languages", strings.NewReader(`{"languages":["sl","de"]}`))
+	req.Header.Set("Content-Type", "application/json")
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), "Slovenian")
+}
+
+func TestSetSkillsHandler_UnknownSkill(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	mockSvc := new(MockSkillsService)
+	controller := GetSkillsController(mockSvc)
+	profileID := uuid.New()
+
+	r := gin.New()
+	r.PUT("/users/:id/skills", func(c *gin.Context) {
+		middlewares.SetPrincipal(c, middlewares.Principal{OrganizationID: 1, Role: "MANAGER"})
+		controller.SetSkillsHandler(c)
+	})
+
+	mockSvc.On("SetSkills", mock.Anything, profileID, int64(1), "MANAGER", []int64{99}).Return(nil, ErrUnknownSkill)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("PUT", "/users/"+profileID.String()+"/skills", strings.NewReader(`{"skill_ids":[99]}`))
+	req.Header.Set("Content-Type", "application/json")
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestNormalizeTags(t *testing.T) {
+	tags, err := NormalizeTags([]string{" Check-In ", "senior", "check-in", ""})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"check-in", "senior"}, tags)
+
+	_, err = NormalizeTags([]string{strings.Repeat("x", MaxTagLength+1)})
+	assert.ErrorIs(t, err, ErrInvalidInput)
+}
+
+func TestNormalizeLanguages(t *testing.T) {
+	codes, err := NormalizeLanguages([]string{"SL", "en", "sl"})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"en", "sl"}, codes)
+
+	_, err = NormalizeLanguages([]string{"slo"})
+	assert.ErrorIs(t, err, ErrInvalidInput)
+}
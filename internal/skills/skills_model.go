@@ -0,0 +1,54 @@
+package skills
+
+import (
+	"time"
+
+	"hostflow/profile-service/pkg/common"
+)
+
+// Skill is an entry in an organization's controlled skill vocabulary.
+type Skill struct {
+	ID             int64     `json:"id" db:"id"`
+	OrganizationID int64     `json:"organization_id" db:"organization_id"`
+	Name           string    `json:"name" db:"name" example:"Deep cleaning"`
+	CreatedAt      time.Time `json:"created_at" db:"created_at"`
+}
+
+// TagUsage is a tag used in the organization and how many profiles carry it.
+type TagUsage struct {
+	Tag      string `json:"tag" db:"tag" example:"check-in"`
+	Profiles int64  `json:"profiles" db:"profiles"`
+}
+
+// Attributes are the skills, languages and tags attached to a profile.
+type Attributes struct {
+	Skills    []Skill           `json:"skills"`
+	Languages []common.Language `json:"languages"`
+	Tags      []string          `json:"tags"`
+}
+
+// SkillRequest is the body for creating or renaming a skill.
+type SkillRequest struct {
+	Name string `json:"name" binding:"required,max=100" example:"Deep cleaning"`
+}
+
+// SetSkillsRequest replaces the skills of a profile.
+type SetSkillsRequest struct {
+	SkillIDs []int64 `json:"skill_ids"`
+}
+
+// SetLanguagesRequest replaces the spoken languages of a profile.
+type SetLanguagesRequest struct {
+	Languages []string `json:"languages" example:"sl,en,de"`
+}
+
+// SetTagsRequest replaces the tags of a profile.
+type SetTagsRequest struct {
+	Tags []string `json:"tags" example:"check-in,weekend"`
+}
+
+// ErrorResponse represents an error response
+type ErrorResponse struct {
+	Error   string `json:"error" example:"Invalid request"`
+	Message string `json:"message,omitempty" example:"The provided data is invalid"`
+}
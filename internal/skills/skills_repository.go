@@ -0,0 +1,254 @@
+package skills
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type SkillsRepository struct {
+	db *pgxpool.Pool
+}
+
+func GetSkillsRepository(db *pgxpool.Pool) *SkillsRepository {
+	return &SkillsRepository{
+		db: db,
+	}
+}
+
+// ProfileInOrganization reports whether the profile belongs to the organization.
+func (r *SkillsRepository) ProfileInOrganization(ctx context.Context, profileID uuid.UUID, orgID int64) (bool, error) {
+	var exists bool
+	query := `SELECT EXISTS (SELECT 1 FROM "profiles" WHERE id = $1 AND organization_id = $2)`
+
+	if err := r.db.QueryRow(ctx, query, profileID, orgID).Scan(&exists); err != nil {
+		return false, err
+	}
+	return exists, nil
+}
+
+// ListSkills returns the skill vocabulary of an organization.
+func (r *SkillsRepository) ListSkills(ctx context.Context, orgID int64) ([]Skill, error) {
+	query := `
+        SELECT id, organization_id, name, created_at
+        FROM skills
+        WHERE organization_id = $1
+        ORDER BY lower(name)
+    `
+
+	rows, err := r.db.Query(ctx, query, orgID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return pgx.CollectRows(rows, pgx.RowToStructByName[Skill])
+}
+
+// CreateSkill adds a skill to the vocabulary of an organization.
+func (r *SkillsRepository) CreateSkill(ctx context.Context, orgID int64, name string) (*Skill, error) {
+	query := `
+        INSERT INTO skills (organization_id, name)
+        VALUES ($1, $2)
+        RETURNING id, organization_id, name, created_at
+    `
+
+	rows, err := r.db.Query(ctx, query, orgID, name)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	skill, err := pgx.CollectOneRow(rows, pgx.RowToStructByName[Skill])
+	if err != nil {
+		return nil, translateError(err)
+	}
+	return &skill, nil
+}
+
+// RenameSkill renames a skill, returning nil if it does not exist.
+func (r *SkillsRepository) RenameSkill(ctx context.Context, id int64, orgID int64, name string) (*Skill, error) {
+	query := `
+        UPDATE skills SET name = $3
+        WHERE id = $1 AND organization_id = $2
+        RETURNING id, organization_id, name, created_at
+    `
+
+	rows, err := r.db.Query(ctx, query, id, orgID, name)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	skill, err := pgx.CollectOneRow(rows, pgx.RowToStructByName[Skill])
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, translateError(err)
+	}
+	return &skill, nil
+}
+
+// DeleteSkill removes a skill (and its profile links) and reports whether
+// it existed.
+func (r *SkillsRepository) DeleteSkill(ctx context.Context, id int64, orgID int64) (bool, error) {
+	result, err := r.db.Exec(ctx, `DELETE FROM skills WHERE id = $1 AND organization_id = $2`, id, orgID)
+	if err != nil {
+		return false, err
+	}
+	return result.RowsAffected() > 0, nil
+}
+
+// ListTags returns the tags used within an organization with usage counts.
+func (r *SkillsRepository) ListTags(ctx context.Context, orgID int64) ([]TagUsage, error) {
+	query := `
+        SELECT tag, count(*) AS profiles
+        FROM profile_tags
+        WHERE organization_id = $1
+        GROUP BY tag
+        ORDER BY tag
+    `
+
+	rows, err := r.db.Query(ctx, query, orgID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return pgx.CollectRows(rows, pgx.RowToStructByName[TagUsage])
+}
+
+// GetAttributes returns the skills, language codes and tags of a profile.
+func (r *SkillsRepository) GetAttributes(ctx context.Context, profileID uuid.UUID) ([]Skill, []string, []string, error) {
+	rows, err := r.db.Query(ctx, `
+        SELECT s.id, s.organization_id, s.name, s.created_at
+        FROM profile_skills ps
+        JOIN skills s ON s.id = ps.skill_id
+        WHERE ps.profile_id = $1
+        ORDER BY lower(s.name)
+    `, profileID)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	skills, err := pgx.CollectRows(rows, pgx.RowToStructByName[Skill])
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	rows, err = r.db.Query(ctx, `
+        SELECT language_code FROM profile_languages WHERE profile_id = $1 ORDER BY language_code
+    `, profileID)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	languages, err := pgx.CollectRows(rows, pgx.RowTo[string])
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	rows, err = r.db.Query(ctx, `
+        SELECT tag FROM profile_tags WHERE profile_id = $1 ORDER BY tag
+    `, profileID)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	tags, err := pgx.CollectRows(rows, pgx.RowTo[string])
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	return skills, languages, tags, nil
+}
+
+// ReplaceSkills replaces the skills of a profile. Every skill must belong
+// to the organization, otherwise ErrUnknownSkill is returned.
+func (r *SkillsRepository) ReplaceSkills(ctx context.Context, profileID uuid.UUID, orgID int64, skillIDs []int64) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	var known int
+	err = tx.QueryRow(ctx,
+		`SELECT count(*) FROM skills WHERE organization_id = $1 AND id = ANY($2)`,
+		orgID, skillIDs,
+	).Scan(&known)
+	if err != nil {
+		return err
+	}
+	if known != len(skillIDs) {
+		return ErrUnknownSkill
+	}
+
+	if _, err := tx.Exec(ctx, `DELETE FROM profile_skills WHERE profile_id = $1`, profileID); err != nil {
+		return err
+	}
+	_, err = tx.Exec(ctx, `
+        INSERT INTO profile_skills (profile_id, skill_id)
+        SELECT $1, unnest($2::bigint[])
+    `, profileID, skillIDs)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+// ReplaceLanguages replaces the spoken languages of a profile.
+func (r *SkillsRepository) ReplaceLanguages(ctx context.Context, profileID uuid.UUID, codes []string) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	if _, err := tx.Exec(ctx, `DELETE FROM profile_languages WHERE profile_id = $1`, profileID); err != nil {
+		return err
+	}
+	_, err = tx.Exec(ctx, `
+        INSERT INTO profile_languages (profile_id, language_code)
+        SELECT $1, unnest($2::text[])
+    `, profileID, codes)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+// ReplaceTags replaces the tags of a profile.
+func (r *SkillsRepository) ReplaceTags(ctx context.Context, profileID uuid.UUID, orgID int64, tags []string) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	if _, err := tx.Exec(ctx, `DELETE FROM profile_tags WHERE profile_id = $1`, profileID); err != nil {
+		return err
+	}
+	_, err = tx.Exec(ctx, `
+        INSERT INTO profile_tags (profile_id, organization_id, tag)
+        SELECT $1, $2, unnest($3::text[])
+    `, profileID, orgID, tags)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+// translateError turns unique violations into ErrDuplicateSkill.
+func translateError(err error) error {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+		return ErrDuplicateSkill
+	}
+	return err
+}
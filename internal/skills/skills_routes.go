@@ -0,0 +1,58 @@
+package skills
+
+import (
+	"hostflow/profile-service/internal/middlewares"
+	"hostflow/profile-service/pkg/lib"
+)
+
+type SkillsRoutes struct {
+	logger           lib.Logger
+	router           *lib.Router
+	skillsController *SkillsController
+	authMiddleware   middlewares.AuthMiddleware
+}
+
+func SetSkillsRoutes(
+	logger lib.Logger,
+	router *lib.Router,
+	skillsController *SkillsController,
+	authMiddleware middlewares.AuthMiddleware,
+) SkillsRoutes {
+	return SkillsRoutes{
+		logger:           logger,
+		router:           router,
+		skillsController: skillsController,
+		authMiddleware:   authMiddleware,
+	}
+}
+
+func (route SkillsRoutes) Setup() {
+	route.logger.Info("Setting up [SKILLS] routes.")
+
+	skills := route.router.Group("/skills")
+	skills.Use(route.authMiddleware.HandlerWithAPIKeys("skills"))
+	{
+		skills.GET("", route.skillsController.ListSkillsHandler)
+		skills.POST("", route.skillsController.CreateSkillHandler)
+		skills.PATCH("/:id", route.skillsController.RenameSkillHandler)
+		skills.DELETE("/:id", route.skillsController.DeleteSkillHandler)
+	}
+
+	vocabulary := route.router.Group("")
+	vocabulary.Use(route.authMiddleware.HandlerWithAPIKeys("skills"))
+	{
+		vocabulary.GET("/languages", route.skillsController.ListLanguagesHandler)
+		vocabulary.GET("/tags", route.skillsController.ListTagsHandler)
+	}
+
+	users := route.router.Group("/users/:id")
+	users.Use(route.authMiddleware.HandlerWithAPIKeys("skills"))
+	{
+		users.GET("/attributes", route.skillsController.GetAttributesHandler)
+		users.PUT("/skills", route.skillsController.SetSkillsHandler)
+		users.PUT("/languages", route.skillsController.SetLanguagesHandler)
+		users.PUT("/tags", route.skillsController.SetTagsHandler)
+	}
+
+	route.logger.Info("[SKILLS] routes setup complete.")
+}
@@ -0,0 +1,280 @@
+package skills
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"hostflow/profile-service/pkg/common"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// MaxTagsPerProfile limits how many tags a single profile can carry.
+	MaxTagsPerProfile = 20
+	// MaxTagLength limits the length of a single tag.
+	MaxTagLength = 50
+)
+
+var (
+	ErrProfileNotFound = errors.New("profile not found in your organization")
+	ErrSkillNotFound   = errors.New("skill not found")
+	ErrUnknownSkill    = errors.New("one or more skills do not exist in your organization")
+	ErrDuplicateSkill  = errors.New("a skill with this name already exists")
+	ErrForbidden       = errors.New("you are not allowed to perform this action")
+	ErrInvalidInput    = errors.New("invalid input")
+)
+
+type SkillsService struct {
+	repo *SkillsRepository
+}
+
+type Service interface {
+	ListSkills(ctx context.Context, orgID int64) ([]Skill, error)
+	CreateSkill(ctx context.Context, orgID int64, role string, name string) (*Skill, error)
+	RenameSkill(ctx context.Context, id int64, orgID int64, role string, name string) (*Skill, error)
+	DeleteSkill(ctx context.Context, id int64, orgID int64, role string) error
+	ListTags(ctx context.Context, orgID int64) ([]TagUsage, error)
+	GetAttributes(ctx context.Context, profileID uuid.UUID, orgID int64) (*Attributes, error)
+	SetSkills(ctx context.Context, profileID uuid.UUID, orgID int64, role string, skillIDs []int64) (*Attributes, error)
+	SetLanguages(ctx context.Context, profileID uuid.UUID, requesterID string, orgID int64, role string, codes []string) (*Attributes, error)
+	SetTags(ctx context.Context, profileID uuid.UUID, orgID int64, role string, tags []string) (*Attributes, error)
+}
+
+func GetSkillsService(repo *SkillsRepository) *SkillsService {
+	return &SkillsService{
+		repo: repo,
+	}
+}
+
+// ListSkills returns the skill vocabulary of the organization.
+func (s *SkillsService) ListSkills(ctx context.Context, orgID int64) ([]Skill, error) {
+	skills, err := s.repo.ListSkills(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+	if skills == nil {
+		return []Skill{}, nil
+	}
+	return skills, nil
+}
+
+// CreateSkill adds a skill to the vocabulary. Requires OWNER role.
+func (s *SkillsService) CreateSkill(ctx context.Context, orgID int64, role string, name string) (*Skill, error) {
+	if role != "OWNER" {
+		return nil, ErrForbidden
+	}
+
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return nil, fmt.Errorf("%w: name must not be blank", ErrInvalidInput)
+	}
+
+	return s.repo.CreateSkill(ctx, orgID, name)
+}
+
+// RenameSkill renames a skill. Requires OWNER role.
+func (s *SkillsService) RenameSkill(ctx context.Context, id int64, orgID int64, role string, name string) (*Skill, error) {
+	if role != "OWNER" {
+		return nil, ErrForbidden
+	}
+
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return nil, fmt.Errorf("%w: name must not be blank", ErrInvalidInput)
+	}
+
+	skill, err := s.repo.RenameSkill(ctx, id, orgID, name)
+	if err != nil {
+		return nil, err
+	}
+	if skill == nil {
+		return nil, ErrSkillNotFound
+	}
+	return skill, nil
+}
+
+// DeleteSkill removes a skill from the vocabulary and from every profile.
+// Requires OWNER role.
+func (s *SkillsService) DeleteSkill(ctx context.Context, id int64, orgID int64, role string) error {
+	if role != "OWNER" {
+		return ErrForbidden
+	}
+
+	deleted, err := s.repo.DeleteSkill(ctx, id, orgID)
+	if err != nil {
+		return err
+	}
+	if !deleted {
+		return ErrSkillNotFound
+	}
+	return nil
+}
+
+// ListTags returns the tags in use within the organization.
+func (s *SkillsService) ListTags(ctx context.Context, orgID int64) ([]TagUsage, error) {
+	tags, err := s.repo.ListTags(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+	if tags == nil {
+		return []TagUsage{}, nil
+	}
+	return tags, nil
+}
+
+// GetAttributes returns the skills, languages and tags of a profile.
+func (s *SkillsService) GetAttributes(ctx context.Context, profileID uuid.UUID, orgID int64) (*Attributes, error) {
+	if err := s.ensureMember(ctx, profileID, orgID); err != nil {
+		return nil, err
+	}
+
+	skills, codes, tags, err := s.repo.GetAttributes(ctx, profileID)
+	if err != nil {
+		return nil, err
+	}
+
+	attributes := &Attributes{
+		Skills:    skills,
+		Languages: make([]common.Language, 0, len(codes)),
+		Tags:      tags,
+	}
+	if attributes.Skills == nil {
+		attributes.Skills = []Skill{}
+	}
+	if attributes.Tags == nil {
+		attributes.Tags = []string{}
+	}
+
+	for _, code := range codes {
+		attributes.Languages = append(attributes.Languages, common.Language{Code: code, Name: common.Languages.Name(code)})
+	}
+
+	return attributes, nil
+}
+
+// SetSkills replaces the skills of a profile. Requires OWNER or MANAGER role.
+func (s *SkillsService) SetSkills(ctx context.Context, profileID uuid.UUID, orgID int64, role string, skillIDs []int64) (*Attributes, error) {
+	if role != "OWNER" && role != "MANAGER" {
+		return nil, ErrForbidden
+	}
+	if err := s.ensureMember(ctx, profileID, orgID); err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.ReplaceSkills(ctx, profileID, orgID, uniqueIDs(skillIDs)); err != nil {
+		return nil, err
+	}
+	return s.GetAttributes(ctx, profileID, orgID)
+}
+
+// SetLanguages replaces the spoken languages of a profile. Members can set
+// their own, owners and managers anyone's.
+func (s *SkillsService) SetLanguages(ctx context.Context, profileID uuid.UUID, requesterID string, orgID int64, role string, codes []string) (*Attributes, error) {
+	if role != "OWNER" && role != "MANAGER" && requesterID != profileID.String() {
+		return nil, ErrForbidden
+	}
+
+	normalized, err := NormalizeLanguages(codes)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.ensureMember(ctx, profileID, orgID); err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.ReplaceLanguages(ctx, profileID, normalized); err != nil {
+		return nil, err
+	}
+	return s.GetAttributes(ctx, profileID, orgID)
+}
+
+// SetTags replaces the tags of a profile. Requires OWNER or MANAGER role.
+func (s *SkillsService) SetTags(ctx context.Context, profileID uuid.UUID, orgID int64, role string, tags []string) (*Attributes, error) {
+	if role != "OWNER" && role != "MANAGER" {
+		return nil, ErrForbidden
+	}
+
+	normalized, err := NormalizeTags(tags)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.ensureMember(ctx, profileID, orgID); err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.ReplaceTags(ctx, profileID, orgID, normalized); err != nil {
+		return nil, err
+	}
+	return s.GetAttributes(ctx, profileID, orgID)
+}
+
+// ensureMember checks that the profile belongs to the requester's organization.
+func (s *SkillsService) ensureMember(ctx context.Context, profileID uuid.UUID, orgID int64) error {
+	ok, err := s.repo.ProfileInOrganization(ctx, profileID, orgID)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return ErrProfileNotFound
+	}
+	return nil
+}
+
+// NormalizeLanguages lowercases, validates and deduplicates ISO 639-1 codes.
+func NormalizeLanguages(codes []string) ([]string, error) {
+	seen := map[string]bool{}
+	out := []string{}
+	for _, code := range codes {
+		code = common.Languages.Normalize(code)
+		if !common.Languages.IsValid(code) {
+			return nil, fmt.Errorf("%w: %q is not an ISO 639-1 language code", ErrInvalidInput, code)
+		}
+		if !seen[code] {
+			seen[code] = true
+			out = append(out, code)
+		}
+	}
+	sort.Strings(out)
+	return out, nil
+}
+
+// NormalizeTags trims, lowercases and deduplicates free-form tags.
+func NormalizeTags(tags []string) ([]string, error) {
+	seen := map[string]bool{}
+	out := []string{}
+	for _, tag := range tags {
+		tag = strings.ToLower(strings.TrimSpace(tag))
+		if tag == "" {
+			continue
+		}
+		if len(tag) > MaxTagLength {
+			return nil, fmt.Errorf("%w: tags must not exceed %d characters", ErrInvalidInput, MaxTagLength)
+		}
+		if !seen[tag] {
+			seen[tag] = true
+			out = append(out, tag)
+		}
+	}
+	if len(out) > MaxTagsPerProfile {
+		return nil, fmt.Errorf("%w: a profile can have at most %d tags", ErrInvalidInput, MaxTagsPerProfile)
+	}
+	sort.Strings(out)
+	return out, nil
+}
+
+// uniqueIDs removes duplicate IDs while keeping the original order.
+func uniqueIDs(ids []int64) []int64 {
+	seen := map[int64]bool{}
+	out := []int64{}
+	for _, id := range ids {
+		if !seen[id] {
+			seen[id] = true
+			out = append(out, id)
+		}
+	}
+	return out
+}
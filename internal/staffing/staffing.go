@@ -0,0 +1,16 @@
+package staffing
+
+import "go.uber.org/fx"
+
+// ======== EXPORTS ========
+
+// Module exports services present
+var Context = fx.Options(
+	fx.Provide(GetStaffingController),
+	fx.Provide(fx.Annotate(
+		GetStaffingService,
+		fx.As(new(Service)),
+	)),
+	fx.Provide(GetStaffingRepository),
+	fx.Provide(SetStaffingRoutes),
+)
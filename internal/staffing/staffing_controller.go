@@ -0,0 +1,227 @@
+package staffing
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"hostflow/profile-service/internal/middlewares"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type StaffingController struct {
+	service Service
+}
+
+func GetStaffingController(service Service) *StaffingController {
+	return &StaffingController{
+		service: service,
+	}
+}
+
+// GetAvailableUsersHandler godoc
+// @Summary Find available staff
+// @Description Returns ACTIVE members of the requester's organization who are assigned to the property, have the skill and speak the language (each if given) and are free for the whole window, taking schedules and approved time off into account. Requires OWNER or MANAGER role.
+// @Tags users
+// @Produce json
+// @Security ApiKeyAuth
+// @Param start query string true "Window start (RFC3339)"
+// @Param end query string true "Window end (RFC3339)"
+// @Param property query int false "Property ID"
+// @Param skill query int false "Skill ID"
+// @Param language query string false "ISO 639-1 language code"
+// @Success 200 {array} profile.User
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /users/available [get]
+func (c *StaffingController) GetAvailableUsersHandler(ctx *gin.Context) {
+	principal := middlewares.GetPrincipal(ctx)
+
+	start, errStart := time.Parse(time.RFC3339, ctx.Query("start"))
+	end, errEnd := time.Parse(time.RFC3339, ctx.Query("end"))
+	if errStart != nil || errEnd != nil {
+		ctx.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "Invalid window",
+			Message: "'start' and 'end' must be RFC3339 timestamps",
+		})
+		return
+	}
+
+	query := AvailableQuery{
+		Start:    start,
+		End:      end,
+		Language: ctx.Query("language"),
+	}
+
+	if value := ctx.Query("property"); value != "" {
+		propertyID, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			ctx.JSON(http.StatusBadRequest, ErrorResponse{
+				Error:   "Invalid property",
+				Message: "'property' must be a numeric property ID",
+			})
+			return
+		}
+		query.PropertyID = &propertyID
+	}
+
+	if value := ctx.Query("skill"); value != "" {
+		skillID, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			ctx.JSON(http.StatusBadRequest, ErrorResponse{
+				Error:   "Invalid skill",
+				Message: "'skill' must be a numeric skill ID",
+			})
+			return
+		}
+		query.SkillID = &skillID
+	}
+
+	users, err := c.service.FindAvailable(ctx.Request.Context(), principal.OrganizationID, principal.Role, query)
+	if err != nil {
+		respondError(ctx, "Failed to find available staff", err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, users)
+}
+
+// ListPropertiesHandler godoc
+// @Summary List a user's properties
+// @Description Returns the properties a user is assigned to.
+// @Tags users
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "User ID (UUID)"
+// @Success 200 {array} PropertyAssignment
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /users/{id}/properties [get]
+func (c *StaffingController) ListPropertiesHandler(ctx *gin.Context) {
+	principal := middlewares.GetPrincipal(ctx)
+
+	profileID, ok := parseProfileID(ctx)
+	if !ok {
+		return
+	}
+
+	assignments, err := c.service.ListProperties(ctx.Request.Context(), profileID, principal.OrganizationID)
+	if err != nil {
+		respondError(ctx, "Failed to fetch properties", err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, assignments)
+}
+
+// AssignPropertyHandler godoc
+// @Summary Assign a user to a property
+// @Description Assigns a user to a property they work at. Requires OWNER role.
+// @Tags users
+// @Security ApiKeyAuth
+// @Param id path string true "User ID (UUID)"
+// @Param propertyId path int true "Property ID"
+// @Success 204 "No Content"
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /users/{id}/properties/{propertyId} [put]
+func (c *StaffingController) AssignPropertyHandler(ctx *gin.Context) {
+	principal := middlewares.GetPrincipal(ctx)
+
+	profileID, propertyID, ok := parseAssignment(ctx)
+	if !ok {
+		return
+	}
+
+	err := c.service.AssignProperty(ctx.Request.Context(), profileID, propertyID, principal.OrganizationID, principal.Role)
+	if err != nil {
+		respondError(ctx, "Failed to assign property", err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+// UnassignPropertyHandler godoc
+// @Summary Remove a user from a property
+// @Description Removes a user's property assignment. Requires OWNER role.
+// @Tags users
+// @Security ApiKeyAuth
+// @Param id path string true "User ID (UUID)"
+// @Param propertyId path int true "Property ID"
+// @Success 204 "No Content"
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /users/{id}/properties/{propertyId} [delete]
+func (c *StaffingController) UnassignPropertyHandler(ctx *gin.Context) {
+	principal := middlewares.GetPrincipal(ctx)
+
+	profileID, propertyID, ok := parseAssignment(ctx)
+	if !ok {
+		return
+	}
+
+	err := c.service.UnassignProperty(ctx.Request.Context(), profileID, propertyID, principal.OrganizationID, principal.Role)
+	if err != nil {
+		respondError(ctx, "Failed to remove property assignment", err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+// parseProfileID reads the :id path parameter and aborts with 400 when it
+// is not a UUID.
+func parseProfileID(ctx *gin.Context) (uuid.UUID, bool) {
+	id, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "Invalid ID format",
+			Message: "ID must be a valid UUID",
+		})
+		return uuid.Nil, false
+	}
+	return id, true
+}
+
+// parseAssignment reads the :id and :propertyId path parameters.
+func parseAssignment(ctx *gin.Context) (uuid.UUID, int64, bool) {
+	profileID, ok := parseProfileID(ctx)
+	if !ok {
+		return uuid.Nil, 0, false
+	}
+
+	propertyID, err := strconv.ParseInt(ctx.Param("propertyId"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "Invalid property",
+			Message: "Property ID must be numeric",
+		})
+		return uuid.Nil, 0, false
+	}
+	return profileID, propertyID, true
+}
+
+// respondError maps service errors to HTTP status codes.
+func respondError(ctx *gin.Context, title string, err error) {
+	status := http.StatusInternalServerError
+	switch {
+	case errors.Is(err, ErrInvalidInput):
+		status = http.StatusBadRequest
+	case errors.Is(err, ErrForbidden):
+		status = http.StatusForbidden
+	case errors.Is(err, ErrProfileNotFound), errors.Is(err, ErrAssignmentNotFound):
+		status = http.StatusNotFound
+	}
+
+	ctx.JSON(status, ErrorResponse{
+		Error:   title,
+		Message: err.Error(),
+	})
+}
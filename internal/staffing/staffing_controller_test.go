@@ -0,0 +1,105 @@
+package staffing
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"hostflow/profile-service/internal/middlewares"
+	"hostflow/profile-service/internal/profile"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockStaffingService struct {
+	mock.Mock
+}
+
+func (m *MockStaffingService) ListProperties(ctx context.Context, profileID uuid.UUID, orgID int64) ([]PropertyAssignment, error) {
+	args := m.Called(ctx, profileID, orgID)
+	return args.Get(0).([]PropertyAssignment), args.Error(1)
+}
+
+func (m *MockStaffingService) AssignProperty(ctx context.Context, profileID uuid.UUID, propertyID int64, orgID int64, role string) error {
+	return m.Called(ctx, profileID, propertyID, orgID, role).Error(0)
+}
+
+func (m *MockStaffingService) UnassignProperty(ctx context.Context, profileID uuid.UUID, propertyID int64, orgID int64, role string) error {
+	return m.Called(ctx, profileID, propertyID, orgID, role).Error(0)
+}
+
+func (m *MockStaffingService) FindAvailable(ctx context.Context, orgID int64, role string, q AvailableQuery) ([]profile.User, error) {
+	args := m.Called(ctx, orgID, role, q)
+	return args.Get(0).([]profile.User), args.Error(1)
+}
+
+func TestGetAvailableUsersHandler_PassesQuery(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	mockSvc := new(MockStaffingService)
+	controller := GetStaffingController(mockSvc)
+
+	r := gin.New()
+	r.GET("/users/available", func(c *gin.Context) {
+		middlewares.SetPrincipal(c, middlewares.Principal{OrganizationID: 1, Role: "MANAGER"})
+		controller.GetAvailableUsersHandler(c)
+	})
+
+	propertyID := int64(42)
+	skillID := int64(3)
+	expected := AvailableQuery{
+		Start:      time.Date(2026, 5, 4, 9, 0, 0, 0, time.UTC),
+		End:        time.Date(2026, 5, 4, 12, 0, 0, 0, time.UTC),
+		PropertyID: &propertyID,
+		SkillID:    &skillID,
+		Language:   "sl",
+	}
+	mockSvc.On("FindAvailable", mock.Anything, int64(1), "MANAGER", expected).
+		Return([]profile.User{{Name: "Maja"}}, nil)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/users/available?start=2026-05-04T09:00:00Z&end=2026-05-04T12:00:00Z&property=42&skill=3&language=sl", nil)
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), "Maja")
+}
+
+func TestGetAvailableUsersHandler_InvalidProperty(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	controller := GetStaffingController(new(MockStaffingService))
+
+	r := gin.New()
+	r.GET("/users/available", controller.GetAvailableUsersHandler)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/users/available?start=2026-05-04T09:00:00Z&end=2026-05-04T12:00:00Z&property=villa", nil)
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestAssignPropertyHandler_ForbiddenForMember(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	mockSvc := new(MockStaffingService)
+	controller := GetStaffingController(mockSvc)
+	profileID := uuid.New()
+
+	r := gin.New()
+	r.PUT("/users/:id/properties/:propertyId", func(c *gin.Context) {
+		middlewares.SetPrincipal(c, middlewares.Principal{OrganizationID: 1, Role: "MEMBER"})
+		controller.AssignPropertyHandler(c)
+	})
+
+	mockSvc.On("AssignProperty", mock.Anything, profileID, int64(7), int64(1), "MEMBER").Return(ErrForbidden)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("PUT", "/users/"+profileID.String()+"/properties/7", nil)
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusForbidden, w.Code)
+}
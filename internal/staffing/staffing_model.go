@@ -0,0 +1,29 @@
+package staffing
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// PropertyAssignment links a staff member to a property they work at.
+type PropertyAssignment struct {
+	ProfileID  uuid.UUID `json:"profile_id" db:"profile_id"`
+	PropertyID int64     `json:"property_id" db:"property_id"`
+	CreatedAt  time.Time `json:"created_at" db:"created_at"`
+}
+
+// AvailableQuery describes the staff a caller is looking for.
+type AvailableQuery struct {
+	Start      time.Time
+	End        time.Time
+	PropertyID *int64
+	SkillID    *int64
+	Language   string
+}
+
+// ErrorResponse represents an error response
+type ErrorResponse struct {
+	Error   string `json:"error" example:"Invalid request"`
+	Message string `json:"message,omitempty" example:"The provided data is invalid"`
+}
@@ -0,0 +1,105 @@
+package staffing
+
+import (
+	"context"
+
+	"hostflow/profile-service/internal/profile"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type StaffingRepository struct {
+	db *pgxpool.Pool
+}
+
+func GetStaffingRepository(db *pgxpool.Pool) *StaffingRepository {
+	return &StaffingRepository{
+		db: db,
+	}
+}
+
+// ProfileInOrganization reports whether the profile belongs to the organization.
+func (r *StaffingRepository) ProfileInOrganization(ctx context.Context, profileID uuid.UUID, orgID int64) (bool, error) {
+	var exists bool
+	query := `SELECT EXISTS (SELECT 1 FROM "profiles" WHERE id = $1 AND organization_id = $2)`
+
+	if err := r.db.QueryRow(ctx, query, profileID, orgID).Scan(&exists); err != nil {
+		return false, err
+	}
+	return exists, nil
+}
+
+// ListAssignments returns the properties a profile is assigned to.
+func (r *StaffingRepository) ListAssignments(ctx context.Context, profileID uuid.UUID, orgID int64) ([]PropertyAssignment, error) {
+	query := `
+        SELECT profile_id, property_id, created_at
+        FROM property_assignments
+        WHERE profile_id = $1 AND organization_id = $2
+        ORDER BY property_id
+    `
+
+	rows, err := r.db.Query(ctx, query, profileID, orgID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return pgx.CollectRows(rows, pgx.RowToStructByName[PropertyAssignment])
+}
+
+// Assign links a profile to a property. Assigning twice is a no-op.
+func (r *StaffingRepository) Assign(ctx context.Context, orgID int64, profileID uuid.UUID, propertyID int64) error {
+	query := `
+        INSERT INTO property_assignments (organization_id, profile_id, property_id)
+        VALUES ($1, $2, $3)
+        ON CONFLICT (profile_id, property_id) DO NOTHING
+    `
+
+	_, err := r.db.Exec(ctx, query, orgID, profileID, propertyID)
+	return err
+}
+
+// Unassign removes a property assignment and reports whether one existed.
+func (r *StaffingRepository) Unassign(ctx context.Context, orgID int64, profileID uuid.UUID, propertyID int64) (bool, error) {
+	query := `DELETE FROM property_assignments WHERE organization_id = $1 AND profile_id = $2 AND property_id = $3`
+
+	result, err := r.db.Exec(ctx, query, orgID, profileID, propertyID)
+	if err != nil {
+		return false, err
+	}
+	return result.RowsAffected() > 0, nil
+}
+
+// FindCandidates returns the ACTIVE members of an organization matching the
+// static parts of a query. Availability is checked by the service.
+func (r *StaffingRepository) FindCandidates(ctx context.Context, orgID int64, q AvailableQuery) ([]profile.User, error) {
+	query := `
+        SELECT p.id, p.organization_id, p.full_name, p.role, p.email, p.status, p.created_at, p.updated_at
+        FROM "profiles" p
+        WHERE p.organization_id = $1
+          AND p.status = 'ACTIVE'
+          AND ($2::bigint IS NULL OR EXISTS (
+                SELECT 1 FROM property_assignments pa
+                WHERE pa.profile_id = p.id AND pa.property_id = $2
+          ))
+          AND ($3::bigint IS NULL OR EXISTS (
+                SELECT 1 FROM profile_skills ps
+                WHERE ps.profile_id = p.id AND ps.skill_id = $3
+          ))
+          AND ($4::text = '' OR EXISTS (
+                SELECT 1 FROM profile_languages pl
+                WHERE pl.profile_id = p.id AND pl.language_code = $4
+          ))
+        ORDER BY p.full_name
+    `
+
+	rows, err := r.db.Query(ctx, query, orgID, q.PropertyID, q.SkillID, q.Language)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return pgx.CollectRows(rows, pgx.RowToStructByName[profile.User])
+}
@@ -0,0 +1,42 @@
+package staffing
+
+import (
+	"hostflow/profile-service/internal/middlewares"
+	"hostflow/profile-service/pkg/lib"
+)
+
+type StaffingRoutes struct {
+	logger             lib.Logger
+	router             *lib.Router
+	staffingController *StaffingController
+	authMiddleware     middlewares.AuthMiddleware
+}
+
+func SetStaffingRoutes(
+	logger lib.Logger,
+	router *lib.Router,
+	staffingController *StaffingController,
+	authMiddleware middlewares.AuthMiddleware,
+) StaffingRoutes {
+	return StaffingRoutes{
+		logger:             logger,
+		router:             router,
+		staffingController: staffingController,
+		authMiddleware:     authMiddleware,
+	}
+}
+
+func (route StaffingRoutes) Setup() {
+	route.logger.Info("Setting up [STAFFING] routes.")
+
+	users := route.router.Group("/users")
+	users.Use(route.authMiddleware.HandlerWithAPIKeys("staffing"))
+	{
+		users.GET("/available", route.staffingController.GetAvailableUsersHandler)
+		users.GET("/:id/properties", route.staffingController.ListPropertiesHandler)
+		users.PUT("/:id/properties/:propertyId", route.staffingController.AssignPropertyHandler)
+		users.DELETE("/:id/properties/:propertyId", route.staffingController.UnassignPropertyHandler)
+	}
+
+	route.logger.Info("[STAFFING] routes setup complete.")
+}
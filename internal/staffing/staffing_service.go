@@ -0,0 +1,138 @@
+package staffing
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"hostflow/profile-service/internal/availability"
+	"hostflow/profile-service/internal/profile"
+	"hostflow/profile-service/pkg/common"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrProfileNotFound    = errors.New("profile not found in your organization")
+	ErrAssignmentNotFound = errors.New("profile is not assigned to this property")
+	ErrForbidden          = errors.New("you are not allowed to perform this action")
+	ErrInvalidInput       = errors.New("invalid input")
+)
+
+type StaffingService struct {
+	repo         *StaffingRepository
+	availability availability.Service
+}
+
+type Service interface {
+	ListProperties(ctx context.Context, profileID uuid.UUID, orgID int64) ([]PropertyAssignment, error)
+	AssignProperty(ctx context.Context, profileID uuid.UUID, propertyID int64, orgID int64, role string) error
+	UnassignProperty(ctx context.Context, profileID uuid.UUID, propertyID int64, orgID int64, role string) error
+	FindAvailable(ctx context.Context, orgID int64, role string, q AvailableQuery) ([]profile.User, error)
+}
+
+func GetStaffingService(repo *StaffingRepository, availability availability.Service) *StaffingService {
+	return &StaffingService{
+		repo:         repo,
+		availability: availability,
+	}
+}
+
+// ListProperties returns the property assignments of a profile.
+func (s *StaffingService) ListProperties(ctx context.Context, profileID uuid.UUID, orgID int64) ([]PropertyAssignment, error) {
+	if err := s.ensureMember(ctx, profileID, orgID); err != nil {
+		return nil, err
+	}
+
+	assignments, err := s.repo.ListAssignments(ctx, profileID, orgID)
+	if err != nil {
+		return nil, err
+	}
+	if assignments == nil {
+		return []PropertyAssignment{}, nil
+	}
+	return assignments, nil
+}
+
+// AssignProperty assigns a profile to a property. Requires OWNER role.
+func (s *StaffingService) AssignProperty(ctx context.Context, profileID uuid.UUID, propertyID int64, orgID int64, role string) error {
+	if role != "OWNER" {
+		return ErrForbidden
+	}
+	if err := s.ensureMember(ctx, profileID, orgID); err != nil {
+		return err
+	}
+
+	return s.repo.Assign(ctx, orgID, profileID, propertyID)
+}
+
+// UnassignProperty removes a property assignment. Requires OWNER role.
+func (s *StaffingService) UnassignProperty(ctx context.Context, profileID uuid.UUID, propertyID int64, orgID int64, role string) error {
+	if role != "OWNER" {
+		return ErrForbidden
+	}
+
+	removed, err := s.repo.Unassign(ctx, orgID, profileID, propertyID)
+	if err != nil {
+		return err
+	}
+	if !removed {
+		return ErrAssignmentNotFound
+	}
+	return nil
+}
+
+// FindAvailable returns the ACTIVE members of the organization that match
+// the query and are free for the whole requested window.
+func (s *StaffingService) FindAvailable(ctx context.Context, orgID int64, role string, q AvailableQuery) ([]profile.User, error) {
+	if role != "OWNER" && role != "MANAGER" {
+		return nil, ErrForbidden
+	}
+	if !q.End.After(q.Start) {
+		return nil, fmt.Errorf("%w: 'end' must be after 'start'", ErrInvalidInput)
+	}
+	if q.End.Sub(q.Start) > availability.MaxExpansionRange {
+		return nil, fmt.Errorf("%w: window must not exceed %d days", ErrInvalidInput, int(availability.MaxExpansionRange.Hours()/24))
+	}
+	if q.Language != "" {
+		q.Language = common.Languages.Normalize(q.Language)
+		if !common.Languages.IsValid(q.Language) {
+			return nil, fmt.Errorf("%w: %q is not an ISO 639-1 language code", ErrInvalidInput, q.Language)
+		}
+	}
+
+	candidates, err := s.repo.FindCandidates(ctx, orgID, q)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]uuid.UUID, len(candidates))
+	for i, candidate := range candidates {
+		ids[i] = candidate.ID
+	}
+	free, err := s.availability.FindFree(ctx, ids, q.Start, q.End)
+	if err != nil {
+		return nil, err
+	}
+
+	available := []profile.User{}
+	for _, candidate := range candidates {
+		if free[candidate.ID] {
+			available = append(available, candidate)
+		}
+	}
+
+	return available, nil
+}
+
+// ensureMember checks that the profile belongs to the requester's organization.
+func (s *StaffingService) ensureMember(ctx context.Context, profileID uuid.UUID, orgID int64) error {
+	ok, err := s.repo.ProfileInOrganization(ctx, profileID, orgID)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return ErrProfileNotFound
+	}
+	return nil
+}
@@ -0,0 +1,16 @@
+package timeoff
+
+import "go.uber.org/fx"
+
+// ======== EXPORTS ========
+
+// Module exports services present
+var Context = fx.Options(
+	fx.Provide(GetTimeOffController),
+	fx.Provide(fx.Annotate(
+		GetTimeOffService,
+		fx.As(new(Service)),
+	)),
+	fx.Provide(GetTimeOffRepository),
+	fx.Provide(SetTimeOffRoutes),
+)
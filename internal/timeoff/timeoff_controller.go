@@ -0,0 +1,269 @@
+package timeoff
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"hostflow/profile-service/internal/middlewares"
+	"hostflow/profile-service/pkg/common"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type TimeOffController struct {
+	service Service
+}
+
+func GetTimeOffController(service Service) *TimeOffController {
+	return &TimeOffController{
+		service: service,
+	}
+}
+
+// CreateHandler godoc
+// @Summary Request time off
+// @Description Submits a time-off request for a user. Members request for themselves, OWNERs may request on behalf of others.
+// @Tags time-off
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "User ID (UUID)"
+// @Param body body CreateTimeOffRequest true "Time-off request"
+// @Success 201 {object} TimeOffRequest
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /users/{id}/time-off [post]
+func (c *TimeOffController) CreateHandler(ctx *gin.Context) {
+	principal := middlewares.GetPrincipal(ctx)
+
+	profileID, ok := parseID(ctx)
+	if !ok {
+		return
+	}
+
+	var body CreateTimeOffRequest
+	if errors := common.Validation.ValidateBody(ctx, &body); errors != nil {
+		ctx.JSON(http.StatusBadRequest, errors)
+		return
+	}
+
+	request, err := c.service.Create(
+		ctx.Request.Context(),
+		profileID,
+		principal.UserID,
+		principal.OrganizationID,
+		principal.Role,
+		body,
+	)
+	if err != nil {
+		respondError(ctx, "Failed to create time-off request", err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, request)
+}
+
+// ListForUserHandler godoc
+// @Summary List a user's time-off requests
+// @Description Lists time-off requests of a user, optionally filtered by status. Members see their own and their reports', OWNERs and MANAGERs see everyone's.
+// @Tags time-off
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "User ID (UUID)"
+// @Param status query string false "PENDING, APPROVED, REJECTED or CANCELLED"
+// @Success 200 {array} TimeOffRequest
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /users/{id}/time-off [get]
+func (c *TimeOffController) ListForUserHandler(ctx *gin.Context) {
+	principal := middlewares.GetPrincipal(ctx)
+
+	profileID, ok := parseID(ctx)
+	if !ok {
+		return
+	}
+
+	requests, err := c.service.ListForUser(
+		ctx.Request.Context(),
+		profileID,
+		principal.UserID,
+		principal.OrganizationID,
+		principal.Role,
+		ctx.Query("status"),
+	)
+	if err != nil {
+		respondError(ctx, "Failed to fetch time-off requests", err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, requests)
+}
+
+// ListForOrganizationHandler godoc
+// @Summary List organization time-off requests
+// @Description Lists all time-off requests in the requester's organization. Requires OWNER or MANAGER role.
+// @Tags time-off
+// @Produce json
+// @Security ApiKeyAuth
+// @Param status query string false "PENDING, APPROVED, REJECTED or CANCELLED"
+// @Success 200 {array} TimeOffRequest
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Router /organization/time-off [get]
+func (c *TimeOffController) ListForOrganizationHandler(ctx *gin.Context) {
+	principal := middlewares.GetPrincipal(ctx)
+
+	requests, err := c.service.ListForOrganization(
+		ctx.Request.Context(),
+		principal.OrganizationID,
+		principal.Role,
+		ctx.Query("status"),
+	)
+	if err != nil {
+		respondError(ctx, "Failed to fetch time-off requests", err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, requests)
+}
+
+// ApproveHandler godoc
+// @Summary Approve a time-off request
+// @Description Approves a pending time-off request. Requires OWNER or MANAGER role, or being above the requester in the reporting lines; nobody can approve their own request.
+// @Tags time-off
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "Request ID (UUID)"
+// @Param body body DecisionRequest false "Decision note"
+// @Success 200 {object} TimeOffRequest
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Router /time-off/{id}/approve [post]
+func (c *TimeOffController) ApproveHandler(ctx *gin.Context) {
+	c.decide(ctx, c.service.Approve, "Failed to approve time-off request")
+}
+
+// RejectHandler godoc
+// @Summary Reject a time-off request
+// @Description Rejects a pending time-off request. Requires OWNER or MANAGER role, or being above the requester in the reporting lines.
+// @Tags time-off
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "Request ID (UUID)"
+// @Param body body DecisionRequest false "Decision note"
+// @Success 200 {object} TimeOffRequest
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Router /time-off/{id}/reject [post]
+func (c *TimeOffController) RejectHandler(ctx *gin.Context) {
+	c.decide(ctx, c.service.Reject, "Failed to reject time-off request")
+}
+
+// CancelHandler godoc
+// @Summary Cancel a time-off request
+// @Description Withdraws the requester's own pending request, or an approved one that has not started yet.
+// @Tags time-off
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "Request ID (UUID)"
+// @Success 200 {object} TimeOffRequest
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Router /time-off/{id}/cancel [post]
+func (c *TimeOffController) CancelHandler(ctx *gin.Context) {
+	principal := middlewares.GetPrincipal(ctx)
+
+	requestID, ok := parseID(ctx)
+	if !ok {
+		return
+	}
+
+	request, err := c.service.Cancel(ctx.Request.Context(), requestID, principal.UserID, principal.OrganizationID)
+	if err != nil {
+		respondError(ctx, "Failed to cancel time-off request", err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, request)
+}
+
+// decide runs an approve/reject service call for the :id request.
+func (c *TimeOffController) decide(
+	ctx *gin.Context,
+	action func(ctx context.Context, requestID uuid.UUID, approverID string, orgID int64, role string, note string) (*TimeOffRequest, error),
+	title string,
+) {
+	principal := middlewares.GetPrincipal(ctx)
+
+	requestID, ok := parseID(ctx)
+	if !ok {
+		return
+	}
+
+	// The note is optional, so an empty body is fine
+	var body DecisionRequest
+	if ctx.Request.ContentLength > 0 {
+		if errors := common.Validation.ValidateBody(ctx, &body); errors != nil {
+			ctx.JSON(http.StatusBadRequest, errors)
+			return
+		}
+	}
+
+	request, err := action(
+		ctx.Request.Context(),
+		requestID,
+		principal.UserID,
+		principal.OrganizationID,
+		principal.Role,
+		body.Note,
+	)
+	if err != nil {
+		respondError(ctx, title, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, request)
+}
+
+// parseID reads the :id path parameter and aborts with 400 when it is not
+// a UUID.
+func parseID(ctx *gin.Context) (uuid.UUID, bool) {
+	id, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "Invalid ID format",
+			Message: "ID must be a valid UUID",
+		})
+		return uuid.Nil, false
+	}
+	return id, true
+}
+
+// respondError maps service errors to HTTP status codes.
+func respondError(ctx *gin.Context, title string, err error) {
+	status := http.StatusInternalServerError
+	switch {
+	case errors.Is(err, ErrInvalidInput):
+		status = http.StatusBadRequest
+	case errors.Is(err, ErrForbidden):
+		status = http.StatusForbidden
+	case errors.Is(err, ErrProfileNotFound), errors.Is(err, ErrRequestNotFound):
+		status = http.StatusNotFound
+	case errors.Is(err, ErrInvalidState):
+		status = http.StatusConflict
+	}
+
+	ctx.JSON(status, ErrorResponse{
+		Error:   title,
+		Message: err.Error(),
+	})
+}
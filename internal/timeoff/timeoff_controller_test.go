@@ -0,0 +1,123 @@
+package timeoff
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"hostflow/profile-service/internal/middlewares"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockTimeOffService struct {
+	mock.Mock
+}
+
+func (m *MockTimeOffService) Create(ctx context.Context, profileID uuid.UUID, requesterID string, orgID int64, role string, req CreateTimeOffRequest) (*TimeOffRequest, error) {
+	args := m.Called(ctx, profileID, requesterID, orgID, role, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*TimeOffRequest), args.Error(1)
+}
+
+func (m *MockTimeOffService) ListForUser(ctx context.Context, profileID uuid.UUID, requesterID string, orgID int64, role string, status string) ([]TimeOffRequest, error) {
+	args := m.Called(ctx, profileID, requesterID, orgID, role, status)
+	return args.Get(0).([]TimeOffRequest), args.Error(1)
+}
+
+func (m *MockTimeOffService) ListForOrganization(ctx context.Context, orgID int64, role string, status string) ([]TimeOffRequest, error) {
+	args := m.Called(ctx, orgID, role, status)
+	return args.Get(0).([]TimeOffRequest), args.Error(1)
+}
+
+func (m *MockTimeOffService) Approve(ctx context.Context, requestID uuid.UUID, approverID string, orgID int64, role string, note string) (*TimeOffRequest, error) {
+	args := m.Called(ctx, requestID, approverID, orgID, role, note)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*TimeOffRequest), args.Error(1)
+}
+
+func (m *MockTimeOffService) Reject(ctx context.Context, requestID uuid.UUID, approverID string, orgID int64, role string, note string) (*TimeOffRequest, error) {
+	args := m.Called(ctx, requestID, approverID, orgID, role, note)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*TimeOffRequest), args.Error(1)
+}
+
+func (m *MockTimeOffService) Cancel(ctx context.Context, requestID uuid.UUID, requesterID string, orgID int64) (*TimeOffRequest, error) {
+	args := m.Called(ctx, requestID, requesterID, orgID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*TimeOffRequest), args.Error(1)
+}
+
+func TestListForOrganizationHandler_FiltersByStatus(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	mockSvc := new(MockTimeOffService)
+	controller := GetTimeOffController(mockSvc)
+
+	r := gin.New()
+	r.GET("/organization/time-off", func(c *gin.Context) {
+		middlewares.SetPrincipal(c, middlewares.Principal{OrganizationID: 1, Role: "MANAGER"})
+		controller.ListForOrganizationHandler(c)
+	})
+
+	mockSvc.On("ListForOrganization", mock.Anything, int64(1), "MANAGER", "PENDING").
+		Return([]TimeOffRequest{{Type: "VACATION", Status: StatusPending}}, nil)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/organization/time-off?status=PENDING", nil)
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), "VACATION")
+}
+
+func TestCreateHandler_InvalidType(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	controller := GetTimeOffController(new(MockTimeOffService))
+
+	r := gin.New()
+	r.POST("/users/:id/time-off", controller.CreateHandler)
+
+	body := `{"type": "HOLIDAY", "starts_at": "2026-07-01T00:00:00Z", "ends_at": "2026-07-10T00:00:00Z"}`
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("POST", "/users/"+uuid.NewString()+"/time-off", bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), "Must be one of")
+}
+
+func TestApproveHandler_AlreadyDecided(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	mockSvc := new(MockTimeOffService)
+	controller := GetTimeOffController(mockSvc)
+	requestID := uuid.New()
+
+	r := gin.New()
+	r.POST("/time-off/:id/approve", func(c *gin.Context) {
+		middlewares.SetPrincipal(c, middlewares.Principal{UserID: "approver", OrganizationID: 1, Role: "OWNER"})
+		controller.ApproveHandler(c)
+	})
+
+	mockSvc.On("Approve", mock.Anything, requestID, "approver", int64(1), "OWNER", "").
+		Return(nil, ErrInvalidState)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("POST", "/time-off/"+requestID.String()+"/approve", nil)
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusConflict, w.Code)
+}
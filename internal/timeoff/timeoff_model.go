@@ -0,0 +1,51 @@
+package timeoff
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Time-off request statuses
+const (
+	StatusPending   = "PENDING"
+	StatusApproved  = "APPROVED"
+	StatusRejected  = "REJECTED"
+	StatusCancelled = "CANCELLED"
+)
+
+// TimeOffRequest is a member's request to be away for a period of time.
+type TimeOffRequest struct {
+	ID             uuid.UUID  `json:"id" db:"id"`
+	OrganizationID int64      `json:"organization_id" db:"organization_id"`
+	ProfileID      uuid.UUID  `json:"profile_id" db:"profile_id"`
+	Type           string     `json:"type" db:"type" example:"VACATION"`
+	StartsAt       time.Time  `json:"starts_at" db:"starts_at"`
+	EndsAt         time.Time  `json:"ends_at" db:"ends_at"`
+	Note           string     `json:"note" db:"note"`
+	Status         string     `json:"status" db:"status" example:"PENDING"`
+	DecidedBy      *uuid.UUID `json:"decided_by,omitempty" db:"decided_by"`
+	DecidedAt      *time.Time `json:"decided_at,omitempty" db:"decided_at"`
+	DecisionNote   string     `json:"decision_note" db:"decision_note"`
+	CreatedAt      time.Time  `json:"created_at" db:"created_at"`
+	UpdatedAt      time.Time  `json:"updated_at" db:"updated_at"`
+}
+
+// CreateTimeOffRequest is the body for submitting a time-off request.
+type CreateTimeOffRequest struct {
+	Type     string    `json:"type" binding:"required,oneof=VACATION SICK PERSONAL OTHER" example:"VACATION"`
+	StartsAt time.Time `json:"starts_at" binding:"required"`
+	EndsAt   time.Time `json:"ends_at" binding:"required"`
+	Note     string    `json:"note" binding:"max=1000"`
+}
+
+// DecisionRequest is the body for approving or rejecting a request.
+type DecisionRequest struct {
+	Note string `json:"note" binding:"max=1000"`
+}
+
+// ErrorResponse represents an error response
+type ErrorResponse struct {
+	Error   string `json:"error" example:"Invalid request"`
+	Message string `json:"message,omitempty" example:"The provided data is invalid"`
+}
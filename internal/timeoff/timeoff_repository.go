@@ -0,0 +1,127 @@
+package timeoff
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+const timeOffColumns = `
+    id, organization_id, profile_id, type, starts_at, ends_at, note, status,
+    decided_by, decided_at, decision_note, created_at, updated_at
+`
+
+type TimeOffRepository struct {
+	db *pgxpool.Pool
+}
+
+func GetTimeOffRepository(db *pgxpool.Pool) *TimeOffRepository {
+	return &TimeOffRepository{
+		db: db,
+	}
+}
+
+// ProfileInOrganization reports whether the profile belongs to the organization.
+func (r *TimeOffRepository) ProfileInOrganization(ctx context.Context, profileID uuid.UUID, orgID int64) (bool, error) {
+	var exists bool
+	query := `SELECT EXISTS (SELECT 1 FROM "profiles" WHERE id = $1 AND organization_id = $2)`
+
+	if err := r.db.QueryRow(ctx, query, profileID, orgID).Scan(&exists); err != nil {
+		return false, err
+	}
+	return exists, nil
+}
+
+// Create inserts a new pending time-off request.
+func (r *TimeOffRepository) Create(ctx context.Context, orgID int64, profileID uuid.UUID, req CreateTimeOffRequest) (*TimeOffRequest, error) {
+	query := `
+        INSERT INTO time_off_requests (organization_id, profile_id, type, starts_at, ends_at, note)
+        VALUES ($1, $2, $3, $4, $5, $6)
+        RETURNING ` + timeOffColumns
+
+	rows, err := r.db.Query(ctx, query, orgID, profileID, req.Type, req.StartsAt, req.EndsAt, req.Note)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	created, err := pgx.CollectOneRow(rows, pgx.RowToStructByName[TimeOffRequest])
+	if err != nil {
+		return nil, err
+	}
+	return &created, nil
+}
+
+// GetByID returns a request within an organization, or nil if none exists.
+func (r *TimeOffRepository) GetByID(ctx context.Context, id uuid.UUID, orgID int64) (*TimeOffRequest, error) {
+	query := `SELECT ` + timeOffColumns + ` FROM time_off_requests WHERE id = $1 AND organization_id = $2`
+
+	rows, err := r.db.Query(ctx, query, id, orgID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	request, err := pgx.CollectOneRow(rows, pgx.RowToStructByName[TimeOffRequest])
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &request, nil
+}
+
+// List returns the requests of an organization, optionally narrowed to a
+// single profile and/or status. Empty filters match everything.
+func (r *TimeOffRepository) List(ctx context.Context, orgID int64, profileID *uuid.UUID, status string) ([]TimeOffRequest, error) {
+	query := `
+        SELECT ` + timeOffColumns + `
+        FROM time_off_requests
+        WHERE organization_id = $1
+          AND ($2::uuid IS NULL OR profile_id = $2)
+          AND ($3 = '' OR status = $3)
+        ORDER BY starts_at DESC
+    `
+
+	rows, err := r.db.Query(ctx, query, orgID, profileID, status)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return pgx.CollectRows(rows, pgx.RowToStructByName[TimeOffRequest])
+}
+
+// UpdateStatus moves a request from one of the expected statuses to a new
+// one and records who made the decision. It returns nil when the request
+// was not in an expected status anymore.
+func (r *TimeOffRepository) UpdateStatus(ctx context.Context, id uuid.UUID, orgID int64, from []string, to string, decidedBy *uuid.UUID, note string) (*TimeOffRequest, error) {
+	query := `
+        UPDATE time_off_requests
+        SET status = $3,
+            decided_by = COALESCE($4, decided_by),
+            decided_at = CASE WHEN $4::uuid IS NULL THEN decided_at ELSE now() END,
+            decision_note = CASE WHEN $4::uuid IS NULL THEN decision_note ELSE $5 END,
+            updated_at = now()
+        WHERE id = $1 AND organization_id = $2 AND status = ANY($6)
+        RETURNING ` + timeOffColumns
+
+	rows, err := r.db.Query(ctx, query, id, orgID, to, decidedBy, note, from)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	updated, err := pgx.CollectOneRow(rows, pgx.RowToStructByName[TimeOffRequest])
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &updated, nil
+}
@@ -0,0 +1,54 @@
+package timeoff
+
+import (
+	"hostflow/profile-service/internal/middlewares"
+	"hostflow/profile-service/pkg/lib"
+)
+
+type TimeOffRoutes struct {
+	logger            lib.Logger
+	router            *lib.Router
+	timeOffController *TimeOffController
+	authMiddleware    middlewares.AuthMiddleware
+}
+
+func SetTimeOffRoutes(
+	logger lib.Logger,
+	router *lib.Router,
+	timeOffController *TimeOffController,
+	authMiddleware middlewares.AuthMiddleware,
+) TimeOffRoutes {
+	return TimeOffRoutes{
+		logger:            logger,
+		router:            router,
+		timeOffController: timeOffController,
+		authMiddleware:    authMiddleware,
+	}
+}
+
+func (route TimeOffRoutes) Setup() {
+	route.logger.Info("Setting up [TIME-OFF] routes.")
+
+	users := route.router.Group("/users/:id/time-off")
+	users.Use(route.authMiddleware.HandlerWithAPIKeys("timeoff"))
+	{
+		users.GET("", route.timeOffController.ListForUserHandler)
+		users.POST("", route.timeOffController.CreateHandler)
+	}
+
+	organizations := route.router.Group("/organization/time-off")
+	organizations.Use(route.authMiddleware.HandlerWithAPIKeys("timeoff"))
+	{
+		organizations.GET("", route.timeOffController.ListForOrganizationHandler)
+	}
+
+	requests := route.router.Group("/time-off/:id")
+	requests.Use(route.authMiddleware.Handler())
+	{
+		requests.POST("/approve", route.timeOffController.ApproveHandler)
+		requests.POST("/reject", route.timeOffController.RejectHandler)
+		requests.POST("/cancel", route.timeOffController.CancelHandler)
+	}
+
+	route.logger.Info("[TIME-OFF] routes setup complete.")
+}
@@ -0,0 +1,228 @@
+package timeoff
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"hostflow/profile-service/internal/orgchart"
+
+	"github.com/google/uuid"
+)
+
+// MaxRequestDuration limits the length of a single time-off request.
+const MaxRequestDuration = 366 * 24 * time.Hour
+
+var (
+	ErrProfileNotFound = errors.New("profile not found in your organization")
+	ErrRequestNotFound = errors.New("time-off request not found")
+	ErrForbidden       = errors.New("you are not allowed to perform this action")
+	ErrInvalidState    = errors.New("time-off request can no longer be changed")
+	ErrInvalidInput    = errors.New("invalid input")
+)
+
+type TimeOffService struct {
+	repo     *TimeOffRepository
+	orgChart orgchart.Service
+}
+
+type Service interface {
+	Create(ctx context.Context, profileID uuid.UUID, requesterID string, orgID int64, role string, req CreateTimeOffRequest) (*TimeOffRequest, error)
+	ListForUser(ctx context.Context, profileID uuid.UUID, requesterID string, orgID int64, role string, status string) ([]TimeOffRequest, error)
+	ListForOrganization(ctx context.Context, orgID int64, role string, status string) ([]TimeOffRequest, error)
+	Approve(ctx context.Context, requestID uuid.UUID, approverID string, orgID int64, role string, note string) (*TimeOffRequest, error)
+	Reject(ctx context.Context, requestID uuid.UUID, approverID string, orgID int64, role string, note string) (*TimeOffRequest, error)
+	Cancel(ctx context.Context, requestID uuid.UUID, requesterID string, orgID int64) (*TimeOffRequest, error)
+}
+
+func GetTimeOffService(repo *TimeOffRepository, orgChart orgchart.Service) *TimeOffService {
+	return &TimeOffService{
+		repo:     repo,
+		orgChart: orgChart,
+	}
+}
+
+// Create submits a time-off request. Members submit for themselves, owners
+// may also submit on behalf of anyone in the organization.
+func (s *TimeOffService) Create(ctx context.Context, profileID uuid.UUID, requesterID string, orgID int64, role string, req CreateTimeOffRequest) (*TimeOffRequest, error) {
+	if role != "OWNER" && requesterID != profileID.String() {
+		return nil, ErrForbidden
+	}
+	if !req.EndsAt.After(req.StartsAt) {
+		return nil, fmt.Errorf("%w: 'ends_at' must be after 'starts_at'", ErrInvalidInput)
+	}
+	if req.EndsAt.Sub(req.StartsAt) > MaxRequestDuration {
+		return nil, fmt.Errorf("%w: a request must not exceed %d days", ErrInvalidInput, int(MaxRequestDuration.Hours()/24))
+	}
+
+	if err := s.ensureMember(ctx, profileID, orgID); err != nil {
+		return nil, err
+	}
+
+	return s.repo.Create(ctx, orgID, profileID, req)
+}
+
+// ListForUser lists the requests of a single profile. Members can see their
+// own requests and those of their reports, owners and managers can see
+// everyone's.
+func (s *TimeOffService) ListForUser(ctx context.Context, profileID uuid.UUID, requesterID string, orgID int64, role string, status string) ([]TimeOffRequest, error) {
+	if !canDecide(role) && requesterID != profileID.String() {
+		allowed, err := s.managesProfile(ctx, requesterID, profileID, orgID)
+		if err != nil {
+			return nil, err
+		}
+		if !allowed {
+			return nil, ErrForbidden
+		}
+	}
+	if err := validateStatusFilter(status); err != nil {
+		return nil, err
+	}
+	if err := s.ensureMember(ctx, profileID, orgID); err != nil {
+		return nil, err
+	}
+
+	return s.list(ctx, orgID, &profileID, status)
+}
+
+// ListForOrganization lists all requests within the organization.
+func (s *TimeOffService) ListForOrganization(ctx context.Context, orgID int64, role string, status string) ([]TimeOffRequest, error) {
+	if !canDecide(role) {
+		return nil, ErrForbidden
+	}
+	if err := validateStatusFilter(status); err != nil {
+		return nil, err
+	}
+
+	return s.list(ctx, orgID, nil, status)
+}
+
+// Approve approves a pending request.
+func (s *TimeOffService) Approve(ctx context.Context, requestID uuid.UUID, approverID string, orgID int64, role string, note string) (*TimeOffRequest, error) {
+	return s.decide(ctx, requestID, approverID, orgID, role, note, StatusApproved)
+}
+
+// Reject rejects a pending request.
+func (s *TimeOffService) Reject(ctx context.Context, requestID uuid.UUID, approverID string, orgID int64, role string, note string) (*TimeOffRequest, error) {
+	return s.decide(ctx, requestID, approverID, orgID, role, note, StatusRejected)
+}
+
+// Cancel withdraws a pending request, or an approved one that has not
+// started yet. Only the requester can cancel.
+func (s *TimeOffService) Cancel(ctx context.Context, requestID uuid.UUID, requesterID string, orgID int64) (*TimeOffRequest, error) {
+	request, err := s.repo.GetByID(ctx, requestID, orgID)
+	if err != nil {
+		return nil, err
+	}
+	if request == nil {
+		return nil, ErrRequestNotFound
+	}
+	if request.ProfileID.String() != requesterID {
+		return nil, ErrForbidden
+	}
+
+	from := []string{StatusPending}
+	if request.StartsAt.After(time.Now()) {
+		from = append(from, StatusApproved)
+	}
+
+	updated, err := s.repo.UpdateStatus(ctx, requestID, orgID, from, StatusCancelled, nil, "")
+	if err != nil {
+		return nil, err
+	}
+	if updated == nil {
+		return nil, ErrInvalidState
+	}
+	return updated, nil
+}
+
+// decide moves a pending request to the given status on behalf of an approver.
+func (s *TimeOffService) decide(ctx context.Context, requestID uuid.UUID, approverID string, orgID int64, role string, note string, status string) (*TimeOffRequest, error) {
+	approver, err := uuid.Parse(approverID)
+	if err != nil {
+		return nil, ErrForbidden
+	}
+
+	request, err := s.repo.GetByID(ctx, requestID, orgID)
+	if err != nil {
+		return nil, err
+	}
+	if request == nil {
+		return nil, ErrRequestNotFound
+	}
+
+	// Nobody approves their own leave
+	if request.ProfileID == approver {
+		return nil, ErrForbidden
+	}
+	// Besides owners and managers, anyone above the requester in the
+	// reporting lines may decide
+	if !canDecide(role) {
+		allowed, err := s.orgChart.IsManagerOf(ctx, approver, request.ProfileID, orgID)
+		if err != nil {
+			return nil, err
+		}
+		if !allowed {
+			return nil, ErrForbidden
+		}
+	}
+
+	updated, err := s.repo.UpdateStatus(ctx, requestID, orgID, []string{StatusPending}, status, &approver, note)
+	if err != nil {
+		return nil, err
+	}
+	if updated == nil {
+		return nil, ErrInvalidState
+	}
+	return updated, nil
+}
+
+// list wraps the repository and never returns a nil slice.
+func (s *TimeOffService) list(ctx context.Context, orgID int64, profileID *uuid.UUID, status string) ([]TimeOffRequest, error) {
+	requests, err := s.repo.List(ctx, orgID, profileID, status)
+	if err != nil {
+		return nil, err
+	}
+	if requests == nil {
+		return []TimeOffRequest{}, nil
+	}
+	return requests, nil
+}
+
+// ensureMember checks that the profile belongs to the requester's organization.
+func (s *TimeOffService) ensureMember(ctx context.Context, profileID uuid.UUID, orgID int64) error {
+	ok, err := s.repo.ProfileInOrganization(ctx, profileID, orgID)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return ErrProfileNotFound
+	}
+	return nil
+}
+
+// managesProfile reports whether the requester is above the profile in the
+// reporting lines.
+func (s *TimeOffService) managesProfile(ctx context.Context, requesterID string, profileID uuid.UUID, orgID int64) (bool, error) {
+	requester, err := uuid.Parse(requesterID)
+	if err != nil {
+		return false, nil
+	}
+	return s.orgChart.IsManagerOf(ctx, requester, profileID, orgID)
+}
+
+// canDecide reports whether a role may approve or reject requests regardless
+// of reporting lines.
+func canDecide(role string) bool {
+	return role == "OWNER" || role == "MANAGER"
+}
+
+// validateStatusFilter accepts an empty filter or a known status.
+func validateStatusFilter(status string) error {
+	switch status {
+	case "", StatusPending, StatusApproved, StatusRejected, StatusCancelled:
+		return nil
+	}
+	return fmt.Errorf("%w: unknown status %q", ErrInvalidInput, status)
+}
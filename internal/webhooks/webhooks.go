@@ -0,0 +1,17 @@
+package webhooks
+
+import "go.uber.org/fx"
+
+// ======== EXPORTS ========
+
+// Module exports services present
+var Context = fx.Options(
+	fx.Provide(GetWebhooksController),
+	fx.Provide(fx.Annotate(
+		GetWebhooksService,
+		fx.As(new(Service)),
+	)),
+	fx.Provide(GetWebhooksRepository),
+	fx.Provide(SetWebhooksRoutes),
+	fx.Invoke(RegisterPruneWorker),
+)
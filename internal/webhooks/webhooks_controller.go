@@ -0,0 +1,85 @@
+package webhooks
+
+import (
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// maxBodyBytes bounds the size of a delivery.
+const maxBodyBytes = 1 << 20
+
+type WebhooksController struct {
+	service Service
+}
+
+func GetWebhooksController(service Service) *WebhooksController {
+	return &WebhooksController{
+		service: service,
+	}
+}
+
+// ReceiveAuthEventHandler godoc
+// @Summary Receive a Supabase auth event
+// @Description Receives user.created, user.updated and user.deleted events from Supabase Auth, signed according to Standard Webhooks (webhook-id, webhook-timestamp and webhook-signature headers). Created users are placed by the join rule of their verified email domain, updated users get their email and name copied to the profile, and deleted users have their profile deactivated and marked deleted. Other event types are acknowledged and ignored, as are repeated deliveries.
+// @Tags webhooks
+// @Accept json
+// @Produce json
+// @Param webhook-id header string true "Unique delivery ID"
+// @Param webhook-timestamp header string true "Unix timestamp of the delivery"
+// @Param webhook-signature header string true "v1,<base64 HMAC-SHA256>"
+// @Param body body Event true "Auth event"
+// @Success 200 {object} Result
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 413 {object} ErrorResponse
+// @Failure 503 {object} ErrorResponse
+// @Router /webhooks/supabase/auth [post]
+func (c *WebhooksController) ReceiveAuthEventHandler(ctx *gin.Context) {
+	body, err := io.ReadAll(http.MaxBytesReader(ctx.Writer, ctx.Request.Body, maxBodyBytes))
+	if err != nil {
+		ctx.JSON(http.StatusRequestEntityTooLarge, ErrorResponse{
+			Error:   "Failed to read webhook",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	result, err := c.service.Receive(ctx.Request.Context(), Delivery{
+		ID:        ctx.GetHeader("webhook-id"),
+		Timestamp: ctx.GetHeader("webhook-timestamp"),
+		Signature: ctx.GetHeader("webhook-signature"),
+		Body:      body,
+	})
+	if err != nil {
+		respondError(ctx, "Failed to process webhook", err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, result)
+}
+
+func respondError(ctx *gin.Context, title string, err error) {
+	status := http.StatusInternalServerError
+	switch {
+	case errors.Is(err, ErrInvalidPayload):
+		status = http.StatusBadRequest
+	case errors.Is(err, ErrInvalidSignature),
+		errors.Is(err, ErrStaleDelivery):
+		status = http.StatusUnauthorized
+	case errors.Is(err, ErrNotConfigured):
+		status = http.StatusServiceUnavailable
+	}
+
+	// Details of internal failures are not shown to an unauthenticated caller
+	message := err.Error()
+	if status == http.StatusInternalServerError {
+		message = ""
+	}
+	ctx.JSON(status, ErrorResponse{
+		Error:   title,
+		Message: message,
+	})
+}
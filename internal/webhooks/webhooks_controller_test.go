@@ -0,0 +1,92 @@
+package webhooks
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockWebhooksService struct {
+	mock.Mock
+}
+
+func (m *MockWebhooksService) Receive(ctx context.Context, delivery Delivery) (*Result, error) {
+	args := m.Called(ctx, delivery)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*Result), args.Error(1)
+}
+
+func (m *MockWebhooksService) PruneDeliveries(ctx context.Context) (int64, error) {
+	args := m.Called(ctx)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func newWebhookRouter(service Service) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	controller := GetWebhooksController(service)
+	r := gin.New()
+	r.POST("/webhooks/supabase/auth", controller.ReceiveAuthEventHandler)
+	return r
+}
+
+func TestReceiveAuthEventHandler_PassesHeadersAndBody(t *testing.T) {
+	mockSvc := new(MockWebhooksService)
+	body := `{"type":"user.created"}`
+	mockSvc.On("Receive", mock.Anything, Delivery{
+		ID:        "msg_1",
+		Timestamp: "1760000000",
+		Signature: "v1,abc",
+		Body:      []byte(body),
+	}).Return(&Result{Result: ResultProvisioned}, nil)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("POST", "/webhooks/supabase/auth", strings.NewReader(body))
+	req.Header.Set("webhook-id", "msg_1")
+	req.Header.Set("webhook-timestamp", "1760000000")
+	req.Header.Set("webhook-signature", "v1,abc")
+	newWebhookRouter(mockSvc).ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{"result":"provisioned"}`, w.Body.String())
+	mockSvc.AssertExpectations(t)
+}
+
+func TestReceiveAuthEventHandler_Errors(t *testing.T) {
+	cases := map[error]int{
+		ErrInvalidSignature:      http.StatusUnauthorized,
+		ErrStaleDelivery:         http.StatusUnauthorized,
+		ErrInvalidPayload:        http.StatusBadRequest,
+		ErrNotConfigured:         http.StatusServiceUnavailable,
+		context.DeadlineExceeded: http.StatusInternalServerError,
+	}
+	for err, status := range cases {
+		mockSvc := new(MockWebhooksService)
+		mockSvc.On("Receive", mock.Anything, mock.Anything).Return(nil, err)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", "/webhooks/supabase/auth", strings.NewReader(`{}`))
+		newWebhookRouter(mockSvc).ServeHTTP(w, req)
+
+		assert.Equal(t, status, w.Code, err.Error())
+	}
+}
+
+func TestReceiveAuthEventHandler_HidesInternalErrors(t *testing.T) {
+	mockSvc := new(MockWebhooksService)
+	mockSvc.On("Receive", mock.Anything, mock.Anything).Return(nil, assert.AnError)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("POST", "/webhooks/supabase/auth", strings.NewReader(`{}`))
+	newWebhookRouter(mockSvc).ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+	assert.NotContains(t, w.Body.String(), assert.AnError.Error())
+}
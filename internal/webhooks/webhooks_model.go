@@ -0,0 +1,64 @@
+package webhooks
+
+import "time"
+
+// Event types sent by the Supabase auth webhook.
+const (
+	EventUserCreated = "user.created"
+	EventUserUpdated = "user.updated"
+	EventUserDeleted = "user.deleted"
+)
+
+// Delivery is a webhook request as received, before its signature is
+// checked. The headers follow the Standard Webhooks specification.
+type Delivery struct {
+	ID        string
+	Timestamp string
+	Signature string
+	Body      []byte
+}
+
+// Event is the body of a webhook delivery.
+type Event struct {
+	Type string    `json:"type" example:"user.created"`
+	User EventUser `json:"user"`
+}
+
+// EventUser is the Supabase Auth user an event is about.
+type EventUser struct {
+	ID               string                 `json:"id" example:"8d0f4c2e-3b1a-4f6e-9c7d-2a5b8e1f0c3d"`
+	Email            string                 `json:"email" example:"ana@villa-bled.si"`
+	EmailConfirmedAt *time.Time             `json:"email_confirmed_at"`
+	UserMetadata     map[string]interface{} `json:"user_metadata"`
+}
+
+// Name is the user's name from their metadata, if any.
+func (u EventUser) Name() string {
+	for _, key := range []string{"full_name", "name"} {
+		if name, ok := u.UserMetadata[key].(string); ok && name != "" {
+			return name
+		}
+	}
+	return ""
+}
+
+// Result tells the sender what was done with a delivery.
+type Result struct {
+	Result string `json:"result" example:"provisioned"`
+}
+
+// Results of a delivery.
+const (
+	ResultProvisioned = "provisioned"
+	ResultPending     = "pending"
+	ResultUpdated     = "updated"
+	ResultDeleted     = "deleted"
+	ResultIgnored     = "ignored"
+	ResultDuplicate   = "duplicate"
+)
+
+// ErrorResponse represents an error response
+type ErrorResponse struct {
+	Error   string `json:"error" example:"Invalid request"`
+	Message string `json:"message,omitempty" example:"The provided data is invalid"`
+}
@@ -0,0 +1,90 @@
+package webhooks
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type WebhooksRepository struct {
+	db *pgxpool.Pool
+}
+
+func GetWebhooksRepository(db *pgxpool.Pool) *WebhooksRepository {
+	return &WebhooksRepository{
+		db: db,
+	}
+}
+
+// RecordDelivery remembers the delivery and reports false when it was
+// already received.
+func (r *WebhooksRepository) RecordDelivery(ctx context.Context, id string) (bool, error) {
+	tag, err := r.db.Exec(ctx,
+		`INSERT INTO webhook_deliveries (id) VALUES ($1) ON CONFLICT (id) DO NOTHING`,
+		id,
+	)
+	if err != nil {
+		return false, err
+	}
+	return tag.RowsAffected() == 1, nil
+}
+
+// ForgetDelivery drops a delivery that could not be handled, so the
+// sender's retry is accepted.
+func (r *WebhooksRepository) ForgetDelivery(ctx context.Context, id string) error {
+	_, err := r.db.Exec(ctx, `DELETE FROM webhook_deliveries WHERE id = $1`, id)
+	return err
+}
+
+// PruneDeliveries drops deliveries received before the given time.
+func (r *WebhooksRepository) PruneDeliveries(ctx context.Context, before time.Time) (int64, error) {
+	tag, err := r.db.Exec(ctx, `DELETE FROM webhook_deliveries WHERE received_at < $1`, before)
+	if err != nil {
+		return 0, err
+	}
+	return tag.RowsAffected(), nil
+}
+
+// UpdateProfile copies the email and, when given, the name of the user to
+// their profile. It reports false when the user has no profile.
+func (r *WebhooksRepository) UpdateProfile(ctx context.Context, userID uuid.UUID, email string, fullName string) (bool, error) {
+	tag, err := r.db.Exec(ctx, `
+        UPDATE "profiles" SET
+            email      = $2,
+            full_name  = COALESCE(NULLIF($3, ''), full_name),
+            updated_at = now()
+        WHERE id = $1 AND deleted_at IS NULL`,
+		userID, email, fullName,
+	)
+	if err != nil {
+		return false, err
+	}
+	return tag.RowsAffected() == 1, nil
+}
+
+// SoftDeleteProfile deactivates the profile and marks it deleted. It
+// returns the profile's organization, or nil when there was no profile
+// left to delete.
+func (r *WebhooksRepository) SoftDeleteProfile(ctx context.Context, userID uuid.UUID) (*int64, error) {
+	var orgID int64
+	err := r.db.QueryRow(ctx, `
+        UPDATE "profiles" SET
+            status     = 'INACTIVE',
+            deleted_at = now(),
+            updated_at = now()
+        WHERE id = $1 AND deleted_at IS NULL
+        RETURNING organization_id`,
+		userID,
+	).Scan(&orgID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &orgID, nil
+}
@@ -0,0 +1,32 @@
+package webhooks
+
+import (
+	"hostflow/profile-service/pkg/lib"
+)
+
+type WebhooksRoutes struct {
+	logger             lib.Logger
+	router             *lib.Router
+	webhooksController *WebhooksController
+}
+
+func SetWebhooksRoutes(
+	logger lib.Logger,
+	router *lib.Router,
+	webhooksController *WebhooksController,
+) WebhooksRoutes {
+	return WebhooksRoutes{
+		logger:             logger,
+		router:             router,
+		webhooksController: webhooksController,
+	}
+}
+
+func (route WebhooksRoutes) Setup() {
+	route.logger.Info("Setting up [WEBHOOKS] routes.")
+
+	// Public: deliveries are authenticated by their signature
+	route.router.POST("/webhooks/supabase/auth", route.webhooksController.ReceiveAuthEventHandler)
+
+	route.logger.Info("[WEBHOOKS] routes setup complete.")
+}
@@ -0,0 +1,194 @@
+package webhooks
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"hostflow/profile-service/internal/audit"
+	"hostflow/profile-service/internal/middlewares"
+	"hostflow/profile-service/pkg/lib"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrNotConfigured    = errors.New("the auth webhook is not configured")
+	ErrInvalidSignature = errors.New("invalid webhook signature")
+	ErrStaleDelivery    = errors.New("webhook timestamp is outside the tolerance")
+	ErrInvalidPayload   = errors.New("invalid webhook payload")
+)
+
+// DefaultTolerance is how far a delivery's timestamp may be from now,
+// unless AUTH_WEBHOOK_TOLERANCE says otherwise.
+const DefaultTolerance = 5 * time.Minute
+
+type WebhooksService struct {
+	repo        *WebhooksRepository
+	onboarding  middlewares.Onboarding
+	memberships *middlewares.Memberships
+	revocations *middlewares.Revocations
+	audit       audit.Service
+	logger      lib.Logger
+	verifier    *signatureVerifier
+}
+
+type Service interface {
+	Receive(ctx context.Context, delivery Delivery) (*Result, error)
+	PruneDeliveries(ctx context.Context) (int64, error)
+}
+
+// GetWebhooksService reads the signing secret from AUTH_WEBHOOK_SECRET.
+// Without it the service starts, but deliveries are refused.
+func GetWebhooksService(
+	repo *WebhooksRepository,
+	onboarding middlewares.Onboarding,
+	memberships *middlewares.Memberships,
+	revocations *middlewares.Revocations,
+	audit audit.Service,
+	logger lib.Logger,
+) (*WebhooksService, error) {
+	tolerance, err := lib.DurationFromEnv("AUTH_WEBHOOK_TOLERANCE", DefaultTolerance)
+	if err != nil {
+		return nil, err
+	}
+	secrets, err := parseSecrets(os.Getenv("AUTH_WEBHOOK_SECRET"))
+	if err != nil {
+		return nil, err
+	}
+
+	service := &WebhooksService{
+		repo:        repo,
+		onboarding:  onboarding,
+		memberships: memberships,
+		revocations: revocations,
+		audit:       audit,
+		logger:      logger,
+	}
+	if len(secrets) > 0 {
+		service.verifier = &signatureVerifier{secrets: secrets, tolerance: tolerance, now: time.Now}
+	}
+	return service, nil
+}
+
+// Receive verifies a delivery and applies its event to the user's profile.
+// A delivery is handled once; repeats are acknowledged without effect. A
+// delivery that fails can be retried by the sender.
+func (s *WebhooksService) Receive(ctx context.Context, delivery Delivery) (*Result, error) {
+	if s.verifier == nil {
+		return nil, ErrNotConfigured
+	}
+	if err := s.verifier.verify(delivery); err != nil {
+		return nil, err
+	}
+
+	var event Event
+	if err := json.Unmarshal(delivery.Body, &event); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidPayload, err)
+	}
+	userID, err := uuid.Parse(event.User.ID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: user.id must be a UUID", ErrInvalidPayload)
+	}
+
+	fresh, err := s.repo.RecordDelivery(ctx, delivery.ID)
+	if err != nil {
+		return nil, err
+	}
+	if !fresh {
+		return &Result{Result: ResultDuplicate}, nil
+	}
+
+	result, err := s.handle(ctx, event, userID)
+	if err != nil {
+		if err := s.repo.ForgetDelivery(ctx, delivery.ID); err != nil {
+			s.logger.Error(fmt.Sprintf("Failed to forget webhook delivery %s: %v", delivery.ID, err))
+		}
+		return nil, err
+	}
+	return &Result{Result: result}, nil
+}
+
+// PruneDeliveries forgets deliveries old enough to be rejected by their
+// timestamp anyway.
+func (s *WebhooksService) PruneDeliveries(ctx context.Context) (int64, error) {
+	if s.verifier == nil {
+		return 0, nil
+	}
+	return s.repo.PruneDeliveries(ctx, time.Now().Add(-2*s.verifier.tolerance))
+}
+
+func (s *WebhooksService) handle(ctx context.Context, event Event, userID uuid.UUID) (string, error) {
+	switch event.Type {
+	case EventUserCreated:
+		return s.provision(ctx, event.User)
+	case EventUserUpdated:
+		email := strings.ToLower(strings.TrimSpace(event.User.Email))
+		updated, err := s.repo.UpdateProfile(ctx, userID, email, strings.TrimSpace(event.User.Name()))
+		if err != nil || updated {
+			return ResultUpdated, err
+		}
+		// Confirming the email can make a join rule apply
+		return s.provision(ctx, event.User)
+	case EventUserDeleted:
+		return s.delete(ctx, userID)
+	default:
+		return ResultIgnored, nil
+	}
+}
+
+// provision places the user the same way as on their first request: by the
+// join rule of their verified email domain. Users no rule applies to keep
+// having no profile until they sign up an organization or ask to join one.
+func (s *WebhooksService) provision(ctx context.Context, user EventUser) (string, error) {
+	membership, err := s.onboarding.Onboard(ctx, middlewares.Identity{
+		UserID:        user.ID,
+		Email:         user.Email,
+		EmailVerified: user.EmailConfirmedAt != nil,
+		Name:          user.Name(),
+	})
+	switch {
+	case err != nil:
+		return "", err
+	case membership == nil:
+		return ResultIgnored, nil
+	case membership.Pending:
+		return ResultPending, nil
+	default:
+		return ResultProvisioned, nil
+	}
+}
+
+// delete soft-deletes the user's profile and revokes their tokens. The
+// deletion is audited in the profile's organization.
+func (s *WebhooksService) delete(ctx context.Context, userID uuid.UUID) (string, error) {
+	orgID, err := s.repo.SoftDeleteProfile(ctx, userID)
+	if err != nil {
+		return "", err
+	}
+	if orgID == nil {
+		return ResultIgnored, nil
+	}
+	s.memberships.Invalidate(userID.String())
+
+	if err := s.revocations.Revoke(ctx, middlewares.RevokeUser, userID.String(), "user_deleted"); err != nil {
+		return "", err
+	}
+
+	targetID := userID.String()
+	err = s.audit.Record(ctx, audit.Entry{
+		OrganizationID: *orgID,
+		Action:         "member.deleted",
+		TargetType:     "profile",
+		TargetID:       &targetID,
+		Details:        map[string]interface{}{"source": "supabase_auth"},
+	})
+	if err != nil {
+		return "", err
+	}
+	return ResultDeleted, nil
+}
@@ -0,0 +1,108 @@
+package webhooks
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testSecret = []byte("0123456789abcdef0123456789abcdef")
+
+func sign(secret []byte, id string, timestamp string, body string) string {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(id + "." + timestamp + "." + body))
+	return "v1," + base64.StdEncoding.EncodeToString(mac.Sum(nil))
+}
+
+func newVerifier(now time.Time, secrets ...[]byte) *signatureVerifier {
+	return &signatureVerifier{secrets: secrets, tolerance: DefaultTolerance, now: func() time.Time { return now }}
+}
+
+func TestParseSecrets(t *testing.T) {
+	encoded := base64.StdEncoding.EncodeToString(testSecret)
+
+	secrets, err := parseSecrets("v1,whsec_" + encoded + " whsec_" + encoded)
+	require.NoError(t, err)
+	assert.Equal(t, [][]byte{testSecret, testSecret}, secrets)
+
+	secrets, err = parseSecrets("")
+	assert.NoError(t, err)
+	assert.Empty(t, secrets)
+
+	_, err = parseSecrets("v1,whsec_not base64!")
+	assert.Error(t, err)
+}
+
+func TestSignatureVerifier(t *testing.T) {
+	now := time.Unix(1_760_000_000, 0)
+	timestamp := strconv.FormatInt(now.Unix(), 10)
+	body := `{"type":"user.created","user":{"id":"8d0f4c2e-3b1a-4f6e-9c7d-2a5b8e1f0c3d"}}`
+	verifier := newVerifier(now, testSecret)
+
+	valid := Delivery{ID: "msg_1", Timestamp: timestamp, Signature: sign(testSecret, "msg_1", timestamp, body), Body: []byte(body)}
+	assert.NoError(t, verifier.verify(valid))
+
+	tampered := valid
+	tampered.Body = []byte(`{"type":"user.deleted"}`)
+	assert.ErrorIs(t, verifier.verify(tampered), ErrInvalidSignature)
+
+	otherID := valid
+	otherID.ID = "msg_2"
+	assert.ErrorIs(t, verifier.verify(otherID), ErrInvalidSignature)
+
+	missing := valid
+	missing.Signature = ""
+	assert.ErrorIs(t, verifier.verify(missing), ErrInvalidSignature)
+
+	// Signed with the right secret, but too old or too far ahead
+	for _, offset := range []time.Duration{-6 * time.Minute, 6 * time.Minute} {
+		ts := strconv.FormatInt(now.Add(offset).Unix(), 10)
+		stale := Delivery{ID: "msg_1", Timestamp: ts, Signature: sign(testSecret, "msg_1", ts, body), Body: []byte(body)}
+		assert.ErrorIs(t, verifier.verify(stale), ErrStaleDelivery)
+	}
+}
+
+func TestSignatureVerifier_Rotation(t *testing.T) {
+	now := time.Unix(1_760_000_000, 0)
+	timestamp := strconv.FormatInt(now.Unix(), 10)
+	body := `{}`
+	newSecret := []byte("fedcba9876543210fedcba9876543210")
+
+	// The sender signs with both secrets while rotating; either is accepted
+	signatures := sign(newSecret, "msg_1", timestamp, body) + " " + sign(testSecret, "msg_1", timestamp, body)
+	delivery := Delivery{ID: "msg_1", Timestamp: timestamp, Signature: signatures, Body: []byte(body)}
+
+	assert.NoError(t, newVerifier(now, testSecret).verify(delivery))
+	assert.NoError(t, newVerifier(now, []byte("unrelated-secret-unrelated-secret"), newSecret).verify(delivery))
+	assert.ErrorIs(t, newVerifier(now, []byte("unrelated-secret-unrelated-secret")).verify(delivery), ErrInvalidSignature)
+}
+
+func TestReceive_RejectsBeforeTouchingTheDatabase(t *testing.T) {
+	now := time.Now()
+	timestamp := strconv.FormatInt(now.Unix(), 10)
+
+	unconfigured := &WebhooksService{}
+	_, err := unconfigured.Receive(context.Background(), Delivery{})
+	assert.ErrorIs(t, err, ErrNotConfigured)
+
+	service := &WebhooksService{verifier: newVerifier(now, testSecret)}
+	_, err = service.Receive(context.Background(), Delivery{ID: "msg_1", Timestamp: timestamp, Signature: "v1,forged", Body: []byte(`{}`)})
+	assert.ErrorIs(t, err, ErrInvalidSignature)
+
+	body := `{"type":"user.created","user":{"id":"not-a-uuid"}}`
+	_, err = service.Receive(context.Background(), Delivery{ID: "msg_1", Timestamp: timestamp, Signature: sign(testSecret, "msg_1", timestamp, body), Body: []byte(body)})
+	assert.ErrorIs(t, err, ErrInvalidPayload)
+}
+
+func TestEventUser_Name(t *testing.T) {
+	assert.Equal(t, "Ana Novak", EventUser{UserMetadata: map[string]interface{}{"full_name": "Ana Novak", "name": "ana"}}.Name())
+	assert.Equal(t, "ana", EventUser{UserMetadata: map[string]interface{}{"name": "ana"}}.Name())
+	assert.Equal(t, "", EventUser{}.Name())
+}
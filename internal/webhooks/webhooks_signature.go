@@ -0,0 +1,70 @@
+package webhooks
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// signatureVerifier checks Standard Webhooks signatures: an HMAC-SHA256 of
+// "<webhook-id>.<webhook-timestamp>.<body>", sent base64 encoded as
+// "v1,<signature>". Several signatures may be sent, separated by spaces,
+// while the secret is rotated.
+type signatureVerifier struct {
+	secrets   [][]byte
+	tolerance time.Duration
+	now       func() time.Time
+}
+
+// parseSecrets decodes secrets in the format Supabase shows them,
+// "v1,whsec_<base64>". Several secrets can be given, separated by spaces,
+// to rotate them without downtime.
+func parseSecrets(value string) ([][]byte, error) {
+	var secrets [][]byte
+	for _, field := range strings.Fields(value) {
+		encoded := strings.TrimPrefix(strings.TrimPrefix(field, "v1,"), "whsec_")
+		secret, err := base64.StdEncoding.DecodeString(encoded)
+		if err != nil || len(secret) == 0 {
+			return nil, fmt.Errorf("invalid webhook secret: expected v1,whsec_<base64>")
+		}
+		secrets = append(secrets, secret)
+	}
+	return secrets, nil
+}
+
+// verify returns ErrInvalidSignature unless one of the signatures was made
+// with one of the secrets, and ErrStaleDelivery when the timestamp is
+// further from now than the tolerance.
+func (v *signatureVerifier) verify(delivery Delivery) error {
+	if delivery.ID == "" || delivery.Timestamp == "" || delivery.Signature == "" {
+		return fmt.Errorf("%w: webhook-id, webhook-timestamp and webhook-signature are required", ErrInvalidSignature)
+	}
+
+	seconds, err := strconv.ParseInt(delivery.Timestamp, 10, 64)
+	if err != nil {
+		return fmt.Errorf("%w: malformed webhook-timestamp", ErrInvalidSignature)
+	}
+	age := v.now().Sub(time.Unix(seconds, 0))
+	if age > v.tolerance || age < -v.tolerance {
+		return ErrStaleDelivery
+	}
+
+	content := delivery.ID + "." + delivery.Timestamp + "." + string(delivery.Body)
+	for _, secret := range v.secrets {
+		mac := hmac.New(sha256.New, secret)
+		mac.Write([]byte(content))
+		expected := base64.StdEncoding.EncodeToString(mac.Sum(nil))
+
+		for _, signature := range strings.Fields(delivery.Signature) {
+			version, value, ok := strings.Cut(signature, ",")
+			if ok && version == "v1" && hmac.Equal([]byte(value), []byte(expected)) {
+				return nil
+			}
+		}
+	}
+	return ErrInvalidSignature
+}
@@ -0,0 +1,24 @@
+package webhooks
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"hostflow/profile-service/pkg/lib"
+
+	"go.uber.org/fx"
+)
+
+// pruneInterval is how often old webhook deliveries are forgotten.
+const pruneInterval = time.Hour
+
+// RegisterPruneWorker forgets old webhook deliveries in the background for
+// the lifetime of the app.
+func RegisterPruneWorker(lifecycle fx.Lifecycle, service Service, logger lib.Logger) {
+	lib.RunPeriodically(lifecycle, pruneInterval, func(ctx context.Context) {
+		if _, err := service.PruneDeliveries(ctx); err != nil {
+			logger.Error(fmt.Sprintf("Webhook delivery pruning failed: %v", err))
+		}
+	})
+}
@@ -3,6 +3,7 @@ package main
 import (
 	_ "hostflow/profile-service/docs" // Import generated swagger docs
 	"hostflow/profile-service/internal/bootstrap"
+	_ "time/tzdata" // Embed timezone data, the runtime image has none
 
 	"github.com/joho/godotenv"
 	"go.uber.org/fx"
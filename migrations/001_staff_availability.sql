@@ -0,0 +1,35 @@
+-- Recurring weekly availability and date-specific overrides per profile.
+-- Times are stored as minutes since local midnight in the profile's timezone.
+
+CREATE TABLE IF NOT EXISTS availability_schedules (
+    profile_id  UUID PRIMARY KEY REFERENCES profiles (id) ON DELETE CASCADE,
+    timezone    TEXT        NOT NULL DEFAULT 'UTC',
+    updated_at  TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE TABLE IF NOT EXISTS availability_weekly_slots (
+    id           BIGSERIAL PRIMARY KEY,
+    profile_id   UUID     NOT NULL REFERENCES profiles (id) ON DELETE CASCADE,
+    weekday      SMALLINT NOT NULL CHECK (weekday BETWEEN 0 AND 6),
+    start_minute SMALLINT NOT NULL CHECK (start_minute BETWEEN 0 AND 1439),
+    end_minute   SMALLINT NOT NULL CHECK (end_minute BETWEEN 1 AND 1440),
+    CHECK (end_minute > start_minute)
+);
+
+CREATE INDEX IF NOT EXISTS availability_weekly_slots_profile_idx
+    ON availability_weekly_slots (profile_id);
+
+-- An override replaces the weekly slots for a single local date. A row with
+-- NULL times marks the whole day as unavailable.
+CREATE TABLE IF NOT EXISTS availability_overrides (
+    id           BIGSERIAL PRIMARY KEY,
+    profile_id   UUID     NOT NULL REFERENCES profiles (id) ON DELETE CASCADE,
+    date         DATE     NOT NULL,
+    start_minute SMALLINT CHECK (start_minute BETWEEN 0 AND 1439),
+    end_minute   SMALLINT CHECK (end_minute BETWEEN 1 AND 1440),
+    CHECK ((start_minute IS NULL) = (end_minute IS NULL)),
+    CHECK (end_minute IS NULL OR end_minute > start_minute)
+);
+
+CREATE INDEX IF NOT EXISTS availability_overrides_profile_date_idx
+    ON availability_overrides (profile_id, date);
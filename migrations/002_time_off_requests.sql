@@ -0,0 +1,25 @@
+-- Leave management: members request time off, owners/managers decide.
+
+CREATE TABLE IF NOT EXISTS time_off_requests (
+    id              UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    organization_id BIGINT      NOT NULL REFERENCES organization (id) ON DELETE CASCADE,
+    profile_id      UUID        NOT NULL REFERENCES profiles (id) ON DELETE CASCADE,
+    type            TEXT        NOT NULL CHECK (type IN ('VACATION', 'SICK', 'PERSONAL', 'OTHER')),
+    starts_at       TIMESTAMPTZ NOT NULL,
+    ends_at         TIMESTAMPTZ NOT NULL,
+    note            TEXT        NOT NULL DEFAULT '',
+    status          TEXT        NOT NULL DEFAULT 'PENDING'
+                    CHECK (status IN ('PENDING', 'APPROVED', 'REJECTED', 'CANCELLED')),
+    decided_by      UUID REFERENCES profiles (id) ON DELETE SET NULL,
+    decided_at      TIMESTAMPTZ,
+    decision_note   TEXT        NOT NULL DEFAULT '',
+    created_at      TIMESTAMPTZ NOT NULL DEFAULT now(),
+    updated_at      TIMESTAMPTZ NOT NULL DEFAULT now(),
+    CHECK (ends_at > starts_at)
+);
+
+CREATE INDEX IF NOT EXISTS time_off_requests_org_status_idx
+    ON time_off_requests (organization_id, status, starts_at);
+
+CREATE INDEX IF NOT EXISTS time_off_requests_profile_range_idx
+    ON time_off_requests (profile_id, starts_at, ends_at);
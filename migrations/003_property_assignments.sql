@@ -0,0 +1,13 @@
+-- Which properties a staff member works at. Property IDs belong to the
+-- booking service, so there is no foreign key on property_id.
+
+CREATE TABLE IF NOT EXISTS property_assignments (
+    organization_id BIGINT      NOT NULL REFERENCES organization (id) ON DELETE CASCADE,
+    profile_id      UUID        NOT NULL REFERENCES profiles (id) ON DELETE CASCADE,
+    property_id     BIGINT      NOT NULL,
+    created_at      TIMESTAMPTZ NOT NULL DEFAULT now(),
+    PRIMARY KEY (profile_id, property_id)
+);
+
+CREATE INDEX IF NOT EXISTS property_assignments_org_property_idx
+    ON property_assignments (organization_id, property_id);
@@ -0,0 +1,37 @@
+-- Per-organization skill vocabulary, spoken languages (ISO 639-1) and
+-- free-form tags attached to profiles.
+
+CREATE TABLE IF NOT EXISTS skills (
+    id              BIGSERIAL PRIMARY KEY,
+    organization_id BIGINT      NOT NULL REFERENCES organization (id) ON DELETE CASCADE,
+    name            TEXT        NOT NULL,
+    created_at      TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS skills_org_name_idx
+    ON skills (organization_id, lower(name));
+
+CREATE TABLE IF NOT EXISTS profile_skills (
+    profile_id UUID   NOT NULL REFERENCES profiles (id) ON DELETE CASCADE,
+    skill_id   BIGINT NOT NULL REFERENCES skills (id) ON DELETE CASCADE,
+    PRIMARY KEY (profile_id, skill_id)
+);
+
+CREATE INDEX IF NOT EXISTS profile_skills_skill_idx ON profile_skills (skill_id);
+
+CREATE TABLE IF NOT EXISTS profile_languages (
+    profile_id    UUID NOT NULL REFERENCES profiles (id) ON DELETE CASCADE,
+    language_code TEXT NOT NULL CHECK (language_code ~ '^[a-z]{2}$'),
+    PRIMARY KEY (profile_id, language_code)
+);
+
+CREATE INDEX IF NOT EXISTS profile_languages_code_idx ON profile_languages (language_code);
+
+CREATE TABLE IF NOT EXISTS profile_tags (
+    profile_id      UUID   NOT NULL REFERENCES profiles (id) ON DELETE CASCADE,
+    organization_id BIGINT NOT NULL REFERENCES organization (id) ON DELETE CASCADE,
+    tag             TEXT   NOT NULL,
+    PRIMARY KEY (profile_id, tag)
+);
+
+CREATE INDEX IF NOT EXISTS profile_tags_org_tag_idx ON profile_tags (organization_id, tag);
@@ -0,0 +1,13 @@
+-- Reporting lines: every profile can have one manager within the same
+-- organization. Cycles are rejected by the service before writing.
+
+ALTER TABLE profiles
+    ADD COLUMN IF NOT EXISTS manager_id UUID REFERENCES profiles (id) ON DELETE SET NULL;
+
+ALTER TABLE profiles
+    DROP CONSTRAINT IF EXISTS profiles_manager_not_self;
+ALTER TABLE profiles
+    ADD CONSTRAINT profiles_manager_not_self CHECK (manager_id IS NULL OR manager_id <> id);
+
+CREATE INDEX IF NOT EXISTS profiles_manager_idx
+    ON profiles (manager_id);
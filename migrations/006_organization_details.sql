@@ -0,0 +1,14 @@
+-- Organization details beyond the name. Existing rows keep a NULL slug
+-- until an owner picks one.
+
+ALTER TABLE organization
+    ADD COLUMN IF NOT EXISTS slug           TEXT,
+    ADD COLUMN IF NOT EXISTS legal_name     TEXT,
+    ADD COLUMN IF NOT EXISTS address        JSONB,
+    ADD COLUMN IF NOT EXISTS contact_email  TEXT,
+    ADD COLUMN IF NOT EXISTS timezone       TEXT NOT NULL DEFAULT 'Europe/Ljubljana',
+    ADD COLUMN IF NOT EXISTS default_locale TEXT NOT NULL DEFAULT 'sl-SI',
+    ADD COLUMN IF NOT EXISTS currency       CHAR(3) NOT NULL DEFAULT 'EUR';
+
+CREATE UNIQUE INDEX IF NOT EXISTS organization_slug_key
+    ON organization (slug) WHERE slug IS NOT NULL;
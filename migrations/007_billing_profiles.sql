@@ -0,0 +1,16 @@
+-- Invoicing details of an organization. The IBAN is stored encrypted
+-- (AES-256-GCM, key in BILLING_ENCRYPTION_KEY) next to a masked copy for
+-- display, so regular reads never need the key.
+
+CREATE TABLE IF NOT EXISTS billing_profiles (
+    organization_id BIGINT      PRIMARY KEY REFERENCES organization (id) ON DELETE CASCADE,
+    legal_name      TEXT        NOT NULL,
+    address         JSONB       NOT NULL,
+    vat_id          TEXT,
+    tax_id          TEXT,
+    iban_encrypted  TEXT,
+    iban_masked     TEXT,
+    invoice_prefix  TEXT        NOT NULL,
+    created_at      TIMESTAMPTZ NOT NULL DEFAULT now(),
+    updated_at      TIMESTAMPTZ NOT NULL DEFAULT now()
+);
@@ -0,0 +1,8 @@
+-- Self-service sign-up: remember which auth user created an organization
+-- so retried sign-ups return the same organization instead of a new one.
+
+ALTER TABLE organization
+    ADD COLUMN IF NOT EXISTS created_by UUID;
+
+CREATE UNIQUE INDEX IF NOT EXISTS organization_created_by_key
+    ON organization (created_by) WHERE created_by IS NOT NULL;
@@ -0,0 +1,39 @@
+-- Agencies: organizations can have a parent. Settings are stored per
+-- organization and inherited down the tree (children override parents).
+-- A child asks to join a parent (pending_parent_id) and the parent accepts.
+-- Depth and cycles are enforced by the service.
+
+ALTER TABLE organization
+    ADD COLUMN IF NOT EXISTS parent_id         BIGINT REFERENCES organization (id) ON DELETE RESTRICT,
+    ADD COLUMN IF NOT EXISTS pending_parent_id BIGINT REFERENCES organization (id) ON DELETE SET NULL,
+    ADD COLUMN IF NOT EXISTS settings          JSONB NOT NULL DEFAULT '{}'::jsonb;
+
+ALTER TABLE organization
+    DROP CONSTRAINT IF EXISTS organization_parent_not_self;
+ALTER TABLE organization
+    ADD CONSTRAINT organization_parent_not_self CHECK (parent_id IS NULL OR parent_id <> id);
+
+CREATE INDEX IF NOT EXISTS organization_parent_idx
+    ON organization (parent_id);
+CREATE INDEX IF NOT EXISTS organization_pending_parent_idx
+    ON organization (pending_parent_id) WHERE pending_parent_id IS NOT NULL;
+
+-- Append-only record of sensitive actions, including parent organizations
+-- acting on a child's members. organization_id is the organization whose
+-- data was touched, actor_organization_id the one the actor belongs to.
+CREATE TABLE IF NOT EXISTS audit_log (
+    id                    BIGSERIAL   PRIMARY KEY,
+    organization_id       BIGINT      NOT NULL REFERENCES organization (id) ON DELETE CASCADE,
+    actor_id              UUID,
+    actor_organization_id BIGINT,
+    action                TEXT        NOT NULL,
+    target_type           TEXT        NOT NULL,
+    target_id             TEXT,
+    details               JSONB       NOT NULL DEFAULT '{}'::jsonb,
+    created_at            TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS audit_log_org_idx
+    ON audit_log (organization_id, id DESC);
+CREATE INDEX IF NOT EXISTS audit_log_actor_org_idx
+    ON audit_log (actor_organization_id, id DESC);
@@ -0,0 +1,14 @@
+-- Subscription plan and entitlements, pushed by the billing service.
+-- NULL limits mean unlimited, so existing organizations keep working until
+-- their plan is set.
+
+ALTER TABLE organization
+    ADD COLUMN IF NOT EXISTS plan            TEXT        NOT NULL DEFAULT 'free',
+    ADD COLUMN IF NOT EXISTS max_members     INTEGER     CHECK (max_members IS NULL OR max_members >= 0),
+    ADD COLUMN IF NOT EXISTS max_properties  INTEGER     CHECK (max_properties IS NULL OR max_properties >= 0),
+    ADD COLUMN IF NOT EXISTS features        TEXT[]      NOT NULL DEFAULT '{}',
+    ADD COLUMN IF NOT EXISTS plan_updated_at TIMESTAMPTZ;
+
+-- Seats are counted over members that are not INACTIVE.
+CREATE INDEX IF NOT EXISTS profiles_org_status_idx
+    ON "profiles" (organization_id, status);
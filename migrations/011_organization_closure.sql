@@ -0,0 +1,30 @@
+-- Organization closure: an OWNER requests it, members lose access at once
+-- (CLOSING), the request can be cancelled until purge_after, and then a
+-- background job purges the organization's data and marks it CLOSED. The
+-- organization row is kept, anonymized, as a tombstone for the report.
+
+ALTER TABLE organization
+    ADD COLUMN IF NOT EXISTS status               TEXT NOT NULL DEFAULT 'ACTIVE',
+    ADD COLUMN IF NOT EXISTS closure_requested_at TIMESTAMPTZ,
+    ADD COLUMN IF NOT EXISTS closure_requested_by UUID,
+    ADD COLUMN IF NOT EXISTS closure_reason       TEXT,
+    ADD COLUMN IF NOT EXISTS purge_after          TIMESTAMPTZ,
+    ADD COLUMN IF NOT EXISTS closed_at            TIMESTAMPTZ;
+
+ALTER TABLE organization
+    DROP CONSTRAINT IF EXISTS organization_status_check;
+ALTER TABLE organization
+    ADD CONSTRAINT organization_status_check CHECK (status IN ('ACTIVE', 'CLOSING', 'CLOSED'));
+
+CREATE INDEX IF NOT EXISTS organization_purge_due_idx
+    ON organization (purge_after) WHERE status = 'CLOSING';
+
+CREATE TABLE IF NOT EXISTS organization_closure_reports (
+    organization_id BIGINT      PRIMARY KEY REFERENCES organization (id) ON DELETE CASCADE,
+    requested_by    UUID,
+    requested_at    TIMESTAMPTZ NOT NULL,
+    reason          TEXT,
+    purged_at       TIMESTAMPTZ NOT NULL DEFAULT now(),
+    counts          JSONB       NOT NULL DEFAULT '{}'::jsonb,
+    identities      JSONB       NOT NULL DEFAULT '{}'::jsonb
+);
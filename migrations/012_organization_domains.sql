@@ -0,0 +1,41 @@
+-- Email domains claimed by organizations. A claim is proven with a DNS TXT
+-- record and only one organization can hold a verified claim per domain.
+-- Verified domains can auto-join new users or ask an owner to approve them.
+
+CREATE TABLE IF NOT EXISTS organization_domains (
+    id                 BIGSERIAL   PRIMARY KEY,
+    organization_id    BIGINT      NOT NULL REFERENCES organization (id) ON DELETE CASCADE,
+    domain             TEXT        NOT NULL,
+    verification_token TEXT        NOT NULL,
+    verified_at        TIMESTAMPTZ,
+    join_policy        TEXT        NOT NULL DEFAULT 'NONE'
+                           CHECK (join_policy IN ('NONE', 'AUTO_JOIN', 'APPROVAL')),
+    join_role          TEXT        NOT NULL DEFAULT 'MEMBER'
+                           CHECK (join_role IN ('MANAGER', 'MEMBER')),
+    created_at         TIMESTAMPTZ NOT NULL DEFAULT now(),
+    UNIQUE (organization_id, domain)
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS organization_domains_verified_idx
+    ON organization_domains (domain) WHERE verified_at IS NOT NULL;
+
+-- Users waiting for an owner to let them into an organization.
+CREATE TABLE IF NOT EXISTS join_requests (
+    id              BIGSERIAL   PRIMARY KEY,
+    organization_id BIGINT      NOT NULL REFERENCES organization (id) ON DELETE CASCADE,
+    user_id         UUID        NOT NULL,
+    email           TEXT        NOT NULL,
+    full_name       TEXT        NOT NULL,
+    role            TEXT        NOT NULL CHECK (role IN ('MANAGER', 'MEMBER')),
+    source          TEXT        NOT NULL,
+    status          TEXT        NOT NULL DEFAULT 'PENDING'
+                        CHECK (status IN ('PENDING', 'APPROVED', 'REJECTED', 'CANCELLED')),
+    created_at      TIMESTAMPTZ NOT NULL DEFAULT now(),
+    decided_at      TIMESTAMPTZ,
+    decided_by      UUID
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS join_requests_pending_idx
+    ON join_requests (organization_id, user_id) WHERE status = 'PENDING';
+CREATE INDEX IF NOT EXISTS join_requests_org_status_idx
+    ON join_requests (organization_id, status, created_at);
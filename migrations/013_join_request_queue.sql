@@ -0,0 +1,24 @@
+-- Users can ask to join an organization by its slug. Requests carry a note
+-- for the approvers and expire when nobody decides them in time.
+
+ALTER TABLE join_requests
+    ADD COLUMN IF NOT EXISTS message    TEXT,
+    ADD COLUMN IF NOT EXISTS expires_at TIMESTAMPTZ;
+
+UPDATE join_requests
+SET expires_at = created_at + interval '14 days'
+WHERE expires_at IS NULL;
+
+ALTER TABLE join_requests
+    ALTER COLUMN expires_at SET NOT NULL;
+
+ALTER TABLE join_requests
+    DROP CONSTRAINT IF EXISTS join_requests_status_check;
+ALTER TABLE join_requests
+    ADD CONSTRAINT join_requests_status_check
+        CHECK (status IN ('PENDING', 'APPROVED', 'REJECTED', 'CANCELLED', 'EXPIRED'));
+
+CREATE INDEX IF NOT EXISTS join_requests_user_pending_idx
+    ON join_requests (user_id) WHERE status = 'PENDING';
+CREATE INDEX IF NOT EXISTS join_requests_expiry_idx
+    ON join_requests (expires_at) WHERE status = 'PENDING';
@@ -0,0 +1,24 @@
+-- Other services (e.g. booking) call the internal API with short-lived
+-- tokens signed by this service. Clients exchange their credentials for a
+-- token carrying the scopes they were granted.
+
+CREATE TABLE IF NOT EXISTS service_clients (
+    id          SERIAL      PRIMARY KEY,
+    name        TEXT        NOT NULL UNIQUE,
+    secret_hash TEXT        NOT NULL,
+    scopes      TEXT[]      NOT NULL DEFAULT '{}',
+    created_at  TIMESTAMPTZ NOT NULL DEFAULT now(),
+    disabled_at TIMESTAMPTZ
+);
+
+-- Signing keys rotate: a new key is published before it is used, and an old
+-- key stays published until the last token it signed has expired. Private
+-- keys are stored encrypted with SERVICE_KEY_ENCRYPTION_KEY.
+CREATE TABLE IF NOT EXISTS service_signing_keys (
+    kid          TEXT        PRIMARY KEY,
+    private_key  TEXT        NOT NULL,
+    public_key   JSONB       NOT NULL,
+    created_at   TIMESTAMPTZ NOT NULL DEFAULT now(),
+    activates_at TIMESTAMPTZ NOT NULL,
+    expires_at   TIMESTAMPTZ
+);
@@ -0,0 +1,21 @@
+-- Organization API keys for integrations such as channel managers. Keys look
+-- like hf_<prefix>_<secret>: the prefix finds the row, the secret is only
+-- stored as an argon2 hash.
+
+CREATE TABLE IF NOT EXISTS api_keys (
+    id              BIGSERIAL   PRIMARY KEY,
+    organization_id BIGINT      NOT NULL REFERENCES organization (id) ON DELETE CASCADE,
+    name            TEXT        NOT NULL,
+    prefix          TEXT        NOT NULL UNIQUE,
+    secret_hash     TEXT        NOT NULL,
+    role            TEXT        NOT NULL CHECK (role IN ('MANAGER', 'MEMBER')),
+    scopes          TEXT[]      NOT NULL DEFAULT '{}',
+    created_by      UUID,
+    created_at      TIMESTAMPTZ NOT NULL DEFAULT now(),
+    expires_at      TIMESTAMPTZ,
+    last_used_at    TIMESTAMPTZ,
+    revoked_at      TIMESTAMPTZ
+);
+
+CREATE INDEX IF NOT EXISTS api_keys_organization_idx
+    ON api_keys (organization_id, created_at DESC);
@@ -0,0 +1,14 @@
+-- Tokens of a user or session issued before revoked_before are rejected,
+-- e.g. after a deactivation, a role change or "log out everywhere". Rows
+-- are dropped once every token they could match has expired.
+
+CREATE TABLE IF NOT EXISTS session_revocations (
+    kind           TEXT        NOT NULL CHECK (kind IN ('USER', 'SESSION')),
+    subject_id     TEXT        NOT NULL,
+    revoked_before TIMESTAMPTZ NOT NULL,
+    reason         TEXT        NOT NULL,
+    PRIMARY KEY (kind, subject_id)
+);
+
+CREATE INDEX IF NOT EXISTS session_revocations_revoked_before_idx
+    ON session_revocations (revoked_before);
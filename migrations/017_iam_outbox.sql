@@ -0,0 +1,18 @@
+-- Users whose identity in Supabase Auth (app_metadata, ban) has to be
+-- brought in line with their profile. A row is kept until the sync
+-- succeeds; failed syncs are retried with a growing delay. Each change
+-- bumps version, so a sync that read older state does not remove the row.
+
+CREATE TABLE IF NOT EXISTS iam_outbox (
+    user_id         UUID        PRIMARY KEY,
+    version         BIGINT      NOT NULL DEFAULT 1,
+    attempts        INT         NOT NULL DEFAULT 0,
+    next_attempt_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    leased_until    TIMESTAMPTZ,
+    last_error      TEXT,
+    created_at      TIMESTAMPTZ NOT NULL DEFAULT now(),
+    updated_at      TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS iam_outbox_next_attempt_idx
+    ON iam_outbox (next_attempt_at);
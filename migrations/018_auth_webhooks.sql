@@ -0,0 +1,16 @@
+-- Deliveries of the Supabase auth webhook, by their webhook-id, so a
+-- captured request cannot be replayed. Rows older than the timestamp
+-- tolerance are pruned; such requests are rejected anyway.
+
+CREATE TABLE IF NOT EXISTS webhook_deliveries (
+    id          TEXT        PRIMARY KEY,
+    received_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS webhook_deliveries_received_at_idx
+    ON webhook_deliveries (received_at);
+
+-- Profiles of users deleted in Supabase Auth are kept (INACTIVE) for the
+-- records, but no longer listed or reactivated.
+ALTER TABLE profiles
+    ADD COLUMN IF NOT EXISTS deleted_at TIMESTAMPTZ;
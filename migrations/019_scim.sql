@@ -0,0 +1,33 @@
+-- SCIM provisioning: identity providers manage members (profiles) and
+-- teams (SCIM groups) of an organization. externalId is the identity
+-- provider's own ID of a resource.
+
+ALTER TABLE profiles
+    ADD COLUMN IF NOT EXISTS external_id TEXT;
+
+CREATE UNIQUE INDEX IF NOT EXISTS profiles_organization_external_id_idx
+    ON profiles (organization_id, external_id)
+    WHERE external_id IS NOT NULL AND deleted_at IS NULL;
+
+CREATE TABLE IF NOT EXISTS teams (
+    id              BIGSERIAL   PRIMARY KEY,
+    organization_id BIGINT      NOT NULL REFERENCES organization (id) ON DELETE CASCADE,
+    name            TEXT        NOT NULL,
+    external_id     TEXT,
+    created_at      TIMESTAMPTZ NOT NULL DEFAULT now(),
+    updated_at      TIMESTAMPTZ NOT NULL DEFAULT now(),
+    UNIQUE (organization_id, name)
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS teams_organization_external_id_idx
+    ON teams (organization_id, external_id)
+    WHERE external_id IS NOT NULL;
+
+CREATE TABLE IF NOT EXISTS team_members (
+    team_id    BIGINT NOT NULL REFERENCES teams (id) ON DELETE CASCADE,
+    profile_id UUID   NOT NULL REFERENCES profiles (id) ON DELETE CASCADE,
+    PRIMARY KEY (team_id, profile_id)
+);
+
+CREATE INDEX IF NOT EXISTS team_members_profile_idx
+    ON team_members (profile_id);
@@ -0,0 +1,15 @@
+-- Security policies an owner sets for their organization, enforced on every
+-- request of its members and API keys. Organizations without a row have no
+-- restrictions.
+
+CREATE TABLE IF NOT EXISTS organization_security_policies (
+    organization_id         BIGINT      PRIMARY KEY REFERENCES organization (id) ON DELETE CASCADE,
+    -- Members need a session with a second factor (aal2)
+    require_mfa             BOOLEAN     NOT NULL DEFAULT false,
+    -- CIDR ranges requests must come from; empty allows any address
+    allowed_networks        TEXT[]      NOT NULL DEFAULT '{}',
+    -- Tokens older than this (by iat) are turned away; NULL for no limit
+    max_session_age_minutes INTEGER     CHECK (max_session_age_minutes > 0),
+    updated_by              UUID,
+    updated_at              TIMESTAMPTZ NOT NULL DEFAULT now()
+);
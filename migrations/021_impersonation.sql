@@ -0,0 +1,33 @@
+-- Platform staff (the support team) are users allowed on the /admin API.
+-- Rows are added by hand; staff need not belong to an organization.
+
+CREATE TABLE IF NOT EXISTS platform_staff (
+    user_id    UUID        PRIMARY KEY,
+    note       TEXT,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+-- Support sessions in which a staff member acts as a user. Tokens look like
+-- hfimp_<id without dashes>_<secret>: the ID finds the row, the secret is
+-- only stored as an argon2 hash. Sessions stay listed after they end so the
+-- organization's owner can review them.
+
+CREATE TABLE IF NOT EXISTS impersonation_sessions (
+    id              UUID        PRIMARY KEY DEFAULT gen_random_uuid(),
+    staff_user_id   UUID        NOT NULL,
+    user_id         UUID        NOT NULL,
+    organization_id BIGINT      NOT NULL REFERENCES organization (id) ON DELETE CASCADE,
+    reason          TEXT        NOT NULL,
+    allow_writes    BOOLEAN     NOT NULL DEFAULT false,
+    secret_hash     TEXT        NOT NULL,
+    started_at      TIMESTAMPTZ NOT NULL DEFAULT now(),
+    expires_at      TIMESTAMPTZ NOT NULL,
+    ended_at        TIMESTAMPTZ,
+    ended_by        UUID
+);
+
+CREATE INDEX IF NOT EXISTS impersonation_sessions_organization_idx
+    ON impersonation_sessions (organization_id, started_at DESC);
+
+CREATE INDEX IF NOT EXISTS impersonation_sessions_staff_idx
+    ON impersonation_sessions (staff_user_id, started_at DESC);
@@ -0,0 +1,8 @@
+-- Platform staff can suspend an organization, e.g. for abuse or unpaid
+-- invoices. Members and API keys are turned away while suspended_at is set;
+-- the closure status is left alone, so unsuspending restores it.
+
+ALTER TABLE organization
+    ADD COLUMN IF NOT EXISTS suspended_at      TIMESTAMPTZ,
+    ADD COLUMN IF NOT EXISTS suspended_by      UUID,
+    ADD COLUMN IF NOT EXISTS suspension_reason TEXT;
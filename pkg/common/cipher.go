@@ -0,0 +1,82 @@
+package common
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ======== TYPES ========
+
+// Cipher encrypts small secrets (bank details, keys) for storage with
+// AES-256-GCM. Ciphertexts are versioned so the scheme can change later.
+type Cipher struct {
+	aead cipher.AEAD
+}
+
+const cipherVersion = "v1"
+
+// ErrDecrypt is returned when a ciphertext is malformed or was tampered with.
+var ErrDecrypt = errors.New("unable to decrypt value")
+
+// ======== PUBLIC METHODS ========
+
+// NewCipher creates a Cipher from a 32-byte key.
+func NewCipher(key []byte) (*Cipher, error) {
+	if len(key) != 32 {
+		return nil, fmt.Errorf("encryption key must be 32 bytes, got %d", len(key))
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+	return &Cipher{aead: aead}, nil
+}
+
+// NewCipherFromBase64 creates a Cipher from a base64-encoded 32-byte key,
+// the format used for keys passed through environment variables.
+func NewCipherFromBase64(encoded string) (*Cipher, error) {
+	key, err := base64.StdEncoding.DecodeString(strings.TrimSpace(encoded))
+	if err != nil {
+		return nil, fmt.Errorf("encryption key is not valid base64: %w", err)
+	}
+	return NewCipher(key)
+}
+
+// Encrypt seals plaintext. The associated data is authenticated but not
+// stored, binding the ciphertext to e.g. the row it belongs to.
+func (c *Cipher) Encrypt(plaintext string, associatedData []byte) (string, error) {
+	nonce := make([]byte, c.aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", err
+	}
+	sealed := c.aead.Seal(nonce, nonce, []byte(plaintext), associatedData)
+	return cipherVersion + ":" + base64.StdEncoding.EncodeToString(sealed), nil
+}
+
+// Decrypt opens a value produced by Encrypt with the same associated data.
+func (c *Cipher) Decrypt(ciphertext string, associatedData []byte) (string, error) {
+	version, encoded, found := strings.Cut(ciphertext, ":")
+	if !found || version != cipherVersion {
+		return "", ErrDecrypt
+	}
+	sealed, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil || len(sealed) < c.aead.NonceSize() {
+		return "", ErrDecrypt
+	}
+
+	nonce, body := sealed[:c.aead.NonceSize()], sealed[c.aead.NonceSize():]
+	plaintext, err := c.aead.Open(nil, nonce, body, associatedData)
+	if err != nil {
+		return "", ErrDecrypt
+	}
+	return string(plaintext), nil
+}
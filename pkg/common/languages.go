@@ -0,0 +1,237 @@
+package common
+
+import (
+	"sort"
+	"strings"
+)
+
+// ======== TYPES ========
+
+// Language is a spoken language identified by its ISO 639-1 code.
+type Language struct {
+	Code string `json:"code" example:"sl"`
+	Name string `json:"name" example:"Slovenian"`
+}
+
+// ======== NAMESPACES ========
+
+// languagesT is used for creating a namespace
+type languagesT struct{}
+
+// the Languages namespace
+var Languages languagesT
+
+// iso6391 maps ISO 639-1 codes to their English names.
+var iso6391 = map[string]string{
+	"aa": "Afar",
+	"ab": "Abkhazian",
+	"ae": "Avestan",
+	"af": "Afrikaans",
+	"ak": "Akan",
+	"am": "Amharic",
+	"an": "Aragonese",
+	"ar": "Arabic",
+	"as": "Assamese",
+	"av": "Avaric",
+	"ay": "Aymara",
+	"az": "Azerbaijani",
+	"ba": "Bashkir",
+	"be": "Belarusian",
+	"bg": "Bulgarian",
+	"bi": "Bislama",
+	"bm": "Bambara",
+	"bn": "Bengali",
+	"bo": "Tibetan",
+	"br": "Breton",
+	"bs": "Bosnian",
+	"ca": "Catalan",
+	"ce": "Chechen",
+	"ch": "Chamorro",
+	"co": "Corsican",
+	"cr": "Cree",
+	"cs": "Czech",
+	"cu": "Church Slavic",
+	"cv": "Chuvash",
+	"cy": "Welsh",
+	"da": "Danish",
+	"de": "German",
+	"dv": "Divehi",
+	"dz": "Dzongkha",
+	"ee": "Ewe",
+	"el": "Greek",
+	"en": "English",
+	"eo": "Esperanto",
+	"es": "Spanish",
+	"et": "Estonian",
+	"eu": "Basque",
+	"fa": "Persian",
+	"ff": "Fulah",
+	"fi": "Finnish",
+	"fj": "Fijian",
+	"fo": "Faroese",
+	"fr": "French",
+	"fy": "Western Frisian",
+	"ga": "Irish",
+	"gd": "Scottish Gaelic",
+	"gl": "Galician",
+	"gn": "Guarani",
+	"gu": "Gujarati",
+	"gv": "Manx",
+	"ha": "Hausa",
+	"he": "Hebrew",
+	"hi": "Hindi",
+	"ho": "Hiri Motu",
+	"hr": "Croatian",
+	"ht": "Haitian",
+	"hu": "Hungarian",
+	"hy": "Armenian",
+	"hz": "Herero",
+	"ia": "Interlingua",
+	"id": "Indonesian",
+	"ie": "Interlingue",
+	"ig": "Igbo",
+	"ii": "Sichuan Yi",
+	"ik": "Inupiaq",
+	"io": "Ido",
+	"is": "Icelandic",
+	"it": "Italian",
+	"iu": "Inuktitut",
+	"ja": "Japanese",
+	"jv": "Javanese",
+	"ka": "Georgian",
+	"kg": "Kongo",
+	"ki": "Kikuyu",
+	"kj": "Kuanyama",
+	"kk": "Kazakh",
+	"kl": "Kalaallisut",
+	"km": "Central Khmer",
+	"kn": "Kannada",
+	"ko": "Korean",
+	"kr": "Kanuri",
+	"ks": "Kashmiri",
+	"ku": "Kurdish",
+	"kv": "Komi",
+	"kw": "Cornish",
+	"ky": "Kirghiz",
+	"la": "Latin",
+	"lb": "Luxembourgish",
+	"lg": "Ganda",
+	"li": "Limburgan",
+	"ln": "Lingala",
+	"lo": "Lao",
+	"lt": "Lithuanian",
+	"lu": "Luba-Katanga",
+	"lv": "Latvian",
+	"mg": "Malagasy",
+	"mh": "Marshallese",
+	"mi": "Maori",
+	"mk": "Macedonian",
+	"ml": "Malayalam",
+	"mn": "Mongolian",
+	"mr": "Marathi",
+	"ms": "Malay",
+	"mt": "Maltese",
+	"my": "Burmese",
+	"na": "Nauru",
+	"nb": "Norwegian Bokmål",
+	"nd": "North Ndebele",
+	"ne": "Nepali",
+	"ng": "Ndonga",
+	"nl": "Dutch",
+	"nn": "Norwegian Nynorsk",
+	"no": "Norwegian",
+	"nr": "South Ndebele",
+	"nv": "Navajo",
+	"ny": "Chichewa",
+	"oc": "Occitan",
+	"oj": "Ojibwa",
+	"om": "Oromo",
+	"or": "Oriya",
+	"os": "Ossetian",
+	"pa": "Punjabi",
+	"pi": "Pali",
+	"pl": "Polish",
+	"ps": "Pashto",
+	"pt": "Portuguese",
+	"qu": "Quechua",
+	"rm": "Romansh",
+	"rn": "Rundi",
+	"ro": "Romanian",
+	"ru": "Russian",
+	"rw": "Kinyarwanda",
+	"sa": "Sanskrit",
+	"sc": "Sardinian",
+	"sd": "Sindhi",
+	"se": "Northern Sami",
+	"sg": "Sango",
+	"si": "Sinhala",
+	"sk": "Slovak",
+	"sl": "Slovenian",
+	"sm": "Samoan",
+	"sn": "Shona",
+	"so": "Somali",
+	"sq": "Albanian",
+	"sr": "Serbian",
+	"ss": "Swati",
+	"st": "Southern Sotho",
+	"su": "Sundanese",
+	"sv": "Swedish",
+	"sw": "Swahili",
+	"ta": "Tamil",
+	"te": "Telugu",
+	"tg": "Tajik",
+	"th": "Thai",
+	"ti": "Tigrinya",
+	"tk": "Turkmen",
+	"tl": "Tagalog",
+	"tn": "Tswana",
+	"to": "Tonga",
+	"tr": "Turkish",
+	"ts": "Tsonga",
+	"tt": "Tatar",
+	"tw": "Twi",
+	"ty": "Tahitian",
+	"ug": "Uighur",
+	"uk": "Ukrainian",
+	"ur": "Urdu",
+	"uz": "Uzbek",
+	"ve": "Venda",
+	"vi": "Vietnamese",
+	"vo": "Volapük",
+	"wa": "Walloon",
+	"wo": "Wolof",
+	"xh": "Xhosa",
+	"yi": "Yiddish",
+	"yo": "Yoruba",
+	"za": "Zhuang",
+	"zh": "Chinese",
+	"zu": "Zulu",
+}
+
+// ======== PUBLIC METHODS ========
+
+// Normalize lowercases and trims a language code.
+func (languagesT) Normalize(code string) string {
+	return strings.ToLower(strings.TrimSpace(code))
+}
+
+// IsValid reports whether the code is a known ISO 639-1 code.
+func (l languagesT) IsValid(code string) bool {
+	_, ok := iso6391[l.Normalize(code)]
+	return ok
+}
+
+// Name returns the English name of a language code, or "" if unknown.
+func (l languagesT) Name(code string) string {
+	return iso6391[l.Normalize(code)]
+}
+
+// All returns every known language sorted by code.
+func (languagesT) All() []Language {
+	out := make([]Language, 0, len(iso6391))
+	for code, name := range iso6391 {
+		out = append(out, Language{Code: code, Name: name})
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Code < out[j].Code })
+	return out
+}
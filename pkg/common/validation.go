@@ -118,6 +118,10 @@ func getValidationErrorMessage(error validator.FieldError) string {
 		return "Please enter a valid email address."
 	case "eqfield":
 		return "Must be equal to " + error.Param() + "."
+	case "oneof":
+		return "Must be one of: " + strings.ReplaceAll(error.Param(), " ", ", ") + "."
+	case "max":
+		return "This field must not exceed " + error.Param() + "."
 	}
 	return error.Tag()
 }
@@ -0,0 +1,289 @@
+package common
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"regexp"
+	"strings"
+)
+
+// ======== TYPES ========
+
+// vatRule describes the national part of a VAT ID for one country prefix.
+// checksum is nil for countries where only the format is checked.
+type vatRule struct {
+	pattern  *regexp.Regexp
+	checksum func(number string) bool
+}
+
+// ======== NAMESPACES ========
+
+// vatT is used for creating a namespace
+type vatT struct{}
+
+// the VAT namespace
+var VAT vatT
+
+// ErrInvalidVAT is returned when a VAT ID fails format or checksum validation.
+var ErrInvalidVAT = errors.New("invalid VAT ID")
+
+// vatRules holds the offline rules for EU member states (Greece uses the
+// "EL" prefix) and Northern Ireland.
+var vatRules = map[string]vatRule{
+	"AT": {regexp.MustCompile(`^U\d{8}$`), checkAT},
+	"BE": {regexp.MustCompile(`^[01]\d{9}$`), checkBE},
+	"BG": {regexp.MustCompile(`^\d{9,10}$`), nil},
+	"CY": {regexp.MustCompile(`^\d{8}[A-Z]$`), nil},
+	"CZ": {regexp.MustCompile(`^\d{8,10}$`), nil},
+	"DE": {regexp.MustCompile(`^\d{9}$`), checkMod1110},
+	"DK": {regexp.MustCompile(`^\d{8}$`), checkDK},
+	"EE": {regexp.MustCompile(`^\d{9}$`), checkEE},
+	"EL": {regexp.MustCompile(`^\d{9}$`), checkEL},
+	"ES": {regexp.MustCompile(`^[A-Z0-9]\d{7}[A-Z0-9]$`), nil},
+	"FI": {regexp.MustCompile(`^\d{8}$`), checkFI},
+	"FR": {regexp.MustCompile(`^[A-HJ-NP-Z0-9]{2}\d{9}$`), checkFR},
+	"HR": {regexp.MustCompile(`^\d{11}$`), checkMod1110},
+	"HU": {regexp.MustCompile(`^\d{8}$`), checkHU},
+	"IE": {regexp.MustCompile(`^(\d{7}[A-W][A-I]?|\d[A-Z+*]\d{5}[A-W])$`), nil},
+	"IT": {regexp.MustCompile(`^\d{11}$`), checkLuhn},
+	"LT": {regexp.MustCompile(`^(\d{9}|\d{12})$`), nil},
+	"LU": {regexp.MustCompile(`^\d{8}$`), checkLU},
+	"LV": {regexp.MustCompile(`^\d{11}$`), nil},
+	"MT": {regexp.MustCompile(`^\d{8}$`), nil},
+	"NL": {regexp.MustCompile(`^\d{9}B\d{2}$`), checkNL},
+	"PL": {regexp.MustCompile(`^\d{10}$`), checkPL},
+	"PT": {regexp.MustCompile(`^\d{9}$`), checkPT},
+	"RO": {regexp.MustCompile(`^[1-9]\d{1,9}$`), nil},
+	"SE": {regexp.MustCompile(`^\d{10}01$`), checkSE},
+	"SI": {regexp.MustCompile(`^[1-9]\d{7}$`), checkSI},
+	"SK": {regexp.MustCompile(`^[1-9]\d{9}$`), checkSK},
+	"XI": {regexp.MustCompile(`^(\d{9}|\d{12}|GD[0-4]\d{2}|HA[5-9]\d{2})$`), nil},
+}
+
+// ======== PUBLIC METHODS ========
+
+// Normalize uppercases a VAT ID and strips spaces, dots and dashes. The
+// ISO "GR" prefix is rewritten to the "EL" prefix used for VAT.
+func (vatT) Normalize(vatID string) string {
+	vatID = strings.ToUpper(vatID)
+	vatID = strings.NewReplacer(" ", "", ".", "", "-", "").Replace(vatID)
+	if strings.HasPrefix(vatID, "GR") {
+		vatID = "EL" + vatID[2:]
+	}
+	return vatID
+}
+
+// Validate checks the country prefix, the national format and, where the
+// algorithm is public, the check digits of a normalized VAT ID. It works
+// offline and therefore cannot tell whether the number is actually issued.
+func (vatT) Validate(vatID string) error {
+	if len(vatID) < 4 {
+		return fmt.Errorf("%w: too short", ErrInvalidVAT)
+	}
+
+	country, number := vatID[:2], vatID[2:]
+	rule, ok := vatRules[country]
+	if !ok {
+		return fmt.Errorf("%w: unsupported country prefix %q", ErrInvalidVAT, country)
+	}
+	if !rule.pattern.MatchString(number) {
+		return fmt.Errorf("%w: wrong format for %s", ErrInvalidVAT, country)
+	}
+	if rule.checksum != nil && !rule.checksum(number) {
+		return fmt.Errorf("%w: check digit mismatch", ErrInvalidVAT)
+	}
+	return nil
+}
+
+// ======== PRIVATE METHODS ========
+
+// digitsOf converts a string of ASCII digits to ints.
+func digitsOf(s string) []int {
+	digits := make([]int, len(s))
+	for i, r := range s {
+		digits[i] = int(r - '0')
+	}
+	return digits
+}
+
+// weightedSum multiplies digits by weights position by position.
+func weightedSum(digits []int, weights []int) int {
+	sum := 0
+	for i, w := range weights {
+		sum += digits[i] * w
+	}
+	return sum
+}
+
+func checkAT(number string) bool {
+	d := digitsOf(number[1:])
+	sum := 0
+	for i := 0; i < 7; i++ {
+		if i%2 == 0 {
+			sum += d[i]
+			continue
+		}
+		doubled := d[i] * 2
+		sum += doubled/10 + doubled%10
+	}
+	return (10-(sum+4)%10)%10 == d[7]
+}
+
+func checkBE(number string) bool {
+	var base, check int
+	fmt.Sscanf(number[:8], "%d", &base)
+	fmt.Sscanf(number[8:], "%d", &check)
+	return 97-base%97 == check
+}
+
+// checkMod1110 implements ISO 7064 MOD 11,10, used by Germany and Croatia.
+func checkMod1110(number string) bool {
+	d := digitsOf(number)
+	product := 10
+	for _, digit := range d[:len(d)-1] {
+		sum := (digit + product) % 10
+		if sum == 0 {
+			sum = 10
+		}
+		product = (2 * sum) % 11
+	}
+	check := 11 - product
+	if check == 10 {
+		check = 0
+	}
+	return check == d[len(d)-1]
+}
+
+func checkDK(number string) bool {
+	return weightedSum(digitsOf(number), []int{2, 7, 6, 5, 4, 3, 2, 1})%11 == 0
+}
+
+func checkEE(number string) bool {
+	d := digitsOf(number)
+	return (10-weightedSum(d, []int{3, 7, 1, 3, 7, 1, 3, 7})%10)%10 == d[8]
+}
+
+func checkEL(number string) bool {
+	d := digitsOf(number)
+	return weightedSum(d, []int{256, 128, 64, 32, 16, 8, 4, 2})%11%10 == d[8]
+}
+
+func checkFI(number string) bool {
+	d := digitsOf(number)
+	r := weightedSum(d, []int{7, 9, 10, 5, 8, 4, 2}) % 11
+	switch r {
+	case 0:
+		return d[7] == 0
+	case 1:
+		return false
+	default:
+		return 11-r == d[7]
+	}
+}
+
+// checkFR validates numeric keys only; alphanumeric keys use an
+// unpublished algorithm and are accepted on format alone.
+func checkFR(number string) bool {
+	key := number[:2]
+	if key[0] < '0' || key[0] > '9' || key[1] < '0' || key[1] > '9' {
+		return true
+	}
+	var k, siren int
+	fmt.Sscanf(key, "%d", &k)
+	fmt.Sscanf(number[2:], "%d", &siren)
+	return (12+3*(siren%97))%97 == k
+}
+
+func checkHU(number string) bool {
+	d := digitsOf(number)
+	return (10-weightedSum(d, []int{9, 7, 3, 1, 9, 7, 3})%10)%10 == d[7]
+}
+
+// checkLuhn validates the Luhn check digit over the whole number.
+func checkLuhn(number string) bool {
+	d := digitsOf(number)
+	sum := 0
+	for i := len(d) - 1; i >= 0; i-- {
+		digit := d[i]
+		if (len(d)-1-i)%2 == 1 {
+			digit *= 2
+			if digit > 9 {
+				digit -= 9
+			}
+		}
+		sum += digit
+	}
+	return sum%10 == 0
+}
+
+func checkLU(number string) bool {
+	var base, check int
+	fmt.Sscanf(number[:6], "%d", &base)
+	fmt.Sscanf(number[6:], "%d", &check)
+	return base%89 == check
+}
+
+// checkNL accepts the legacy eleven-test and the mod-97 scheme used for
+// sole proprietors since 2020.
+func checkNL(number string) bool {
+	d := digitsOf(number[:9])
+	r := weightedSum(d, []int{9, 8, 7, 6, 5, 4, 3, 2}) % 11
+	if r != 10 && r == d[8] {
+		return true
+	}
+	return mod97("NL"+number) == 1
+}
+
+func checkPL(number string) bool {
+	d := digitsOf(number)
+	r := weightedSum(d, []int{6, 5, 7, 2, 3, 4, 5, 6, 7}) % 11
+	return r != 10 && r == d[9]
+}
+
+func checkPT(number string) bool {
+	d := digitsOf(number)
+	check := 11 - weightedSum(d, []int{9, 8, 7, 6, 5, 4, 3, 2})%11
+	if check > 9 {
+		check = 0
+	}
+	return check == d[8]
+}
+
+func checkSE(number string) bool {
+	return checkLuhn(number[:10])
+}
+
+func checkSI(number string) bool {
+	d := digitsOf(number)
+	check := 11 - weightedSum(d, []int{8, 7, 6, 5, 4, 3, 2})%11
+	if check == 11 {
+		return false
+	}
+	if check == 10 {
+		check = 0
+	}
+	return check == d[7]
+}
+
+func checkSK(number string) bool {
+	n, ok := new(big.Int).SetString(number, 10)
+	return ok && new(big.Int).Mod(n, big.NewInt(11)).Sign() == 0
+}
+
+// mod97 converts letters to numbers (A=10 ... Z=35) and returns the
+// remainder of the resulting integer divided by 97, as used by IBAN.
+func mod97(value string) int {
+	remainder := 0
+	for _, r := range value {
+		switch {
+		case r >= '0' && r <= '9':
+			remainder = (remainder*10 + int(r-'0')) % 97
+		case r >= 'A' && r <= 'Z':
+			remainder = (remainder*100 + int(r-'A'+10)) % 97
+		default:
+			return -1
+		}
+	}
+	return remainder
+}
@@ -0,0 +1,69 @@
+package common
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVAT_Normalize(t *testing.T) {
+	assert.Equal(t, "SI15012557", VAT.Normalize("si 15.01-2557"))
+	assert.Equal(t, "EL094259216", VAT.Normalize("GR094259216"))
+}
+
+func TestVAT_Validate_Valid(t *testing.T) {
+	valid := []string{
+		"ATU13585627", "BE0417497106", "DE136695976", "DK13585628",
+		"EE100931558", "EL094259216", "FI20774740", "FR40303265045",
+		"HR33392005961", "HU12892312", "IT00743110157", "LU15027442",
+		"NL004495445B01", "PL5260250995", "PT501964843", "SE556188840401",
+		"SI15012557", "SI50223054", "SK2020317068", "ESA28015865",
+	}
+	for _, vatID := range valid {
+		assert.NoError(t, VAT.Validate(vatID), vatID)
+	}
+}
+
+func TestVAT_Validate_Invalid(t *testing.T) {
+	invalid := []string{
+		"", "SI", "US123456789", "SI1501255", "SI05012557",
+		"SI15012558", "DE136695977", "ATU13585628", "IT00743110158",
+		"NL004495446B01", "PL5260250996", "HR33392005962",
+	}
+	for _, vatID := range invalid {
+		assert.ErrorIs(t, VAT.Validate(vatID), ErrInvalidVAT, vatID)
+	}
+}
+
+func TestIBAN_Validate(t *testing.T) {
+	for _, iban := range []string{"SI56 1910 0000 0123 438", "DE89 3704 0044 0532 0130 00", "GB82 WEST 1234 5698 7654 32"} {
+		assert.NoError(t, IBAN.Validate(IBAN.Normalize(iban)), iban)
+	}
+
+	for _, iban := range []string{"SI56191000000123439", "SI5619100000012343", "XX56191000000123438", "not an iban"} {
+		assert.ErrorIs(t, IBAN.Validate(IBAN.Normalize(iban)), ErrInvalidIBAN, iban)
+	}
+}
+
+func TestIBAN_Mask(t *testing.T) {
+	assert.Equal(t, "SI56***********3438", IBAN.Mask("SI56191000000123438"))
+}
+
+func TestCipher_RoundTrip(t *testing.T) {
+	c, err := NewCipher(make([]byte, 32))
+	assert.NoError(t, err)
+
+	sealed, err := c.Encrypt("SI56191000000123438", []byte("org:1"))
+	assert.NoError(t, err)
+	assert.NotContains(t, sealed, "SI56")
+
+	plain, err := c.Decrypt(sealed, []byte("org:1"))
+	assert.NoError(t, err)
+	assert.Equal(t, "SI56191000000123438", plain)
+
+	_, err = c.Decrypt(sealed, []byte("org:2"))
+	assert.ErrorIs(t, err, ErrDecrypt)
+
+	_, err = NewCipher([]byte("short"))
+	assert.Error(t, err)
+}
@@ -0,0 +1,36 @@
+package iam
+
+import (
+	"context"
+
+	"go.uber.org/fx"
+)
+
+// ======== INTERFACES ========
+
+// IdentityProvider is the subset of the identity provider's admin API the
+// service relies on. It is an interface so tests can replace it.
+type IdentityProvider interface {
+	// UpdateAppMetadata merges the given keys into the user's app_metadata,
+	// which is embedded in the user's tokens and cannot be changed by the
+	// user themselves.
+	UpdateAppMetadata(ctx context.Context, userID string, metadata map[string]interface{}) error
+
+	// SetBanned bans the user, which stops them from signing in and
+	// refreshing their session, or lifts the ban.
+	SetBanned(ctx context.Context, userID string, banned bool) error
+
+	// CreateUser creates a user with a confirmed email and returns the
+	// user's ID.
+	CreateUser(ctx context.Context, email string, name string) (string, error)
+}
+
+// ======== EXPORTS ========
+
+// Module exports dependency
+var Module = fx.Options(
+	fx.Provide(fx.Annotate(
+		NewSupabaseClient,
+		fx.As(new(IdentityProvider)),
+	)),
+)
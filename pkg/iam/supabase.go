@@ -0,0 +1,131 @@
+package iam
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+)
+
+var (
+	// ErrNotConfigured is returned when the Supabase admin credentials are missing.
+	ErrNotConfigured = errors.New("identity provider is not configured")
+
+	// ErrUserNotFound is returned when the user does not exist in the
+	// identity provider, e.g. because the account was deleted.
+	ErrUserNotFound = errors.New("identity provider user not found")
+
+	// ErrUserExists is returned when a user with the email already exists.
+	ErrUserExists = errors.New("identity provider user already exists")
+)
+
+// banDuration is how long a ban lasts. Supabase has no permanent ban, so
+// deactivated users are banned for a century.
+const banDuration = "876000h"
+
+// SupabaseClient talks to the Supabase Auth (GoTrue) admin API using the
+// service role key from SUPABASE_URL and SUPABASE_SERVICE_ROLE_KEY.
+type SupabaseClient struct {
+	baseURL    string
+	serviceKey string
+	httpClient *http.Client
+}
+
+func NewSupabaseClient() *SupabaseClient {
+	return &SupabaseClient{
+		baseURL:    strings.TrimRight(os.Getenv("SUPABASE_URL"), "/"),
+		serviceKey: os.Getenv("SUPABASE_SERVICE_ROLE_KEY"),
+		httpClient: &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+// UpdateAppMetadata merges metadata into the user's app_metadata.
+func (c *SupabaseClient) UpdateAppMetadata(ctx context.Context, userID string, metadata map[string]interface{}) error {
+	return c.updateUser(ctx, userID, map[string]interface{}{"app_metadata": metadata})
+}
+
+// SetBanned bans the user or lifts the ban.
+func (c *SupabaseClient) SetBanned(ctx context.Context, userID string, banned bool) error {
+	duration := "none"
+	if banned {
+		duration = banDuration
+	}
+	return c.updateUser(ctx, userID, map[string]interface{}{"ban_duration": duration})
+}
+
+// CreateUser creates a user with a confirmed email, who signs in through
+// single sign-on or by resetting the password. It returns the user's ID,
+// or ErrUserExists when the email is taken.
+func (c *SupabaseClient) CreateUser(ctx context.Context, email string, name string) (string, error) {
+	body := map[string]interface{}{
+		"email":         email,
+		"email_confirm": true,
+		"user_metadata": map[string]interface{}{"full_name": name},
+	}
+
+	var created struct {
+		ID string `json:"id"`
+	}
+	status, err := c.send(ctx, http.MethodPost, "/auth/v1/admin/users", body, &created)
+	if status == http.StatusUnprocessableEntity {
+		return "", fmt.Errorf("%w: %s", ErrUserExists, email)
+	}
+	if err != nil {
+		return "", err
+	}
+	return created.ID, nil
+}
+
+// updateUser sends a partial update to the admin users endpoint.
+func (c *SupabaseClient) updateUser(ctx context.Context, userID string, body map[string]interface{}) error {
+	status, err := c.send(ctx, http.MethodPut, "/auth/v1/admin/users/"+url.PathEscape(userID), body, nil)
+	if status == http.StatusNotFound {
+		return fmt.Errorf("%w: %s", ErrUserNotFound, userID)
+	}
+	return err
+}
+
+// send calls the admin API and decodes the response into out, if given. The
+// status is returned along with errors so callers can tell them apart.
+func (c *SupabaseClient) send(ctx context.Context, method string, path string, body interface{}, out interface{}) (int, error) {
+	if c.baseURL == "" || c.serviceKey == "" {
+		return 0, ErrNotConfigured
+	}
+
+	payload, err := json.Marshal(body)
+	if err != nil {
+		return 0, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, c.baseURL+path, bytes.NewReader(payload))
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("apikey", c.serviceKey)
+	req.Header.Set("Authorization", "Bearer "+c.serviceKey)
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		detail, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return resp.StatusCode, fmt.Errorf("identity provider returned %d: %s", resp.StatusCode, strings.TrimSpace(string(detail)))
+	}
+	if out != nil {
+		if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+			return resp.StatusCode, fmt.Errorf("identity provider returned an invalid response: %w", err)
+		}
+	}
+	return resp.StatusCode, nil
+}
@@ -0,0 +1,99 @@
+package iam
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSupabaseClient_UpdateAppMetadata(t *testing.T) {
+	var received map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPut, r.Method)
+		assert.Equal(t, "/auth/v1/admin/users/user-1", r.URL.Path)
+		assert.Equal(t, "service-key", r.Header.Get("apikey"))
+		assert.Equal(t, "Bearer service-key", r.Header.Get("Authorization"))
+		json.NewDecoder(r.Body).Decode(&received)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	client := &SupabaseClient{baseURL: server.URL, serviceKey: "service-key", httpClient: server.Client()}
+	err := client.UpdateAppMetadata(context.Background(), "user-1", map[string]interface{}{"organization_id": 9, "role": "OWNER"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"organization_id": float64(9), "role": "OWNER"}, received["app_metadata"])
+}
+
+func TestSupabaseClient_SetBanned(t *testing.T) {
+	var received []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPut, r.Method)
+		assert.Equal(t, "/auth/v1/admin/users/user-1", r.URL.Path)
+		var body map[string]interface{}
+		json.NewDecoder(r.Body).Decode(&body)
+		received = append(received, body["ban_duration"].(string))
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	client := &SupabaseClient{baseURL: server.URL, serviceKey: "service-key", httpClient: server.Client()}
+	assert.NoError(t, client.SetBanned(context.Background(), "user-1", true))
+	assert.NoError(t, client.SetBanned(context.Background(), "user-1", false))
+
+	assert.Equal(t, []string{"876000h", "none"}, received)
+}
+
+func TestSupabaseClient_CreateUser(t *testing.T) {
+	var received map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "/auth/v1/admin/users", r.URL.Path)
+		json.NewDecoder(r.Body).Decode(&received)
+		if received["email"] == "taken@example.com" {
+			http.Error(w, `{"code":"email_exists"}`, http.StatusUnprocessableEntity)
+			return
+		}
+		w.Write([]byte(`{"id":"user-2","email":"ana@example.com"}`))
+	}))
+	defer server.Close()
+
+	client := &SupabaseClient{baseURL: server.URL, serviceKey: "service-key", httpClient: server.Client()}
+	id, err := client.CreateUser(context.Background(), "ana@example.com", "Ana Novak")
+
+	assert.NoError(t, err)
+	assert.Equal(t, "user-2", id)
+	assert.Equal(t, true, received["email_confirm"])
+	assert.Equal(t, map[string]interface{}{"full_name": "Ana Novak"}, received["user_metadata"])
+
+	_, err = client.CreateUser(context.Background(), "taken@example.com", "")
+	assert.ErrorIs(t, err, ErrUserExists)
+}
+
+func TestSupabaseClient_Errors(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, `{"msg":"User not found"}`, http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	client := &SupabaseClient{baseURL: server.URL, serviceKey: "service-key", httpClient: server.Client()}
+	err := client.UpdateAppMetadata(context.Background(), "missing", map[string]interface{}{})
+	assert.ErrorIs(t, err, ErrUserNotFound)
+
+	failing := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, `{"msg":"Internal error"}`, http.StatusBadGateway)
+	}))
+	defer failing.Close()
+
+	client = &SupabaseClient{baseURL: failing.URL, serviceKey: "service-key", httpClient: failing.Client()}
+	err = client.SetBanned(context.Background(), "user-1", true)
+	assert.ErrorContains(t, err, "502")
+	assert.NotErrorIs(t, err, ErrUserNotFound)
+
+	unconfigured := &SupabaseClient{httpClient: http.DefaultClient}
+	assert.ErrorIs(t, unconfigured.UpdateAppMetadata(context.Background(), "user-1", nil), ErrNotConfigured)
+}
@@ -0,0 +1,22 @@
+package lib
+
+import (
+	"fmt"
+	"os"
+	"time"
+)
+
+// DurationFromEnv reads a Go duration such as "720h" from the environment,
+// returning fallback when the variable is unset.
+func DurationFromEnv(key string, fallback time.Duration) (time.Duration, error) {
+	value := os.Getenv(key)
+	if value == "" {
+		return fallback, nil
+	}
+
+	duration, err := time.ParseDuration(value)
+	if err != nil || duration <= 0 {
+		return 0, fmt.Errorf("%s: must be a positive duration such as 720h", key)
+	}
+	return duration, nil
+}
@@ -0,0 +1,26 @@
+package lib
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDurationFromEnv(t *testing.T) {
+	t.Setenv("LIB_TEST_DURATION", "")
+	duration, err := DurationFromEnv("LIB_TEST_DURATION", time.Hour)
+	assert.NoError(t, err)
+	assert.Equal(t, time.Hour, duration)
+
+	t.Setenv("LIB_TEST_DURATION", "48h")
+	duration, err = DurationFromEnv("LIB_TEST_DURATION", time.Hour)
+	assert.NoError(t, err)
+	assert.Equal(t, 48*time.Hour, duration)
+
+	for _, value := range []string{"30d", "-1h", "0s"} {
+		t.Setenv("LIB_TEST_DURATION", value)
+		_, err = DurationFromEnv("LIB_TEST_DURATION", time.Hour)
+		assert.Error(t, err, value)
+	}
+}
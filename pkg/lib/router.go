@@ -32,6 +32,9 @@ SOFTWARE.
 package lib
 
 import (
+	"fmt"
+	"os"
+	"strings"
 	"time"
 
 	ginzap "github.com/gin-contrib/zap"
@@ -47,7 +50,7 @@ type Router = gin.Engine
 // ======== METHODS ========
 
 // GetRouter retrieves the router used by the API.
-func GetRouter() *Router {
+func GetRouter() (*Router, error) {
 
 	// ======== ROUTER ========
 	router := gin.New()
@@ -69,8 +72,26 @@ func GetRouter() *Router {
 
 	// ======== SETTINGS ========
 	router.Use(gin.Recovery())
-	router.SetTrustedProxies(nil)
 
-	return router
+	// The client IP (used by IP allowlists) is only read from
+	// X-Forwarded-For when the request comes through a trusted proxy
+	if err := router.SetTrustedProxies(trustedProxies()); err != nil {
+		return nil, fmt.Errorf("TRUSTED_PROXIES: %w", err)
+	}
 
+	return router, nil
+
+}
+
+// trustedProxies reads the comma-separated IPs and CIDR ranges of the
+// proxies in front of the service from TRUSTED_PROXIES. Without it no
+// proxy is trusted and the client IP is the peer's address.
+func trustedProxies() []string {
+	var proxies []string
+	for _, proxy := range strings.Split(os.Getenv("TRUSTED_PROXIES"), ",") {
+		if proxy = strings.TrimSpace(proxy); proxy != "" {
+			proxies = append(proxies, proxy)
+		}
+	}
+	return proxies
 }
@@ -0,0 +1,38 @@
+package lib
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetRouter_TrustedProxies(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	clientIP := func(router *Router) string {
+		router.GET("/ip", func(c *gin.Context) { c.String(http.StatusOK, c.ClientIP()) })
+		req := httptest.NewRequest(http.MethodGet, "/ip", nil)
+		req.RemoteAddr = "10.0.0.2:4242"
+		req.Header.Set("X-Forwarded-For", "203.0.113.9")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w.Body.String()
+	}
+
+	t.Setenv("TRUSTED_PROXIES", "")
+	router, err := GetRouter()
+	require.NoError(t, err)
+	assert.Equal(t, "10.0.0.2", clientIP(router))
+
+	t.Setenv("TRUSTED_PROXIES", "10.0.0.0/8, 192.168.1.1")
+	router, err = GetRouter()
+	require.NoError(t, err)
+	assert.Equal(t, "203.0.113.9", clientIP(router))
+
+	t.Setenv("TRUSTED_PROXIES", "not-a-network")
+	_, err = GetRouter()
+	assert.Error(t, err)
+}
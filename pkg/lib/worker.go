@@ -0,0 +1,45 @@
+package lib
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/fx"
+)
+
+// RunPeriodically calls run every interval for the lifetime of the app. The
+// context passed to run is cancelled when the app stops, and stopping waits
+// for a run in progress to return.
+func RunPeriodically(lifecycle fx.Lifecycle, interval time.Duration, run func(ctx context.Context)) {
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+
+	lifecycle.Append(fx.Hook{
+		OnStart: func(context.Context) error {
+			go func() {
+				defer close(done)
+
+				ticker := time.NewTicker(interval)
+				defer ticker.Stop()
+
+				for {
+					select {
+					case <-ctx.Done():
+						return
+					case <-ticker.C:
+						run(ctx)
+					}
+				}
+			}()
+			return nil
+		},
+		OnStop: func(stopCtx context.Context) error {
+			cancel()
+			select {
+			case <-done:
+			case <-stopCtx.Done():
+			}
+			return nil
+		},
+	})
+}
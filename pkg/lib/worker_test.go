@@ -0,0 +1,42 @@
+package lib
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/fx/fxtest"
+)
+
+func TestRunPeriodically(t *testing.T) {
+	lifecycle := fxtest.NewLifecycle(t)
+
+	var runs atomic.Int32
+	stopped := make(chan struct{})
+	RunPeriodically(lifecycle, 10*time.Millisecond, func(ctx context.Context) {
+		if runs.Add(1) == 2 {
+			go func() {
+				<-ctx.Done()
+				close(stopped)
+			}()
+		}
+	})
+
+	lifecycle.RequireStart()
+	assert.Eventually(t, func() bool { return runs.Load() >= 2 }, time.Second, 5*time.Millisecond)
+	lifecycle.RequireStop()
+
+	// Stopping cancels the context handed to run
+	select {
+	case <-stopped:
+	case <-time.After(time.Second):
+		t.Fatal("context was not cancelled on stop")
+	}
+
+	// and no run starts afterwards
+	after := runs.Load()
+	time.Sleep(30 * time.Millisecond)
+	assert.Equal(t, after, runs.Load())
+}
@@ -0,0 +1,35 @@
+package notify
+
+import (
+	"context"
+
+	"go.uber.org/fx"
+)
+
+// ======== TYPES ========
+
+// Notification is a message for one or more people, rendered and delivered
+// by the notification service from its type and data.
+type Notification struct {
+	Type       string                 `json:"type"`
+	Recipients []string               `json:"recipients"`
+	Data       map[string]interface{} `json:"data"`
+}
+
+// ======== INTERFACES ========
+
+// Notifier delivers notifications. It is an interface so tests can replace
+// it.
+type Notifier interface {
+	Notify(ctx context.Context, notification Notification) error
+}
+
+// ======== EXPORTS ========
+
+// Module exports dependency
+var Module = fx.Options(
+	fx.Provide(fx.Annotate(
+		NewWebhookNotifier,
+		fx.As(new(Notifier)),
+	)),
+)
@@ -0,0 +1,70 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+)
+
+// ErrNotConfigured is returned when NOTIFICATIONS_URL is missing.
+var ErrNotConfigured = errors.New("notifications are not configured")
+
+// WebhookNotifier posts notifications as JSON to the notification service
+// at NOTIFICATIONS_URL, authenticated with INTERNAL_API_TOKEN.
+type WebhookNotifier struct {
+	url        string
+	token      string
+	httpClient *http.Client
+}
+
+func NewWebhookNotifier() *WebhookNotifier {
+	return &WebhookNotifier{
+		url:        strings.TrimSpace(os.Getenv("NOTIFICATIONS_URL")),
+		token:      os.Getenv("INTERNAL_API_TOKEN"),
+		httpClient: &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+// Notify sends the notification. Notifications without recipients are
+// dropped.
+func (n *WebhookNotifier) Notify(ctx context.Context, notification Notification) error {
+	if len(notification.Recipients) == 0 {
+		return nil
+	}
+	if n.url == "" {
+		return ErrNotConfigured
+	}
+
+	payload, err := json.Marshal(notification)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, n.url, bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if n.token != "" {
+		req.Header.Set("Authorization", "Bearer "+n.token)
+	}
+
+	resp, err := n.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		detail, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("notification service returned %d: %s", resp.StatusCode, strings.TrimSpace(string(detail)))
+	}
+	return nil
+}
@@ -0,0 +1,49 @@
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWebhookNotifier_Notify(t *testing.T) {
+	var received Notification
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "Bearer internal-token", r.Header.Get("Authorization"))
+		json.NewDecoder(r.Body).Decode(&received)
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer server.Close()
+
+	notifier := &WebhookNotifier{url: server.URL, token: "internal-token", httpClient: server.Client()}
+	err := notifier.Notify(context.Background(), Notification{
+		Type:       "join_request.created",
+		Recipients: []string{"owner@vila-bled.si"},
+		Data:       map[string]interface{}{"request_id": 7},
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "join_request.created", received.Type)
+	assert.Equal(t, []string{"owner@vila-bled.si"}, received.Recipients)
+	assert.Equal(t, float64(7), received.Data["request_id"])
+}
+
+func TestWebhookNotifier_Errors(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "unknown template", http.StatusUnprocessableEntity)
+	}))
+	defer server.Close()
+
+	notifier := &WebhookNotifier{url: server.URL, httpClient: server.Client()}
+	err := notifier.Notify(context.Background(), Notification{Type: "x", Recipients: []string{"a@b.si"}})
+	assert.ErrorContains(t, err, "422")
+
+	unconfigured := &WebhookNotifier{httpClient: http.DefaultClient}
+	assert.ErrorIs(t, unconfigured.Notify(context.Background(), Notification{Recipients: []string{"a@b.si"}}), ErrNotConfigured)
+	assert.NoError(t, unconfigured.Notify(context.Background(), Notification{}))
+}